@@ -2,9 +2,11 @@ package api
 
 import (
 	"encoding/json"
+	"fmt"
 	"github.com/dannyvelas/parkspot-backend/app"
 	"github.com/dannyvelas/parkspot-backend/config"
 	"github.com/dannyvelas/parkspot-backend/errs"
+	"github.com/dannyvelas/parkspot-backend/i18n"
 	"github.com/rs/zerolog/log"
 	"net/http"
 	"strings"
@@ -25,21 +27,35 @@ func newAuthHandler(httpConfig config.HTTPConfig, jwtService app.JWTService, aut
 	}
 }
 
+type loginReq struct {
+	ID       string `json:"id"`
+	Password string `json:"password"`
+}
+
+type passwordResetEmailReq struct {
+	ID string `json:"id"`
+}
+
+type passwordResetReq struct {
+	Password string `json:"password"`
+}
+
+type preferredLangReq struct {
+	PreferredLang i18n.Lang `json:"preferredLang"`
+}
+
 func (h authHandler) login() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		var credentials struct {
-			ID       string
-			Password string
-		}
+		var credentials loginReq
 		err := json.NewDecoder(r.Body).Decode(&credentials)
 		if err != nil {
-			respondError(w, errs.Malformed("Credentials"))
+			respondError(w, r, errs.Malformed("Credentials"))
 			return
 		}
 
-		session, refreshToken, err := h.authService.Login(credentials.ID, credentials.Password)
+		session, refreshToken, err := h.authService.Login(r.Context(), credentials.ID, credentials.Password)
 		if err != nil {
-			respondError(w, err)
+			respondError(w, r, err)
 			return
 		}
 
@@ -63,21 +79,21 @@ func (h authHandler) refreshTokens() http.HandlerFunc {
 		cookie, err := r.Cookie(config.RefreshCookieKey)
 		if err != nil {
 			log.Debug().Msgf("could not find cookie")
-			respondError(w, errs.Unauthorized)
+			respondError(w, r, errs.Unauthorized)
 			return
 		}
 
 		refreshPayload, err := h.jwtService.ParseRefresh(cookie.Value)
 		if err != nil {
 			log.Debug().Msgf("could not parse cookie: %v", err)
-			respondError(w, errs.Unauthorized)
+			respondError(w, r, errs.Unauthorized)
 			return
 		}
 
-		session, refreshToken, err := h.authService.RefreshTokens(refreshPayload)
+		session, refreshToken, err := h.authService.RefreshTokens(r.Context(), refreshPayload)
 		if err != nil {
 			log.Debug().Msgf("could not have auth service refresh tokens: %v", err)
-			respondError(w, err)
+			respondError(w, r, err)
 			return
 		}
 
@@ -92,17 +108,17 @@ func (h authHandler) sendResetPasswordEmail() http.HandlerFunc {
 		emailSentResponse := message{"If this account is in our database, instructions to" +
 			" reset a password have been sent to the email associated with this account."}
 
-		var payload struct{ ID string }
+		var payload passwordResetEmailReq
 		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
-			respondError(w, errs.Malformed("id object"))
+			respondError(w, r, errs.Malformed("id object"))
 			return
 		} else if payload.ID == "" {
-			respondError(w, errs.EmptyFields("id"))
+			respondError(w, r, errs.EmptyFields("id"))
 			return
 		}
 
-		if err := h.authService.SendResetPasswordEmail(r.Context(), payload.ID); err != nil {
-			respondError(w, err)
+		if err := h.authService.SendResetPasswordEmail(r.Context(), payload.ID, r.Header.Get("Accept-Language")); err != nil {
+			respondError(w, r, err)
 			return
 		}
 
@@ -112,28 +128,28 @@ func (h authHandler) sendResetPasswordEmail() http.HandlerFunc {
 
 func (h authHandler) resetPassword() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		var payload struct{ Password string }
+		var payload passwordResetReq
 		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
-			respondError(w, errs.Malformed("password object"))
+			respondError(w, r, errs.Malformed("password object"))
 			return
 		}
 
 		authHeader := r.Header.Get("Authorization")
 		if !strings.HasPrefix(authHeader, "Bearer ") {
 			log.Debug().Msg("No 'Authorization' header was present with 'Bearer ' prefix.")
-			respondError(w, errs.Unauthorized)
+			respondError(w, r, errs.Unauthorized)
 			return
 		}
 
 		accessToken := strings.TrimPrefix(authHeader, "Bearer ")
 		user, err := h.jwtService.ParseAccess(accessToken)
 		if err != nil {
-			respondError(w, errs.Unauthorized)
+			respondError(w, r, errs.Unauthorized)
 			return
 		}
 
-		if err := h.authService.ResetPassword(user.ID, payload.Password); err != nil {
-			respondError(w, err)
+		if err := h.authService.ResetPassword(r.Context(), user.ID, payload.Password); err != nil {
+			respondError(w, r, err)
 			return
 		}
 
@@ -151,3 +167,26 @@ func (h authHandler) sendRefreshToken(w http.ResponseWriter, refreshToken string
 	}
 	http.SetCookie(w, &cookie)
 }
+
+func (h authHandler) setPreferredLang() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var payload preferredLangReq
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			respondError(w, r, errs.Malformed("preferred language object"))
+			return
+		}
+
+		accessPayload, err := ctxGetAccessPayload(r.Context())
+		if err != nil {
+			respondError(w, r, fmt.Errorf("error getting access payload: %v", err))
+			return
+		}
+
+		if err := h.authService.SetPreferredLang(r.Context(), accessPayload.ID, payload.PreferredLang); err != nil {
+			respondError(w, r, err)
+			return
+		}
+
+		respondJSON(w, http.StatusOK, message{"Preferred language has been successfully set. It applies once your session is refreshed."})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/dannyvelas/parkspot-backend/app"
 	"github.com/dannyvelas/parkspot-backend/config"
@@ -40,7 +41,7 @@ func (suite *authRouterSuite) SetupSuite() {
 	}
 
 	// configure and start container
-	container, database, err := psql.NewSandboxDatabase()
+	container, database, err := psql.NewSandboxDatabase(time.Local)
 	if err != nil {
 		suite.T().Fatalf("error getting sandbox database: %v", err)
 	}
@@ -52,11 +53,11 @@ func (suite *authRouterSuite) SetupSuite() {
 	router := newRouter(c, suite.app)
 	suite.testServer = httptest.NewServer(router)
 
-	if _, err := suite.app.AdminService.Create(models.TestAdmin); err != nil {
+	if _, err := suite.app.AdminService.Create(context.Background(), models.TestAdmin); err != nil {
 		log.Fatal().Msgf("error creating test admin: %v", err.Error())
 	}
 
-	if _, err := suite.app.ResidentService.Create(models.TestResident); err != nil {
+	if _, err := suite.app.ResidentService.Create(context.Background(), models.TestResident); err != nil {
 		log.Fatal().Msgf("error creating test resident: %v", err.Error())
 	}
 }
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/dannyvelas/parkspot-backend/app"
+	"github.com/dannyvelas/parkspot-backend/errs"
+	"github.com/dannyvelas/parkspot-backend/models"
+	"github.com/dannyvelas/parkspot-backend/util"
+	"github.com/go-chi/chi/v5"
+	"net/http"
+)
+
+type banHandler struct {
+	banService app.BanService
+}
+
+func newBanHandler(banService app.BanService) banHandler {
+	return banHandler{
+		banService: banService,
+	}
+}
+
+func (h banHandler) get(status models.Status) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		limit := util.ToPosInt(r.URL.Query().Get("limit"))
+		page := util.ToPosInt(r.URL.Query().Get("page"))
+		search := r.URL.Query().Get("search")
+
+		bansWithMetadata, err := h.banService.GetAll(r.Context(), status, limit, page, search)
+		if err != nil {
+			respondError(w, r, err)
+			return
+		}
+
+		respondJSON(w, http.StatusOK, bansWithMetadata)
+	}
+}
+
+func (h banHandler) create() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var desiredBan models.Ban
+		if err := json.NewDecoder(r.Body).Decode(&desiredBan); err != nil {
+			respondError(w, r, errs.Malformed("Ban"))
+			return
+		}
+
+		ctx := r.Context()
+		accessPayload, err := ctxGetAccessPayload(ctx)
+		if err != nil {
+			respondError(w, r, fmt.Errorf("ban_handler.create: error getting access payload: %v", err))
+			return
+		}
+		desiredBan.CreatedBy = accessPayload.ID
+
+		ban, err := h.banService.Create(ctx, desiredBan)
+		if err != nil {
+			respondError(w, r, err)
+			return
+		}
+
+		respondJSON(w, http.StatusOK, ban)
+	}
+}
+
+func (h banHandler) deleteOne() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := chi.URLParam(r, "id")
+
+		if err := h.banService.Delete(r.Context(), id); err != nil {
+			respondError(w, r, err)
+			return
+		}
+
+		respondJSON(w, http.StatusOK, message{"Successfully deleted ban"})
+	}
+}
@@ -5,33 +5,39 @@ import (
 	"fmt"
 	"github.com/dannyvelas/parkspot-backend/app"
 	"github.com/dannyvelas/parkspot-backend/errs"
+	"github.com/dannyvelas/parkspot-backend/export"
 	"github.com/dannyvelas/parkspot-backend/models"
 	"github.com/dannyvelas/parkspot-backend/util"
 	"github.com/go-chi/chi/v5"
 	"net/http"
+	"time"
 )
 
 type carHandler struct {
 	carService app.CarService
+	// location is the timezone of the community, which exports are dated in
+	location *time.Location
 }
 
-func newCarHandler(carService app.CarService) carHandler {
+func newCarHandler(carService app.CarService, location *time.Location) carHandler {
 	return carHandler{
 		carService: carService,
+		location:   location,
 	}
 }
 
 func (h carHandler) get() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		limit := util.ToPosInt(r.URL.Query().Get("limit"))
-		page := util.ToPosInt(r.URL.Query().Get("page"))
-		reversed := util.ToBool(r.URL.Query().Get("reversed"))
-		search := r.URL.Query().Get("search")
+		params, err := newListParams(r)
+		if err != nil {
+			respondError(w, r, err)
+			return
+		}
 
 		ctx := r.Context()
 		accessPayload, err := ctxGetAccessPayload(ctx)
 		if err != nil {
-			respondError(w, fmt.Errorf("error getting access payload: %v", err))
+			respondError(w, r, fmt.Errorf("error getting access payload: %v", err))
 			return
 		}
 
@@ -40,9 +46,9 @@ func (h carHandler) get() http.HandlerFunc {
 			residentID = accessPayload.ID
 		}
 
-		carsWithMetadata, err := h.carService.GetAll(limit, page, reversed, search, residentID)
+		carsWithMetadata, err := h.carService.GetAll(ctx, params, residentID)
 		if err != nil {
-			respondError(w, err)
+			respondError(w, r, err)
 			return
 		}
 
@@ -50,34 +56,61 @@ func (h carHandler) get() http.HandlerFunc {
 	}
 }
 
+// export streams the cars that get lists, on every page, as a CSV or XLSX file
+func (h carHandler) export() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params, err := newListParams(r)
+		if err != nil {
+			respondError(w, r, err)
+			return
+		}
+
+		ctx := r.Context()
+		accessPayload, err := ctxGetAccessPayload(ctx)
+		if err != nil {
+			respondError(w, r, fmt.Errorf("error getting access payload: %v", err))
+			return
+		}
+
+		residentID := ""
+		if accessPayload.Role == models.ResidentRole {
+			residentID = accessPayload.ID
+		}
+
+		respondExport(w, r, "cars", export.Cars, h.location, func(each func(models.Car) error) error {
+			return h.carService.Export(ctx, params, residentID, each)
+		})
+	}
+}
+
 func (h carHandler) deleteOne() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		id := chi.URLParam(r, "id")
 		if !util.IsUUIDV4(id) {
-			respondError(w, errs.IDNotUUID)
+			respondError(w, r, errs.IDNotUUID)
 			return
 		}
 
 		ctx := r.Context()
 		accessPayload, err := ctxGetAccessPayload(ctx)
 		if err != nil {
-			respondError(w, fmt.Errorf("car_handler.deleteOne: error getting access payload: %v", err))
+			respondError(w, r, fmt.Errorf("car_handler.deleteOne: error getting access payload: %v", err))
 			return
 		}
 
-		carToDelete, err := h.carService.GetOne(id)
+		carToDelete, err := h.carService.GetOne(ctx, id)
 		if err != nil {
-			respondError(w, err)
+			respondError(w, r, err)
 			return
 		}
 
 		if accessPayload.Role == models.ResidentRole && carToDelete.ResidentID != accessPayload.ID {
-			respondError(w, errs.NewUnauthorized("resident cannot delete car of another resident"))
+			respondError(w, r, errs.NewUnauthorized("resident cannot delete car of another resident"))
 			return
 		}
 
-		if err := h.carService.Delete(id); err != nil {
-			respondError(w, err)
+		if err := h.carService.Delete(ctx, id); err != nil {
+			respondError(w, r, err)
 			return
 		}
 
@@ -87,12 +120,13 @@ func (h carHandler) deleteOne() http.HandlerFunc {
 
 func (h carHandler) getOne() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		car, err := h.carService.GetOne(chi.URLParam(r, "id"))
+		car, err := h.carService.GetOne(r.Context(), chi.URLParam(r, "id"))
 		if err != nil {
-			respondError(w, err)
+			respondError(w, r, err)
 			return
 		}
 
+		setETag(w, car.Version)
 		respondJSON(w, http.StatusOK, car)
 	}
 }
@@ -101,38 +135,45 @@ func (h carHandler) edit() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		var editCarReq models.Car
 		if err := json.NewDecoder(r.Body).Decode(&editCarReq); err != nil {
-			respondError(w, errs.Malformed("EditCarReq"))
+			respondError(w, r, errs.Malformed("EditCarReq"))
 			return
 		}
 		if !util.IsUUIDV4(editCarReq.ID) {
-			respondError(w, errs.IDNotUUID)
+			respondError(w, r, errs.IDNotUUID)
+			return
+		}
+		version, fromIfMatch, err := editVersion(r, editCarReq.Version)
+		if err != nil {
+			respondError(w, r, err)
 			return
 		}
+		editCarReq.Version = version
 
 		ctx := r.Context()
 		accessPayload, err := ctxGetAccessPayload(ctx)
 		if err != nil {
-			respondError(w, fmt.Errorf("car_handler.edit(): error getting access payload: %v", err))
+			respondError(w, r, fmt.Errorf("car_handler.edit(): error getting access payload: %v", err))
 			return
 		}
 
-		carToEdit, err := h.carService.GetOne(editCarReq.ID)
+		carToEdit, err := h.carService.GetOne(ctx, editCarReq.ID)
 		if err != nil {
-			respondError(w, err)
+			respondError(w, r, err)
 			return
 		}
 
 		if accessPayload.Role == models.ResidentRole && carToEdit.ResidentID != accessPayload.ID {
-			respondError(w, errs.NewUnauthorized("resident cannot edit car of another resident"))
+			respondError(w, r, errs.NewUnauthorized("resident cannot edit car of another resident"))
 			return
 		}
 
-		car, err := h.carService.Update(editCarReq)
+		car, err := h.carService.Update(ctx, editCarReq)
 		if err != nil {
-			respondError(w, err)
+			respondEditError(w, r, err, fromIfMatch)
 			return
 		}
 
+		setETag(w, car.Version)
 		respondJSON(w, http.StatusOK, car)
 	}
 }
@@ -141,20 +182,20 @@ func (h carHandler) create() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		var desiredCar models.Car
 		if err := json.NewDecoder(r.Body).Decode(&desiredCar); err != nil {
-			respondError(w, errs.Malformed("New Car Request"))
+			respondError(w, r, errs.Malformed("New Car Request"))
 			return
 		}
 
 		ctx := r.Context()
 		accessPayload, err := ctxGetAccessPayload(ctx)
 		if err != nil {
-			respondError(w, fmt.Errorf("car_handler.create(): error getting access payload: %v", err))
+			respondError(w, r, fmt.Errorf("car_handler.create(): error getting access payload: %v", err))
 			return
 		}
 
 		if accessPayload.Role == models.ResidentRole {
 			if desiredCar.ResidentID != "" && desiredCar.ResidentID != accessPayload.ID {
-				respondError(w, errs.NewUnauthorized("resident cannot create car for another resident"))
+				respondError(w, r, errs.NewUnauthorized("resident cannot create car for another resident"))
 				return
 			}
 			if desiredCar.ResidentID == "" {
@@ -162,9 +203,9 @@ func (h carHandler) create() http.HandlerFunc {
 			}
 		}
 
-		car, err := h.carService.Create(desiredCar)
+		car, err := h.carService.Create(ctx, desiredCar)
 		if err != nil {
-			respondError(w, err)
+			respondError(w, r, err)
 			return
 		}
 		respondJSON(w, http.StatusOK, car)
@@ -175,13 +216,13 @@ func (h carHandler) getOfResident() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		residentID := chi.URLParam(r, "id")
 		if residentID == "" {
-			respondError(w, errs.MissingIDField)
+			respondError(w, r, errs.MissingIDField)
 			return
 		}
 
-		cars, err := h.carService.GetAll(0, 0, false, "", residentID)
+		cars, err := h.carService.GetAll(r.Context(), app.ListParams{}, residentID)
 		if err != nil {
-			respondError(w, err)
+			respondError(w, r, err)
 			return
 		}
 
@@ -3,11 +3,14 @@ package api
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/dannyvelas/parkspot-backend/app"
 	"github.com/dannyvelas/parkspot-backend/config"
+	"github.com/dannyvelas/parkspot-backend/errs"
 	"github.com/dannyvelas/parkspot-backend/models"
 	"github.com/dannyvelas/parkspot-backend/storage/psql"
 	"github.com/rs/zerolog/log"
@@ -34,7 +37,7 @@ func (suite *carRouterSuite) SetupSuite() {
 	}
 
 	// configure and start container
-	container, database, err := psql.NewSandboxDatabase()
+	container, database, err := psql.NewSandboxDatabase(time.Local)
 	if err != nil {
 		suite.T().Fatalf("error getting sandbox database: %v", err)
 	}
@@ -47,7 +50,7 @@ func (suite *carRouterSuite) SetupSuite() {
 	suite.testServer = httptest.NewServer(router)
 
 	// owner of car must exist before creating test car
-	if _, err := suite.app.ResidentService.Create(models.TestResident); err != nil {
+	if _, err := suite.app.ResidentService.Create(context.Background(), models.TestResident); err != nil {
 		log.Fatal().Msgf("error creating test resident: %v", err.Error())
 	}
 }
@@ -62,7 +65,7 @@ func (suite *carRouterSuite) TearDownSuite() {
 
 func (suite *carRouterSuite) SetupTest() {
 	// create fresh instance of car before each test
-	if _, err := suite.app.CarService.Create(models.TestCar); err != nil {
+	if _, err := suite.app.CarService.Create(context.Background(), models.TestCar); err != nil {
 		suite.TearDownSuite()
 		suite.T().Fatalf("tearing down because failed to create resident: %v", err)
 	}
@@ -70,7 +73,7 @@ func (suite *carRouterSuite) SetupTest() {
 
 func (suite *carRouterSuite) TearDownTest() {
 	// delete car after each test
-	if err := suite.app.CarService.Delete(models.TestCar.ID); err != nil {
+	if err := suite.app.CarService.Delete(context.Background(), models.TestCar.ID); err != nil {
 		suite.TearDownSuite()
 		suite.T().Fatalf("tearing down because failed to create resident: %v", err)
 	}
@@ -79,14 +82,15 @@ func (suite *carRouterSuite) TearDownTest() {
 func (suite *carRouterSuite) TestAdmin_Edit_Positive() {
 	newColor := models.TestCar.Color + "NEW"
 
-	token, err := suite.app.JWTService.NewAccess(models.TestAdmin.ID, models.AdminRole)
+	token, err := suite.app.JWTService.NewAccess(models.TestAdmin.ID, models.AdminRole, "")
 	if err != nil {
 		require.NoError(suite.T(), fmt.Errorf("error creating access token for admin: %v", err))
 	}
 
 	returnedCar, _ := authenticatedReq[models.Car, models.Car]("PUT", suite.testServer.URL+"/api/car", token, &models.Car{
-		ID:    models.TestCar.ID,
-		Color: newColor,
+		ID:      models.TestCar.ID,
+		Color:   newColor,
+		Version: 1,
 	})
 
 	expectedCar := models.TestCar
@@ -99,17 +103,43 @@ func (suite *carRouterSuite) TestAdmin_Edit_Positive() {
 	require.Equal(suite.T(), expectedCar.Model, returnedCar.Model, "model in car response was not the same as expected")
 }
 
+func (suite *carRouterSuite) TestAdmin_EditOldVersion_Negative() {
+	token, err := suite.app.JWTService.NewAccess(models.TestAdmin.ID, models.AdminRole, "")
+	if err != nil {
+		require.NoError(suite.T(), fmt.Errorf("error creating access token for admin: %v", err))
+	}
+
+	_, err = authenticatedReq[models.Car, models.Car]("PUT", suite.testServer.URL+"/api/car", token, &models.Car{
+		ID:      models.TestCar.ID,
+		Color:   models.TestCar.Color + "NEW",
+		Version: 1,
+	})
+	require.NoError(suite.T(), err)
+
+	// this edit was made to the version of the car before the edit above
+	_, err = authenticatedReq[models.Car, models.Car]("PUT", suite.testServer.URL+"/api/car", token, &models.Car{
+		ID:      models.TestCar.ID,
+		Color:   models.TestCar.Color + "OLD",
+		Version: 1,
+	})
+	var apiErr *errs.APIErr
+	require.ErrorAs(suite.T(), err, &apiErr)
+	require.Equal(suite.T(), http.StatusConflict, apiErr.StatusCode)
+	require.Equal(suite.T(), "car.conflict", apiErr.Code)
+}
+
 func (suite *carRouterSuite) TestSecurity_Edit_Negative() {
 	newColor := models.TestCar.Color + "NEW"
 
-	token, err := suite.app.JWTService.NewAccess(models.TestSecurity.ID, models.SecurityRole)
+	token, err := suite.app.JWTService.NewAccess(models.TestSecurity.ID, models.SecurityRole, "")
 	if err != nil {
 		require.NoError(suite.T(), fmt.Errorf("error creating access token for security: %v", err))
 	}
 
 	_, err = authenticatedReq[models.Car, models.Car]("PUT", suite.testServer.URL+"/api/car", token, &models.Car{
-		ID:    models.TestCar.ID,
-		Color: newColor,
+		ID:      models.TestCar.ID,
+		Color:   newColor,
+		Version: 1,
 	})
 	require.Error(suite.T(), err)
 
@@ -119,14 +149,15 @@ func (suite *carRouterSuite) TestSecurity_Edit_Negative() {
 func (suite *carRouterSuite) TestResident_EditCar_Positive() {
 	newColor := models.TestCar.Color + "NEW"
 
-	token, err := suite.app.JWTService.NewAccess(models.TestResident.ID, models.ResidentRole)
+	token, err := suite.app.JWTService.NewAccess(models.TestResident.ID, models.ResidentRole, "")
 	if err != nil {
 		require.NoError(suite.T(), fmt.Errorf("error creating access token for resident: %v", err))
 	}
 
 	returnedCar, _ := authenticatedReq[models.Car, models.Car]("PUT", suite.testServer.URL+"/api/car", token, &models.Car{
-		ID:    models.TestCar.ID,
-		Color: newColor,
+		ID:      models.TestCar.ID,
+		Color:   newColor,
+		Version: 1,
 	})
 
 	expectedCar := models.TestCar
@@ -144,14 +175,15 @@ func (suite *carRouterSuite) TestResident_EditOthersCar_Negative() {
 
 	// this is an access token belonging to models.TestResidentUnlimDays.
 	// however, the car that is edited in the request belongs to models.TestResident
-	token, err := suite.app.JWTService.NewAccess(models.TestResidentUnlimDays.ID, models.ResidentRole)
+	token, err := suite.app.JWTService.NewAccess(models.TestResidentUnlimDays.ID, models.ResidentRole, "")
 	if err != nil {
 		require.NoError(suite.T(), fmt.Errorf("error creating access token for resident: %v", err))
 	}
 
 	_, err = authenticatedReq[models.Car, models.Car]("PUT", suite.testServer.URL+"/api/car", token, &models.Car{
-		ID:    models.TestCar.ID,
-		Color: newColor,
+		ID:      models.TestCar.ID,
+		Color:   newColor,
+		Version: 1,
 	})
 	require.Error(suite.T(), err)
 
@@ -161,7 +193,7 @@ func (suite *carRouterSuite) TestResident_EditOthersCar_Negative() {
 func (suite *carRouterSuite) TestResident_DeleteOthersCar_Negative() {
 	// this is an access token belonging to models.TestResidentUnlimDays.
 	// however, the car that is deleted in the request belongs to models.TestResident
-	token, err := suite.app.JWTService.NewAccess(models.TestResidentUnlimDays.ID, models.ResidentRole)
+	token, err := suite.app.JWTService.NewAccess(models.TestResidentUnlimDays.ID, models.ResidentRole, "")
 	if err != nil {
 		require.NoError(suite.T(), fmt.Errorf("error creating access token for resident: %v", err))
 	}
@@ -0,0 +1,50 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/dannyvelas/parkspot-backend/errs"
+)
+
+// setETag sets the ETag of a response of one row to the version of that row. clients send it back in the If-Match
+// header of their edits, so that edits of a row that changed since it was read are rejected
+func setETag(w http.ResponseWriter, version int) {
+	w.Header().Set("ETag", strconv.Quote(strconv.Itoa(version)))
+}
+
+// editVersion is the version of the row that an edit was made to: the one of the If-Match header of r, or else the
+// one of the body of r. fromIfMatch is whether it came from the If-Match header
+func editVersion(r *http.Request, bodyVersion int) (version int, fromIfMatch bool, err error) {
+	ifMatch := r.Header.Get("If-Match")
+	if ifMatch == "" {
+		if bodyVersion == 0 {
+			return 0, false, errs.MissingVersion
+		}
+		return bodyVersion, false, nil
+	}
+
+	// weak ETags are compared like strong ones, since the versions of rows are their only ETags
+	unquoted, err := strconv.Unquote(strings.TrimPrefix(ifMatch, "W/"))
+	if err != nil {
+		return 0, false, errs.InvalidIfMatch
+	}
+	version, err = strconv.Atoi(unquoted)
+	if err != nil || version < 1 {
+		return 0, false, errs.InvalidIfMatch
+	}
+
+	return version, true, nil
+}
+
+// respondEditError responds with err, which is a 412 instead of a 409 when it is a conflict of a version that was
+// sent in an If-Match header
+func respondEditError(w http.ResponseWriter, r *http.Request, err error, fromIfMatch bool) {
+	var apiErr *errs.APIErr
+	if fromIfMatch && errors.Is(err, errs.Conflict) && errors.As(err, &apiErr) {
+		err = errs.PreconditionFailed(apiErr)
+	}
+	respondError(w, r, err)
+}
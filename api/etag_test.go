@@ -0,0 +1,69 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dannyvelas/parkspot-backend/errs"
+)
+
+func TestEditVersion(t *testing.T) {
+	tests := map[string]struct {
+		ifMatch         string
+		bodyVersion     int
+		expected        int
+		fromIfMatch     bool
+		expectedErr     error
+		expectedErrCode int
+	}{
+		"if-match":               {ifMatch: `"3"`, expected: 3, fromIfMatch: true},
+		"weak if-match":          {ifMatch: `W/"3"`, expected: 3, fromIfMatch: true},
+		"if-match over body":     {ifMatch: `"3"`, bodyVersion: 2, expected: 3, fromIfMatch: true},
+		"body":                   {bodyVersion: 2, expected: 2},
+		"neither":                {expectedErr: errs.MissingVersion, expectedErrCode: http.StatusPreconditionRequired},
+		"unquoted if-match":      {ifMatch: "3", expectedErr: errs.InvalidIfMatch, expectedErrCode: http.StatusBadRequest},
+		"if-match of no version": {ifMatch: `"abc"`, expectedErr: errs.InvalidIfMatch, expectedErrCode: http.StatusBadRequest},
+		"if-match of any":        {ifMatch: "*", expectedErr: errs.InvalidIfMatch, expectedErrCode: http.StatusBadRequest},
+	}
+
+	for name, test := range tests {
+		r := httptest.NewRequest(http.MethodPut, "/api/car", nil)
+		if test.ifMatch != "" {
+			r.Header.Set("If-Match", test.ifMatch)
+		}
+
+		version, fromIfMatch, err := editVersion(r, test.bodyVersion)
+		if test.expectedErr != nil {
+			var apiErr *errs.APIErr
+			if !errors.Is(err, test.expectedErr) || !errors.As(err, &apiErr) || apiErr.StatusCode != test.expectedErrCode {
+				t.Errorf("%s failed: expected %v with status %d, got: %v", name, test.expectedErr, test.expectedErrCode, err)
+			}
+		} else if err != nil {
+			t.Errorf("%s failed: unexpected error: %v", name, err)
+		} else if version != test.expected || fromIfMatch != test.fromIfMatch {
+			t.Errorf("%s failed: expected version %d and fromIfMatch %t, got %d and %t", name, test.expected, test.fromIfMatch, version, fromIfMatch)
+		}
+	}
+}
+
+func TestRespondEditError(t *testing.T) {
+	tests := map[string]struct {
+		err            error
+		fromIfMatch    bool
+		expectedStatus int
+	}{
+		"conflict of if-match": {err: errs.NewConflict("car"), fromIfMatch: true, expectedStatus: http.StatusPreconditionFailed},
+		"conflict of body":     {err: errs.NewConflict("car"), expectedStatus: http.StatusConflict},
+		"other error":          {err: errs.NewNotFound("car"), fromIfMatch: true, expectedStatus: http.StatusNotFound},
+	}
+
+	for name, test := range tests {
+		w := httptest.NewRecorder()
+		respondEditError(w, httptest.NewRequest(http.MethodPut, "/api/car", nil), test.err, test.fromIfMatch)
+		if w.Code != test.expectedStatus {
+			t.Errorf("%s failed: expected status %d, got %d", name, test.expectedStatus, w.Code)
+		}
+	}
+}
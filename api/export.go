@@ -0,0 +1,64 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/dannyvelas/parkspot-backend/config"
+	"github.com/dannyvelas/parkspot-backend/errs"
+	"github.com/dannyvelas/parkspot-backend/export"
+	"github.com/rs/zerolog/log"
+)
+
+// respondExport streams the rows that forEach passes to its callback as a spreadsheet, in the format of the
+// format query param. nothing is sent until the first row, or until forEach returns, so that errors that
+// happen before the first row can still be responded with. the file is named after the day of location
+func respondExport[T any](w http.ResponseWriter, r *http.Request, name string, table export.Table[T], location *time.Location, forEach func(each func(T) error) error) {
+	format, ok := export.ParseFormat(r.URL.Query().Get("format"))
+	if !ok {
+		respondError(w, r, errs.InvalidExport)
+		return
+	}
+
+	var writer export.Writer
+	start := func() error {
+		lang := requestLang(r)
+		filename := fmt.Sprintf("%s-%s.%s", name, time.Now().In(location).Format(config.DateFormat), format)
+		w.Header().Set("Content-Language", string(lang))
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+		w.Header().Set("Content-Type", format.ContentType())
+		w.WriteHeader(http.StatusOK)
+
+		writer = export.NewWriter(w, format)
+		return writer.WriteRow(table.HeadersIn(lang))
+	}
+
+	err := forEach(func(row T) error {
+		if writer == nil {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+		return writer.WriteRow(table.Row(row))
+	})
+	if err != nil && writer == nil {
+		respondError(w, r, err)
+		return
+	} else if err != nil {
+		// the status of the response was already sent, so the client can only find out from the connection closing
+		log.Error().Msgf("export.respondExport: error exporting %s: %v", name, err)
+		panic(http.ErrAbortHandler)
+	}
+
+	if writer == nil {
+		if err := start(); err != nil {
+			log.Error().Msgf("export.respondExport: error writing headers of %s: %v", name, err)
+			panic(http.ErrAbortHandler)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		log.Error().Msgf("export.respondExport: error finishing %s: %v", name, err)
+		panic(http.ErrAbortHandler)
+	}
+}
@@ -0,0 +1,81 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dannyvelas/parkspot-backend/export"
+	"github.com/dannyvelas/parkspot-backend/i18n"
+)
+
+var testExportTable = export.Table[string]{
+	Headers: map[i18n.Lang][]string{
+		i18n.English: {"Name"},
+		i18n.Spanish: {"Nombre"},
+	},
+	Row: func(name string) []string { return []string{name} },
+}
+
+func exportNames(names ...string) func(each func(string) error) error {
+	return func(each func(string) error) error {
+		for _, name := range names {
+			if err := each(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func TestRespondExport_CSV(t *testing.T) {
+	tests := map[string]struct {
+		acceptLanguage string
+		names          []string
+		expected       string
+	}{
+		"english":       {acceptLanguage: "en", names: []string{"Ana", "Luis, Jr."}, expected: "Name\nAna\n\"Luis, Jr.\"\n"},
+		"spanish":       {acceptLanguage: "es", names: []string{"Ana"}, expected: "Nombre\nAna\n"},
+		"without rows":  {acceptLanguage: "en", expected: "Name\n"},
+		"fallback lang": {acceptLanguage: "fr", names: []string{"Ana"}, expected: "Name\nAna\n"},
+	}
+
+	for name, test := range tests {
+		request := httptest.NewRequest(http.MethodGet, "/residents/export", nil)
+		request.Header.Set("Accept-Language", test.acceptLanguage)
+		recorder := httptest.NewRecorder()
+		respondExport(recorder, request, "residents", testExportTable, time.UTC, exportNames(test.names...))
+
+		if got := recorder.Header().Get("Content-Type"); got != export.CSV.ContentType() {
+			t.Errorf("%s failed: expected %q, got %q", name, export.CSV.ContentType(), got)
+		}
+		if got := recorder.Body.String(); got != test.expected {
+			t.Errorf("%s failed: expected %q, got %q", name, test.expected, got)
+		}
+	}
+}
+
+func TestRespondExport_Errors(t *testing.T) {
+	tests := map[string]struct {
+		url      string
+		forEach  func(each func(string) error) error
+		expected int
+	}{
+		"unknown format":         {url: "/residents/export?format=pdf", forEach: exportNames("Ana"), expected: http.StatusBadRequest},
+		"error before first row": {url: "/residents/export", forEach: func(func(string) error) error { return errors.New("db down") }, expected: http.StatusInternalServerError},
+	}
+
+	for name, test := range tests {
+		recorder := httptest.NewRecorder()
+		respondExport(recorder, httptest.NewRequest(http.MethodGet, test.url, nil), "residents", testExportTable, time.UTC, test.forEach)
+
+		if recorder.Code != test.expected {
+			t.Errorf("%s failed: expected %d, got %d", name, test.expected, recorder.Code)
+		}
+		if got := recorder.Header().Get("Content-Disposition"); got != "" {
+			t.Errorf("%s failed: expected no attachment, got %q", name, got)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/dannyvelas/parkspot-backend/app"
+	"github.com/dannyvelas/parkspot-backend/errs"
+	"github.com/dannyvelas/parkspot-backend/models"
+	"github.com/dannyvelas/parkspot-backend/util"
+	"github.com/go-chi/chi/v5"
+	"net/http"
+)
+
+type gateEventHandler struct {
+	gateEventService app.GateEventService
+}
+
+func newGateEventHandler(gateEventService app.GateEventService) gateEventHandler {
+	return gateEventHandler{
+		gateEventService: gateEventService,
+	}
+}
+
+func (h gateEventHandler) get(status models.Status) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		limit := util.ToPosInt(r.URL.Query().Get("limit"))
+		page := util.ToPosInt(r.URL.Query().Get("page"))
+		search := r.URL.Query().Get("search")
+
+		gateEventsWithMetadata, err := h.gateEventService.GetAll(r.Context(), status, limit, page, search, "")
+		if err != nil {
+			respondError(w, r, err)
+			return
+		}
+
+		respondJSON(w, http.StatusOK, gateEventsWithMetadata)
+	}
+}
+
+func (h gateEventHandler) getOfResident() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		residentID := chi.URLParam(r, "id")
+		if residentID == "" {
+			respondError(w, r, errs.MissingIDField)
+			return
+		}
+		limit := util.ToPosInt(r.URL.Query().Get("limit"))
+		page := util.ToPosInt(r.URL.Query().Get("page"))
+
+		ctx := r.Context()
+		accessPayload, err := ctxGetAccessPayload(ctx)
+		if err != nil {
+			respondError(w, r, fmt.Errorf("gate_event_handler.getOfResident: error getting access payload: %v", err))
+			return
+		}
+
+		if accessPayload.Role == models.ResidentRole && residentID != accessPayload.ID {
+			respondError(w, r, errs.NewUnauthorized("resident cannot see arrivals of another resident"))
+			return
+		}
+
+		gateEventsWithMetadata, err := h.gateEventService.GetAll(ctx, models.AnyStatus, limit, page, "", residentID)
+		if err != nil {
+			respondError(w, r, err)
+			return
+		}
+
+		respondJSON(w, http.StatusOK, gateEventsWithMetadata)
+	}
+}
+
+func (h gateEventHandler) checkIn() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var desiredGateEvent models.GateEvent
+		if err := json.NewDecoder(r.Body).Decode(&desiredGateEvent); err != nil {
+			respondError(w, r, errs.Malformed("Check In Request"))
+			return
+		}
+
+		ctx := r.Context()
+		accessPayload, err := ctxGetAccessPayload(ctx)
+		if err != nil {
+			respondError(w, r, fmt.Errorf("gate_event_handler.checkIn: error getting access payload: %v", err))
+			return
+		}
+		desiredGateEvent.RecordedBy = accessPayload.ID
+
+		gateEvent, err := h.gateEventService.CheckIn(ctx, desiredGateEvent)
+		if err != nil {
+			respondError(w, r, err)
+			return
+		}
+
+		respondJSON(w, http.StatusOK, gateEvent)
+	}
+}
+
+func (h gateEventHandler) checkOut() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var desiredGateEvent models.GateEvent
+		if err := json.NewDecoder(r.Body).Decode(&desiredGateEvent); err != nil {
+			respondError(w, r, errs.Malformed("Check Out Request"))
+			return
+		}
+
+		gateEvent, err := h.gateEventService.CheckOut(r.Context(), desiredGateEvent)
+		if err != nil {
+			respondError(w, r, err)
+			return
+		}
+
+		respondJSON(w, http.StatusOK, gateEvent)
+	}
+}
@@ -0,0 +1,78 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/dannyvelas/parkspot-backend/app"
+	"github.com/dannyvelas/parkspot-backend/config"
+	"github.com/dannyvelas/parkspot-backend/errs"
+	"github.com/dannyvelas/parkspot-backend/util"
+)
+
+type importHandler struct {
+	importService app.ImportService
+}
+
+func newImportHandler(importService app.ImportService) importHandler {
+	return importHandler{
+		importService: importService,
+	}
+}
+
+// importResponse is the report of an import. it has the code and message of an error when rows have errors and
+// nothing was imported
+type importResponse struct {
+	*errorResponse
+	DryRun    bool             `json:"dryRun"`
+	Residents int              `json:"residents"`
+	Cars      int              `json:"cars"`
+	Errors    []importRowError `json:"errors"`
+}
+
+type importRowError struct {
+	Row int `json:"row"`
+	errorResponse
+}
+
+// importResidents imports the CSV in the body of the request. with dryRun=true, rows are only checked
+func (h importHandler) importResidents() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		dryRun := util.ToBool(r.URL.Query().Get("dryRun"))
+		body := http.MaxBytesReader(w, r.Body, config.MaxImportBytes)
+
+		report, err := h.importService.Import(r.Context(), body, dryRun)
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			respondError(w, r, errs.ImportTooLarge)
+			return
+		} else if err != nil {
+			respondError(w, r, err)
+			return
+		}
+
+		lang := requestLang(r)
+		response := importResponse{
+			DryRun:    report.DryRun,
+			Residents: report.Residents,
+			Cars:      report.Cars,
+			Errors:    make([]importRowError, 0, len(report.RowErrs)),
+		}
+		for _, rowErr := range report.RowErrs {
+			response.Errors = append(response.Errors, importRowError{
+				Row:           rowErr.Row,
+				errorResponse: newErrorResponse(rowErr.Err.In(lang)),
+			})
+		}
+
+		w.Header().Set("Content-Language", string(lang))
+		if len(report.RowErrs) != 0 && !dryRun {
+			apiErr := errs.ImportInvalidRows.In(lang)
+			response.errorResponse = &errorResponse{Code: apiErr.Code, Status: apiErr.StatusCode, Message: apiErr.Error()}
+			respondJSON(w, apiErr.StatusCode, response)
+			return
+		}
+
+		respondJSON(w, http.StatusOK, response)
+	}
+}
@@ -0,0 +1,78 @@
+package api
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/dannyvelas/parkspot-backend/app"
+	"github.com/dannyvelas/parkspot-backend/errs"
+	"github.com/dannyvelas/parkspot-backend/storage/selectopts"
+	"github.com/dannyvelas/parkspot-backend/util"
+)
+
+// newListParams reads the query params of a request for a sortable list. filters are sent as filter params,
+// like ?filter=startDate>=2025-01-01&filter=affectsDays=false
+func newListParams(r *http.Request) (app.ListParams, error) {
+	query := r.URL.Query()
+
+	filters, err := parseFilters(query["filter"])
+	if err != nil {
+		return app.ListParams{}, err
+	}
+
+	return app.ListParams{
+		Limit:    util.ToPosInt(query.Get("limit")),
+		Page:     util.ToPosInt(query.Get("page")),
+		Reversed: util.ToBool(query.Get("reversed")),
+		Sort:     query.Get("sort"),
+		Cursor:   query.Get("cursor"),
+		Search:   query.Get("search"),
+		Filters:  filters,
+	}, nil
+}
+
+var filterFieldRe = regexp.MustCompile(`^[a-zA-Z]+$`)
+
+// parseFilters parses filters of the form <field><operator><value>, like make=honda. whether a list can be
+// filtered by a field, and whether the value has the type of the field, is checked by the app
+func parseFilters(rawFilters []string) ([]selectopts.Filter, error) {
+	var filters []selectopts.Filter
+	var fieldErrs []errs.FieldError
+	for _, rawFilter := range rawFilters {
+		filter, ok := parseFilter(rawFilter)
+		if !ok {
+			fieldErrs = append(fieldErrs, errs.NewFieldError("filter", "format",
+				"filter "+rawFilter+" must be a field, an operator (=, !=, >, >=, <, <=) and a value, like startDate>=2025-01-01"))
+			continue
+		}
+		filters = append(filters, filter)
+	}
+
+	if len(fieldErrs) != 0 {
+		return nil, errs.InvalidFields(fieldErrs...)
+	}
+	return filters, nil
+}
+
+func parseFilter(rawFilter string) (selectopts.Filter, bool) {
+	// the operator is the first one in rawFilter. operators that start with another come first in
+	// selectopts.Ops, so that >= is not read as >
+	opIndex, op := -1, selectopts.Op("")
+	for _, candidate := range selectopts.Ops {
+		i := strings.Index(rawFilter, string(candidate))
+		if i >= 0 && (opIndex < 0 || i < opIndex) {
+			opIndex, op = i, candidate
+		}
+	}
+	if opIndex < 0 {
+		return selectopts.Filter{}, false
+	}
+
+	field, value := rawFilter[:opIndex], rawFilter[opIndex+len(op):]
+	if !filterFieldRe.MatchString(field) || value == "" {
+		return selectopts.Filter{}, false
+	}
+
+	return selectopts.Filter{Field: field, Op: op, Value: value}, true
+}
@@ -0,0 +1,34 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/dannyvelas/parkspot-backend/storage/selectopts"
+)
+
+func TestParseFilter(t *testing.T) {
+	tests := map[string]struct {
+		rawFilter string
+		expected  selectopts.Filter
+		ok        bool
+	}{
+		"equal":                    {rawFilter: "make=honda", expected: selectopts.Filter{Field: "make", Op: selectopts.Eq, Value: "honda"}, ok: true},
+		"not equal":                {rawFilter: "make!=honda", expected: selectopts.Filter{Field: "make", Op: selectopts.NotEq, Value: "honda"}, ok: true},
+		"greater than or equal":    {rawFilter: "startDate>=2025-01-01", expected: selectopts.Filter{Field: "startDate", Op: selectopts.Gte, Value: "2025-01-01"}, ok: true},
+		"less than":                {rawFilter: "amtParkingDaysUsed<3", expected: selectopts.Filter{Field: "amtParkingDaysUsed", Op: selectopts.Lt, Value: "3"}, ok: true},
+		"operator in value":        {rawFilter: "model=a=b", expected: selectopts.Filter{Field: "model", Op: selectopts.Eq, Value: "a=b"}, ok: true},
+		"no operator":              {rawFilter: "honda", ok: false},
+		"no field":                 {rawFilter: "=honda", ok: false},
+		"no value":                 {rawFilter: "make=", ok: false},
+		"field that is not a word": {rawFilter: "make;--=honda", ok: false},
+	}
+
+	for name, test := range tests {
+		filter, ok := parseFilter(test.rawFilter)
+		if ok != test.ok {
+			t.Errorf("%s failed: expected ok to be %t, got %t", name, test.ok, ok)
+		} else if filter != test.expected {
+			t.Errorf("%s failed: expected %+v, got %+v", name, test.expected, filter)
+		}
+	}
+}
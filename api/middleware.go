@@ -1,9 +1,11 @@
 package api
 
 import (
+	"context"
 	"net/http"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/dannyvelas/parkspot-backend/app"
 	"github.com/dannyvelas/parkspot-backend/errs"
@@ -26,14 +28,14 @@ func (m middleware) authenticate(firstRole models.Role, roles ...models.Role) fu
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			authHeader := r.Header.Get("Authorization")
 			if !strings.HasPrefix(authHeader, "Bearer ") {
-				respondError(w, errs.Unauthorized)
+				respondError(w, r, errs.Unauthorized)
 				return
 			}
 
 			accessToken := strings.TrimPrefix(authHeader, "Bearer ")
 			accessPayload, err := m.jwtService.ParseAccess(accessToken)
 			if err != nil {
-				respondError(w, errs.Unauthorized)
+				respondError(w, r, errs.Unauthorized)
 				return
 			}
 
@@ -41,7 +43,7 @@ func (m middleware) authenticate(firstRole models.Role, roles ...models.Role) fu
 			userHasPermittedRole := slices.Contains(permittedRoles, accessPayload.Role)
 			if !userHasPermittedRole {
 				log.Debug().Msgf("User role: %s, not in permittedRoles: %v", accessPayload.Role, permittedRoles)
-				respondError(w, errs.Unauthorized)
+				respondError(w, r, errs.Unauthorized)
 				return
 			}
 
@@ -53,3 +55,42 @@ func (m middleware) authenticate(firstRole models.Role, roles ...models.Role) fu
 		})
 	}
 }
+
+// deadlineKey is the key of the timer of withDeadline in the context of a request
+type deadlineKey struct{}
+
+// withDeadline cancels the context of requests once their response can't be written anymore, so that the queries
+// of requests that ran past the WriteTimeout of the server stop instead of running for nobody. routes that are
+// expected to take longer lift it with withoutDeadline
+func withDeadline(timeout time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if timeout <= 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			// a timer instead of context.WithTimeout, because the deadline of a context can't be lifted
+			ctx, cancel := context.WithCancelCause(r.Context())
+			defer cancel(nil)
+			deadline := time.AfterFunc(timeout, func() { cancel(context.DeadlineExceeded) })
+			defer deadline.Stop()
+
+			next.ServeHTTP(w, r.WithContext(context.WithValue(ctx, deadlineKey{}, deadline)))
+		})
+	}
+}
+
+// withoutDeadline lifts the deadline of withDeadline and the WriteTimeout of the server, for routes that take longer
+// than it on purpose: exports stream every row, and imports hash the password of every row. their requests still
+// stop when their client goes away
+func withoutDeadline(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if deadline, ok := r.Context().Value(deadlineKey{}).(*time.Timer); ok {
+			deadline.Stop()
+		}
+		_ = http.NewResponseController(w).SetWriteDeadline(time.Time{})
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,34 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWithDeadline(t *testing.T) {
+	const timeout = 10 * time.Millisecond
+
+	tests := map[string]struct {
+		route         func(http.Handler) http.Handler
+		expectedAlive bool
+	}{
+		"route with deadline":    {route: func(next http.Handler) http.Handler { return next }, expectedAlive: false},
+		"route without deadline": {route: withoutDeadline, expectedAlive: true},
+	}
+	for testName, test := range tests {
+		t.Run(testName, func(t *testing.T) {
+			var alive bool
+			handler := withDeadline(timeout)(test.route(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				time.Sleep(3 * timeout)
+				alive = r.Context().Err() == nil
+			})))
+
+			handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+			if alive != test.expectedAlive {
+				t.Fatalf("expected the context of the request to be alive: %t, got %t", test.expectedAlive, alive)
+			}
+		})
+	}
+}
@@ -0,0 +1,381 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dannyvelas/parkspot-backend/export"
+	"github.com/go-chi/chi/v5"
+)
+
+// openAPIDoc is an OpenAPI 3 document. only the parts of the specification that this API uses are modeled
+type openAPIDoc struct {
+	OpenAPI    string                                 `json:"openapi"`
+	Info       openAPIInfo                            `json:"info"`
+	Servers    []openAPIServer                        `json:"servers"`
+	Paths      map[string]map[string]openAPIOperation `json:"paths"`
+	Components openAPIComponents                      `json:"components"`
+}
+
+type openAPIInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type openAPIServer struct {
+	URL string `json:"url"`
+}
+
+type openAPIComponents struct {
+	Schemas         map[string]*openAPISchema        `json:"schemas"`
+	SecuritySchemes map[string]openAPISecurityScheme `json:"securitySchemes"`
+}
+
+type openAPISecurityScheme struct {
+	Type         string `json:"type"`
+	Scheme       string `json:"scheme"`
+	BearerFormat string `json:"bearerFormat"`
+}
+
+type openAPIOperation struct {
+	Summary     string                     `json:"summary"`
+	Parameters  []openAPIParameter         `json:"parameters,omitempty"`
+	RequestBody *openAPIRequestBody        `json:"requestBody,omitempty"`
+	Responses   map[string]openAPIResponse `json:"responses"`
+	Security    []map[string][]string      `json:"security,omitempty"`
+}
+
+type openAPIParameter struct {
+	Name     string         `json:"name"`
+	In       string         `json:"in"`
+	Required bool           `json:"required,omitempty"`
+	Schema   *openAPISchema `json:"schema"`
+}
+
+type openAPIRequestBody struct {
+	Required bool                        `json:"required"`
+	Content  map[string]openAPIMediaType `json:"content"`
+}
+
+type openAPIResponse struct {
+	Description string                      `json:"description"`
+	Content     map[string]openAPIMediaType `json:"content,omitempty"`
+}
+
+type openAPIMediaType struct {
+	Schema *openAPISchema `json:"schema"`
+}
+
+type openAPISchema struct {
+	Ref                  string                    `json:"$ref,omitempty"`
+	Type                 string                    `json:"type,omitempty"`
+	Format               string                    `json:"format,omitempty"`
+	Nullable             bool                      `json:"nullable,omitempty"`
+	Items                *openAPISchema            `json:"items,omitempty"`
+	Properties           map[string]*openAPISchema `json:"properties,omitempty"`
+	AdditionalProperties *openAPISchema            `json:"additionalProperties,omitempty"`
+}
+
+// openAPIHandler serves the OpenAPI document of the route tree of version v. the document is generated on the
+// first request, once every route has been registered
+func openAPIHandler(router chi.Routes, v apiVersion) http.HandlerFunc {
+	var once sync.Once
+	var doc openAPIDoc
+	var err error
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		once.Do(func() {
+			doc, err = newOpenAPIDoc(router, v)
+		})
+		if err != nil {
+			respondError(w, r, fmt.Errorf("openapi.openAPIHandler: error generating document: %v", err))
+			return
+		}
+
+		respondJSON(w, http.StatusOK, doc)
+	}
+}
+
+// newOpenAPIDoc documents every route of the route tree of version v with its entry in the routeDocs of v.
+// routes that are missing from them are left out of the document. routeDocCoverage reports them
+func newOpenAPIDoc(router chi.Routes, v apiVersion) (openAPIDoc, error) {
+	docs := newRouteDocs(v.permits)
+	doc := openAPIDoc{
+		OpenAPI: "3.0.3",
+		Info:    openAPIInfo{Title: "Park Spot API", Version: v.name},
+		Servers: []openAPIServer{{URL: "/api/" + v.name}},
+		Paths:   map[string]map[string]openAPIOperation{},
+		Components: openAPIComponents{
+			Schemas: map[string]*openAPISchema{},
+			SecuritySchemes: map[string]openAPISecurityScheme{
+				"bearerAuth": {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+			},
+		},
+	}
+
+	err := chi.Walk(router, func(method string, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		routeDoc, ok := docs[method+" "+route]
+		if !ok {
+			return nil
+		}
+
+		path, pathParams := openAPIPath(route)
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = map[string]openAPIOperation{}
+		}
+		doc.Paths[path][strings.ToLower(method)] = routeDoc.operation(pathParams, doc.Components.Schemas)
+		return nil
+	})
+	if err != nil {
+		return openAPIDoc{}, fmt.Errorf("error walking router: %v", err)
+	}
+
+	return doc, nil
+}
+
+// routeDocCoverage returns the routes of the route tree of version v that have no entry in the routeDocs of v,
+// and the entries of those routeDocs that are not routes of the tree
+func routeDocCoverage(router chi.Routes, v apiVersion) (undocumented []string, stale []string, err error) {
+	routeDocs := newRouteDocs(v.permits)
+	routed := map[string]bool{}
+	err = chi.Walk(router, func(method string, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		key := method + " " + route
+		routed[key] = true
+		if _, ok := routeDocs[key]; !ok {
+			undocumented = append(undocumented, key)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("error walking router: %v", err)
+	}
+
+	for key := range routeDocs {
+		if !routed[key] {
+			stale = append(stale, key)
+		}
+	}
+
+	sort.Strings(undocumented)
+	sort.Strings(stale)
+	return undocumented, stale, nil
+}
+
+var chiParamRe = regexp.MustCompile(`\{([^}:]+)(:[^}]+)?\}`)
+
+// openAPIPath turns a chi route, like /permit/{id:[0-9]+}, into an OpenAPI path, like /permit/{id}
+func openAPIPath(route string) (string, []string) {
+	var params []string
+	for _, match := range chiParamRe.FindAllStringSubmatch(route, -1) {
+		params = append(params, match[1])
+	}
+
+	return chiParamRe.ReplaceAllString(route, "{$1}"), params
+}
+
+// routeDoc documents one route. request and response are values of the types of the bodies of the route
+type routeDoc struct {
+	summary  string
+	public   bool // public routes don't need an access token
+	query    []queryParam
+	request  any
+	response any
+	// accepted is the body of a 202 response, for routes that can accept a request without completing it
+	accepted any
+	// export routes respond with a CSV or XLSX file instead of with response
+	export bool
+	// csvRequest routes take a CSV file as their body instead of request
+	csvRequest bool
+}
+
+type queryParam struct {
+	name       string
+	schemaType string
+}
+
+var (
+	limitParam    = queryParam{"limit", "integer"}
+	pageParam     = queryParam{"page", "integer"}
+	searchParam   = queryParam{"search", "string"}
+	reversedParam = queryParam{"reversed", "boolean"}
+	sortParam     = queryParam{"sort", "string"}
+	cursorParam   = queryParam{"cursor", "string"}
+	filterParam   = queryParam{"filter", "string"}
+	listParams    = []queryParam{limitParam, pageParam, searchParam}
+	// sortableListParams are the params of lists that can be sorted by other fields than id, paged by cursor and filtered
+	sortableListParams = []queryParam{limitParam, pageParam, searchParam, sortParam, cursorParam, filterParam}
+	// reversibleListParams are the params of sortable lists that can also be sorted by id in reverse
+	reversibleListParams = []queryParam{limitParam, pageParam, searchParam, reversedParam, sortParam, cursorParam, filterParam}
+	formatParam          = queryParam{"format", "string"}
+	// exportParams are the params of the exports of sortable lists, which export every page
+	exportParams = []queryParam{searchParam, sortParam, filterParam, formatParam}
+	// reversibleExportParams are the params of the exports of reversible lists
+	reversibleExportParams = []queryParam{searchParam, reversedParam, sortParam, filterParam, formatParam}
+)
+
+func (d routeDoc) operation(pathParams []string, schemas map[string]*openAPISchema) openAPIOperation {
+	operation := openAPIOperation{
+		Summary: d.summary,
+		Responses: map[string]openAPIResponse{
+			"200": jsonResponse("OK", d.response, schemas),
+			"default": jsonResponse(
+				"Error. code is a stable identifier of the error, and fields lists the invalid fields of the request, if any",
+				errorResponse{}, schemas),
+		},
+	}
+
+	for _, name := range pathParams {
+		operation.Parameters = append(operation.Parameters, openAPIParameter{
+			Name:     name,
+			In:       "path",
+			Required: true,
+			Schema:   &openAPISchema{Type: "string"},
+		})
+	}
+	for _, param := range d.query {
+		operation.Parameters = append(operation.Parameters, openAPIParameter{
+			Name:   param.name,
+			In:     "query",
+			Schema: &openAPISchema{Type: param.schemaType},
+		})
+	}
+
+	if d.request != nil {
+		operation.RequestBody = &openAPIRequestBody{
+			Required: true,
+			Content:  map[string]openAPIMediaType{"application/json": {Schema: schemaOf(reflect.TypeOf(d.request), schemas)}},
+		}
+	}
+	if d.csvRequest {
+		operation.RequestBody = &openAPIRequestBody{
+			Required: true,
+			Content:  map[string]openAPIMediaType{"text/csv": {Schema: &openAPISchema{Type: "string"}}},
+		}
+	}
+	if d.export {
+		operation.Responses["200"] = openAPIResponse{
+			Description: "OK. a CSV file, or an XLSX file when format is xlsx",
+			Content: map[string]openAPIMediaType{
+				export.CSV.ContentType():  {Schema: &openAPISchema{Type: "string"}},
+				export.XLSX.ContentType(): {Schema: &openAPISchema{Type: "string", Format: "binary"}},
+			},
+		}
+	}
+	if d.accepted != nil {
+		operation.Responses["202"] = jsonResponse("Accepted", d.accepted, schemas)
+	}
+	if !d.public {
+		operation.Security = []map[string][]string{{"bearerAuth": {}}}
+	}
+
+	return operation
+}
+
+func jsonResponse(description string, body any, schemas map[string]*openAPISchema) openAPIResponse {
+	schema := &openAPISchema{}
+	if body != nil {
+		schema = schemaOf(reflect.TypeOf(body), schemas)
+	}
+
+	return openAPIResponse{
+		Description: description,
+		Content:     map[string]openAPIMediaType{"application/json": {Schema: schema}},
+	}
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// schemaOf describes how t is encoded as JSON. named structs are added to schemas and referenced
+func schemaOf(t reflect.Type, schemas map[string]*openAPISchema) *openAPISchema {
+	if t == timeType {
+		return &openAPISchema{Type: "string", Format: "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		schema := schemaOf(t.Elem(), schemas)
+		if schema.Ref == "" {
+			schema.Nullable = true
+		}
+		return schema
+	case reflect.Bool:
+		return &openAPISchema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &openAPISchema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return &openAPISchema{Type: "integer", Format: "int64"}
+	case reflect.Float32, reflect.Float64:
+		return &openAPISchema{Type: "number"}
+	case reflect.String:
+		return &openAPISchema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		return &openAPISchema{Type: "array", Items: schemaOf(t.Elem(), schemas)}
+	case reflect.Map:
+		return &openAPISchema{Type: "object", AdditionalProperties: schemaOf(t.Elem(), schemas)}
+	case reflect.Struct:
+		name := schemaName(t)
+		if name == "" {
+			return structSchema(t, schemas)
+		}
+		if _, ok := schemas[name]; !ok {
+			// registered before its fields are described, so that recursive types terminate
+			schemas[name] = &openAPISchema{}
+			*schemas[name] = *structSchema(t, schemas)
+		}
+		return &openAPISchema{Ref: "#/components/schemas/" + name}
+	default:
+		return &openAPISchema{}
+	}
+}
+
+func structSchema(t reflect.Type, schemas map[string]*openAPISchema) *openAPISchema {
+	schema := &openAPISchema{Type: "object", Properties: map[string]*openAPISchema{}}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		// the fields of embedded structs are encoded as fields of t
+		if field.Anonymous && field.Tag.Get("json") == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				for name, property := range structSchema(embedded, schemas).Properties {
+					schema.Properties[name] = property
+				}
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		} else if name == "" {
+			name = field.Name
+		}
+
+		schema.Properties[name] = schemaOf(field.Type, schemas)
+	}
+	return schema
+}
+
+var typeArgPkgRe = regexp.MustCompile(`[\w./-]+\.`)
+
+// schemaName is the name of a named struct without its package, starting in uppercase. type arguments are
+// appended to the name, so ListWithMetadata[models.Permit] is named ListWithMetadata_Permit
+func schemaName(t reflect.Type) string {
+	name := typeArgPkgRe.ReplaceAllString(t.Name(), "")
+	name = strings.NewReplacer("[", "_", "]", "", ",", "_", "*", "").Replace(name)
+	if name == "" {
+		return ""
+	}
+	return strings.ToUpper(name[:1]) + name[1:]
+}
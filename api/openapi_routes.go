@@ -0,0 +1,122 @@
+package api
+
+import (
+	"github.com/dannyvelas/parkspot-backend/app"
+	"github.com/dannyvelas/parkspot-backend/errs"
+	"github.com/dannyvelas/parkspot-backend/models"
+)
+
+// newRouteDocs documents every route of a version of the API, keyed by method and chi route relative to the
+// version. permits is how that version shapes permits.
+// TestOpenAPI_CoversRouter fails when a route is added to newRouter without being added here
+func newRouteDocs(permits permitShape) map[string]routeDoc {
+	permit := permits.encode(models.Permit{})
+	permitList := permits.encodeList(models.ListWithMetadata[models.Permit]{})
+
+	return map[string]routeDoc{
+		"GET /openapi.json": {summary: "This document", public: true, response: map[string]any{}},
+
+		// auth
+		"POST /login":                {summary: "Log in. The refresh token is set as a cookie", public: true, request: loginReq{}, response: app.Session{}},
+		"POST /logout":               {summary: "Log out by clearing the refresh token cookie", public: true, response: message{}},
+		"POST /refresh-tokens":       {summary: "Exchange the refresh token cookie for a new session", public: true, response: app.Session{}},
+		"POST /password-reset-email": {summary: "Email a password reset link to a user", public: true, request: passwordResetEmailReq{}, response: message{}},
+		"PUT /user/password":         {summary: "Reset the password of the user of the access token", request: passwordResetReq{}, response: message{}},
+		"PUT /user/preferred-lang":   {summary: "Choose the language of the user's account", request: preferredLangReq{}, response: message{}},
+		"GET /hello":                 {summary: "Greet the user of the access token", response: ""},
+
+		// residents
+		"GET /residents":        {summary: "List residents", query: sortableListParams, response: models.ListWithMetadata[models.Resident]{}},
+		"GET /residents/export": {summary: "Export residents", query: exportParams, export: true},
+		"GET /resident/{id}":    {summary: "Get a resident", response: models.Resident{}},
+		"POST /residents/import": {
+			summary:    "Import residents and their cars from a CSV. With dryRun=true, rows are only checked",
+			query:      []queryParam{{"dryRun", "boolean"}},
+			csvRequest: true,
+			response:   importResponse{},
+		},
+		"POST /resident":        {summary: "Create a resident", request: models.Resident{}, response: models.Resident{}},
+		"PUT /resident":         {summary: "Edit a resident, given the ETag of it in If-Match or its version", request: models.Resident{}, response: models.Resident{}},
+		"DELETE /resident/{id}": {summary: "Delete a resident", response: message{}},
+
+		// cars
+		"GET /cars":               {summary: "List cars. Residents only see their own", query: reversibleListParams, response: models.ListWithMetadata[models.Car]{}},
+		"GET /cars/export":        {summary: "Export cars. Residents only export their own", query: reversibleExportParams, export: true},
+		"GET /car/{id}":           {summary: "Get a car", response: models.Car{}},
+		"GET /resident/{id}/cars": {summary: "List the cars of a resident", response: models.ListWithMetadata[models.Car]{}},
+		"POST /car":               {summary: "Create a car", request: models.Car{}, response: models.Car{}},
+		"PUT /car":                {summary: "Edit a car, given the ETag of it in If-Match or its version", request: models.Car{}, response: models.Car{}},
+		"DELETE /car/{id}":        {summary: "Delete a car", response: message{}},
+
+		// permits
+		"GET /permits/all":               {summary: "List permits. Residents only see their own", query: reversibleListParams, response: permitList},
+		"GET /permits/active":            {summary: "List active permits. Residents only see their own", query: reversibleListParams, response: permitList},
+		"GET /permits/exceptions":        {summary: "List permits with exceptions. Residents only see their own", query: reversibleListParams, response: permitList},
+		"GET /permits/expired":           {summary: "List expired permits. Residents only see their own", query: reversibleListParams, response: permitList},
+		"GET /permits/all/export":        {summary: "Export permits. Residents only export their own", query: reversibleExportParams, export: true},
+		"GET /permits/active/export":     {summary: "Export active permits. Residents only export their own", query: reversibleExportParams, export: true},
+		"GET /permits/exceptions/export": {summary: "Export permits with exceptions. Residents only export their own", query: reversibleExportParams, export: true},
+		"GET /permits/expired/export":    {summary: "Export expired permits. Residents only export their own", query: reversibleExportParams, export: true},
+		"GET /permit/{id:[0-9]+}":        {summary: "Get a permit", response: permit},
+		"POST /permit": {
+			summary:  "Create a permit. With waitlist=true, requests that are blocked by capacity or quota are waitlisted instead",
+			query:    []queryParam{{"waitlist", "boolean"}},
+			request:  permit,
+			response: permit,
+			accepted: models.WaitlistEntry{},
+		},
+		"PUT /permit":                {summary: "Edit the car of a permit, given the ETag of it in If-Match or its version", request: permit, response: permit},
+		"DELETE /permit/{id:[0-9]+}": {summary: "Delete a permit", response: message{}},
+		"POST /permit/quote":         {summary: "Report every check that a permit request would fail, without creating it", request: permit, response: quoteResponse{}},
+		"POST /permit/dry-run":       {summary: "Report the permit rules that a permit request would break, without creating it", request: permit, response: []errs.RuleError{}},
+
+		// waitlist
+		"GET /waitlist":         {summary: "List waitlisted permit requests. Residents only see their own", query: []queryParam{limitParam, pageParam, {"status", "string"}}, response: models.ListWithMetadata[models.WaitlistEntry]{}},
+		"DELETE /waitlist/{id}": {summary: "Cancel a waitlisted permit request", response: message{}},
+
+		// visitors
+		"GET /visitors/active":        {summary: "List visitors with active access. Residents only see their own", query: sortableListParams, response: models.ListWithMetadata[models.Visitor]{}},
+		"GET /visitors/active/export": {summary: "Export visitors with active access. Residents only export their own", query: exportParams, export: true},
+		"GET /visitor/{id}":           {summary: "Get a visitor of the resident", response: models.Visitor{}},
+		"POST /visitor":               {summary: "Create a visitor", request: models.Visitor{}, response: models.Visitor{}},
+		"PUT /visitor":                {summary: "Edit a visitor, given the ETag of it in If-Match or its version", request: models.Visitor{}, response: models.Visitor{}},
+		"DELETE /visitor/{id}":        {summary: "Delete a visitor", response: message{}},
+
+		// gate events
+		"POST /gate-event/check-in":    {summary: "Check a guest in at the gate", request: models.GateEvent{}, response: models.GateEvent{}},
+		"POST /gate-event/check-out":   {summary: "Check a guest out at the gate", request: models.GateEvent{}, response: models.GateEvent{}},
+		"GET /gate-events/on-property": {summary: "List guests that are on the property", query: listParams, response: models.ListWithMetadata[models.GateEvent]{}},
+		"GET /gate-events/overstays":   {summary: "List guests that stayed past their pass", query: listParams, response: models.ListWithMetadata[models.GateEvent]{}},
+		"GET /resident/{id}/arrivals":  {summary: "List the arrivals of the guests of a resident", query: []queryParam{limitParam, pageParam}, response: models.ListWithMetadata[models.GateEvent]{}},
+		"GET /plates/{plate}/status":   {summary: "Look up whether a license plate is allowed to park", response: models.PlateStatus{}},
+
+		// violations
+		"GET /violations":                {summary: "List violations. Residents only see their own", query: listParams, response: models.ListWithMetadata[models.Violation]{}},
+		"GET /violation/{id}":            {summary: "Get a violation", response: models.Violation{}},
+		"POST /violation":                {summary: "Record a violation", request: models.Violation{}, response: models.Violation{}},
+		"GET /violation/{id}/tow-notice": {summary: "Generate the tow notice of a violation", response: models.TowNotice{}},
+		"PUT /violation/{id}/dispute":    {summary: "Dispute a violation", request: disputeReq{}, response: models.Violation{}},
+		"PUT /violation/{id}/resolve":    {summary: "Resolve a disputed violation", request: resolveReq{}, response: models.Violation{}},
+
+		// bans
+		"GET /bans/all":     {summary: "List bans", query: listParams, response: models.ListWithMetadata[models.Ban]{}},
+		"GET /bans/active":  {summary: "List active bans", query: listParams, response: models.ListWithMetadata[models.Ban]{}},
+		"GET /bans/expired": {summary: "List expired bans", query: listParams, response: models.ListWithMetadata[models.Ban]{}},
+		"POST /ban":         {summary: "Ban a license plate or a visitor", request: models.Ban{}, response: models.Ban{}},
+		"DELETE /ban/{id}":  {summary: "Delete a ban", response: message{}},
+
+		// parking spaces
+		"GET /parking-spaces":              {summary: "List parking spaces", query: []queryParam{{"zone", "string"}}, response: []models.ParkingSpace{}},
+		"GET /parking-zones":               {summary: "List parking zones and their capacity", response: []models.ZoneCapacity{}},
+		"GET /parking-spaces/availability": {summary: "Count the free guest parking spaces of each day", query: []queryParam{{"startDate", "string"}, {"endDate", "string"}}, response: []models.DayAvailability{}},
+		"POST /parking-space":              {summary: "Create a parking space", request: models.ParkingSpace{}, response: models.ParkingSpace{}},
+		"DELETE /parking-space/{id}":       {summary: "Delete a parking space", response: message{}},
+
+		// parking rules
+		"GET /parking-policy":      {summary: "Get the parking policy of the community", response: models.ParkingPolicy{}},
+		"PUT /parking-policy":      {summary: "Edit the parking policy of the community", request: models.ParkingPolicy{}, response: models.ParkingPolicy{}},
+		"GET /permit-rules":        {summary: "List permit rules", query: []queryParam{{"target", "string"}}, response: []models.PermitRule{}},
+		"POST /permit-rule":        {summary: "Create a permit rule", request: models.PermitRule{}, response: models.PermitRule{}},
+		"DELETE /permit-rule/{id}": {summary: "Delete a permit rule", response: message{}},
+	}
+}
@@ -0,0 +1,104 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dannyvelas/parkspot-backend/app"
+	"github.com/dannyvelas/parkspot-backend/config"
+)
+
+// none of the handlers run in these tests, so the router doesn't need a database
+func TestOpenAPI_CoversRouter(t *testing.T) {
+	router := newRouter(config.Config{}, app.App{})
+	versions := map[string]apiVersion{
+		"/api/*":    newV1(config.HTTPConfig{}),
+		"/api/v1/*": newV1(config.HTTPConfig{}),
+		"/api/v2/*": newV2(time.UTC),
+	}
+
+	for _, route := range router.Routes() {
+		if route.SubRoutes == nil {
+			continue
+		}
+
+		v, ok := versions[route.Pattern]
+		if !ok {
+			t.Errorf("%s is a route tree of newRouter but it is not a version of the API", route.Pattern)
+			continue
+		}
+
+		undocumented, stale, err := routeDocCoverage(route.SubRoutes, v)
+		if err != nil {
+			t.Fatalf("error checking the coverage of the OpenAPI document of %s: %v", route.Pattern, err)
+		}
+
+		for _, key := range undocumented {
+			t.Errorf("%s is a route of %s but it is missing from routeDocs", key, route.Pattern)
+		}
+		for _, key := range stale {
+			t.Errorf("%s is in routeDocs but it is not a route of %s", key, route.Pattern)
+		}
+	}
+}
+
+func TestOpenAPI_Served(t *testing.T) {
+	for _, path := range []string{"/api/openapi.json", "/api/v1/openapi.json", "/api/v2/openapi.json"} {
+		testOpenAPIServed(t, path)
+	}
+}
+
+func testOpenAPIServed(t *testing.T, path string) {
+	router := newRouter(config.Config{}, app.App{})
+
+	request := httptest.NewRequest(http.MethodGet, path, nil)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("%s: expected status 200, got %d: %s", path, recorder.Code, recorder.Body.String())
+	}
+
+	var doc openAPIDoc
+	if err := json.NewDecoder(recorder.Body).Decode(&doc); err != nil {
+		t.Fatalf("%s: error decoding document: %v", path, err)
+	}
+
+	if _, ok := doc.Paths["/permit/{id}"]["get"]; !ok {
+		t.Errorf("%s: expected chi params with patterns to be documented as plain OpenAPI path params", path)
+	}
+
+	// every reference must point to a schema of the document
+	var checkRefs func(path string, schema *openAPISchema)
+	checkRefs = func(location string, schema *openAPISchema) {
+		if schema == nil {
+			return
+		}
+		if name, ok := strings.CutPrefix(schema.Ref, "#/components/schemas/"); ok {
+			if _, ok := doc.Components.Schemas[name]; !ok {
+				t.Errorf("%s references %s, which is not in components", location, schema.Ref)
+			}
+		}
+		checkRefs(location, schema.Items)
+		checkRefs(location, schema.AdditionalProperties)
+		for _, property := range schema.Properties {
+			checkRefs(location, property)
+		}
+	}
+	for docPath, operations := range doc.Paths {
+		for method, operation := range operations {
+			if operation.RequestBody != nil {
+				checkRefs(path+": "+method+" "+docPath, operation.RequestBody.Content["application/json"].Schema)
+			}
+			for _, response := range operation.Responses {
+				checkRefs(path+": "+method+" "+docPath, response.Content["application/json"].Schema)
+			}
+		}
+	}
+	for name, schema := range doc.Components.Schemas {
+		checkRefs(path+": "+name, schema)
+	}
+}
@@ -0,0 +1,49 @@
+package api
+
+import (
+	"encoding/json"
+	"github.com/dannyvelas/parkspot-backend/app"
+	"github.com/dannyvelas/parkspot-backend/errs"
+	"github.com/dannyvelas/parkspot-backend/models"
+	"net/http"
+)
+
+type parkingPolicyHandler struct {
+	parkingPolicyService app.ParkingPolicyService
+}
+
+func newParkingPolicyHandler(parkingPolicyService app.ParkingPolicyService) parkingPolicyHandler {
+	return parkingPolicyHandler{
+		parkingPolicyService: parkingPolicyService,
+	}
+}
+
+func (h parkingPolicyHandler) get() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		policy, err := h.parkingPolicyService.Get(r.Context())
+		if err != nil {
+			respondError(w, r, err)
+			return
+		}
+
+		respondJSON(w, http.StatusOK, policy)
+	}
+}
+
+func (h parkingPolicyHandler) edit() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var editPolicyReq models.ParkingPolicy
+		if err := json.NewDecoder(r.Body).Decode(&editPolicyReq); err != nil {
+			respondError(w, r, errs.Malformed("EditParkingPolicyReq"))
+			return
+		}
+
+		policy, err := h.parkingPolicyService.Update(r.Context(), editPolicyReq)
+		if err != nil {
+			respondError(w, r, err)
+			return
+		}
+
+		respondJSON(w, http.StatusOK, policy)
+	}
+}
@@ -0,0 +1,117 @@
+package api
+
+import (
+	"encoding/json"
+	"github.com/dannyvelas/parkspot-backend/app"
+	"github.com/dannyvelas/parkspot-backend/config"
+	"github.com/dannyvelas/parkspot-backend/errs"
+	"github.com/dannyvelas/parkspot-backend/models"
+	"github.com/dannyvelas/parkspot-backend/util"
+	"github.com/go-chi/chi/v5"
+	"net/http"
+	"time"
+)
+
+type parkingSpaceHandler struct {
+	parkingSpaceService app.ParkingSpaceService
+	// location is the timezone of the community, whose calendar availability is split into days of
+	location *time.Location
+}
+
+func newParkingSpaceHandler(parkingSpaceService app.ParkingSpaceService, location *time.Location) parkingSpaceHandler {
+	return parkingSpaceHandler{
+		parkingSpaceService: parkingSpaceService,
+		location:            location,
+	}
+}
+
+func (h parkingSpaceHandler) getAll() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		zone := r.URL.Query().Get("zone")
+
+		parkingSpaces, err := h.parkingSpaceService.GetAll(r.Context(), zone)
+		if err != nil {
+			respondError(w, r, err)
+			return
+		}
+
+		respondJSON(w, http.StatusOK, parkingSpaces)
+	}
+}
+
+func (h parkingSpaceHandler) getZones() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		zones, err := h.parkingSpaceService.GetZones(r.Context())
+		if err != nil {
+			respondError(w, r, err)
+			return
+		}
+
+		respondJSON(w, http.StatusOK, zones)
+	}
+}
+
+// getAvailability expects startDate and endDate query params in config.DateFormat. both days are included.
+// by default, it returns the availability of the next config.DefaultAvailabilityDays days
+func (h parkingSpaceHandler) getAvailability() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		startDate := util.StartOfDay(time.Now().In(h.location))
+		if startDateString := r.URL.Query().Get("startDate"); startDateString != "" {
+			parsed, err := time.ParseInLocation(config.DateFormat, startDateString, h.location)
+			if err != nil {
+				respondError(w, r, errs.InvalidFields(errs.NewFieldError("startDate", "format", "startDate must be in YYYY-MM-DD format")))
+				return
+			}
+			startDate = parsed
+		}
+
+		endDate := startDate.AddDate(0, 0, config.DefaultAvailabilityDays)
+		if endDateString := r.URL.Query().Get("endDate"); endDateString != "" {
+			parsed, err := time.ParseInLocation(config.DateFormat, endDateString, h.location)
+			if err != nil {
+				respondError(w, r, errs.InvalidFields(errs.NewFieldError("endDate", "format", "endDate must be in YYYY-MM-DD format")))
+				return
+			}
+			endDate = parsed.AddDate(0, 0, 1)
+		}
+
+		availability, err := h.parkingSpaceService.GetAvailability(r.Context(), startDate, endDate)
+		if err != nil {
+			respondError(w, r, err)
+			return
+		}
+
+		respondJSON(w, http.StatusOK, availability)
+	}
+}
+
+func (h parkingSpaceHandler) create() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var desiredParkingSpace models.ParkingSpace
+		if err := json.NewDecoder(r.Body).Decode(&desiredParkingSpace); err != nil {
+			respondError(w, r, errs.Malformed("Parking Space"))
+			return
+		}
+
+		parkingSpace, err := h.parkingSpaceService.Create(r.Context(), desiredParkingSpace)
+		if err != nil {
+			respondError(w, r, err)
+			return
+		}
+
+		respondJSON(w, http.StatusOK, parkingSpace)
+	}
+}
+
+func (h parkingSpaceHandler) deleteOne() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := chi.URLParam(r, "id")
+
+		if err := h.parkingSpaceService.Delete(r.Context(), id); err != nil {
+			respondError(w, r, err)
+			return
+		}
+
+		respondJSON(w, http.StatusOK, message{"Successfully deleted parking space"})
+	}
+}
@@ -1,37 +1,44 @@
 package api
 
 import (
-	"encoding/json"
 	"fmt"
 	"github.com/dannyvelas/parkspot-backend/app"
 	"github.com/dannyvelas/parkspot-backend/errs"
+	"github.com/dannyvelas/parkspot-backend/export"
 	"github.com/dannyvelas/parkspot-backend/models"
 	"github.com/dannyvelas/parkspot-backend/util"
 	"github.com/go-chi/chi/v5"
 	"net/http"
+	"time"
 )
 
 type permitHandler struct {
-	permitService app.PermitService
+	permitService   app.PermitService
+	waitlistService app.WaitlistService
+	// location is the timezone of the community, whose calendar the dates of exports are days of
+	location *time.Location
 }
 
-func newPermitHandler(permitService app.PermitService) permitHandler {
+func newPermitHandler(permitService app.PermitService, waitlistService app.WaitlistService, location *time.Location) permitHandler {
 	return permitHandler{
-		permitService: permitService,
+		permitService:   permitService,
+		waitlistService: waitlistService,
+		location:        location,
 	}
 }
 
-func (h permitHandler) get(status models.Status) http.HandlerFunc {
+func (h permitHandler) get(status models.Status, shape permitShape) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		limit := util.ToPosInt(r.URL.Query().Get("limit"))
-		page := util.ToPosInt(r.URL.Query().Get("page"))
-		reversed := util.ToBool(r.URL.Query().Get("reversed"))
-		search := r.URL.Query().Get("search")
+		params, err := newListParams(r)
+		if err != nil {
+			respondError(w, r, err)
+			return
+		}
 
 		ctx := r.Context()
 		accessPayload, err := ctxGetAccessPayload(ctx)
 		if err != nil {
-			respondError(w, fmt.Errorf("error getting access payload: %v", err))
+			respondError(w, r, fmt.Errorf("error getting access payload: %v", err))
 			return
 		}
 
@@ -40,96 +47,210 @@ func (h permitHandler) get(status models.Status) http.HandlerFunc {
 			residentID = accessPayload.ID
 		}
 
-		permitsWithMetadata, err := h.permitService.GetAll(status, limit, page, reversed, search, residentID)
+		permitsWithMetadata, err := h.permitService.GetAll(ctx, status, params, residentID)
 		if err != nil {
-			respondError(w, err)
+			respondError(w, r, err)
 			return
 		}
 
-		respondJSON(w, http.StatusOK, permitsWithMetadata)
+		respondJSON(w, http.StatusOK, shape.encodeList(permitsWithMetadata))
+	}
+}
+
+// export streams the permits that get lists, on every page, as a CSV or XLSX file
+func (h permitHandler) export(status models.Status) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params, err := newListParams(r)
+		if err != nil {
+			respondError(w, r, err)
+			return
+		}
+
+		ctx := r.Context()
+		accessPayload, err := ctxGetAccessPayload(ctx)
+		if err != nil {
+			respondError(w, r, fmt.Errorf("error getting access payload: %v", err))
+			return
+		}
+
+		residentID := ""
+		if accessPayload.Role == models.ResidentRole {
+			residentID = accessPayload.ID
+		}
+
+		respondExport(w, r, "permits", export.Permits(h.location), h.location, func(each func(models.Permit) error) error {
+			return h.permitService.Export(ctx, status, params, residentID, each)
+		})
 	}
 }
 
-func (h permitHandler) getOne() http.HandlerFunc {
+func (h permitHandler) getOne(shape permitShape) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		id := util.ToPosInt(chi.URLParam(r, "id"))
-		permit, err := h.permitService.GetOne(id)
+		permit, err := h.permitService.GetOne(r.Context(), id)
 		if err != nil {
-			respondError(w, err)
+			respondError(w, r, err)
 			return
 		}
 
-		respondJSON(w, http.StatusOK, permit)
+		setETag(w, permit.Version)
+		respondJSON(w, http.StatusOK, shape.encode(permit))
 	}
 }
 
-func (h permitHandler) create() http.HandlerFunc {
+func (h permitHandler) create(shape permitShape) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		var newPermitReq models.Permit
-		if err := json.NewDecoder(r.Body).Decode(&newPermitReq); err != nil {
-			respondError(w, errs.Malformed("NewPermitReq"))
+		newPermitReq, err := shape.decode(r, "NewPermitReq")
+		if err != nil {
+			respondError(w, r, err)
 			return
 		}
 
 		ctx := r.Context()
 		accessPayload, err := ctxGetAccessPayload(ctx)
 		if err != nil {
-			respondError(w, fmt.Errorf("permit_router.createPermit: error getting access payload: %v", err))
+			respondError(w, r, fmt.Errorf("permit_router.createPermit: error getting access payload: %v", err))
 			return
 		}
 
-		if accessPayload.Role == models.ResidentRole {
-			if newPermitReq.ExceptionReason != "" {
-				message := "Residents cannot request parking permits with exceptions"
-				respondError(w, errs.BadRequest(message))
-				return
-			}
-			if newPermitReq.ResidentID != "" && newPermitReq.ResidentID != accessPayload.ID {
-				respondError(w, errs.BadRequest("Residents cannot request a parking permit for another resident"))
+		newPermitReq, err = scopePermitReq(accessPayload, newPermitReq)
+		if err != nil {
+			respondError(w, r, err)
+			return
+		}
+
+		createdPermit, err := h.permitService.Create(ctx, newPermitReq)
+		if err != nil && util.ToBool(r.URL.Query().Get("waitlist")) && app.IsWaitlistable(err) {
+			waitlistEntry, err := h.waitlistService.Add(ctx, newPermitReq, err)
+			if err != nil {
+				respondError(w, r, err)
 				return
 			}
-			if newPermitReq.ResidentID == "" {
-				newPermitReq.ResidentID = accessPayload.ID
-			}
-		}
 
-		createdPermit, err := h.permitService.Create(newPermitReq)
-		if err != nil {
-			respondError(w, err)
+			respondJSON(w, http.StatusAccepted, waitlistEntry)
+			return
+		} else if err != nil {
+			respondError(w, r, err)
 			return
 		}
 
-		respondJSON(w, http.StatusOK, createdPermit)
+		respondJSON(w, http.StatusOK, shape.encode(createdPermit))
 	}
 }
 
 func (h permitHandler) deleteOne() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		id := util.ToPosInt(chi.URLParam(r, "id"))
-		err := h.permitService.Delete(id)
+		err := h.permitService.Delete(r.Context(), id)
 		if err != nil {
-			respondError(w, err)
+			respondError(w, r, err)
 			return
 		}
 
+		// the space of the permit may be taken by a waitlisted request, outside of this request
+		h.waitlistService.PromoteSoon()
+
 		respondJSON(w, http.StatusOK, message{"Successfully deleted permit"})
 	}
 }
 
-func (h permitHandler) edit() http.HandlerFunc {
+func (h permitHandler) edit(shape permitShape) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		editPermitReq, err := shape.decode(r, "EditPermitReq")
+		if err != nil {
+			respondError(w, r, err)
+			return
+		}
+
+		version, fromIfMatch, err := editVersion(r, editPermitReq.Version)
+		if err != nil {
+			respondError(w, r, err)
+			return
+		}
+		editPermitReq.Version = version
+
+		permit, err := h.permitService.Update(r.Context(), editPermitReq)
+		if err != nil {
+			respondEditError(w, r, err, fromIfMatch)
+			return
+		}
+
+		setETag(w, permit.Version)
+		respondJSON(w, http.StatusOK, shape.encode(permit))
+	}
+}
+
+// quoteResponse is a quote whose failures are error responses in the language of the request
+type quoteResponse struct {
+	models.PermitQuote
+	Failures []errorResponse `json:"failures"`
+}
+
+// quote responds with what creating a permit would do, without creating it
+func (h permitHandler) quote(shape permitShape) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		var editPermitReq models.Permit
-		if err := json.NewDecoder(r.Body).Decode(&editPermitReq); err != nil {
-			respondError(w, errs.Malformed("EditPermitReq"))
+		permitReq, err := shape.decode(r, "NewPermitReq")
+		if err != nil {
+			respondError(w, r, err)
+			return
+		}
+
+		accessPayload, err := ctxGetAccessPayload(r.Context())
+		if err != nil {
+			respondError(w, r, fmt.Errorf("permit_router.quote: error getting access payload: %v", err))
+			return
+		}
+
+		permitReq, err = scopePermitReq(accessPayload, permitReq)
+		if err != nil {
+			respondError(w, r, err)
 			return
 		}
 
-		permit, err := h.permitService.Update(editPermitReq)
+		lang := requestLang(r)
+		quote, err := h.permitService.Quote(r.Context(), permitReq, lang)
 		if err != nil {
-			respondError(w, err)
+			respondError(w, r, err)
 			return
 		}
 
-		respondJSON(w, http.StatusOK, permit)
+		response := quoteResponse{PermitQuote: quote, Failures: make([]errorResponse, 0, len(quote.Failures))}
+		for _, failure := range quote.Failures {
+			response.Failures = append(response.Failures, newErrorResponse(quoteFailureErr(failure)))
+		}
+
+		w.Header().Set("Content-Language", string(lang))
+		respondJSON(w, http.StatusOK, response)
+	}
+}
+
+// quoteFailureErr is the error that creating a permit would respond with for failure
+func quoteFailureErr(failure models.PermitQuoteFailure) *errs.APIErr {
+	apiErr := errs.NewAPIErr(failure.Status, failure.Code, failure.Message)
+	for _, field := range failure.Fields {
+		apiErr.Fields = append(apiErr.Fields, errs.NewFieldError(field.Name, field.Check, field.Message))
 	}
+	for _, rule := range failure.Rules {
+		apiErr.Rules = append(apiErr.Rules, errs.RuleError{Rule: rule.Name, Kind: rule.Check, Message: rule.Message})
+	}
+	return apiErr
+}
+
+// scopePermitReq makes sure that residents only request regular permits for themselves
+func scopePermitReq(accessPayload app.AccessPayload, permitReq models.Permit) (models.Permit, error) {
+	if accessPayload.Role != models.ResidentRole {
+		return permitReq, nil
+	}
+
+	if permitReq.ExceptionReason != "" {
+		return models.Permit{}, errs.BadRequest("permit.resident_exception", "Residents cannot request parking permits with exceptions")
+	}
+	if permitReq.ResidentID != "" && permitReq.ResidentID != accessPayload.ID {
+		return models.Permit{}, errs.BadRequest("permit.other_resident", "Residents cannot request a parking permit for another resident")
+	}
+	if permitReq.ResidentID == "" {
+		permitReq.ResidentID = accessPayload.ID
+	}
+
+	return permitReq, nil
 }
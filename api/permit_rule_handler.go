@@ -0,0 +1,103 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/dannyvelas/parkspot-backend/app"
+	"github.com/dannyvelas/parkspot-backend/errs"
+	"github.com/dannyvelas/parkspot-backend/models"
+	"github.com/go-chi/chi/v5"
+	"net/http"
+)
+
+type permitRuleHandler struct {
+	permitRuleService app.PermitRuleService
+}
+
+func newPermitRuleHandler(permitRuleService app.PermitRuleService) permitRuleHandler {
+	return permitRuleHandler{
+		permitRuleService: permitRuleService,
+	}
+}
+
+// getAll accepts an optional target query param to only get the rules of permits or of visitors
+func (h permitRuleHandler) getAll() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		target := models.PermitRuleTarget(r.URL.Query().Get("target"))
+
+		permitRules, err := h.permitRuleService.GetAll(r.Context(), target)
+		if err != nil {
+			respondError(w, r, err)
+			return
+		}
+
+		respondJSON(w, http.StatusOK, permitRules)
+	}
+}
+
+func (h permitRuleHandler) create() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var desiredPermitRule models.PermitRule
+		if err := json.NewDecoder(r.Body).Decode(&desiredPermitRule); err != nil {
+			respondError(w, r, errs.Malformed("PermitRule"))
+			return
+		}
+
+		permitRule, err := h.permitRuleService.Create(r.Context(), desiredPermitRule)
+		if err != nil {
+			respondError(w, r, err)
+			return
+		}
+
+		respondJSON(w, http.StatusOK, permitRule)
+	}
+}
+
+func (h permitRuleHandler) deleteOne() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := chi.URLParam(r, "id")
+
+		if err := h.permitRuleService.Delete(r.Context(), id); err != nil {
+			respondError(w, r, err)
+			return
+		}
+
+		respondJSON(w, http.StatusOK, message{"Successfully deleted permit rule"})
+	}
+}
+
+// dryRun responds with the permit rules that a permit would break, without creating it
+func (h permitRuleHandler) dryRun(shape permitShape) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		permitReq, err := shape.decode(r, "NewPermitReq")
+		if err != nil {
+			respondError(w, r, err)
+			return
+		}
+
+		accessPayload, err := ctxGetAccessPayload(r.Context())
+		if err != nil {
+			respondError(w, r, fmt.Errorf("permit_rule_handler.dryRun: error getting access payload: %v", err))
+			return
+		}
+
+		permitReq, err = scopePermitReq(accessPayload, permitReq)
+		if err != nil {
+			respondError(w, r, err)
+			return
+		}
+
+		violations, err := h.permitRuleService.EvaluatePermit(r.Context(), permitReq)
+		if err != nil {
+			respondError(w, r, err)
+			return
+		}
+
+		lang := requestLang(r)
+		for i := range violations {
+			violations[i] = violations[i].In(lang)
+		}
+
+		respondJSON(w, http.StatusOK, violations)
+	}
+}
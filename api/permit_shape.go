@@ -0,0 +1,154 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/dannyvelas/parkspot-backend/config"
+	"github.com/dannyvelas/parkspot-backend/errs"
+	"github.com/dannyvelas/parkspot-backend/models"
+	"github.com/dannyvelas/parkspot-backend/util"
+)
+
+// permitShape is how one version of the API encodes permits in its responses and decodes them from its requests
+type permitShape interface {
+	decode(r *http.Request, payloadName string) (models.Permit, error)
+	encode(permit models.Permit) any
+	encodeList(permits models.ListWithMetadata[models.Permit]) any
+}
+
+// permitShapeV1 sends permits as they are in models.Permit
+type permitShapeV1 struct{}
+
+func (permitShapeV1) decode(r *http.Request, payloadName string) (models.Permit, error) {
+	var permit models.Permit
+	if err := json.NewDecoder(r.Body).Decode(&permit); err != nil {
+		return models.Permit{}, errs.Malformed(payloadName)
+	}
+	return permit, nil
+}
+
+func (permitShapeV1) encode(permit models.Permit) any {
+	return permit
+}
+
+func (permitShapeV1) encodeList(permits models.ListWithMetadata[models.Permit]) any {
+	return permits
+}
+
+// permitV2 nests the car of a permit, and sends its dates as days of the community's calendar, like 2026-01-10
+type permitV2 struct {
+	ID              int         `json:"id"`
+	ResidentID      string      `json:"residentID"`
+	Car             permitCarV2 `json:"car"`
+	StartDate       string      `json:"startDate"`
+	EndDate         string      `json:"endDate"`
+	RequestedAt     *time.Time  `json:"requestedAt,omitempty"`
+	AffectsDays     bool        `json:"affectsDays"`
+	ExceptionReason string      `json:"exceptionReason,omitempty"`
+	SpaceID         string      `json:"spaceID,omitempty"`
+	Version         int         `json:"version"`
+}
+
+type permitCarV2 struct {
+	ID           string `json:"id"`
+	LicensePlate string `json:"licensePlate"`
+	Color        string `json:"color"`
+	Make         string `json:"make"`
+	Model        string `json:"model"`
+}
+
+type permitShapeV2 struct {
+	// location is the timezone of the community, whose calendar the dates of permits are days of
+	location *time.Location
+}
+
+func (shape permitShapeV2) decode(r *http.Request, payloadName string) (models.Permit, error) {
+	var permit permitV2
+	if err := json.NewDecoder(r.Body).Decode(&permit); err != nil {
+		return models.Permit{}, errs.Malformed(payloadName)
+	}
+
+	var fieldErrs []errs.FieldError
+	startDate, err := parseOptionalDate(permit.StartDate, shape.location)
+	if err != nil {
+		fieldErrs = append(fieldErrs, errs.NewFieldError("startDate", "format", "startDate must be in YYYY-MM-DD format"))
+	}
+	endDate, err := parseOptionalDate(permit.EndDate, shape.location)
+	if err != nil {
+		fieldErrs = append(fieldErrs, errs.NewFieldError("endDate", "format", "endDate must be in YYYY-MM-DD format"))
+	}
+	if len(fieldErrs) != 0 {
+		return models.Permit{}, errs.InvalidFields(fieldErrs...)
+	}
+
+	return models.Permit{
+		ID:              permit.ID,
+		ResidentID:      permit.ResidentID,
+		CarID:           permit.Car.ID,
+		LicensePlate:    permit.Car.LicensePlate,
+		Color:           permit.Car.Color,
+		Make:            permit.Car.Make,
+		Model:           permit.Car.Model,
+		StartDate:       startDate,
+		EndDate:         endDate,
+		AffectsDays:     permit.AffectsDays,
+		ExceptionReason: permit.ExceptionReason,
+		SpaceID:         permit.SpaceID,
+		Version:         permit.Version,
+	}, nil
+}
+
+func (shape permitShapeV2) encode(permit models.Permit) any {
+	return toPermitV2(permit, shape.location)
+}
+
+func (shape permitShapeV2) encodeList(permits models.ListWithMetadata[models.Permit]) any {
+	return models.ListWithMetadata[permitV2]{
+		Records:  util.MapSlice(permits.Records, func(permit models.Permit) permitV2 { return toPermitV2(permit, shape.location) }),
+		Metadata: permits.Metadata,
+	}
+}
+
+func toPermitV2(permit models.Permit, location *time.Location) permitV2 {
+	var requestedAt *time.Time
+	if permit.RequestTS != 0 {
+		requestedAt = util.ToPtr(time.Unix(permit.RequestTS, 0))
+	}
+
+	return permitV2{
+		ID:         permit.ID,
+		ResidentID: permit.ResidentID,
+		Car: permitCarV2{
+			ID:           permit.CarID,
+			LicensePlate: permit.LicensePlate,
+			Color:        permit.Color,
+			Make:         permit.Make,
+			Model:        permit.Model,
+		},
+		StartDate:       formatOptionalDate(permit.StartDate, location),
+		EndDate:         formatOptionalDate(permit.EndDate, location),
+		RequestedAt:     requestedAt,
+		AffectsDays:     permit.AffectsDays,
+		ExceptionReason: permit.ExceptionReason,
+		SpaceID:         permit.SpaceID,
+		Version:         permit.Version,
+	}
+}
+
+// parseOptionalDate parses a day of the calendar of location, the community's timezone. empty dates are left for
+// the app to reject
+func parseOptionalDate(date string, location *time.Location) (time.Time, error) {
+	if date == "" {
+		return time.Time{}, nil
+	}
+	return time.ParseInLocation(config.DateFormat, date, location)
+}
+
+func formatOptionalDate(date time.Time, location *time.Location) string {
+	if date.IsZero() {
+		return ""
+	}
+	return date.In(location).Format(config.DateFormat)
+}
@@ -0,0 +1,29 @@
+package api
+
+import (
+	"github.com/dannyvelas/parkspot-backend/app"
+	"github.com/go-chi/chi/v5"
+	"net/http"
+)
+
+type plateHandler struct {
+	plateService app.PlateService
+}
+
+func newPlateHandler(plateService app.PlateService) plateHandler {
+	return plateHandler{
+		plateService: plateService,
+	}
+}
+
+func (h plateHandler) getStatus() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		plateStatus, err := h.plateService.GetStatus(r.Context(), chi.URLParam(r, "plate"))
+		if err != nil {
+			respondError(w, r, err)
+			return
+		}
+
+		respondJSON(w, http.StatusOK, plateStatus)
+	}
+}
@@ -4,31 +4,38 @@ import (
 	"encoding/json"
 	"github.com/dannyvelas/parkspot-backend/app"
 	"github.com/dannyvelas/parkspot-backend/errs"
+	"github.com/dannyvelas/parkspot-backend/export"
 	"github.com/dannyvelas/parkspot-backend/models"
 	"github.com/dannyvelas/parkspot-backend/util"
 	"github.com/go-chi/chi/v5"
 	"net/http"
+	"time"
 )
 
 type residentHandler struct {
 	residentService app.ResidentService
+	// location is the timezone of the community, which exports are dated in
+	location *time.Location
 }
 
-func newResidentHandler(residentService app.ResidentService) residentHandler {
+func newResidentHandler(residentService app.ResidentService, location *time.Location) residentHandler {
 	return residentHandler{
 		residentService: residentService,
+		location:        location,
 	}
 }
 
 func (h residentHandler) getAll() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		limit := util.ToPosInt(r.URL.Query().Get("limit"))
-		page := util.ToPosInt(r.URL.Query().Get("page"))
-		search := r.URL.Query().Get("search")
+		params, err := newListParams(r)
+		if err != nil {
+			respondError(w, r, err)
+			return
+		}
 
-		residentsWithMetadata, err := h.residentService.GetAll(limit, page, search)
+		residentsWithMetadata, err := h.residentService.GetAll(r.Context(), params)
 		if err != nil {
-			respondError(w, err)
+			respondError(w, r, err)
 			return
 		}
 		residents := util.MapSlice(residentsWithMetadata.Records, h.removeHash)
@@ -38,14 +45,30 @@ func (h residentHandler) getAll() http.HandlerFunc {
 	}
 }
 
+// export streams the residents that getAll lists, on every page, as a CSV or XLSX file
+func (h residentHandler) export() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params, err := newListParams(r)
+		if err != nil {
+			respondError(w, r, err)
+			return
+		}
+
+		respondExport(w, r, "residents", export.Residents, h.location, func(each func(models.Resident) error) error {
+			return h.residentService.Export(r.Context(), params, each)
+		})
+	}
+}
+
 func (h residentHandler) getOne() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		resident, err := h.residentService.GetOne(chi.URLParam(r, "id"))
+		resident, err := h.residentService.GetOne(r.Context(), chi.URLParam(r, "id"))
 		if err != nil {
-			respondError(w, err)
+			respondError(w, r, err)
 			return
 		}
 
+		setETag(w, resident.Version)
 		respondJSON(w, http.StatusOK, h.removeHash(resident))
 	}
 }
@@ -54,29 +77,37 @@ func (h residentHandler) edit() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		var editResidentReq models.Resident
 		if err := json.NewDecoder(r.Body).Decode(&editResidentReq); err != nil {
-			respondError(w, errs.Malformed("EditResidentReq"))
+			respondError(w, r, errs.Malformed("EditResidentReq"))
 			return
 		}
 
 		if editResidentReq.Password != "" {
-			respondError(w, errs.BadRequest("Resident passwords cannot be edited. These can only be changed if a resident requests a password reset."))
+			respondError(w, r, errs.BadRequest("resident.password_not_editable", "Resident passwords cannot be edited. These can only be changed if a resident requests a password reset."))
+			return
+		}
+
+		version, fromIfMatch, err := editVersion(r, editResidentReq.Version)
+		if err != nil {
+			respondError(w, r, err)
 			return
 		}
+		editResidentReq.Version = version
 
-		resident, err := h.residentService.Update(editResidentReq)
+		resident, err := h.residentService.Update(r.Context(), editResidentReq)
 		if err != nil {
-			respondError(w, err)
+			respondEditError(w, r, err, fromIfMatch)
 			return
 		}
 
+		setETag(w, resident.Version)
 		respondJSON(w, http.StatusOK, h.removeHash(resident))
 	}
 }
 
 func (h residentHandler) deleteOne() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		if err := h.residentService.Delete(chi.URLParam(r, "id")); err != nil {
-			respondError(w, err)
+		if err := h.residentService.Delete(r.Context(), chi.URLParam(r, "id")); err != nil {
+			respondError(w, r, err)
 			return
 		}
 
@@ -88,13 +119,13 @@ func (h residentHandler) create() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		var payload models.Resident
 		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
-			respondError(w, errs.Malformed("NewResidentReq"))
+			respondError(w, r, errs.Malformed("NewResidentReq"))
 			return
 		}
 
-		createdRes, err := h.residentService.Create(payload)
+		createdRes, err := h.residentService.Create(r.Context(), payload)
 		if err != nil {
-			respondError(w, err)
+			respondError(w, r, err)
 			return
 		}
 
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"github.com/dannyvelas/parkspot-backend/errs"
+	"github.com/dannyvelas/parkspot-backend/i18n"
 	"github.com/rs/zerolog/log"
 	"io"
 	"net/http"
@@ -13,6 +14,15 @@ type message struct {
 	Message string `json:"message"`
 }
 
+// errorResponse is the body of every response of an error
+type errorResponse struct {
+	Code    string            `json:"code"`
+	Status  int               `json:"status"`
+	Message string            `json:"message"`
+	Fields  []errs.FieldError `json:"fields,omitempty"`
+	Rules   []errs.RuleError  `json:"rules,omitempty"`
+}
+
 func respondJSON(w http.ResponseWriter, statusCode int, data any) {
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
 	w.WriteHeader(statusCode)
@@ -28,13 +38,39 @@ func respondJSON(w http.ResponseWriter, statusCode int, data any) {
 	}
 }
 
-func respondError(w http.ResponseWriter, err error) {
+// respondError responds with err in the language of r
+func respondError(w http.ResponseWriter, r *http.Request, err error) {
 	var apiErr *errs.APIErr
 	if !errors.As(err, &apiErr) {
 		log.Error().Msg(err.Error())
-		respondJSON(w, http.StatusInternalServerError, "Internal Server Error")
-		return
+		apiErr = errs.Internal
+	}
+	lang := requestLang(r)
+	apiErr = apiErr.In(lang)
+
+	w.Header().Set("Content-Language", string(lang))
+
+	respondJSON(w, apiErr.StatusCode, newErrorResponse(apiErr))
+}
+
+// newErrorResponse is the body of a response of apiErr, which is expected to be translated already
+func newErrorResponse(apiErr *errs.APIErr) errorResponse {
+	return errorResponse{
+		Code:    apiErr.Code,
+		Status:  apiErr.StatusCode,
+		Message: apiErr.Error(),
+		Fields:  apiErr.Fields,
+		Rules:   apiErr.Rules,
+	}
+}
+
+// requestLang is the language that a user chose for their account, if they are authenticated and chose one.
+// otherwise, it is picked from the Accept-Language header of r
+func requestLang(r *http.Request) i18n.Lang {
+	var preferred i18n.Lang
+	if accessPayload, err := ctxGetAccessPayload(r.Context()); err == nil {
+		preferred = accessPayload.Lang
 	}
 
-	respondJSON(w, apiErr.StatusCode, apiErr.Error())
+	return i18n.Negotiate(preferred, r.Header.Get("Accept-Language"))
 }
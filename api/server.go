@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/dannyvelas/parkspot-backend/app"
 	"github.com/dannyvelas/parkspot-backend/config"
+	"github.com/dannyvelas/parkspot-backend/errs"
 	"github.com/dannyvelas/parkspot-backend/models"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/cors"
@@ -40,77 +41,147 @@ func newRouter(c config.Config, app app.App) (router *chi.Mux) {
 		AllowedOrigins:   c.HTTP.CORSAllowedOrigins,
 		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
 		AllowCredentials: true,
-		AllowedHeaders:   []string{"Content-Type", "Authorization"},
+		AllowedHeaders:   []string{"Content-Type", "Authorization", "If-Match"},
+		ExposedHeaders:   []string{"ETag"},
 		MaxAge:           300,
 	}))
+	router.Use(withDeadline(c.HTTP.WriteTimeout))
 
 	// handlers
 	middleware := newMiddleware(app.JWTService)
 	authHandler := newAuthHandler(c.HTTP, app.JWTService, app.AuthService)
-	residentHandler := newResidentHandler(app.ResidentService)
-	visitorHandler := newVisitorHandler(app.VisitorService)
-	carHandler := newCarHandler(app.CarService)
-	permitHandler := newPermitHandler(app.PermitService)
+	residentHandler := newResidentHandler(app.ResidentService, c.Community.Location)
+	visitorHandler := newVisitorHandler(app.VisitorService, c.Community.Location)
+	carHandler := newCarHandler(app.CarService, c.Community.Location)
+	permitHandler := newPermitHandler(app.PermitService, app.WaitlistService, c.Community.Location)
+	gateEventHandler := newGateEventHandler(app.GateEventService)
+	plateHandler := newPlateHandler(app.PlateService)
+	violationHandler := newViolationHandler(app.ViolationService)
+	banHandler := newBanHandler(app.BanService)
+	parkingSpaceHandler := newParkingSpaceHandler(app.ParkingSpaceService, c.Community.Location)
+	waitlistHandler := newWaitlistHandler(app.WaitlistService)
+	parkingPolicyHandler := newParkingPolicyHandler(app.ParkingPolicyService)
+	permitRuleHandler := newPermitRuleHandler(app.PermitRuleService)
+	importHandler := newImportHandler(app.ImportService)
+
+	// chi's default not found and method not allowed handlers respond with plain text
+	router.NotFound(func(w http.ResponseWriter, r *http.Request) {
+		respondError(w, r, errs.NotFound)
+	})
+	router.MethodNotAllowed(func(w http.ResponseWriter, r *http.Request) {
+		respondError(w, r, errs.MethodNotAllowed)
+	})
 
 	// index
-	router.Handle("/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	router.Get("/", func(w http.ResponseWriter, r *http.Request) {
 		respondJSON(w, http.StatusOK, "hello world")
-	}))
-
-	// api
-	router.Route("/api", func(r chi.Router) {
-		r.Group(func(anyoneRouter chi.Router) {
-			anyoneRouter.Post("/login", authHandler.login())
-			anyoneRouter.Post("/logout", authHandler.logout())
-			anyoneRouter.Post("/refresh-tokens", authHandler.refreshTokens()) // needs to be here instead of userRouter. this is because user-router checks access tokens and an access token might be expired when this is called
-			anyoneRouter.Post("/password-reset-email", authHandler.sendResetPasswordEmail())
-		})
-
-		r.Group(func(adminRouter chi.Router) {
-			adminRouter.Use(middleware.authenticate(models.AdminRole))
-			adminRouter.Delete("/permit/{id:[0-9]+}", permitHandler.deleteOne())
-			adminRouter.Delete("/resident/{id}", residentHandler.deleteOne())
-			adminRouter.Post("/resident", residentHandler.create())
-			adminRouter.Put("/resident", residentHandler.edit())
-			adminRouter.Put("/permit", permitHandler.edit())
-		})
-
-		r.Group(func(officeRouter chi.Router) {
-			officeRouter.Use(middleware.authenticate(models.AdminRole, models.SecurityRole))
-			officeRouter.Get("/residents", residentHandler.getAll())
-			officeRouter.Get("/resident/{id}", residentHandler.getOne())
-			officeRouter.Get("/car/{id}", carHandler.getOne())
-		})
-
-		r.Group(func(adminAndResidentRouter chi.Router) {
-			adminAndResidentRouter.Use(middleware.authenticate(models.AdminRole, models.ResidentRole))
-			adminAndResidentRouter.Post("/permit", permitHandler.create())
-			adminAndResidentRouter.Post("/car", carHandler.create())
-			adminAndResidentRouter.Put("/car", carHandler.edit())
-			adminAndResidentRouter.Delete("/car/{id}", carHandler.deleteOne())
-		})
-
-		r.Group(func(residentRouter chi.Router) {
-			residentRouter.Use(middleware.authenticate(models.ResidentRole))
-			residentRouter.Post("/visitor", visitorHandler.create())
-			residentRouter.Delete("/visitor/{id}", visitorHandler.deleteOne())
-		})
-
-		r.Group(func(userRouter chi.Router) {
-			userRouter.Use(middleware.authenticate(models.AdminRole, models.SecurityRole, models.ResidentRole))
-			userRouter.Put("/user/password", authHandler.resetPassword())
-			userRouter.Get("/hello", sayHello())
-			userRouter.Get("/permits/all", permitHandler.get(models.AnyStatus))
-			userRouter.Get("/permits/active", permitHandler.get(models.ActiveStatus))
-			userRouter.Get("/permits/exceptions", permitHandler.get(models.ExceptionStatus))
-			userRouter.Get("/permits/expired", permitHandler.get(models.ExpiredStatus))
-			userRouter.Get("/permit/{id:[0-9]+}", permitHandler.getOne())
-			userRouter.Get("/visitors/active", visitorHandler.get(models.ActiveStatus))
-			userRouter.Get("/resident/{id}/cars", carHandler.getOfResident())
-			userRouter.Get("/cars", carHandler.get())
-		})
 	})
 
+	// api. every version of the api has its own route tree. /api is an alias of /api/v1,
+	// for clients that were deployed before the api was versioned
+	apiRoutes := func(v apiVersion) func(chi.Router) {
+		return func(r chi.Router) {
+			r.Group(func(anyoneRouter chi.Router) {
+				anyoneRouter.Post("/login", authHandler.login())
+				anyoneRouter.Post("/logout", authHandler.logout())
+				anyoneRouter.Post("/refresh-tokens", authHandler.refreshTokens()) // needs to be here instead of userRouter. this is because user-router checks access tokens and an access token might be expired when this is called
+				anyoneRouter.Post("/password-reset-email", authHandler.sendResetPasswordEmail())
+				anyoneRouter.Get("/openapi.json", openAPIHandler(r, v))
+			})
+
+			r.Group(func(adminRouter chi.Router) {
+				adminRouter.Use(middleware.authenticate(models.AdminRole))
+				adminRouter.Delete("/permit/{id:[0-9]+}", permitHandler.deleteOne())
+				adminRouter.Delete("/resident/{id}", residentHandler.deleteOne())
+				adminRouter.Post("/resident", residentHandler.create())
+				adminRouter.With(withoutDeadline).Post("/residents/import", importHandler.importResidents())
+				adminRouter.Put("/resident", residentHandler.edit())
+				adminRouter.With(v.changed).Put("/permit", permitHandler.edit(v.permits))
+				adminRouter.Put("/violation/{id}/dispute", violationHandler.dispute())
+				adminRouter.Put("/violation/{id}/resolve", violationHandler.resolve())
+				adminRouter.Get("/bans/all", banHandler.get(models.AnyStatus))
+				adminRouter.Get("/bans/active", banHandler.get(models.ActiveStatus))
+				adminRouter.Get("/bans/expired", banHandler.get(models.ExpiredStatus))
+				adminRouter.Post("/ban", banHandler.create())
+				adminRouter.Delete("/ban/{id}", banHandler.deleteOne())
+				adminRouter.Post("/parking-space", parkingSpaceHandler.create())
+				adminRouter.Delete("/parking-space/{id}", parkingSpaceHandler.deleteOne())
+				adminRouter.Put("/parking-policy", parkingPolicyHandler.edit())
+				adminRouter.Post("/permit-rule", permitRuleHandler.create())
+				adminRouter.Delete("/permit-rule/{id}", permitRuleHandler.deleteOne())
+			})
+
+			r.Group(func(officeRouter chi.Router) {
+				officeRouter.Use(middleware.authenticate(models.AdminRole, models.SecurityRole))
+				officeRouter.Get("/residents", residentHandler.getAll())
+				officeRouter.With(withoutDeadline).Get("/residents/export", residentHandler.export())
+				officeRouter.Get("/resident/{id}", residentHandler.getOne())
+				officeRouter.Get("/car/{id}", carHandler.getOne())
+				officeRouter.Post("/gate-event/check-in", gateEventHandler.checkIn())
+				officeRouter.Post("/gate-event/check-out", gateEventHandler.checkOut())
+				officeRouter.Get("/gate-events/on-property", gateEventHandler.get(models.ActiveStatus))
+				officeRouter.Get("/gate-events/overstays", gateEventHandler.get(models.OverstayStatus))
+				officeRouter.Get("/plates/{plate}/status", plateHandler.getStatus())
+				officeRouter.Post("/violation", violationHandler.create())
+				officeRouter.Get("/violation/{id}/tow-notice", violationHandler.getTowNotice())
+				officeRouter.Get("/parking-spaces", parkingSpaceHandler.getAll())
+				officeRouter.Get("/parking-zones", parkingSpaceHandler.getZones())
+			})
+
+			r.Group(func(adminAndResidentRouter chi.Router) {
+				adminAndResidentRouter.Use(middleware.authenticate(models.AdminRole, models.ResidentRole))
+				adminAndResidentRouter.With(v.changed).Post("/permit", permitHandler.create(v.permits))
+				adminAndResidentRouter.With(v.changed).Post("/permit/dry-run", permitRuleHandler.dryRun(v.permits))
+				adminAndResidentRouter.With(v.changed).Post("/permit/quote", permitHandler.quote(v.permits))
+				adminAndResidentRouter.Post("/car", carHandler.create())
+				adminAndResidentRouter.Put("/car", carHandler.edit())
+				adminAndResidentRouter.Delete("/car/{id}", carHandler.deleteOne())
+				adminAndResidentRouter.Get("/waitlist", waitlistHandler.get())
+				adminAndResidentRouter.Delete("/waitlist/{id}", waitlistHandler.cancel())
+			})
+
+			r.Group(func(residentRouter chi.Router) {
+				residentRouter.Use(middleware.authenticate(models.ResidentRole))
+				residentRouter.Get("/visitor/{id}", visitorHandler.getOne())
+				residentRouter.Post("/visitor", visitorHandler.create())
+				residentRouter.Put("/visitor", visitorHandler.edit())
+				residentRouter.Delete("/visitor/{id}", visitorHandler.deleteOne())
+			})
+
+			r.Group(func(userRouter chi.Router) {
+				userRouter.Use(middleware.authenticate(models.AdminRole, models.SecurityRole, models.ResidentRole))
+				userRouter.Put("/user/password", authHandler.resetPassword())
+				userRouter.Put("/user/preferred-lang", authHandler.setPreferredLang())
+				userRouter.Get("/hello", sayHello())
+				userRouter.With(v.changed).Get("/permits/all", permitHandler.get(models.AnyStatus, v.permits))
+				userRouter.With(v.changed).Get("/permits/active", permitHandler.get(models.ActiveStatus, v.permits))
+				userRouter.With(v.changed).Get("/permits/exceptions", permitHandler.get(models.ExceptionStatus, v.permits))
+				userRouter.With(v.changed).Get("/permits/expired", permitHandler.get(models.ExpiredStatus, v.permits))
+				userRouter.With(v.changed).Get("/permit/{id:[0-9]+}", permitHandler.getOne(v.permits))
+				userRouter.With(withoutDeadline).Get("/permits/all/export", permitHandler.export(models.AnyStatus))
+				userRouter.With(withoutDeadline).Get("/permits/active/export", permitHandler.export(models.ActiveStatus))
+				userRouter.With(withoutDeadline).Get("/permits/exceptions/export", permitHandler.export(models.ExceptionStatus))
+				userRouter.With(withoutDeadline).Get("/permits/expired/export", permitHandler.export(models.ExpiredStatus))
+				userRouter.Get("/visitors/active", visitorHandler.get(models.ActiveStatus))
+				userRouter.With(withoutDeadline).Get("/visitors/active/export", visitorHandler.export(models.ActiveStatus))
+				userRouter.Get("/resident/{id}/cars", carHandler.getOfResident())
+				userRouter.Get("/resident/{id}/arrivals", gateEventHandler.getOfResident())
+				userRouter.Get("/cars", carHandler.get())
+				userRouter.With(withoutDeadline).Get("/cars/export", carHandler.export())
+				userRouter.Get("/violations", violationHandler.get())
+				userRouter.Get("/violation/{id}", violationHandler.getOne())
+				userRouter.Get("/parking-spaces/availability", parkingSpaceHandler.getAvailability())
+				userRouter.Get("/parking-policy", parkingPolicyHandler.get())
+				userRouter.Get("/permit-rules", permitRuleHandler.getAll())
+			})
+		}
+	}
+
+	v1, v2 := newV1(c.HTTP), newV2(c.Community.Location)
+	router.Route("/api", apiRoutes(v1))
+	router.Route("/api/v1", apiRoutes(v1))
+	router.Route("/api/v2", apiRoutes(v2))
+
 	return
 }
 
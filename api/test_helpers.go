@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 
 	"github.com/dannyvelas/parkspot-backend/errs"
@@ -32,16 +31,14 @@ func authenticatedReq[T, U any](method, url, accessToken string, requestBody *T)
 	defer func() { _ = response.Body.Close() }()
 
 	if response.StatusCode != http.StatusOK {
-		bodyBytes, err := io.ReadAll(response.Body)
-		if err != nil {
-			return parsedResp, fmt.Errorf("error reading response after non-200 status code, %d: %v", response.StatusCode, err)
-		}
-
-		if response.StatusCode == 405 {
-			return parsedResp, errs.NewAPIErr(response.StatusCode, "Method Not Allowed")
+		var errResp errorResponse
+		if err := json.NewDecoder(response.Body).Decode(&errResp); err != nil {
+			return parsedResp, fmt.Errorf("error decoding response after non-200 status code, %d: %v", response.StatusCode, err)
 		}
 
-		return parsedResp, errs.NewAPIErr(response.StatusCode, string(bodyBytes))
+		apiErr := errs.NewAPIErr(response.StatusCode, errResp.Code, errResp.Message)
+		apiErr.Fields = errResp.Fields
+		return parsedResp, apiErr
 	}
 
 	if err := json.NewDecoder(response.Body).Decode(&parsedResp); err != nil {
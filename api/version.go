@@ -0,0 +1,58 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/dannyvelas/parkspot-backend/config"
+)
+
+// apiVersion is what differs between the route trees of each version of the API.
+// every version shares the same services
+type apiVersion struct {
+	name    string
+	permits permitShape
+	// changed wraps the routes whose shapes changed in a later version
+	changed func(http.Handler) http.Handler
+}
+
+func newV1(httpConfig config.HTTPConfig) apiVersion {
+	return apiVersion{
+		name:    "v1",
+		permits: permitShapeV1{},
+		changed: deprecated(httpConfig, "/api/v2"),
+	}
+}
+
+func newV2(location *time.Location) apiVersion {
+	return apiVersion{
+		name:    "v2",
+		permits: permitShapeV2{location: location},
+		changed: func(next http.Handler) http.Handler { return next },
+	}
+}
+
+// deprecated tells clients when a route was deprecated, when it will be removed, and where its successor is
+func deprecated(httpConfig config.HTTPConfig, successorPrefix string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Deprecation", fmt.Sprintf("@%d", httpConfig.V1DeprecatedAt.Unix()))
+			w.Header().Set("Sunset", httpConfig.V1Sunset.UTC().Format(http.TimeFormat))
+			w.Header().Set("Link", fmt.Sprintf("<%s>; rel=\"successor-version\"", successorPrefix+unversionedPath(r.URL.Path)))
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// unversionedPath removes the version from the path of a request, so /api/v1/permits/all and /api/permits/all
+// are both /permits/all
+func unversionedPath(path string) string {
+	path = strings.TrimPrefix(path, "/api")
+	if rest, ok := strings.CutPrefix(path, "/v1"); ok && (rest == "" || strings.HasPrefix(rest, "/")) {
+		return rest
+	}
+	return path
+}
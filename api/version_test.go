@@ -0,0 +1,50 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dannyvelas/parkspot-backend/config"
+)
+
+func TestUnversionedPath(t *testing.T) {
+	tests := map[string]struct {
+		path     string
+		expected string
+	}{
+		"alias":             {path: "/api/permits/all", expected: "/permits/all"},
+		"v1":                {path: "/api/v1/permits/all", expected: "/permits/all"},
+		"v1 root":           {path: "/api/v1", expected: ""},
+		"v1 prefix of word": {path: "/api/v1x/permits", expected: "/v1x/permits"},
+	}
+
+	for name, test := range tests {
+		if got := unversionedPath(test.path); got != test.expected {
+			t.Errorf("%s failed: expected %q, got %q", name, test.expected, got)
+		}
+	}
+}
+
+func TestDeprecated(t *testing.T) {
+	httpConfig := config.HTTPConfig{
+		V1DeprecatedAt: time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC),
+		V1Sunset:       time.Date(2027, 5, 1, 0, 0, 0, 0, time.UTC),
+	}
+	handler := newV1(httpConfig).changed(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/permit/4", nil))
+
+	expected := map[string]string{
+		"Deprecation": "@1793491200",
+		"Sunset":      "Sat, 01 May 2027 00:00:00 GMT",
+		"Link":        `</api/v2/permit/4>; rel="successor-version"`,
+	}
+	for header, value := range expected {
+		if got := recorder.Header().Get(header); got != value {
+			t.Errorf("%s header failed: expected %q, got %q", header, value, got)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/dannyvelas/parkspot-backend/app"
+	"github.com/dannyvelas/parkspot-backend/errs"
+	"github.com/dannyvelas/parkspot-backend/models"
+	"github.com/dannyvelas/parkspot-backend/util"
+	"github.com/go-chi/chi/v5"
+	"net/http"
+)
+
+type violationHandler struct {
+	violationService app.ViolationService
+}
+
+func newViolationHandler(violationService app.ViolationService) violationHandler {
+	return violationHandler{
+		violationService: violationService,
+	}
+}
+
+func (h violationHandler) get() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		limit := util.ToPosInt(r.URL.Query().Get("limit"))
+		page := util.ToPosInt(r.URL.Query().Get("page"))
+		search := r.URL.Query().Get("search")
+
+		ctx := r.Context()
+		accessPayload, err := ctxGetAccessPayload(ctx)
+		if err != nil {
+			respondError(w, r, fmt.Errorf("violation_handler.get: error getting access payload: %v", err))
+			return
+		}
+
+		residentID := ""
+		if accessPayload.Role == models.ResidentRole {
+			residentID = accessPayload.ID
+		}
+
+		violationsWithMetadata, err := h.violationService.GetAll(ctx, limit, page, search, residentID)
+		if err != nil {
+			respondError(w, r, err)
+			return
+		}
+
+		respondJSON(w, http.StatusOK, violationsWithMetadata)
+	}
+}
+
+func (h violationHandler) getOne() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := chi.URLParam(r, "id")
+
+		ctx := r.Context()
+		accessPayload, err := ctxGetAccessPayload(ctx)
+		if err != nil {
+			respondError(w, r, fmt.Errorf("violation_handler.getOne: error getting access payload: %v", err))
+			return
+		}
+
+		violation, err := h.violationService.GetOne(ctx, id)
+		if err != nil {
+			respondError(w, r, err)
+			return
+		}
+
+		if accessPayload.Role == models.ResidentRole && violation.ResidentID != accessPayload.ID {
+			respondError(w, r, errs.NewUnauthorized("resident cannot see violations of another resident"))
+			return
+		}
+
+		respondJSON(w, http.StatusOK, violation)
+	}
+}
+
+func (h violationHandler) create() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var desiredViolation models.Violation
+		if err := json.NewDecoder(r.Body).Decode(&desiredViolation); err != nil {
+			respondError(w, r, errs.Malformed("Violation"))
+			return
+		}
+
+		ctx := r.Context()
+		accessPayload, err := ctxGetAccessPayload(ctx)
+		if err != nil {
+			respondError(w, r, fmt.Errorf("violation_handler.create: error getting access payload: %v", err))
+			return
+		}
+		desiredViolation.RecordedBy = accessPayload.ID
+
+		violation, err := h.violationService.Create(ctx, desiredViolation)
+		if err != nil {
+			respondError(w, r, err)
+			return
+		}
+
+		respondJSON(w, http.StatusOK, violation)
+	}
+}
+
+func (h violationHandler) getTowNotice() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := chi.URLParam(r, "id")
+
+		towNotice, err := h.violationService.GetTowNotice(r.Context(), id)
+		if err != nil {
+			respondError(w, r, err)
+			return
+		}
+
+		respondJSON(w, http.StatusOK, towNotice)
+	}
+}
+
+type disputeReq struct {
+	DisputeReason string `json:"disputeReason"`
+}
+
+type resolveReq struct {
+	Upheld     bool   `json:"upheld"`
+	Resolution string `json:"resolution"`
+}
+
+func (h violationHandler) dispute() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := chi.URLParam(r, "id")
+
+		var payload disputeReq
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			respondError(w, r, errs.Malformed("Dispute Request"))
+			return
+		}
+
+		violation, err := h.violationService.Dispute(r.Context(), id, payload.DisputeReason)
+		if err != nil {
+			respondError(w, r, err)
+			return
+		}
+
+		respondJSON(w, http.StatusOK, violation)
+	}
+}
+
+func (h violationHandler) resolve() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := chi.URLParam(r, "id")
+
+		var payload resolveReq
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			respondError(w, r, errs.Malformed("Resolve Request"))
+			return
+		}
+
+		violation, err := h.violationService.Resolve(r.Context(), id, payload.Upheld, payload.Resolution)
+		if err != nil {
+			respondError(w, r, err)
+			return
+		}
+
+		respondJSON(w, http.StatusOK, violation)
+	}
+}
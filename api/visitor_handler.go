@@ -5,32 +5,39 @@ import (
 	"fmt"
 	"github.com/dannyvelas/parkspot-backend/app"
 	"github.com/dannyvelas/parkspot-backend/errs"
+	"github.com/dannyvelas/parkspot-backend/export"
 	"github.com/dannyvelas/parkspot-backend/models"
 	"github.com/dannyvelas/parkspot-backend/util"
 	"github.com/go-chi/chi/v5"
 	"net/http"
+	"time"
 )
 
 type visitorHandler struct {
 	visitorService app.VisitorService
+	// location is the timezone of the community, whose calendar the dates of exports are days of
+	location *time.Location
 }
 
-func newVisitorHandler(visitorService app.VisitorService) visitorHandler {
+func newVisitorHandler(visitorService app.VisitorService, location *time.Location) visitorHandler {
 	return visitorHandler{
 		visitorService: visitorService,
+		location:       location,
 	}
 }
 
 func (h visitorHandler) get(status models.Status) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		limit := util.ToPosInt(r.URL.Query().Get("limit"))
-		page := util.ToPosInt(r.URL.Query().Get("page"))
-		search := r.URL.Query().Get("search")
+		params, err := newListParams(r)
+		if err != nil {
+			respondError(w, r, err)
+			return
+		}
 
 		ctx := r.Context()
 		accessPayload, err := ctxGetAccessPayload(ctx)
 		if err != nil {
-			respondError(w, fmt.Errorf("visitor_router.getVisitorsOfResident: %v", err))
+			respondError(w, r, fmt.Errorf("visitor_router.getVisitorsOfResident: %v", err))
 			return
 		}
 
@@ -39,9 +46,9 @@ func (h visitorHandler) get(status models.Status) http.HandlerFunc {
 			residentID = accessPayload.ID
 		}
 
-		visitorsWithMetadata, err := h.visitorService.Get(status, limit, page, search, residentID)
+		visitorsWithMetadata, err := h.visitorService.Get(ctx, status, params, residentID)
 		if err != nil {
-			respondError(w, err)
+			respondError(w, r, err)
 			return
 		}
 
@@ -49,35 +56,144 @@ func (h visitorHandler) get(status models.Status) http.HandlerFunc {
 	}
 }
 
+// export streams the visitors that get lists, on every page, as a CSV or XLSX file
+func (h visitorHandler) export(status models.Status) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params, err := newListParams(r)
+		if err != nil {
+			respondError(w, r, err)
+			return
+		}
+
+		ctx := r.Context()
+		accessPayload, err := ctxGetAccessPayload(ctx)
+		if err != nil {
+			respondError(w, r, fmt.Errorf("visitor_router.export: %v", err))
+			return
+		}
+
+		residentID := ""
+		if accessPayload.Role == models.ResidentRole {
+			residentID = accessPayload.ID
+		}
+
+		respondExport(w, r, "visitors", export.Visitors(h.location), h.location, func(each func(models.Visitor) error) error {
+			return h.visitorService.Export(ctx, status, params, residentID, each)
+		})
+	}
+}
+
 func (h visitorHandler) create() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		var desiredVisitor models.Visitor
 		if err := json.NewDecoder(r.Body).Decode(&desiredVisitor); err != nil {
-			respondError(w, errs.Malformed("New Visitor Request"))
+			respondError(w, r, errs.Malformed("New Visitor Request"))
 			return
 		}
 
 		ctx := r.Context()
 		accessPayload, err := ctxGetAccessPayload(ctx)
 		if err != nil {
-			respondError(w, fmt.Errorf("visitor_handler.create(): error getting access payload: %v", err))
+			respondError(w, r, fmt.Errorf("visitor_handler.create(): error getting access payload: %v", err))
 			return
 		}
 
 		if desiredVisitor.ResidentID != "" && desiredVisitor.ResidentID != accessPayload.ID {
-			respondError(w, errs.BadRequest("Residents cannot create a visitor for another resident"))
+			respondError(w, r, errs.BadRequest("visitor.other_resident", "Residents cannot create a visitor for another resident"))
 			return
 		}
 		if desiredVisitor.ResidentID == "" {
 			desiredVisitor.ResidentID = accessPayload.ID
 		}
 
-		visitor, err := h.visitorService.Create(desiredVisitor)
+		visitor, err := h.visitorService.Create(ctx, desiredVisitor)
+		if err != nil {
+			respondError(w, r, err)
+			return
+		}
+
+		respondJSON(w, http.StatusOK, visitor)
+	}
+}
+
+func (h visitorHandler) getOne() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := chi.URLParam(r, "id")
+		if !util.IsUUIDV4(id) {
+			respondError(w, r, errs.IDNotUUID)
+			return
+		}
+
+		ctx := r.Context()
+		accessPayload, err := ctxGetAccessPayload(ctx)
+		if err != nil {
+			respondError(w, r, fmt.Errorf("visitor_handler.getOne: error getting access payload: %v", err))
+			return
+		}
+
+		visitor, err := h.visitorService.GetOne(ctx, id)
+		if err != nil {
+			respondError(w, r, err)
+			return
+		}
+
+		if visitor.ResidentID != accessPayload.ID {
+			respondError(w, r, errs.Unauthorized)
+			return
+		}
+
+		setETag(w, visitor.Version)
+		respondJSON(w, http.StatusOK, visitor)
+	}
+}
+
+func (h visitorHandler) edit() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var editVisitorReq models.Visitor
+		if err := json.NewDecoder(r.Body).Decode(&editVisitorReq); err != nil {
+			respondError(w, r, errs.Malformed("EditVisitorReq"))
+			return
+		}
+		if !util.IsUUIDV4(editVisitorReq.ID) {
+			respondError(w, r, errs.IDNotUUID)
+			return
+		}
+		version, fromIfMatch, err := editVersion(r, editVisitorReq.Version)
+		if err != nil {
+			respondError(w, r, err)
+			return
+		}
+		editVisitorReq.Version = version
+
+		ctx := r.Context()
+		accessPayload, err := ctxGetAccessPayload(ctx)
+		if err != nil {
+			respondError(w, r, fmt.Errorf("visitor_handler.edit: error getting access payload: %v", err))
+			return
+		}
+
+		visitorToEdit, err := h.visitorService.GetOne(ctx, editVisitorReq.ID)
+		if err != nil {
+			respondError(w, r, err)
+			return
+		}
+
+		if visitorToEdit.ResidentID != accessPayload.ID {
+			respondError(w, r, errs.Unauthorized)
+			return
+		}
+		if editVisitorReq.ResidentID != "" && editVisitorReq.ResidentID != accessPayload.ID {
+			respondError(w, r, errs.BadRequest("visitor.other_resident", "Residents cannot move a visitor to another resident"))
+			return
+		}
+
+		visitor, err := h.visitorService.Update(ctx, editVisitorReq)
 		if err != nil {
-			respondError(w, err)
+			respondEditError(w, r, err, fromIfMatch)
 			return
 		}
 
+		setETag(w, visitor.Version)
 		respondJSON(w, http.StatusOK, visitor)
 	}
 }
@@ -86,30 +202,30 @@ func (h visitorHandler) deleteOne() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		id := chi.URLParam(r, "id")
 		if !util.IsUUIDV4(id) {
-			respondError(w, errs.IDNotUUID)
+			respondError(w, r, errs.IDNotUUID)
 			return
 		}
 
 		ctx := r.Context()
 		accessPayload, err := ctxGetAccessPayload(ctx)
 		if err != nil {
-			respondError(w, fmt.Errorf("visitor_handler.deleteOne: error getting access payload: %v", err))
+			respondError(w, r, fmt.Errorf("visitor_handler.deleteOne: error getting access payload: %v", err))
 			return
 		}
 
-		visitorToDelete, err := h.visitorService.GetOne(id)
+		visitorToDelete, err := h.visitorService.GetOne(ctx, id)
 		if err != nil {
-			respondError(w, err)
+			respondError(w, r, err)
 			return
 		}
 
 		if visitorToDelete.ResidentID != accessPayload.ID {
-			respondError(w, errs.Unauthorized)
+			respondError(w, r, errs.Unauthorized)
 			return
 		}
 
-		if err := h.visitorService.Delete(id); err != nil {
-			respondError(w, err)
+		if err := h.visitorService.Delete(ctx, id); err != nil {
+			respondError(w, r, err)
 			return
 		}
 
@@ -0,0 +1,80 @@
+package api
+
+import (
+	"fmt"
+	"github.com/dannyvelas/parkspot-backend/app"
+	"github.com/dannyvelas/parkspot-backend/errs"
+	"github.com/dannyvelas/parkspot-backend/models"
+	"github.com/dannyvelas/parkspot-backend/util"
+	"github.com/go-chi/chi/v5"
+	"net/http"
+)
+
+type waitlistHandler struct {
+	waitlistService app.WaitlistService
+}
+
+func newWaitlistHandler(waitlistService app.WaitlistService) waitlistHandler {
+	return waitlistHandler{
+		waitlistService: waitlistService,
+	}
+}
+
+func (h waitlistHandler) get() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		limit := util.ToPosInt(r.URL.Query().Get("limit"))
+		page := util.ToPosInt(r.URL.Query().Get("page"))
+		status := models.WaitlistStatus(r.URL.Query().Get("status"))
+
+		ctx := r.Context()
+		accessPayload, err := ctxGetAccessPayload(ctx)
+		if err != nil {
+			respondError(w, r, fmt.Errorf("waitlist_handler.get: error getting access payload: %v", err))
+			return
+		}
+
+		residentID := ""
+		if accessPayload.Role == models.ResidentRole {
+			residentID = accessPayload.ID
+		}
+
+		entriesWithMetadata, err := h.waitlistService.GetAll(ctx, status, limit, page, residentID)
+		if err != nil {
+			respondError(w, r, err)
+			return
+		}
+
+		respondJSON(w, http.StatusOK, entriesWithMetadata)
+	}
+}
+
+func (h waitlistHandler) cancel() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := chi.URLParam(r, "id")
+
+		ctx := r.Context()
+		accessPayload, err := ctxGetAccessPayload(ctx)
+		if err != nil {
+			respondError(w, r, fmt.Errorf("waitlist_handler.cancel: error getting access payload: %v", err))
+			return
+		}
+
+		entry, err := h.waitlistService.GetOne(ctx, id)
+		if err != nil {
+			respondError(w, r, err)
+			return
+		}
+
+		if accessPayload.Role == models.ResidentRole && entry.ResidentID != accessPayload.ID {
+			respondError(w, r, errs.NewUnauthorized("resident cannot cancel the waitlisted request of another resident"))
+			return
+		}
+
+		if err := h.waitlistService.Cancel(ctx, id); err != nil {
+			respondError(w, r, err)
+			return
+		}
+
+		respondJSON(w, http.StatusOK, message{"Successfully cancelled waitlisted permit request"})
+	}
+}
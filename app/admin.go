@@ -1,6 +1,7 @@
 package app
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
@@ -21,12 +22,12 @@ func NewAdminService(adminRepo storage.AdminRepo) AdminService {
 	}
 }
 
-func (s AdminService) GetOne(id string) (models.Admin, error) {
+func (s AdminService) GetOne(ctx context.Context, id string) (models.Admin, error) {
 	if id == "" {
 		return models.Admin{}, errs.MissingIDField
 	}
 
-	admin, err := s.adminRepo.GetOne(id)
+	admin, err := s.adminRepo.GetOne(ctx, id)
 	if err != nil {
 		return models.Admin{}, err
 	}
@@ -34,7 +35,7 @@ func (s AdminService) GetOne(id string) (models.Admin, error) {
 	return admin, nil
 }
 
-func (s AdminService) Update(desiredAdmin models.Admin) (models.Admin, error) {
+func (s AdminService) Update(ctx context.Context, desiredAdmin models.Admin) (models.Admin, error) {
 	// if a password is being changed, make sure it is hashed before setting it in db
 	if desiredAdmin.Password != "" {
 		hashBytes, err := bcrypt.GenerateFromPassword([]byte(desiredAdmin.Password), bcrypt.DefaultCost)
@@ -44,12 +45,12 @@ func (s AdminService) Update(desiredAdmin models.Admin) (models.Admin, error) {
 		desiredAdmin.Password = string(hashBytes)
 	}
 
-	err := s.adminRepo.Update(desiredAdmin)
+	err := s.adminRepo.Update(ctx, desiredAdmin)
 	if err != nil {
 		return models.Admin{}, fmt.Errorf("adminService.Update: Error updating admin: %w", err)
 	}
 
-	admin, err := s.GetOne(desiredAdmin.ID)
+	admin, err := s.GetOne(ctx, desiredAdmin.ID)
 	if err != nil {
 		return models.Admin{}, err
 	}
@@ -57,13 +58,13 @@ func (s AdminService) Update(desiredAdmin models.Admin) (models.Admin, error) {
 	return admin, nil
 }
 
-func (s AdminService) Create(desiredAdmin models.Admin) (models.Admin, error) {
+func (s AdminService) Create(ctx context.Context, desiredAdmin models.Admin) (models.Admin, error) {
 	if err := validator.CreateAdmin.Run(desiredAdmin); err != nil {
 		return models.Admin{}, err
 	}
 
 	// make sure admin doesn't already exist
-	if _, err := s.adminRepo.GetOne(desiredAdmin.ID); err != nil && !errors.Is(err, errs.NotFound) {
+	if _, err := s.adminRepo.GetOne(ctx, desiredAdmin.ID); err != nil && !errors.Is(err, errs.NotFound) {
 		return models.Admin{}, fmt.Errorf("admin_service.createAdmin: error getting admin by id: %v", err)
 	} else if err == nil {
 		return models.Admin{}, errs.NewAlreadyExists("an admin with ID: " + desiredAdmin.ID)
@@ -78,12 +79,12 @@ func (s AdminService) Create(desiredAdmin models.Admin) (models.Admin, error) {
 	hashString := string(hashBytes)
 
 	desiredAdmin.Password = hashString
-	err = s.adminRepo.Create(desiredAdmin)
+	err = s.adminRepo.Create(ctx, desiredAdmin)
 	if err != nil {
 		return models.Admin{}, fmt.Errorf("admin_service.createAdmin: Error querying adminRepo: %v", err)
 	}
 
-	createdAdmin, err := s.GetOne(desiredAdmin.ID)
+	createdAdmin, err := s.GetOne(ctx, desiredAdmin.ID)
 	if err != nil {
 		return models.Admin{}, fmt.Errorf("error getting admin which was just created: %w", err)
 	}
@@ -6,13 +6,23 @@ import (
 )
 
 type App struct {
-	JWTService      JWTService
-	AuthService     AuthService
-	AdminService    AdminService
-	ResidentService ResidentService
-	VisitorService  VisitorService
-	CarService      CarService
-	PermitService   PermitService
+	JWTService           JWTService
+	AuthService          AuthService
+	AdminService         AdminService
+	ResidentService      ResidentService
+	VisitorService       VisitorService
+	CarService           CarService
+	PermitService        PermitService
+	GateEventService     GateEventService
+	PlateService         PlateService
+	ViolationService     ViolationService
+	BanService           BanService
+	ParkingSpaceService  ParkingSpaceService
+	WaitlistService      WaitlistService
+	ParkingPolicyService ParkingPolicyService
+	PermitRuleService    PermitRuleService
+	ImportService        ImportService
+	ParkingDaysService   ParkingDaysService
 }
 
 func NewApp(c config.Config, database storage.Database) App {
@@ -21,17 +31,38 @@ func NewApp(c config.Config, database storage.Database) App {
 	adminService := NewAdminService(database.AdminRepo())
 	residentService := NewResidentService(database.ResidentRepo())
 	authService := NewAuthService(jwtService, adminService, residentService, c.HTTP, c.OAuth)
-	visitorService := NewVisitorService(database.VisitorRepo())
+	banService := NewBanService(database.BanRepo())
+	permitRuleService := NewPermitRuleService(database.PermitRuleRepo(), database.PermitRepo(), database.VisitorRepo(), database.ResidentRepo(), database.ParkingPolicyRepo(), c.Community.Location)
+	visitorService := NewVisitorService(database.VisitorRepo(), database.ParkingPolicyRepo(), banService, permitRuleService, c.Community.Location)
 	carService := NewCarService(database.CarRepo())
-	permitService := NewPermitService(database.PermitRepo(), database.ResidentRepo(), carService)
+	permitService := NewPermitService(database.PermitRepo(), database.ResidentRepo(), database.ParkingSpaceRepo(), database.ParkingPolicyRepo(), carService, banService, permitRuleService, c.Community.Location)
+	gateEventService := NewGateEventService(database.GateEventRepo(), database.VisitorRepo(), database.PermitRepo())
+	plateService := NewPlateService(database.CarRepo(), database.PermitRepo(), banService)
+	parkingSpaceService := NewParkingSpaceService(database.ParkingSpaceRepo(), database.PermitRepo())
+	parkingPolicyService := NewParkingPolicyService(database.ParkingPolicyRepo())
+	mailService := NewMailService(c.OAuth)
+	violationService := NewViolationService(database.ViolationRepo(), database.ResidentRepo(), plateService, mailService, c.Community.Location)
+	importService := NewImportService(database.ImportRepo(), database.ResidentRepo(), database.CarRepo())
+	parkingDaysService := NewParkingDaysService(database.ResidentRepo(), database.CarRepo(), database.PermitRepo(), permitRuleService)
+	waitlistService := NewWaitlistService(database.WaitlistRepo(), database.ResidentRepo(), permitService, mailService, c.Community.Location)
 
 	return App{
-		JWTService:      jwtService,
-		AuthService:     authService,
-		AdminService:    adminService,
-		ResidentService: residentService,
-		VisitorService:  visitorService,
-		CarService:      carService,
-		PermitService:   permitService,
+		JWTService:           jwtService,
+		AuthService:          authService,
+		AdminService:         adminService,
+		ResidentService:      residentService,
+		VisitorService:       visitorService,
+		CarService:           carService,
+		PermitService:        permitService,
+		GateEventService:     gateEventService,
+		PlateService:         plateService,
+		ViolationService:     violationService,
+		BanService:           banService,
+		ParkingSpaceService:  parkingSpaceService,
+		WaitlistService:      waitlistService,
+		ParkingPolicyService: parkingPolicyService,
+		PermitRuleService:    permitRuleService,
+		ImportService:        importService,
+		ParkingDaysService:   parkingDaysService,
 	}
 }
@@ -1,28 +1,24 @@
 package app
 
 import (
-	"bytes"
 	"context"
-	"encoding/base64"
 	"errors"
 	"fmt"
 
 	"github.com/dannyvelas/parkspot-backend/config"
 	"github.com/dannyvelas/parkspot-backend/errs"
+	"github.com/dannyvelas/parkspot-backend/i18n"
 	"github.com/dannyvelas/parkspot-backend/models"
 	"github.com/rs/zerolog/log"
 	"golang.org/x/crypto/bcrypt"
-	"golang.org/x/oauth2"
-	"google.golang.org/api/gmail/v1"
-	"google.golang.org/api/option"
 )
 
 type AuthService struct {
 	jwtService      JWTService
 	adminService    AdminService
 	residentService ResidentService
+	mailService     MailService
 	httpConfig      config.HTTPConfig
-	oauthConfig     config.OAuthConfig
 }
 
 func NewAuthService(
@@ -36,8 +32,8 @@ func NewAuthService(
 		jwtService:      jwtService,
 		adminService:    adminService,
 		residentService: residentService,
+		mailService:     NewMailService(oauthConfig),
 		httpConfig:      httpConfig,
-		oauthConfig:     oauthConfig,
 	}
 }
 
@@ -46,8 +42,8 @@ type Session struct {
 	AccessToken string      `json:"accessToken"`
 }
 
-func (a AuthService) Login(id, password string) (Session, string, error) {
-	loginable, err := a.getUser(id)
+func (a AuthService) Login(ctx context.Context, id, password string) (Session, string, error) {
+	loginable, err := a.getUser(ctx, id)
 	if errors.Is(err, errs.NotFound) {
 		return Session{}, "", errs.Unauthorized
 	} else if err != nil {
@@ -69,7 +65,7 @@ func (a AuthService) Login(id, password string) (Session, string, error) {
 		return Session{}, "", fmt.Errorf("auth_service.login: Error generating refresh JWT: %v", err)
 	}
 
-	accessToken, err := a.jwtService.NewAccess(user.ID, user.Role)
+	accessToken, err := a.jwtService.NewAccess(user.ID, user.Role, user.PreferredLang)
 	if err != nil {
 		return Session{}, "", fmt.Errorf("auth_service.login: Error generating access JWT: %v", err)
 	}
@@ -77,8 +73,8 @@ func (a AuthService) Login(id, password string) (Session, string, error) {
 	return Session{user, accessToken}, refreshToken, nil
 }
 
-func (a AuthService) RefreshTokens(user models.User) (Session, string, error) {
-	loginable, err := a.getUser(user.ID)
+func (a AuthService) RefreshTokens(ctx context.Context, user models.User) (Session, string, error) {
+	loginable, err := a.getUser(ctx, user.ID)
 	if errors.Is(err, errs.NotFound) {
 		return Session{}, "", errs.Unauthorized
 	} else if err != nil {
@@ -94,13 +90,16 @@ func (a AuthService) RefreshTokens(user models.User) (Session, string, error) {
 		return Session{}, "", errs.Unauthorized
 	}
 
+	// the preferred language may have changed since the refresh token was generated
+	user.PreferredLang = userFromDB.PreferredLang
+
 	// generate tokens
 	refreshToken, err := a.jwtService.NewRefresh(user)
 	if err != nil {
 		return Session{}, "", fmt.Errorf("auth_service.refreshTokens: Error generating refresh JWT: %v", err)
 	}
 
-	accessToken, err := a.jwtService.NewAccess(user.ID, user.Role)
+	accessToken, err := a.jwtService.NewAccess(user.ID, user.Role, user.PreferredLang)
 	if err != nil {
 		return Session{}, "", fmt.Errorf("auth_service.refreshTokens: Error generating access JWT: %v", err)
 	}
@@ -108,33 +107,31 @@ func (a AuthService) RefreshTokens(user models.User) (Session, string, error) {
 	return Session{user, accessToken}, refreshToken, nil
 }
 
-func (a AuthService) SendResetPasswordEmail(ctx context.Context, id string) error {
-	loginable, err := a.getUser(id)
+// SendResetPasswordEmail sends the email in the language that the user chose for their account.
+// if they didn't choose one, it is picked from acceptLanguage, the Accept-Language header of the request
+func (a AuthService) SendResetPasswordEmail(ctx context.Context, id string, acceptLanguage string) error {
+	loginable, err := a.getUser(ctx, id)
 	if errors.Is(err, errs.NotFound) {
 		return errs.Unauthorized
 	} else if err != nil {
 		return fmt.Errorf("auth_service.sendResetPasswordEmail: error querying repo: %v", err)
 	}
 
-	service, err := a.getGmailService(ctx)
+	user := loginable.AsUser()
+	email := resetPasswordEmails[i18n.Negotiate(user.PreferredLang, acceptLanguage)]
+	htmlBody, err := a.resetPasswordEmailBody(user, email)
 	if err != nil {
 		return fmt.Errorf("auth_service.sendResetPasswordEmail: %v", err)
 	}
 
-	gmailMessage, err := a.createGmailMessage(loginable.AsUser())
-	if err != nil {
+	if err := a.mailService.Send(ctx, user, email.subject, htmlBody); err != nil {
 		return fmt.Errorf("auth_service.sendResetPasswordEmail: %v", err)
 	}
 
-	_, err = service.Users.Messages.Send("me", gmailMessage).Do()
-	if err != nil {
-		return fmt.Errorf("auth_service.sendResetPasswordEmail: error sending mail: %v", err)
-	}
-
 	return nil
 }
 
-func (a AuthService) ResetPassword(id, newPass string) error {
+func (a AuthService) ResetPassword(ctx context.Context, id, newPass string) error {
 	if id == "" {
 		return errs.MissingIDField
 	} else if newPass == "" {
@@ -143,9 +140,9 @@ func (a AuthService) ResetPassword(id, newPass string) error {
 
 	var err error
 	if resCheckErr := models.IsResidentID(id); resCheckErr != nil {
-		_, err = a.adminService.Update(models.Admin{ID: id, Password: newPass})
+		_, err = a.adminService.Update(ctx, models.Admin{ID: id, Password: newPass})
 	} else {
-		_, err = a.residentService.Update(models.Resident{ID: id, Password: newPass})
+		_, err = a.residentService.Update(ctx, models.Resident{ID: id, Password: newPass})
 	}
 
 	if err != nil {
@@ -155,71 +152,103 @@ func (a AuthService) ResetPassword(id, newPass string) error {
 	return nil
 }
 
-func (a AuthService) getGmailService(ctx context.Context) (*gmail.Service, error) {
-	config := &oauth2.Config{
-		ClientID:     a.oauthConfig.ClientID,
-		ClientSecret: a.oauthConfig.ClientSecret,
-		RedirectURL:  a.oauthConfig.RedirectURL,
-		Scopes:       []string{a.oauthConfig.Scope},
-		Endpoint: oauth2.Endpoint{
-			AuthURL:  a.oauthConfig.AuthURL,
-			TokenURL: a.oauthConfig.TokenURL,
-		},
+// RevokeSessions signs the user with this id out of every device, by changing the token version that their
+// refresh tokens must have. access tokens that were already generated work until they expire
+func (a AuthService) RevokeSessions(ctx context.Context, id string) error {
+	loginable, err := a.getUser(ctx, id)
+	if err != nil {
+		return err
 	}
+	tokenVersion := loginable.AsUser().TokenVersion + 1
 
-	token := &oauth2.Token{
-		AccessToken:  a.oauthConfig.AccessToken,
-		RefreshToken: a.oauthConfig.RefreshToken,
-		TokenType:    a.oauthConfig.TokenType,
-		Expiry:       a.oauthConfig.Expiry,
+	if resCheckErr := models.IsResidentID(id); resCheckErr != nil {
+		_, err = a.adminService.Update(ctx, models.Admin{ID: id, TokenVersion: &tokenVersion})
+	} else {
+		// residentService.Update rejects edits that only change the token version
+		err = a.residentService.residentRepo.Update(ctx, models.Resident{ID: id, TokenVersion: &tokenVersion})
 	}
 
-	client := config.Client(ctx, token)
-
-	service, err := gmail.NewService(ctx, option.WithHTTPClient(client))
 	if err != nil {
-		return nil, fmt.Errorf("unable to retrieve Gmail client: %v", err)
+		return fmt.Errorf("authService.revokeSessions: error updating token version: %v", err)
 	}
 
-	return service, nil
+	return nil
 }
 
-func (a AuthService) createGmailMessage(toUser models.User) (*gmail.Message, error) {
-	body := &bytes.Buffer{}
+// SetPreferredLang sets the language that the API and its emails use for the user with this id.
+// it takes effect on the access tokens that are generated after it is set
+func (a AuthService) SetPreferredLang(ctx context.Context, id string, lang i18n.Lang) error {
+	if id == "" {
+		return errs.MissingIDField
+	} else if lang == "" {
+		return errs.EmptyFields("preferredLang")
+	} else if !lang.IsSupported() {
+		return errs.InvalidFields(errs.NewFieldError("preferredLang", "one_of", "preferredLang must be one of: en, es"))
+	}
+
+	var err error
+	if resCheckErr := models.IsResidentID(id); resCheckErr != nil {
+		_, err = a.adminService.Update(ctx, models.Admin{ID: id, PreferredLang: lang})
+	} else {
+		_, err = a.residentService.Update(ctx, models.Resident{ID: id, PreferredLang: lang})
+	}
 
-	token, err := a.jwtService.NewAccess(toUser.ID, toUser.Role)
 	if err != nil {
-		return nil, fmt.Errorf("error generating JWT: %v", err)
+		return fmt.Errorf("authService.setPreferredLang: error updating preferred language: %v", err)
 	}
 
-	fmt.Fprintf(body, "From: Park Spot <parkspotapplication@gmail.com>\r\n")
-	fmt.Fprintf(body, "To: %s %s <%s>\r\n", toUser.FirstName, toUser.LastName, toUser.Email)
-	fmt.Fprintf(body, "Subject: Password Reset\r\n")
-	fmt.Fprintf(body, "MIME-Version: 1.0\r\n")
-	fmt.Fprintf(body, "Content-Type: text/html\r\n")
-	fmt.Fprintf(body, `
+	return nil
+}
+
+type resetPasswordEmail struct {
+	subject string
+	// body is formatted with the frontend url and the access token of the user
+	body string
+}
+
+var resetPasswordEmails = map[i18n.Lang]resetPasswordEmail{
+	i18n.English: {
+		subject: "Password Reset",
+		body: `
     <body style='text-align: center;'>
         <h1>Password Reset</h1>
         <p>Hi, a password reset was requested.</p>
         <p>If you sent the request, please click the button below to reset your password.
            Otherwise, you can ignore this email.</p>
         <a href='%s/reset-password?token=%s'>Reset Your Password</a>
-    </body>`, a.httpConfig.FrontendURL, token)
+    </body>`,
+	},
+	i18n.Spanish: {
+		subject: "Restablecer Contraseña",
+		body: `
+    <body style='text-align: center;'>
+        <h1>Restablecer Contraseña</h1>
+        <p>Hola, se solicitó restablecer su contraseña.</p>
+        <p>Si usted envió la solicitud, haga clic en el botón de abajo para restablecer su contraseña.
+           De lo contrario, puede ignorar este correo.</p>
+        <a href='%s/reset-password?token=%s'>Restablecer Su Contraseña</a>
+    </body>`,
+	},
+}
 
-	gmailMessage := &gmail.Message{Raw: base64.URLEncoding.EncodeToString(body.Bytes())}
+func (a AuthService) resetPasswordEmailBody(toUser models.User, email resetPasswordEmail) (string, error) {
+	token, err := a.jwtService.NewAccess(toUser.ID, toUser.Role, toUser.PreferredLang)
+	if err != nil {
+		return "", fmt.Errorf("error generating JWT: %v", err)
+	}
 
-	return gmailMessage, nil
+	return fmt.Sprintf(email.body, a.httpConfig.FrontendURL, token), nil
 }
 
-func (a AuthService) getUser(id string) (models.Loginable, error) {
+func (a AuthService) getUser(ctx context.Context, id string) (models.Loginable, error) {
 	// i wanted to define an interface that both adminService and residentService implement:
 	// type UserService interface { GetOne(id string) (models.Loginable, error) }
 	// but adminService and residentService cannot implement the GetOne function
 	// because they return different types (models.Admin) and (models.User).
 	// and, go mandates that structs must return the same exact type to implement a common interface
 	if resCheckErr := models.IsResidentID(id); resCheckErr != nil {
-		return a.adminService.GetOne(id)
+		return a.adminService.GetOne(ctx, id)
 	} else {
-		return a.residentService.GetOne(id)
+		return a.residentService.GetOne(ctx, id)
 	}
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/testcontainers/testcontainers-go"
 	"golang.org/x/crypto/bcrypt"
 	"testing"
+	"time"
 )
 
 type authTestSuite struct {
@@ -26,7 +27,7 @@ func TestAuthService(t *testing.T) {
 
 func (suite *authTestSuite) SetupSuite() {
 	// configure and start container
-	container, database, err := psql.NewSandboxDatabase()
+	container, database, err := psql.NewSandboxDatabase(time.Local)
 	if err != nil {
 		suite.T().Fatalf("error getting sandbox database: %v", err)
 	}
@@ -42,7 +43,7 @@ func (suite *authTestSuite) SetupSuite() {
 	suite.authService = NewAuthService(jwtService, adminService, suite.residentService, config.HTTPConfig{}, config.OAuthConfig{})
 
 	// create resident
-	if _, err := suite.residentService.Create(models.TestResident); err != nil {
+	if _, err := suite.residentService.Create(context.Background(), models.TestResident); err != nil {
 		suite.TearDownSuite()
 		suite.T().Fatalf("tearing down because failed to create resident: %v", err)
 	}
@@ -56,18 +57,18 @@ func (suite *authTestSuite) TearDownSuite() {
 }
 
 func (suite *authTestSuite) TearDownTest() {
-	if err := suite.residentService.residentRepo.Reset(); err != nil {
+	if err := suite.residentService.residentRepo.Reset(context.Background()); err != nil {
 		suite.T().Fatalf("encountered error resetting auth repo in-between tests")
 	}
 }
 
 func (suite *authTestSuite) TestResetPassword() {
 	const desiredPassword = "newPass"
-	if err := suite.authService.ResetPassword(models.TestResident.ID, desiredPassword); err != nil {
+	if err := suite.authService.ResetPassword(context.Background(), models.TestResident.ID, desiredPassword); err != nil {
 		require.NoError(suite.T(), fmt.Errorf("error resetting password: %v", err))
 	}
 
-	resident, err := suite.residentService.GetOne(models.TestResident.ID)
+	resident, err := suite.residentService.GetOne(context.Background(), models.TestResident.ID)
 	if err != nil {
 		require.NoError(suite.T(), fmt.Errorf("error getting resident from database: %v", err))
 	}
@@ -0,0 +1,132 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dannyvelas/parkspot-backend/errs"
+	"github.com/dannyvelas/parkspot-backend/models"
+	"github.com/dannyvelas/parkspot-backend/models/validator"
+	"github.com/dannyvelas/parkspot-backend/storage"
+	"github.com/dannyvelas/parkspot-backend/storage/selectopts"
+	"github.com/dannyvelas/parkspot-backend/util"
+)
+
+type BanService struct {
+	banRepo storage.BanRepo
+}
+
+func NewBanService(banRepo storage.BanRepo) BanService {
+	return BanService{
+		banRepo: banRepo,
+	}
+}
+
+func (s BanService) GetAll(ctx context.Context, status models.Status, limit, page int, search string) (models.ListWithMetadata[models.Ban], error) {
+	boundedLimit, offset := getBoundedLimitAndOffset(limit, page)
+
+	allBans, err := s.banRepo.SelectWhere(ctx, models.Ban{},
+		selectopts.WithStatus(status),
+		selectopts.WithSearch(search),
+		selectopts.WithLimitAndOffset(boundedLimit, offset),
+	)
+	if err != nil {
+		return models.ListWithMetadata[models.Ban]{}, fmt.Errorf("error getting bans from ban repo: %v", err)
+	}
+
+	totalAmount, err := s.banRepo.SelectCountWhere(ctx, models.Ban{},
+		selectopts.WithStatus(status),
+		selectopts.WithSearch(search),
+	)
+	if err != nil {
+		return models.ListWithMetadata[models.Ban]{}, fmt.Errorf("error getting total amount from ban repo: %v", err)
+	}
+
+	return models.NewListWithMetadata(allBans, totalAmount), nil
+}
+
+func (s BanService) Create(ctx context.Context, desiredBan models.Ban) (models.Ban, error) {
+	if err := validator.CreateBan.Run(desiredBan); err != nil {
+		return models.Ban{}, err
+	}
+	if desiredBan.CreatedBy == "" {
+		return models.Ban{}, errs.EmptyFields("createdBy")
+	}
+
+	desiredBan.LicensePlate = util.NormalizeLicensePlate(desiredBan.LicensePlate)
+	desiredBan.FirstName = strings.TrimSpace(desiredBan.FirstName)
+	desiredBan.LastName = strings.TrimSpace(desiredBan.LastName)
+	desiredBan.CreatedAt = time.Now()
+
+	banID, err := s.banRepo.Create(ctx, desiredBan)
+	if err != nil {
+		return models.Ban{}, fmt.Errorf("error creating ban in ban repo: %v", err)
+	}
+
+	ban, err := s.banRepo.GetOne(ctx, banID)
+	if err != nil {
+		return models.Ban{}, fmt.Errorf("error getting ban after creating in ban repo: %v", err)
+	}
+
+	return ban, nil
+}
+
+func (s BanService) Delete(ctx context.Context, id string) error {
+	if id == "" {
+		return errs.MissingIDField
+	}
+	if !util.IsUUIDV4(id) {
+		return errs.IDNotUUID
+	}
+	return s.banRepo.Delete(ctx, id)
+}
+
+// GetActiveOfLicensePlate returns the unexpired ban of licensePlate, or nil if there is none
+func (s BanService) GetActiveOfLicensePlate(ctx context.Context, licensePlate string) (*models.Ban, error) {
+	normalized := util.NormalizeLicensePlate(licensePlate)
+	if normalized == "" {
+		return nil, nil
+	}
+
+	bans, err := s.banRepo.SelectWhere(ctx, models.Ban{},
+		selectopts.WithStatus(models.ActiveStatus),
+		selectopts.WithLicensePlate(normalized),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("ban_service.GetActiveOfLicensePlate: error getting bans of license plate: %v", err)
+	} else if len(bans) == 0 {
+		return nil, nil
+	}
+
+	return &bans[0], nil
+}
+
+// CheckLicensePlate returns errs.BannedLicensePlate if licensePlate has an unexpired ban
+func (s BanService) CheckLicensePlate(ctx context.Context, licensePlate string) error {
+	ban, err := s.GetActiveOfLicensePlate(ctx, licensePlate)
+	if err != nil {
+		return err
+	} else if ban != nil {
+		return errs.BannedLicensePlate
+	}
+	return nil
+}
+
+// CheckVisitor returns errs.BannedVisitor if a visitor with this name has an unexpired ban
+func (s BanService) CheckVisitor(ctx context.Context, firstName, lastName string) error {
+	if strings.TrimSpace(firstName) == "" || strings.TrimSpace(lastName) == "" {
+		return nil
+	}
+
+	amtBans, err := s.banRepo.SelectCountWhere(ctx, models.Ban{FirstName: firstName, LastName: lastName},
+		selectopts.WithStatus(models.ActiveStatus),
+	)
+	if err != nil {
+		return fmt.Errorf("ban_service.CheckVisitor: error getting bans of visitor: %v", err)
+	} else if amtBans != 0 {
+		return errs.BannedVisitor
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package app
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/dannyvelas/parkspot-backend/errs"
@@ -21,44 +22,60 @@ func NewCarService(carRepo storage.CarRepo) CarService {
 	}
 }
 
-func (s CarService) GetAll(limit, page int, reversed bool, search, residentID string) (models.ListWithMetadata[models.Car], error) {
-	boundedLimit, offset := getBoundedLimitAndOffset(limit, page)
+func (s CarService) GetAll(ctx context.Context, params ListParams, residentID string) (models.ListWithMetadata[models.Car], error) {
+	paging, err := newPaging(s.carRepo, params)
+	if err != nil {
+		return models.ListWithMetadata[models.Car]{}, err
+	}
 
-	allCars, err := s.carRepo.SelectWhere(models.Car{ResidentID: residentID},
-		selectopts.WithLimitAndOffset(boundedLimit, offset),
-		selectopts.WithReversed(reversed),
-		selectopts.WithSearch(search),
+	allCars, err := s.carRepo.SelectWhere(ctx, models.Car{ResidentID: residentID},
+		paging.selectOpts()...,
 	)
 	if err != nil {
 		return models.ListWithMetadata[models.Car]{}, fmt.Errorf("error getting cars from car repo: %v", err)
 	}
 
-	totalAmount, err := s.carRepo.SelectCountWhere(models.Car{ResidentID: residentID},
-		selectopts.WithSearch(search),
-	)
-	if err != nil {
-		return models.ListWithMetadata[models.Car]{}, fmt.Errorf("error getting total amount from car repo: %v", err)
+	var totalAmount *int
+	if !paging.byCursor() {
+		count, err := s.carRepo.SelectCountWhere(ctx, models.Car{ResidentID: residentID}, paging.countOpts()...)
+		if err != nil {
+			return models.ListWithMetadata[models.Car]{}, fmt.Errorf("error getting total amount from car repo: %v", err)
+		}
+		totalAmount = &count
+	}
+
+	return newPagedList(paging, allCars, func(car models.Car) string { return car.ID }, totalAmount), nil
+}
+
+// Export calls each with every car that GetAll lists with params, on every page
+func (s CarService) Export(ctx context.Context, params ListParams, residentID string, each func(models.Car) error) error {
+	selectPage := func(opts []selectopts.SelectOpt) ([]models.Car, error) {
+		cars, err := s.carRepo.SelectWhere(ctx, models.Car{ResidentID: residentID}, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("error getting cars from car repo: %v", err)
+		}
+		return cars, nil
 	}
 
-	return models.NewListWithMetadata(allCars, totalAmount), nil
+	return forEachRow(s.carRepo, params, selectPage, func(car models.Car) string { return car.ID }, each)
 }
 
-func (s CarService) GetOne(id string) (models.Car, error) {
+func (s CarService) GetOne(ctx context.Context, id string) (models.Car, error) {
 	if id == "" {
 		return models.Car{}, errs.MissingIDField
 	}
 	if !util.IsUUIDV4(id) {
 		return models.Car{}, errs.IDNotUUID
 	}
-	return s.carRepo.GetOne(id)
+	return s.carRepo.GetOne(ctx, id)
 }
 
-func (s CarService) GetOneByLicensePlate(licensePlate string) (models.Car, error) {
+func (s CarService) GetOneByLicensePlate(ctx context.Context, licensePlate string) (models.Car, error) {
 	if licensePlate == "" {
 		return models.Car{}, errs.EmptyFields("licensePlate")
 	}
 
-	cars, err := s.carRepo.SelectWhere(models.Car{LicensePlate: licensePlate})
+	cars, err := s.carRepo.SelectWhere(ctx, models.Car{LicensePlate: licensePlate})
 	if err != nil {
 		return models.Car{}, fmt.Errorf("error getting car by licensePlate: %v", err)
 	} else if len(cars) == 0 {
@@ -68,14 +85,14 @@ func (s CarService) GetOneByLicensePlate(licensePlate string) (models.Car, error
 	return cars[0], nil
 }
 
-func (s CarService) Delete(id string) error {
+func (s CarService) Delete(ctx context.Context, id string) error {
 	if id == "" {
 		return errs.MissingIDField
 	}
-	return s.carRepo.Delete(id)
+	return s.carRepo.Delete(ctx, id)
 }
 
-func (s CarService) Update(updatedFields models.Car) (models.Car, error) {
+func (s CarService) Update(ctx context.Context, updatedFields models.Car) (models.Car, error) {
 	if updatedFields.ID == "" {
 		return models.Car{}, errs.MissingIDField
 	}
@@ -91,7 +108,7 @@ func (s CarService) Update(updatedFields models.Car) (models.Car, error) {
 
 	// if license plate is being updated to a new one, make sure it's unique
 	if updatedFields.LicensePlate != "" {
-		if cars, err := s.carRepo.SelectWhere(models.Car{LicensePlate: updatedFields.LicensePlate}); err != nil {
+		if cars, err := s.carRepo.SelectWhere(ctx, models.Car{LicensePlate: updatedFields.LicensePlate}); err != nil {
 			return models.Car{}, fmt.Errorf("car_service.update error getting car by license plate: %v", err)
 		} else if len(cars) != 0 && cars[0].ID != updatedFields.ID {
 			// it's possible that the car we found with the same licensePlate in the database is the car we're currently updating
@@ -100,12 +117,12 @@ func (s CarService) Update(updatedFields models.Car) (models.Car, error) {
 		}
 	}
 
-	err := s.carRepo.Update(updatedFields)
+	err := s.carRepo.Update(ctx, updatedFields)
 	if err != nil {
 		return models.Car{}, fmt.Errorf("error updating car from carRepo: %w", err)
 	}
 
-	car, err := s.carRepo.GetOne(updatedFields.ID)
+	car, err := s.carRepo.GetOne(ctx, updatedFields.ID)
 	if err != nil {
 		return models.Car{}, fmt.Errorf("error getting car from carRepo: %w", err)
 	}
@@ -113,29 +130,29 @@ func (s CarService) Update(updatedFields models.Car) (models.Car, error) {
 	return car, nil
 }
 
-func (s CarService) Create(desiredCar models.Car) (models.Car, error) {
+func (s CarService) Create(ctx context.Context, desiredCar models.Car) (models.Car, error) {
 	if err := validator.CreateCar.Run(desiredCar); err != nil {
 		return models.Car{}, err
 	}
 	if desiredCar.ID != "" {
-		if cars, err := s.carRepo.SelectWhere(models.Car{ID: desiredCar.ID}); err != nil {
+		if cars, err := s.carRepo.SelectWhere(ctx, models.Car{ID: desiredCar.ID}); err != nil {
 			return models.Car{}, fmt.Errorf("carService.Create: error getting car by id: %v", err)
 		} else if len(cars) != 0 {
 			return models.Car{}, errs.NewAlreadyExists("a car with this ID: " + desiredCar.ID)
 		}
 	}
 
-	if cars, err := s.carRepo.SelectWhere(models.Car{LicensePlate: desiredCar.LicensePlate}); err != nil {
+	if cars, err := s.carRepo.SelectWhere(ctx, models.Car{LicensePlate: desiredCar.LicensePlate}); err != nil {
 		return models.Car{}, fmt.Errorf("carService.createCar error getting car by licensePlate: %v", err)
 	} else if len(cars) != 0 {
 		return models.Car{}, errs.NewErrCarWithLPAlreadyExists(desiredCar.LicensePlate)
 	}
 
-	carID, err := s.carRepo.Create(desiredCar)
+	carID, err := s.carRepo.Create(ctx, desiredCar)
 	if err != nil {
 		return models.Car{}, fmt.Errorf("error creating car with carRepo: %v", err)
 	}
 
-	newCar := models.NewCar(carID, desiredCar.ResidentID, desiredCar.LicensePlate, desiredCar.Color, desiredCar.Make, desiredCar.Model, 0)
+	newCar := models.NewCar(carID, desiredCar.ResidentID, desiredCar.LicensePlate, desiredCar.Color, desiredCar.Make, desiredCar.Model, 0, 1)
 	return newCar, nil
 }
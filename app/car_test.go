@@ -6,12 +6,14 @@ import (
 	"github.com/dannyvelas/parkspot-backend/errs"
 	"github.com/dannyvelas/parkspot-backend/models"
 	"github.com/dannyvelas/parkspot-backend/storage/psql"
+	"github.com/dannyvelas/parkspot-backend/storage/selectopts"
 	"github.com/imdario/mergo"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"github.com/testcontainers/testcontainers-go"
 	"net/http"
 	"testing"
+	"time"
 )
 
 type carTestSuite struct {
@@ -26,7 +28,7 @@ func TestCarService(t *testing.T) {
 
 func (suite *carTestSuite) SetupSuite() {
 	// configure and start container
-	container, database, err := psql.NewSandboxDatabase()
+	container, database, err := psql.NewSandboxDatabase(time.Local)
 	if err != nil {
 		suite.T().Fatalf("error getting sandbox database: %v", err)
 	}
@@ -35,7 +37,7 @@ func (suite *carTestSuite) SetupSuite() {
 
 	residentService := NewResidentService(database.ResidentRepo())
 	// use default models.TestResident for duration of tests
-	if _, err := residentService.Create(models.TestResident); err != nil {
+	if _, err := residentService.Create(context.Background(), models.TestResident); err != nil {
 		suite.TearDownSuite()
 		suite.T().Fatalf("tearing down because failed to create resident: %v", err)
 	}
@@ -51,7 +53,7 @@ func (suite *carTestSuite) TearDownSuite() {
 }
 
 func (suite *carTestSuite) TearDownTest() {
-	err := suite.carService.carRepo.Reset()
+	err := suite.carService.carRepo.Reset(context.Background())
 	if err != nil {
 		suite.T().Fatalf("encountered error resetting car repo in-between tests")
 	}
@@ -60,7 +62,7 @@ func (suite *carTestSuite) TearDownTest() {
 func (suite *carTestSuite) TestEdit_CarDNE_Negative() {
 	carWithIDThatDNE := models.Car{ID: "9b6d89a6-0b66-4170-be8d-eba43f8bf478", LicensePlate: "NEWLP"}
 
-	_, err := suite.carService.Update(carWithIDThatDNE)
+	_, err := suite.carService.Update(context.Background(), carWithIDThatDNE)
 	require.Error(suite.T(), err, "No error encountered when editing a non-existing car")
 
 	var apiErr *errs.APIErr
@@ -70,7 +72,7 @@ func (suite *carTestSuite) TestEdit_CarDNE_Negative() {
 }
 
 func (suite *carTestSuite) TestEdit_Car_Positive() {
-	carToEdit := models.NewCar("d1e0affb-14e7-4e9f-b8a3-70be7d49d063", models.TestResident.ID, "lp1", "color", "make", "model", 0)
+	carToEdit := models.NewCar("d1e0affb-14e7-4e9f-b8a3-70be7d49d063", models.TestResident.ID, "lp1", "color", "make", "model", 0, 0)
 
 	// set up a table of tests
 	type test struct {
@@ -94,7 +96,7 @@ func (suite *carTestSuite) TestEdit_Car_Positive() {
 
 	// this func will execute one row of above table
 	executeTest := func(test test) error {
-		result, err := suite.carService.Update(test.argument)
+		result, err := suite.carService.Update(context.Background(), test.argument)
 		if err != nil {
 			return fmt.Errorf("error making request: %v", err)
 		}
@@ -109,7 +111,7 @@ func (suite *carTestSuite) TestEdit_Car_Positive() {
 	}
 
 	for testName, test := range tests {
-		createdCar, err := suite.carService.Create(carToEdit)
+		createdCar, err := suite.carService.Create(context.Background(), carToEdit)
 		require.NoError(suite.T(), err)
 
 		err = executeTest(test)
@@ -117,7 +119,7 @@ func (suite *carTestSuite) TestEdit_Car_Positive() {
 			require.NoError(suite.T(), fmt.Errorf("%s failed: %v", testName, err))
 		}
 
-		err = suite.carService.Delete(createdCar.ID)
+		err = suite.carService.Delete(context.Background(), createdCar.ID)
 		if err != nil {
 			require.NoError(suite.T(), err)
 		}
@@ -125,14 +127,77 @@ func (suite *carTestSuite) TestEdit_Car_Positive() {
 }
 
 func (suite *carTestSuite) TestCreate_CarRepeatLP_Negative() {
-	prevExistingCar := models.NewCar("", models.TestResident.ID, "lp1", "color", "make", "model", 0)
-	if _, err := suite.carService.Create(prevExistingCar); err != nil {
+	prevExistingCar := models.NewCar("", models.TestResident.ID, "lp1", "color", "make", "model", 0, 0)
+	if _, err := suite.carService.Create(context.Background(), prevExistingCar); err != nil {
 		require.NoError(suite.T(), fmt.Errorf("error creating test car before running test: %v", err))
 	}
 
-	carWithSameLP := models.NewCar("", models.TestResident.ID, "lp1", "color", "make", "model", 0)
-	_, err := suite.carService.Create(carWithSameLP)
+	carWithSameLP := models.NewCar("", models.TestResident.ID, "lp1", "color", "make", "model", 0, 0)
+	_, err := suite.carService.Create(context.Background(), carWithSameLP)
 	require.NotNil(suite.T(), err, "error when creating car with duplicate LP was not nil but it should have been")
 
 	require.ErrorIs(suite.T(), err, errs.AlreadyExists, "error is expected to be one of already exists")
 }
+
+func (suite *carTestSuite) TestGetAll_Search_Positive() {
+	for _, car := range []models.Car{
+		models.NewCar("", models.TestResident.ID, "lp1", "red", "Toyota", "Corolla", 0, 0),
+		models.NewCar("", models.TestResident.ID, "lp2", "blue", "Honda", "Civic", 0, 0),
+	} {
+		if _, err := suite.carService.Create(context.Background(), car); err != nil {
+			require.NoError(suite.T(), fmt.Errorf("error creating test car before running test: %v", err))
+		}
+	}
+
+	tests := map[string]struct {
+		search   string
+		expected []string
+	}{
+		"part of a word":                       {search: "toy", expected: []string{"lp1"}},
+		"every term matches a column":          {search: "COROLLA red", expected: []string{"lp1"}},
+		"terms that match different cars":      {search: "toyota civic", expected: []string{}},
+		"like wildcards are matched literally": {search: "%", expected: []string{}},
+	}
+
+	for testName, test := range tests {
+		cars, err := suite.carService.GetAll(context.Background(), ListParams{Search: test.search}, "")
+		require.NoError(suite.T(), err, "%s failed", testName)
+
+		licensePlates := []string{}
+		for _, car := range cars.Records {
+			licensePlates = append(licensePlates, car.LicensePlate)
+		}
+		require.Equal(suite.T(), test.expected, licensePlates, "%s failed", testName)
+	}
+}
+
+func (suite *carTestSuite) TestGetAll_Filters_Positive() {
+	for _, car := range []models.Car{
+		models.NewCar("", models.TestResident.ID, "lp1", "red", "Toyota", "Corolla", 0, 0),
+		models.NewCar("", models.TestResident.ID, "lp2", "blue", "Honda", "Civic", 0, 0),
+	} {
+		if _, err := suite.carService.Create(context.Background(), car); err != nil {
+			require.NoError(suite.T(), fmt.Errorf("error creating test car before running test: %v", err))
+		}
+	}
+
+	cars, err := suite.carService.GetAll(context.Background(), ListParams{Filters: []selectopts.Filter{
+		{Field: "make", Op: selectopts.Eq, Value: "honda"},
+		{Field: "amtParkingDaysUsed", Op: selectopts.Lte, Value: "0"},
+	}}, "")
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), cars.Records, 1)
+	require.Equal(suite.T(), "lp2", cars.Records[0].LicensePlate)
+	require.Equal(suite.T(), 1, *cars.Metadata.TotalAmount)
+}
+
+func (suite *carTestSuite) TestGetAll_UnknownFilter_Negative() {
+	_, err := suite.carService.GetAll(context.Background(), ListParams{Filters: []selectopts.Filter{{Field: "password", Op: selectopts.Eq, Value: "x"}}}, "")
+
+	var apiErr *errs.APIErr
+	require.ErrorAs(suite.T(), err, &apiErr)
+	require.Equal(suite.T(), http.StatusBadRequest, apiErr.StatusCode)
+	require.Len(suite.T(), apiErr.Fields, 1)
+	require.Equal(suite.T(), "password", apiErr.Fields[0].Field)
+	require.Equal(suite.T(), "filterable", apiErr.Fields[0].Rule)
+}
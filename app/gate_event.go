@@ -0,0 +1,186 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dannyvelas/parkspot-backend/errs"
+	"github.com/dannyvelas/parkspot-backend/models"
+	"github.com/dannyvelas/parkspot-backend/storage"
+	"github.com/dannyvelas/parkspot-backend/storage/selectopts"
+)
+
+type GateEventService struct {
+	gateEventRepo storage.GateEventRepo
+	visitorRepo   storage.VisitorRepo
+	permitRepo    storage.PermitRepo
+}
+
+func NewGateEventService(gateEventRepo storage.GateEventRepo, visitorRepo storage.VisitorRepo, permitRepo storage.PermitRepo) GateEventService {
+	return GateEventService{
+		gateEventRepo: gateEventRepo,
+		visitorRepo:   visitorRepo,
+		permitRepo:    permitRepo,
+	}
+}
+
+// GetAll returns the gate events with a given status. models.ActiveStatus returns guests that are currently
+// on the property. models.OverstayStatus returns guests that are on the property after their pass ended
+func (s GateEventService) GetAll(ctx context.Context, status models.Status, limit, page int, search, residentID string) (models.ListWithMetadata[models.GateEvent], error) {
+	boundedLimit, offset := getBoundedLimitAndOffset(limit, page)
+
+	allGateEvents, err := s.gateEventRepo.SelectWhere(ctx, models.GateEvent{ResidentID: residentID},
+		selectopts.WithStatus(status),
+		selectopts.WithLimitAndOffset(boundedLimit, offset),
+		selectopts.WithSearch(search),
+	)
+	if err != nil {
+		return models.ListWithMetadata[models.GateEvent]{}, fmt.Errorf("error getting gate events from gate event repo: %v", err)
+	}
+
+	totalAmount, err := s.gateEventRepo.SelectCountWhere(ctx, models.GateEvent{ResidentID: residentID},
+		selectopts.WithStatus(status),
+		selectopts.WithSearch(search),
+	)
+	if err != nil {
+		return models.ListWithMetadata[models.GateEvent]{}, fmt.Errorf("error getting total amount from gate event repo: %v", err)
+	}
+
+	return models.NewListWithMetadata(allGateEvents, totalAmount), nil
+}
+
+// CheckIn records the arrival of a guest. the guest is identified by exactly one of the
+// VisitorID, PermitID or LicensePlate fields of desiredGateEvent
+func (s GateEventService) CheckIn(ctx context.Context, desiredGateEvent models.GateEvent) (models.GateEvent, error) {
+	if desiredGateEvent.RecordedBy == "" {
+		return models.GateEvent{}, errs.EmptyFields("recordedBy")
+	}
+
+	now := time.Now()
+	populatedGateEvent, err := s.populatePassFields(ctx, desiredGateEvent, now)
+	if err != nil {
+		return models.GateEvent{}, err
+	}
+
+	if _, err := s.findOnProperty(ctx, populatedGateEvent); err == nil {
+		return models.GateEvent{}, errs.GateEventAlreadyOnProperty
+	} else if !errors.Is(err, errs.GateEventNotOnProperty) {
+		return models.GateEvent{}, err
+	}
+
+	populatedGateEvent.CheckIn = now
+	gateEventID, err := s.gateEventRepo.Create(ctx, populatedGateEvent)
+	if err != nil {
+		return models.GateEvent{}, fmt.Errorf("error creating gate event in gate event repo: %v", err)
+	}
+
+	gateEvent, err := s.gateEventRepo.GetOne(ctx, gateEventID)
+	if err != nil {
+		return models.GateEvent{}, fmt.Errorf("error getting gate event after creating in gate event repo: %v", err)
+	}
+
+	return gateEvent, nil
+}
+
+// CheckOut records the departure of a guest that is currently on the property
+func (s GateEventService) CheckOut(ctx context.Context, desiredGateEvent models.GateEvent) (models.GateEvent, error) {
+	if countPassFields(desiredGateEvent) != 1 {
+		return models.GateEvent{}, errs.GateEventNoPass
+	}
+
+	openGateEvent, err := s.findOnProperty(ctx, desiredGateEvent)
+	if err != nil {
+		return models.GateEvent{}, err
+	}
+
+	if err := s.gateEventRepo.SetCheckOut(ctx, openGateEvent.ID, time.Now()); err != nil {
+		return models.GateEvent{}, fmt.Errorf("error setting check out in gate event repo: %w", err)
+	}
+
+	gateEvent, err := s.gateEventRepo.GetOne(ctx, openGateEvent.ID)
+	if err != nil {
+		return models.GateEvent{}, fmt.Errorf("error getting gate event after checking out in gate event repo: %w", err)
+	}
+
+	return gateEvent, nil
+}
+
+// helpers
+func (s GateEventService) populatePassFields(ctx context.Context, g models.GateEvent, now time.Time) (models.GateEvent, error) {
+	if countPassFields(g) != 1 {
+		return models.GateEvent{}, errs.GateEventNoPass
+	}
+
+	if g.VisitorID != "" {
+		visitor, err := s.visitorRepo.GetOne(ctx, g.VisitorID)
+		if err != nil {
+			return models.GateEvent{}, err
+		}
+		if now.Before(visitor.AccessStart) || now.After(visitor.AccessEnd) {
+			return models.GateEvent{}, errs.GateEventPassNotActive
+		}
+		g.ResidentID = visitor.ResidentID
+		return g, nil
+	}
+
+	var permit models.Permit
+	if g.PermitID != 0 {
+		foundPermit, err := s.permitRepo.GetOne(ctx, g.PermitID)
+		if err != nil {
+			return models.GateEvent{}, err
+		}
+		permit = foundPermit
+	} else {
+		activePermits, err := s.permitRepo.SelectWhere(ctx, models.Permit{},
+			selectopts.WithLicensePlate(g.LicensePlate),
+			selectopts.WithStatus(models.ActiveStatus),
+		)
+		if err != nil {
+			return models.GateEvent{}, fmt.Errorf("error getting active permits by licensePlate in permit repo: %v", err)
+		} else if len(activePermits) == 0 {
+			return models.GateEvent{}, errs.GateEventPassNotActive
+		}
+		permit = activePermits[0]
+	}
+
+	if now.Before(permit.StartDate) || now.After(permit.EndDate) {
+		return models.GateEvent{}, errs.GateEventPassNotActive
+	}
+
+	g.ResidentID = permit.ResidentID
+	g.PermitID = permit.ID
+	g.LicensePlate = permit.LicensePlate
+	return g, nil
+}
+
+func (s GateEventService) findOnProperty(ctx context.Context, g models.GateEvent) (models.GateEvent, error) {
+	onProperty, err := s.gateEventRepo.SelectWhere(
+		ctx,
+		models.GateEvent{VisitorID: g.VisitorID, PermitID: g.PermitID},
+		selectopts.WithLicensePlate(g.LicensePlate),
+		selectopts.WithStatus(models.ActiveStatus),
+	)
+	if err != nil {
+		return models.GateEvent{}, fmt.Errorf("error getting gate events on property from gate event repo: %v", err)
+	} else if len(onProperty) == 0 {
+		return models.GateEvent{}, errs.GateEventNotOnProperty
+	}
+
+	return onProperty[0], nil
+}
+
+func countPassFields(g models.GateEvent) int {
+	amt := 0
+	if g.VisitorID != "" {
+		amt++
+	}
+	if g.PermitID != 0 {
+		amt++
+	}
+	if g.LicensePlate != "" {
+		amt++
+	}
+	return amt
+}
@@ -0,0 +1,125 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dannyvelas/parkspot-backend/errs"
+	"github.com/dannyvelas/parkspot-backend/models"
+	"github.com/dannyvelas/parkspot-backend/storage/psql"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/testcontainers/testcontainers-go"
+)
+
+type gateEventTestSuite struct {
+	suite.Suite
+	container        testcontainers.Container
+	gateEventService GateEventService
+	permitService    PermitService
+	visitorService   VisitorService
+}
+
+func TestGateEventService(t *testing.T) {
+	suite.Run(t, new(gateEventTestSuite))
+}
+
+func (suite *gateEventTestSuite) SetupSuite() {
+	// configure and start container
+	container, database, err := psql.NewSandboxDatabase(time.Local)
+	if err != nil {
+		suite.T().Fatalf("error getting sandbox database: %v", err)
+	}
+	// save container in suite struct so we can terminate it on suite teardown
+	suite.container = container
+
+	residentService := NewResidentService(database.ResidentRepo())
+	carService := NewCarService(database.CarRepo())
+	banService := NewBanService(database.BanRepo())
+	permitRuleService := NewPermitRuleService(database.PermitRuleRepo(), database.PermitRepo(), database.VisitorRepo(), database.ResidentRepo(), database.ParkingPolicyRepo(), time.Local)
+	suite.permitService = NewPermitService(database.PermitRepo(), database.ResidentRepo(), database.ParkingSpaceRepo(), database.ParkingPolicyRepo(), carService, banService, permitRuleService, time.Local)
+	suite.visitorService = NewVisitorService(database.VisitorRepo(), database.ParkingPolicyRepo(), banService, permitRuleService, time.Local)
+	suite.gateEventService = NewGateEventService(database.GateEventRepo(), database.VisitorRepo(), database.PermitRepo())
+
+	if _, err := residentService.Create(context.Background(), models.TestResident); err != nil {
+		suite.TearDownSuite()
+		suite.T().Fatalf("tearing down because failed to create resident: %v", err)
+	}
+
+	if _, err := carService.Create(context.Background(), models.TestCar); err != nil {
+		suite.TearDownSuite()
+		suite.T().Fatalf("tearing down because failed to create car: %v", err)
+	}
+}
+
+func (suite *gateEventTestSuite) TearDownSuite() {
+	err := suite.container.Terminate(context.Background())
+	if err != nil {
+		require.NoError(suite.T(), fmt.Errorf("error tearing down container: %v", err))
+	}
+}
+
+func (suite *gateEventTestSuite) TearDownTest() {
+	if err := suite.gateEventService.gateEventRepo.Reset(context.Background()); err != nil {
+		suite.T().Fatalf("encountered error resetting gate event repo in-between tests")
+	}
+	if err := suite.permitService.permitRepo.Reset(context.Background()); err != nil {
+		suite.T().Fatalf("encountered error resetting permit repo in-between tests")
+	}
+}
+
+func (suite *gateEventTestSuite) TestCheckIn_ByLicensePlate_Positive() {
+	permit, err := suite.permitService.Create(context.Background(), activeFor24Hrs(models.Permit{ResidentID: models.TestResident.ID, CarID: models.TestCar.ID, ExceptionReason: "gate test"}, -1))
+	require.NoError(suite.T(), err, "error creating permit before test")
+
+	gateEvent, err := suite.gateEventService.CheckIn(context.Background(), models.GateEvent{LicensePlate: models.TestCar.LicensePlate, RecordedBy: models.TestSecurity.ID})
+	require.NoError(suite.T(), err)
+
+	require.Equal(suite.T(), permit.ID, gateEvent.PermitID)
+	require.Equal(suite.T(), models.TestResident.ID, gateEvent.ResidentID)
+	require.Nil(suite.T(), gateEvent.CheckOut)
+
+	onProperty, err := suite.gateEventService.GetAll(context.Background(), models.ActiveStatus, 0, 0, "", "")
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), 1, *onProperty.Metadata.TotalAmount)
+}
+
+func (suite *gateEventTestSuite) TestCheckIn_Twice_Negative() {
+	_, err := suite.permitService.Create(context.Background(), activeFor24Hrs(models.Permit{ResidentID: models.TestResident.ID, CarID: models.TestCar.ID, ExceptionReason: "gate test"}, -1))
+	require.NoError(suite.T(), err, "error creating permit before test")
+
+	_, err = suite.gateEventService.CheckIn(context.Background(), models.GateEvent{LicensePlate: models.TestCar.LicensePlate, RecordedBy: models.TestSecurity.ID})
+	require.NoError(suite.T(), err)
+
+	_, err = suite.gateEventService.CheckIn(context.Background(), models.GateEvent{LicensePlate: models.TestCar.LicensePlate, RecordedBy: models.TestSecurity.ID})
+	require.ErrorIs(suite.T(), err, errs.GateEventAlreadyOnProperty)
+}
+
+func (suite *gateEventTestSuite) TestCheckIn_NoActivePass_Negative() {
+	_, err := suite.gateEventService.CheckIn(context.Background(), models.GateEvent{LicensePlate: "nopass", RecordedBy: models.TestSecurity.ID})
+	require.ErrorIs(suite.T(), err, errs.GateEventPassNotActive)
+}
+
+func (suite *gateEventTestSuite) TestCheckOut_Visitor_Positive() {
+	visitor, err := suite.visitorService.Create(context.Background(), models.Visitor{
+		ResidentID:   models.TestResident.ID,
+		FirstName:    "first",
+		LastName:     "last",
+		Relationship: "fam/fri",
+		AccessStart:  time.Now().Add(-time.Hour).Truncate(time.Second),
+		AccessEnd:    time.Now().Add(time.Hour).Truncate(time.Second),
+	})
+	require.NoError(suite.T(), err, "error creating visitor before test")
+
+	_, err = suite.gateEventService.CheckIn(context.Background(), models.GateEvent{VisitorID: visitor.ID, RecordedBy: models.TestSecurity.ID})
+	require.NoError(suite.T(), err)
+
+	gateEvent, err := suite.gateEventService.CheckOut(context.Background(), models.GateEvent{VisitorID: visitor.ID})
+	require.NoError(suite.T(), err)
+	require.NotNil(suite.T(), gateEvent.CheckOut)
+
+	_, err = suite.gateEventService.CheckOut(context.Background(), models.GateEvent{VisitorID: visitor.ID})
+	require.ErrorIs(suite.T(), err, errs.GateEventNotOnProperty)
+}
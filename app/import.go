@@ -0,0 +1,326 @@
+package app
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/dannyvelas/parkspot-backend/errs"
+	"github.com/dannyvelas/parkspot-backend/i18n"
+	"github.com/dannyvelas/parkspot-backend/models"
+	"github.com/dannyvelas/parkspot-backend/models/validator"
+	"github.com/dannyvelas/parkspot-backend/storage"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	// importResidentColumns must be in the header of every import
+	importResidentColumns = []string{"id", "firstName", "lastName", "phone", "email", "password"}
+	// importOptionalColumns can be left out of the header. a row without a license plate has no car
+	importOptionalColumns = []string{"unlimDays", "preferredLang", "licensePlate", "color", "make", "model"}
+)
+
+type ImportService struct {
+	importRepo   storage.ImportRepo
+	residentRepo storage.ResidentRepo
+	carRepo      storage.CarRepo
+}
+
+func NewImportService(importRepo storage.ImportRepo, residentRepo storage.ResidentRepo, carRepo storage.CarRepo) ImportService {
+	return ImportService{
+		importRepo:   importRepo,
+		residentRepo: residentRepo,
+		carRepo:      carRepo,
+	}
+}
+
+// ImportReport is how many residents and cars an import created, or would have created if it had no errors
+// or was a dry run
+type ImportReport struct {
+	DryRun    bool
+	Residents int
+	Cars      int
+	RowErrs   []ImportRowErr
+}
+
+// ImportRowErr is a problem with one row of an import. Row is the line of the CSV where the row starts
+type ImportRowErr struct {
+	Row int
+	Err *errs.APIErr
+}
+
+// Import creates the residents of a CSV, and their cars. every row is a resident, with an optional car.
+// rows that repeat the id of a resident add another car to that resident, and can leave the other columns of
+// the resident empty. nothing is created when any row has errors, or when dryRun is true
+func (s ImportService) Import(ctx context.Context, file io.Reader, dryRun bool) (ImportReport, error) {
+	residents, cars, rowErrs, err := s.readImport(ctx, file)
+	if err != nil {
+		return ImportReport{}, err
+	}
+
+	report := ImportReport{DryRun: dryRun, Residents: len(residents), Cars: len(cars), RowErrs: rowErrs}
+	if dryRun || len(rowErrs) != 0 {
+		return report, nil
+	}
+
+	for i := range residents {
+		hashBytes, err := bcrypt.GenerateFromPassword([]byte(residents[i].Password), bcrypt.DefaultCost)
+		if err != nil {
+			return ImportReport{}, fmt.Errorf("import_service.Import: error generating hash: %v", err)
+		}
+		residents[i].Password = string(hashBytes)
+	}
+
+	if err := s.importRepo.Import(ctx, residents, cars); err != nil {
+		return ImportReport{}, fmt.Errorf("import_service.Import: error importing: %v", err)
+	}
+
+	return report, nil
+}
+
+// readImport reads the residents and cars of file, and checks every row
+func (s ImportService) readImport(ctx context.Context, file io.Reader) ([]models.Resident, []models.Car, []ImportRowErr, error) {
+	reader := csv.NewReader(file)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil, nil, errs.ImportEmpty
+	} else if err != nil {
+		return nil, nil, nil, importReadErr(err)
+	}
+	columns, err := importColumns(header)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	var residents []models.Resident
+	var cars []models.Car
+	var rowErrs []ImportRowErr
+	// residents are kept by id even when their row has errors, so that the rows that repeat them are only
+	// checked for their cars
+	residentsByID := map[string]models.Resident{}
+	emails := map[string]bool{}
+	licensePlates := map[string]bool{}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, nil, nil, importReadErr(err)
+		}
+		row, _ := reader.FieldPos(0)
+		get := func(column string) string {
+			i, ok := columns[strings.ToLower(column)]
+			if !ok {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+
+		resident, fieldErrs := importResident(get)
+		first, repeated := residentsByID[resident.ID]
+		if repeated {
+			fieldErrs = append(fieldErrs, importConflicts(first, resident)...)
+		} else {
+			residentsByID[resident.ID] = resident
+			if err := validator.CreateResident.Run(resident); err != nil {
+				fieldErrs = append(fieldErrs, err.Fields...)
+			}
+		}
+
+		car, hasCar := importCar(resident.ID, get)
+		if hasCar {
+			if err := validator.CreateCar.Run(car); err != nil {
+				// the id of the resident was already checked with the resident
+				fieldErrs = append(fieldErrs, slices.DeleteFunc(err.Fields, func(fieldErr errs.FieldError) bool {
+					return fieldErr.Field == "residentID"
+				})...)
+			}
+		}
+
+		if len(fieldErrs) != 0 {
+			rowErrs = append(rowErrs, ImportRowErr{Row: row, Err: errs.InvalidFields(fieldErrs...)})
+			continue
+		}
+
+		var existsErrs []*errs.APIErr
+		if !repeated {
+			existsErrs, err = s.residentExists(ctx, resident, emails)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+		}
+		if hasCar {
+			carExistsErr, err := s.carExists(ctx, car, licensePlates)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			if carExistsErr != nil {
+				existsErrs = append(existsErrs, carExistsErr)
+			}
+		}
+
+		if len(existsErrs) != 0 {
+			for _, existsErr := range existsErrs {
+				rowErrs = append(rowErrs, ImportRowErr{Row: row, Err: existsErr})
+			}
+			continue
+		}
+
+		if !repeated {
+			residents = append(residents, resident)
+		}
+		if hasCar {
+			cars = append(cars, car)
+		}
+	}
+
+	if len(residents) == 0 && len(rowErrs) == 0 {
+		return nil, nil, nil, errs.ImportEmpty
+	}
+
+	return residents, cars, rowErrs, nil
+}
+
+// importColumns maps the columns of header to their index, ignoring case
+func importColumns(header []string) (map[string]int, error) {
+	known := map[string]bool{}
+	for _, column := range append(slices.Clone(importResidentColumns), importOptionalColumns...) {
+		known[strings.ToLower(column)] = true
+	}
+
+	columns := map[string]int{}
+	var fieldErrs []errs.FieldError
+	for i, column := range header {
+		column = strings.TrimSpace(column)
+		if !known[strings.ToLower(column)] {
+			fieldErrs = append(fieldErrs, errs.NewFieldError(column, "forbidden", "column "+column+" is not one of the columns of an import"))
+			continue
+		}
+		columns[strings.ToLower(column)] = i
+	}
+	for _, column := range importResidentColumns {
+		if _, ok := columns[strings.ToLower(column)]; !ok {
+			fieldErrs = append(fieldErrs, errs.NewFieldError(column, "required", "column "+column+" is required"))
+		}
+	}
+
+	if len(fieldErrs) != 0 {
+		return nil, errs.InvalidFields(fieldErrs...)
+	}
+	return columns, nil
+}
+
+func importReadErr(err error) error {
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return errs.NewImportMalformed(parseErr.Line)
+	}
+	return fmt.Errorf("import_service.readImport: error reading CSV: %w", err)
+}
+
+func importResident(get func(column string) string) (models.Resident, []errs.FieldError) {
+	var fieldErrs []errs.FieldError
+	var unlimDays *bool
+	if rawUnlimDays := get("unlimDays"); rawUnlimDays != "" {
+		parsed, err := strconv.ParseBool(rawUnlimDays)
+		if err != nil {
+			fieldErrs = append(fieldErrs, errs.NewFieldError("unlimDays", "format", "unlimDays must be true or false"))
+		}
+		unlimDays = &parsed
+	}
+
+	resident := models.Resident{
+		ID:            get("id"),
+		FirstName:     get("firstName"),
+		LastName:      get("lastName"),
+		Phone:         get("phone"),
+		Email:         get("email"),
+		Password:      get("password"),
+		UnlimDays:     unlimDays,
+		PreferredLang: i18n.Lang(get("preferredLang")),
+	}
+	return resident, fieldErrs
+}
+
+// importCar is the car of a row, if the row has one
+func importCar(residentID string, get func(column string) string) (models.Car, bool) {
+	car := models.Car{
+		ResidentID:   residentID,
+		LicensePlate: get("licensePlate"),
+		Color:        get("color"),
+		Make:         get("make"),
+		Model:        get("model"),
+	}
+	hasCar := car.LicensePlate != "" || car.Color != "" || car.Make != "" || car.Model != ""
+	return car, hasCar
+}
+
+// importConflicts are the columns of a row that repeats a resident which are not empty and differ from the
+// first row of that resident
+func importConflicts(first, repeated models.Resident) []errs.FieldError {
+	var fieldErrs []errs.FieldError
+	for _, column := range []struct{ name, first, repeated string }{
+		{"firstName", first.FirstName, repeated.FirstName},
+		{"lastName", first.LastName, repeated.LastName},
+		{"phone", first.Phone, repeated.Phone},
+		{"email", first.Email, repeated.Email},
+		{"password", first.Password, repeated.Password},
+		{"preferredLang", string(first.PreferredLang), string(repeated.PreferredLang)},
+	} {
+		if column.repeated != "" && column.repeated != column.first {
+			fieldErrs = append(fieldErrs, errs.NewFieldError(column.name, "conflict",
+				column.name+" does not match the first row of resident "+repeated.ID))
+		}
+	}
+	if repeated.UnlimDays != nil && (first.UnlimDays == nil || *first.UnlimDays != *repeated.UnlimDays) {
+		fieldErrs = append(fieldErrs, errs.NewFieldError("unlimDays", "conflict",
+			"unlimDays does not match the first row of resident "+repeated.ID))
+	}
+	return fieldErrs
+}
+
+// residentExists checks whether the id or the email of resident are taken, by a resident that already exists or
+// by an earlier row. emails are the emails of earlier rows
+func (s ImportService) residentExists(ctx context.Context, resident models.Resident, emails map[string]bool) ([]*errs.APIErr, error) {
+	var existsErrs []*errs.APIErr
+	if residents, err := s.residentRepo.SelectWhere(ctx, models.Resident{ID: resident.ID}); err != nil {
+		return nil, fmt.Errorf("import_service.residentExists: error getting resident by id: %v", err)
+	} else if len(residents) != 0 {
+		existsErrs = append(existsErrs, errs.NewAlreadyExists("a resident with ID: "+resident.ID))
+	}
+
+	email := strings.ToLower(resident.Email)
+	if residents, err := s.residentRepo.SelectWhere(ctx, models.Resident{Email: resident.Email}); err != nil {
+		return nil, fmt.Errorf("import_service.residentExists: error getting resident by email: %v", err)
+	} else if len(residents) != 0 || emails[email] {
+		existsErrs = append(existsErrs, errs.NewAlreadyExists("a resident with this email: "+resident.Email))
+	}
+	emails[email] = true
+
+	return existsErrs, nil
+}
+
+// carExists checks whether the license plate of car is taken, by a car that already exists or by an earlier row.
+// licensePlates are the license plates of earlier rows
+func (s ImportService) carExists(ctx context.Context, car models.Car, licensePlates map[string]bool) (*errs.APIErr, error) {
+	licensePlate := strings.ToUpper(car.LicensePlate)
+	defer func() { licensePlates[licensePlate] = true }()
+
+	if licensePlates[licensePlate] {
+		return errs.NewErrCarWithLPAlreadyExists(car.LicensePlate), nil
+	}
+	if cars, err := s.carRepo.SelectWhere(ctx, models.Car{LicensePlate: car.LicensePlate}); err != nil {
+		return nil, fmt.Errorf("import_service.carExists: error getting car by licensePlate: %v", err)
+	} else if len(cars) != 0 {
+		return errs.NewErrCarWithLPAlreadyExists(car.LicensePlate), nil
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,137 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dannyvelas/parkspot-backend/errs"
+	"github.com/dannyvelas/parkspot-backend/models"
+	"github.com/dannyvelas/parkspot-backend/storage"
+	"github.com/dannyvelas/parkspot-backend/storage/memory"
+)
+
+type importRepoSpy struct {
+	residents []models.Resident
+	cars      []models.Car
+}
+
+func (r *importRepoSpy) Import(ctx context.Context, residents []models.Resident, cars []models.Car) error {
+	r.residents, r.cars = residents, cars
+	return nil
+}
+
+const importHeader = "id,firstName,lastName,phone,email,password,licensePlate,color,make,model\n"
+
+func newTestImportService(importRepo storage.ImportRepo) ImportService {
+	database := memory.NewDatabase(time.Local)
+	_ = database.ResidentRepo().Create(context.Background(), models.Resident{ID: "B9999999", Email: "taken@example.com"})
+	_, _ = database.CarRepo().Create(context.Background(), models.Car{ResidentID: "B9999999", LicensePlate: "TAKEN1"})
+	return NewImportService(importRepo, database.ResidentRepo(), database.CarRepo())
+}
+
+func TestImport_Valid(t *testing.T) {
+	importRepo := &importRepoSpy{}
+	csv := importHeader +
+		"B1234567,John,Smith,123,john@example.com,pass,ABC123,red,Toyota,Corolla\n" +
+		"B1234567,,,,,,XYZ789,blue,Honda,Civic\n" +
+		"T7654321,Ana,Lopez,456,ana@example.com,pass,,,,\n"
+
+	report, err := newTestImportService(importRepo).Import(context.Background(), strings.NewReader(csv), false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(report.RowErrs) != 0 {
+		t.Fatalf("unexpected row errors: %v", report.RowErrs)
+	}
+	if report.Residents != 2 || report.Cars != 2 {
+		t.Errorf("expected 2 residents and 2 cars, got %d residents and %d cars", report.Residents, report.Cars)
+	}
+	if len(importRepo.residents) != 2 || len(importRepo.cars) != 2 {
+		t.Fatalf("expected 2 residents and 2 cars to be imported, got %d residents and %d cars", len(importRepo.residents), len(importRepo.cars))
+	}
+	if importRepo.residents[0].Password == "pass" {
+		t.Errorf("expected password to be hashed before importing")
+	}
+	if importRepo.cars[1].ResidentID != "B1234567" {
+		t.Errorf("expected %q, got %q", "B1234567", importRepo.cars[1].ResidentID)
+	}
+}
+
+func TestImport_RowErrors(t *testing.T) {
+	tests := map[string]struct {
+		rows         string
+		expectedRow  int
+		expectedCode string
+	}{
+		"invalid resident":     {rows: "B123,John,Smith,123,john@example.com,pass,,,,\n", expectedRow: 2, expectedCode: "request.invalid_fields"},
+		"invalid car":          {rows: "B1234567,John,Smith,123,john@example.com,pass,ABC-123,red,Toyota,Corolla\n", expectedRow: 2, expectedCode: "request.invalid_fields"},
+		"existing resident":    {rows: "B9999999,John,Smith,123,john@example.com,pass,,,,\n", expectedRow: 2, expectedCode: "already_exists"},
+		"existing email":       {rows: "B1234567,John,Smith,123,taken@example.com,pass,,,,\n", expectedRow: 2, expectedCode: "already_exists"},
+		"existing plate":       {rows: "B1234567,John,Smith,123,john@example.com,pass,TAKEN1,red,Toyota,Corolla\n", expectedRow: 2, expectedCode: "car.license_plate_exists"},
+		"repeated email":       {rows: "B1234567,John,Smith,123,john@example.com,pass,,,,\nT7654321,Ana,Lopez,456,john@example.com,pass,,,,\n", expectedRow: 3, expectedCode: "already_exists"},
+		"repeated plate":       {rows: "B1234567,John,Smith,123,john@example.com,pass,ABC123,red,Toyota,Corolla\nT7654321,Ana,Lopez,456,ana@example.com,pass,ABC123,red,Toyota,Corolla\n", expectedRow: 3, expectedCode: "car.license_plate_exists"},
+		"conflicting resident": {rows: "B1234567,John,Smith,123,john@example.com,pass,,,,\nB1234567,Johnny,,,,,XYZ789,blue,Honda,Civic\n", expectedRow: 3, expectedCode: "request.invalid_fields"},
+	}
+
+	for name, test := range tests {
+		importRepo := &importRepoSpy{}
+		report, err := newTestImportService(importRepo).Import(context.Background(), strings.NewReader(importHeader+test.rows), false)
+		if err != nil {
+			t.Errorf("%s failed: unexpected error: %v", name, err)
+			continue
+		}
+		if len(report.RowErrs) != 1 {
+			t.Errorf("%s failed: expected 1 row error, got %v", name, report.RowErrs)
+			continue
+		}
+		if rowErr := report.RowErrs[0]; rowErr.Row != test.expectedRow || rowErr.Err.Code != test.expectedCode {
+			t.Errorf("%s failed: expected row %d with %q, got row %d with %q", name, test.expectedRow, test.expectedCode, rowErr.Row, rowErr.Err.Code)
+		}
+		if importRepo.residents != nil {
+			t.Errorf("%s failed: expected nothing to be imported", name)
+		}
+	}
+}
+
+func TestImport_DryRun(t *testing.T) {
+	importRepo := &importRepoSpy{}
+	csv := importHeader + "B1234567,John,Smith,123,john@example.com,pass,ABC123,red,Toyota,Corolla\n"
+
+	report, err := newTestImportService(importRepo).Import(context.Background(), strings.NewReader(csv), true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !report.DryRun || report.Residents != 1 || report.Cars != 1 {
+		t.Errorf("expected a dry run of 1 resident and 1 car, got %+v", report)
+	}
+	if importRepo.residents != nil {
+		t.Errorf("expected nothing to be imported")
+	}
+}
+
+func TestImport_Invalid(t *testing.T) {
+	tests := map[string]struct {
+		csv      string
+		expected *errs.APIErr
+	}{
+		"empty":          {csv: "", expected: errs.ImportEmpty},
+		"only header":    {csv: importHeader, expected: errs.ImportEmpty},
+		"missing column": {csv: "id,firstName\nB1234567,John\n", expected: errs.InvalidFields()},
+		"unknown column": {csv: strings.TrimSuffix(importHeader, "\n") + ",age\n", expected: errs.InvalidFields()},
+		"malformed":      {csv: importHeader + "B1234567,John\n", expected: errs.NewImportMalformed(2)},
+	}
+
+	for name, test := range tests {
+		_, err := newTestImportService(&importRepoSpy{}).Import(context.Background(), strings.NewReader(test.csv), false)
+
+		var apiErr *errs.APIErr
+		if !errors.As(err, &apiErr) {
+			t.Errorf("%s failed: expected an APIErr, got %v", name, err)
+		} else if apiErr.Code != test.expected.Code {
+			t.Errorf("%s failed: expected %q, got %q", name, test.expected.Code, apiErr.Code)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package app
 import (
 	"errors"
 	"github.com/dannyvelas/parkspot-backend/config"
+	"github.com/dannyvelas/parkspot-backend/i18n"
 	"github.com/dannyvelas/parkspot-backend/models"
 	"github.com/golang-jwt/jwt/v4"
 	"time"
@@ -34,6 +35,8 @@ type accessClaims struct {
 type AccessPayload struct {
 	ID   string      `json:"id"`
 	Role models.Role `json:"role"`
+	// Lang is the language that this user chose for their account, if any
+	Lang i18n.Lang `json:"lang,omitempty"`
 }
 
 type refreshClaims struct {
@@ -41,9 +44,9 @@ type refreshClaims struct {
 	jwt.StandardClaims
 }
 
-func (jwtService JWTService) NewAccess(id string, role models.Role) (string, error) {
+func (jwtService JWTService) NewAccess(id string, role models.Role, lang i18n.Lang) (string, error) {
 	claims := accessClaims{
-		AccessPayload{id, role},
+		AccessPayload{id, role, lang},
 		jwt.StandardClaims{ExpiresAt: time.Now().Add(time.Minute * 15).Unix()},
 	}
 
@@ -0,0 +1,83 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/dannyvelas/parkspot-backend/config"
+	"github.com/dannyvelas/parkspot-backend/models"
+	"golang.org/x/oauth2"
+	"google.golang.org/api/gmail/v1"
+	"google.golang.org/api/option"
+)
+
+// mailSender sends emails like MailService does, so that tests can send them somewhere else
+type mailSender interface {
+	Send(ctx context.Context, toUser models.User, subject, htmlBody string) error
+}
+
+type MailService struct {
+	oauthConfig config.OAuthConfig
+}
+
+func NewMailService(oauthConfig config.OAuthConfig) MailService {
+	return MailService{
+		oauthConfig: oauthConfig,
+	}
+}
+
+// Send sends an html email to toUser from the ParkSpot gmail account
+func (s MailService) Send(ctx context.Context, toUser models.User, subject, htmlBody string) error {
+	service, err := s.getGmailService(ctx)
+	if err != nil {
+		return fmt.Errorf("mail_service.Send: %v", err)
+	}
+
+	body := &bytes.Buffer{}
+	fmt.Fprintf(body, "From: Park Spot <parkspotapplication@gmail.com>\r\n")
+	fmt.Fprintf(body, "To: %s %s <%s>\r\n", toUser.FirstName, toUser.LastName, toUser.Email)
+	fmt.Fprintf(body, "Subject: %s\r\n", subject)
+	fmt.Fprintf(body, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(body, "Content-Type: text/html\r\n")
+	fmt.Fprint(body, htmlBody)
+
+	gmailMessage := &gmail.Message{Raw: base64.URLEncoding.EncodeToString(body.Bytes())}
+
+	_, err = service.Users.Messages.Send("me", gmailMessage).Do()
+	if err != nil {
+		return fmt.Errorf("mail_service.Send: error sending mail: %v", err)
+	}
+
+	return nil
+}
+
+func (s MailService) getGmailService(ctx context.Context) (*gmail.Service, error) {
+	config := &oauth2.Config{
+		ClientID:     s.oauthConfig.ClientID,
+		ClientSecret: s.oauthConfig.ClientSecret,
+		RedirectURL:  s.oauthConfig.RedirectURL,
+		Scopes:       []string{s.oauthConfig.Scope},
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  s.oauthConfig.AuthURL,
+			TokenURL: s.oauthConfig.TokenURL,
+		},
+	}
+
+	token := &oauth2.Token{
+		AccessToken:  s.oauthConfig.AccessToken,
+		RefreshToken: s.oauthConfig.RefreshToken,
+		TokenType:    s.oauthConfig.TokenType,
+		Expiry:       s.oauthConfig.Expiry,
+	}
+
+	client := config.Client(ctx, token)
+
+	service, err := gmail.NewService(ctx, option.WithHTTPClient(client))
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve Gmail client: %v", err)
+	}
+
+	return service, nil
+}
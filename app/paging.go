@@ -0,0 +1,188 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/dannyvelas/parkspot-backend/config"
+	"github.com/dannyvelas/parkspot-backend/errs"
+	"github.com/dannyvelas/parkspot-backend/models"
+	"github.com/dannyvelas/parkspot-backend/storage/selectopts"
+)
+
+// ListParams say which rows of a list to get, in which order, and which page of them
+type ListParams struct {
+	Limit, Page int
+	// Reversed sorts the list by id, in reverse, when Sort is empty
+	Reversed bool
+	// Sort is like startDate:desc
+	Sort string
+	// Cursor is used instead of Page when it is not empty. the list keeps the sort of the cursor
+	Cursor  string
+	Search  string
+	Filters []selectopts.Filter
+}
+
+// paging is which page of a sorted list to get. a page starts at an offset, or right after or before the
+// row of a cursor
+type paging struct {
+	limit, offset int
+	cursor        selectopts.Cursor
+	search        string
+	filters       []selectopts.Filter
+	// ranked lists are sorted by how relevant their rows are to search. they are only paged by offset,
+	// since relevance is not a column that a cursor can point into
+	ranked bool
+}
+
+// newPaging checks params against what repo can sort and filter by. lists that are searched without a sort
+// are ranked by relevance
+func newPaging(repo any, params ListParams) (paging, error) {
+	if err := checkFilters(repo, params.Filters); err != nil {
+		return paging{}, err
+	}
+
+	boundedLimit, offset := getBoundedLimitAndOffset(params.Limit, params.Page)
+	p := paging{limit: boundedLimit, search: params.Search, filters: params.Filters}
+
+	if params.Cursor != "" {
+		cursor, err := selectopts.DecodeCursor(params.Cursor)
+		if err != nil || !selectopts.IsSortable(repo, cursor.Sort) || !selectopts.IsValidID(repo, cursor.ID) {
+			return paging{}, errs.InvalidCursor
+		}
+		p.cursor = cursor
+		return p, nil
+	}
+
+	sort := selectopts.Sort{Field: "id", Desc: params.Reversed}
+	if params.Sort != "" {
+		var err error
+		sort, err = selectopts.ParseSort(params.Sort)
+		if err != nil || !selectopts.IsSortable(repo, sort) {
+			return paging{}, errs.InvalidSort(params.Sort, selectopts.SortableFields(repo))
+		}
+	}
+
+	p.offset = offset
+	p.cursor = selectopts.Cursor{Sort: sort}
+	p.ranked = strings.TrimSpace(params.Search) != "" && params.Sort == "" && !params.Reversed
+	return p, nil
+}
+
+// checkFilters returns the filters that repo can't filter its rows with as field errors
+func checkFilters(repo any, filters []selectopts.Filter) error {
+	var fieldErrs []errs.FieldError
+	for _, filter := range filters {
+		err := selectopts.CheckFilter(repo, filter)
+		if errors.Is(err, selectopts.ErrNotFilterable) {
+			fieldErrs = append(fieldErrs, errs.NewFieldError(filter.Field, "filterable",
+				fmt.Sprintf("cannot filter by %s. filterable fields: %s", filter.Field, strings.Join(selectopts.FilterableFields(repo), ", "))))
+		} else if errors.Is(err, selectopts.ErrFilterOp) {
+			fieldErrs = append(fieldErrs, errs.NewFieldError(filter.Field, "operator",
+				fmt.Sprintf("%s cannot be compared with %s", filter.Field, filter.Op)))
+		} else if errors.Is(err, selectopts.ErrFilterValueFormat) {
+			fieldErrs = append(fieldErrs, errs.NewFieldError(filter.Field, "format",
+				fmt.Sprintf("%s cannot be compared with %q", filter.Field, filter.Value)))
+		}
+	}
+
+	if len(fieldErrs) != 0 {
+		return errs.InvalidFields(fieldErrs...)
+	}
+	return nil
+}
+
+func (p paging) byCursor() bool {
+	return p.cursor.ID != ""
+}
+
+func (p paging) selectOpts() []selectopts.SelectOpt {
+	var opts []selectopts.SelectOpt
+	if p.ranked {
+		opts = append(opts, selectopts.WithSearchRank(p.search))
+	}
+	return append(opts,
+		selectopts.WithSearch(p.search),
+		selectopts.WithFilters(p.filters...),
+		selectopts.WithCursor(p.cursor),
+		selectopts.WithPage(p.limit, p.offset),
+	)
+}
+
+// countOpts select every row of the list, to count them
+func (p paging) countOpts() []selectopts.SelectOpt {
+	return []selectopts.SelectOpt{
+		selectopts.WithSearch(p.search),
+		selectopts.WithFilters(p.filters...),
+	}
+}
+
+// newPagedList turns the rows that were selected with p.selectOpts into a page with cursors to the pages
+// around it. idOf returns the id of a row. totalAmount is nil when the rows were not counted
+func newPagedList[T any](p paging, rows []T, idOf func(T) string, totalAmount *int) models.ListWithMetadata[T] {
+	// one row past the page is selected, to know whether the list goes on in the direction of the page
+	goesOn := len(rows) > p.limit
+	if goesOn {
+		rows = rows[:p.limit]
+	}
+	if p.cursor.Before {
+		slices.Reverse(rows)
+	}
+
+	metadata := models.Metadata{TotalAmount: totalAmount}
+	if len(rows) == 0 || p.ranked {
+		return models.ListWithMetadata[T]{Records: rows, Metadata: metadata}
+	}
+
+	hasNext := goesOn
+	hasPrev := p.offset > 0 || p.byCursor()
+	if p.cursor.Before {
+		// the row of the cursor is after the page
+		hasNext, hasPrev = true, goesOn
+	}
+
+	if hasNext {
+		metadata.Next = selectopts.Cursor{Sort: p.cursor.Sort, ID: idOf(rows[len(rows)-1])}.Encode()
+	}
+	if hasPrev {
+		metadata.Prev = selectopts.Cursor{Sort: p.cursor.Sort, ID: idOf(rows[0]), Before: true}.Encode()
+	}
+
+	return models.ListWithMetadata[T]{Records: rows, Metadata: metadata}
+}
+
+// forEachRow calls each with every row of a list, selecting config.MaxLimit rows at a time, so that the rows of
+// a list are never all in memory. selectPage selects the rows of a page with opts. rows are selected by cursor,
+// so they are sorted by id when params has no sort
+func forEachRow[T any](repo any, params ListParams, selectPage func(opts []selectopts.SelectOpt) ([]T, error), idOf func(T) string, each func(T) error) error {
+	params.Limit, params.Page, params.Cursor = config.MaxLimit, 0, ""
+	if params.Sort == "" {
+		params.Sort = selectopts.Sort{Field: "id", Desc: params.Reversed}.String()
+	}
+
+	for {
+		paging, err := newPaging(repo, params)
+		if err != nil {
+			return err
+		}
+
+		rows, err := selectPage(paging.selectOpts())
+		if err != nil {
+			return err
+		}
+
+		page := newPagedList(paging, rows, idOf, nil)
+		for _, row := range page.Records {
+			if err := each(row); err != nil {
+				return err
+			}
+		}
+
+		if page.Metadata.Next == "" {
+			return nil
+		}
+		params.Cursor = page.Metadata.Next
+	}
+}
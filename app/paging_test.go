@@ -0,0 +1,99 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/dannyvelas/parkspot-backend/errs"
+	"github.com/dannyvelas/parkspot-backend/storage/selectopts"
+)
+
+func TestNewPagedList(t *testing.T) {
+	sort := selectopts.Sort{Field: "startDate"}
+	after := func(id string) string { return selectopts.Cursor{Sort: sort, ID: id}.Encode() }
+	before := func(id string) string { return selectopts.Cursor{Sort: sort, ID: id, Before: true}.Encode() }
+
+	tests := map[string]struct {
+		paging       paging
+		rows         []string
+		expectedRows []string
+		expectedNext string
+		expectedPrev string
+	}{
+		"first page with more rows": {
+			paging:       paging{limit: 2, cursor: selectopts.Cursor{Sort: sort}},
+			rows:         []string{"a", "b", "c"},
+			expectedRows: []string{"a", "b"},
+			expectedNext: after("b"),
+		},
+		"only page": {
+			paging:       paging{limit: 2, cursor: selectopts.Cursor{Sort: sort}},
+			rows:         []string{"a"},
+			expectedRows: []string{"a"},
+		},
+		"second page by offset": {
+			paging:       paging{limit: 2, offset: 2, cursor: selectopts.Cursor{Sort: sort}},
+			rows:         []string{"c"},
+			expectedRows: []string{"c"},
+			expectedPrev: before("c"),
+		},
+		"page after cursor": {
+			paging:       paging{limit: 2, cursor: selectopts.Cursor{Sort: sort, ID: "b"}},
+			rows:         []string{"c", "d", "e"},
+			expectedRows: []string{"c", "d"},
+			expectedNext: after("d"),
+			expectedPrev: before("c"),
+		},
+		"page before cursor, which is selected nearest first": {
+			paging:       paging{limit: 2, cursor: selectopts.Cursor{Sort: sort, ID: "d", Before: true}},
+			rows:         []string{"c", "b", "a"},
+			expectedRows: []string{"b", "c"},
+			expectedNext: after("c"),
+			expectedPrev: before("b"),
+		},
+		"first page before cursor": {
+			paging:       paging{limit: 2, cursor: selectopts.Cursor{Sort: sort, ID: "c", Before: true}},
+			rows:         []string{"b", "a"},
+			expectedRows: []string{"a", "b"},
+			expectedNext: after("b"),
+		},
+	}
+
+	for name, test := range tests {
+		list := newPagedList(test.paging, test.rows, func(row string) string { return row }, nil)
+		if got, expected := fmt.Sprint(list.Records), fmt.Sprint(test.expectedRows); got != expected {
+			t.Errorf("%s failed: expected %q, got %q", name, expected, got)
+		}
+		if list.Metadata.Next != test.expectedNext {
+			t.Errorf("%s failed: expected next %q, got %q", name, test.expectedNext, list.Metadata.Next)
+		}
+		if list.Metadata.Prev != test.expectedPrev {
+			t.Errorf("%s failed: expected prev %q, got %q", name, test.expectedPrev, list.Metadata.Prev)
+		}
+	}
+}
+
+type intIDRepo struct{}
+
+func (intIDRepo) IDType() selectopts.FieldType { return selectopts.IntField }
+
+func TestNewPaging_CursorID(t *testing.T) {
+	tests := map[string]struct {
+		repo        any
+		id          string
+		expectedErr error
+	}{
+		"integer id":               {repo: intIDRepo{}, id: "4"},
+		"non-integer id":           {repo: intIDRepo{}, id: "not a number", expectedErr: errs.InvalidCursor},
+		"string id of string repo": {repo: struct{}{}, id: "not a number"},
+	}
+
+	for name, test := range tests {
+		cursor := selectopts.Cursor{Sort: selectopts.Sort{Field: "id"}, ID: test.id}.Encode()
+		_, err := newPaging(test.repo, ListParams{Cursor: cursor})
+		if !errors.Is(err, test.expectedErr) {
+			t.Errorf("%s failed: expected error %v, got %v", name, test.expectedErr, err)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/dannyvelas/parkspot-backend/models"
+	"github.com/dannyvelas/parkspot-backend/storage"
+	"github.com/dannyvelas/parkspot-backend/storage/selectopts"
+)
+
+// ParkingDaysService keeps the counters of the guest parking days that residents and their cars used this year
+type ParkingDaysService struct {
+	residentRepo      storage.ResidentRepo
+	carRepo           storage.CarRepo
+	permitRepo        storage.PermitRepo
+	permitRuleService PermitRuleService
+}
+
+func NewParkingDaysService(residentRepo storage.ResidentRepo, carRepo storage.CarRepo, permitRepo storage.PermitRepo, permitRuleService PermitRuleService) ParkingDaysService {
+	return ParkingDaysService{
+		residentRepo:      residentRepo,
+		carRepo:           carRepo,
+		permitRepo:        permitRepo,
+		permitRuleService: permitRuleService,
+	}
+}
+
+// ParkingDaysReport is how many residents and cars had their parking days used changed
+type ParkingDaysReport struct {
+	Residents int
+	Cars      int
+}
+
+// Reset sets the parking days used by every resident and car back to 0, for the start of a new year
+func (s ParkingDaysService) Reset(ctx context.Context) (ParkingDaysReport, error) {
+	return s.setDaysUsed(ctx, map[string]int{}, map[string]int{})
+}
+
+// Recompute sets the parking days used by every resident and car to the days that their permits that affect
+// days and start in year were charged. it fixes counters that drifted from the permits, like after permits were
+// edited or deleted by hand
+func (s ParkingDaysService) Recompute(ctx context.Context, year int) (ParkingDaysReport, error) {
+	params := ListParams{Filters: []selectopts.Filter{
+		{Field: "startDate", Op: selectopts.Gte, Value: fmt.Sprintf("%04d-01-01", year)},
+		{Field: "startDate", Op: selectopts.Lt, Value: fmt.Sprintf("%04d-01-01", year+1)},
+		{Field: "affectsDays", Op: selectopts.Eq, Value: "true"},
+	}}
+	selectPage := func(opts []selectopts.SelectOpt) ([]models.Permit, error) {
+		permits, err := s.permitRepo.SelectWhere(ctx, models.Permit{}, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("error getting permits from permit repo: %v", err)
+		}
+		return permits, nil
+	}
+
+	weekendWeight, err := s.permitRuleService.WeekendWeight(ctx)
+	if err != nil {
+		return ParkingDaysReport{}, fmt.Errorf("parking_days_service.Recompute: %v", err)
+	}
+
+	residentDays, carDays := map[string]int{}, map[string]int{}
+	err = forEachRow(s.permitRepo, params, selectPage, func(permit models.Permit) string { return strconv.Itoa(permit.ID) },
+		func(permit models.Permit) error {
+			daysCharged := s.permitRuleService.daysChargedWith(permit, weekendWeight)
+			residentDays[permit.ResidentID] += daysCharged
+			carDays[permit.CarID] += daysCharged
+			return nil
+		})
+	if err != nil {
+		return ParkingDaysReport{}, fmt.Errorf("parking_days_service.Recompute: %v", err)
+	}
+
+	return s.setDaysUsed(ctx, residentDays, carDays)
+}
+
+// setDaysUsed sets the parking days used by every resident and car to their days in residentDays and carDays,
+// or to 0 when they have none
+func (s ParkingDaysService) setDaysUsed(ctx context.Context, residentDays, carDays map[string]int) (ParkingDaysReport, error) {
+	var report ParkingDaysReport
+
+	selectResidents := func(opts []selectopts.SelectOpt) ([]models.Resident, error) {
+		residents, err := s.residentRepo.SelectWhere(ctx, models.Resident{}, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("error getting residents from resident repo: %v", err)
+		}
+		return residents, nil
+	}
+	err := forEachRow(s.residentRepo, ListParams{}, selectResidents, func(resident models.Resident) string { return resident.ID },
+		func(resident models.Resident) error {
+			daysUsed := residentDays[resident.ID]
+			if resident.AmtParkingDaysUsed != nil && *resident.AmtParkingDaysUsed == daysUsed {
+				return nil
+			}
+			if err := s.residentRepo.Update(ctx, models.Resident{ID: resident.ID, AmtParkingDaysUsed: &daysUsed}); err != nil {
+				return fmt.Errorf("error updating resident %s: %v", resident.ID, err)
+			}
+			report.Residents++
+			return nil
+		})
+	if err != nil {
+		return ParkingDaysReport{}, fmt.Errorf("parking_days_service.setDaysUsed: %v", err)
+	}
+
+	selectCars := func(opts []selectopts.SelectOpt) ([]models.Car, error) {
+		cars, err := s.carRepo.SelectWhere(ctx, models.Car{}, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("error getting cars from car repo: %v", err)
+		}
+		return cars, nil
+	}
+	err = forEachRow(s.carRepo, ListParams{}, selectCars, func(car models.Car) string { return car.ID },
+		func(car models.Car) error {
+			daysUsed := carDays[car.ID]
+			if car.AmtParkingDaysUsed != nil && *car.AmtParkingDaysUsed == daysUsed {
+				return nil
+			}
+			if err := s.carRepo.Update(ctx, models.Car{ID: car.ID, AmtParkingDaysUsed: &daysUsed}); err != nil {
+				return fmt.Errorf("error updating car %s: %v", car.ID, err)
+			}
+			report.Cars++
+			return nil
+		})
+	if err != nil {
+		return ParkingDaysReport{}, fmt.Errorf("parking_days_service.setDaysUsed: %v", err)
+	}
+
+	return report, nil
+}
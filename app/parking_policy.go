@@ -0,0 +1,73 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dannyvelas/parkspot-backend/errs"
+	"github.com/dannyvelas/parkspot-backend/models"
+	"github.com/dannyvelas/parkspot-backend/storage"
+)
+
+type ParkingPolicyService struct {
+	parkingPolicyRepo storage.ParkingPolicyRepo
+}
+
+func NewParkingPolicyService(parkingPolicyRepo storage.ParkingPolicyRepo) ParkingPolicyService {
+	return ParkingPolicyService{
+		parkingPolicyRepo: parkingPolicyRepo,
+	}
+}
+
+func (s ParkingPolicyService) Get(ctx context.Context) (models.ParkingPolicy, error) {
+	return s.parkingPolicyRepo.Get(ctx)
+}
+
+// Update changes the fields of the parking policy that are non-zero in updatedFields
+func (s ParkingPolicyService) Update(ctx context.Context, updatedFields models.ParkingPolicy) (models.ParkingPolicy, error) {
+	if updatedFields == (models.ParkingPolicy{}) {
+		return models.ParkingPolicy{}, errs.AllEditFieldsEmpty("maxParkingDays, maxPermitLength, maxActivePermits, latestEndDate")
+	}
+
+	var fieldErrs []errs.FieldError
+	if updatedFields.MaxParkingDays < 0 {
+		fieldErrs = append(fieldErrs, errs.NewFieldError("maxParkingDays", "min", "maxParkingDays cannot be negative"))
+	}
+	if updatedFields.MaxPermitLength < 0 {
+		fieldErrs = append(fieldErrs, errs.NewFieldError("maxPermitLength", "min", "maxPermitLength cannot be negative"))
+	}
+	if updatedFields.MaxActivePermits < 0 {
+		fieldErrs = append(fieldErrs, errs.NewFieldError("maxActivePermits", "min", "maxActivePermits cannot be negative"))
+	}
+	if !updatedFields.LatestEndDate.IsZero() && !updatedFields.LatestEndDate.After(time.Now()) {
+		fieldErrs = append(fieldErrs, errs.NewFieldError("latestEndDate", "future", "latestEndDate must be in the future"))
+	}
+	if len(fieldErrs) > 0 {
+		return models.ParkingPolicy{}, errs.InvalidFields(fieldErrs...)
+	}
+
+	policy, err := s.parkingPolicyRepo.Get(ctx)
+	if err != nil {
+		return models.ParkingPolicy{}, fmt.Errorf("error getting parking policy from parking policy repo: %v", err)
+	}
+
+	if updatedFields.MaxParkingDays != 0 {
+		policy.MaxParkingDays = updatedFields.MaxParkingDays
+	}
+	if updatedFields.MaxPermitLength != 0 {
+		policy.MaxPermitLength = updatedFields.MaxPermitLength
+	}
+	if updatedFields.MaxActivePermits != 0 {
+		policy.MaxActivePermits = updatedFields.MaxActivePermits
+	}
+	if !updatedFields.LatestEndDate.IsZero() {
+		policy.LatestEndDate = updatedFields.LatestEndDate
+	}
+
+	if err := s.parkingPolicyRepo.Set(ctx, policy); err != nil {
+		return models.ParkingPolicy{}, fmt.Errorf("error setting parking policy in parking policy repo: %v", err)
+	}
+
+	return s.parkingPolicyRepo.Get(ctx)
+}
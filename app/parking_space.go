@@ -0,0 +1,151 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dannyvelas/parkspot-backend/config"
+	"github.com/dannyvelas/parkspot-backend/errs"
+	"github.com/dannyvelas/parkspot-backend/models"
+	"github.com/dannyvelas/parkspot-backend/storage"
+	"github.com/dannyvelas/parkspot-backend/storage/selectopts"
+	"github.com/dannyvelas/parkspot-backend/util"
+)
+
+type ParkingSpaceService struct {
+	parkingSpaceRepo storage.ParkingSpaceRepo
+	permitRepo       storage.PermitRepo
+}
+
+func NewParkingSpaceService(parkingSpaceRepo storage.ParkingSpaceRepo, permitRepo storage.PermitRepo) ParkingSpaceService {
+	return ParkingSpaceService{
+		parkingSpaceRepo: parkingSpaceRepo,
+		permitRepo:       permitRepo,
+	}
+}
+
+func (s ParkingSpaceService) GetAll(ctx context.Context, zone string) ([]models.ParkingSpace, error) {
+	parkingSpaces, err := s.parkingSpaceRepo.SelectWhere(ctx, models.ParkingSpace{Zone: zone})
+	if err != nil {
+		return nil, fmt.Errorf("error getting parking spaces from parking space repo: %v", err)
+	}
+
+	return parkingSpaces, nil
+}
+
+func (s ParkingSpaceService) GetZones(ctx context.Context) ([]models.ZoneCapacity, error) {
+	parkingSpaces, err := s.GetAll(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	// parking spaces are ordered by zone, so spaces of the same zone are next to each other
+	zones := []models.ZoneCapacity{}
+	for _, parkingSpace := range parkingSpaces {
+		if len(zones) == 0 || zones[len(zones)-1].Zone != parkingSpace.Zone {
+			zones = append(zones, models.ZoneCapacity{Zone: parkingSpace.Zone})
+		}
+		zones[len(zones)-1].Capacity++
+	}
+
+	return zones, nil
+}
+
+func (s ParkingSpaceService) Create(ctx context.Context, desiredParkingSpace models.ParkingSpace) (models.ParkingSpace, error) {
+	desiredParkingSpace.Zone = strings.TrimSpace(desiredParkingSpace.Zone)
+	desiredParkingSpace.Label = strings.TrimSpace(desiredParkingSpace.Label)
+
+	emptyFields := []string{}
+	if desiredParkingSpace.Zone == "" {
+		emptyFields = append(emptyFields, "zone")
+	}
+	if desiredParkingSpace.Label == "" {
+		emptyFields = append(emptyFields, "label")
+	}
+	if len(emptyFields) > 0 {
+		return models.ParkingSpace{}, errs.EmptyFields(emptyFields...)
+	}
+
+	amtExisting, err := s.parkingSpaceRepo.SelectCountWhere(ctx, models.ParkingSpace{Zone: desiredParkingSpace.Zone, Label: desiredParkingSpace.Label})
+	if err != nil {
+		return models.ParkingSpace{}, fmt.Errorf("error getting parking spaces from parking space repo: %v", err)
+	} else if amtExisting != 0 {
+		return models.ParkingSpace{}, errs.NewAlreadyExists("a parking space with label " + desiredParkingSpace.Label + " in zone " + desiredParkingSpace.Zone)
+	}
+
+	parkingSpaceID, err := s.parkingSpaceRepo.Create(ctx, desiredParkingSpace)
+	if err != nil {
+		return models.ParkingSpace{}, fmt.Errorf("error creating parking space in parking space repo: %v", err)
+	}
+
+	parkingSpace, err := s.parkingSpaceRepo.GetOne(ctx, parkingSpaceID)
+	if err != nil {
+		return models.ParkingSpace{}, fmt.Errorf("error getting parking space after creating in parking space repo: %v", err)
+	}
+
+	return parkingSpace, nil
+}
+
+func (s ParkingSpaceService) Delete(ctx context.Context, id string) error {
+	if id == "" {
+		return errs.MissingIDField
+	}
+	if !util.IsUUIDV4(id) {
+		return errs.IDNotUUID
+	}
+	return s.parkingSpaceRepo.Delete(ctx, id)
+}
+
+// GetAvailability returns how many guest parking spaces are free on each day from startDate until endDate
+func (s ParkingSpaceService) GetAvailability(ctx context.Context, startDate, endDate time.Time) ([]models.DayAvailability, error) {
+	if !startDate.Before(endDate) {
+		return nil, errs.InvalidFields(errs.NewFieldError("startDate", "before", "startDate must be before endDate"))
+	}
+	if endDate.Sub(startDate) > config.MaxAvailabilityDays*24*time.Hour {
+		return nil, errs.AvailabilityRangeTooLong
+	}
+
+	capacity, err := s.parkingSpaceRepo.SelectCountWhere(ctx, models.ParkingSpace{})
+	if err != nil {
+		return nil, fmt.Errorf("error getting amount of parking spaces from parking space repo: %v", err)
+	}
+
+	permits, err := s.permitRepo.SelectWhere(ctx, models.Permit{}, selectopts.WithDateIntersect(startDate, endDate))
+	if err != nil {
+		return nil, fmt.Errorf("error getting permits during dates from permit repo: %v", err)
+	}
+
+	return dailyAvailability(capacity, permits, startDate, endDate), nil
+}
+
+// helpers
+
+// dailyAvailability splits the time from startDate until endDate into days, and counts
+// the permits that are active at any moment of each day
+func dailyAvailability(capacity int, permits []models.Permit, startDate, endDate time.Time) []models.DayAvailability {
+	availability := []models.DayAvailability{}
+
+	dayStart := util.StartOfDay(startDate)
+	for dayStart.Before(endDate) {
+		dayEnd := dayStart.AddDate(0, 0, 1)
+
+		occupied := 0
+		for _, permit := range permits {
+			if permit.StartDate.Before(dayEnd) && permit.EndDate.After(dayStart) {
+				occupied++
+			}
+		}
+
+		availability = append(availability, models.DayAvailability{
+			Date:     dayStart,
+			Capacity: capacity,
+			Occupied: occupied,
+			Free:     max(capacity-occupied, 0),
+		})
+		dayStart = dayEnd
+	}
+
+	return availability
+}
@@ -1,12 +1,15 @@
 package app
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"strings"
+	"strconv"
+	"time"
 
 	"github.com/dannyvelas/parkspot-backend/config"
 	"github.com/dannyvelas/parkspot-backend/errs"
+	"github.com/dannyvelas/parkspot-backend/i18n"
 	"github.com/dannyvelas/parkspot-backend/models"
 	"github.com/dannyvelas/parkspot-backend/models/validator"
 	"github.com/dannyvelas/parkspot-backend/storage"
@@ -15,73 +18,104 @@ import (
 )
 
 type PermitService struct {
-	permitRepo   storage.PermitRepo
-	residentRepo storage.ResidentRepo
-	carService   CarService
+	permitRepo        storage.PermitRepo
+	residentRepo      storage.ResidentRepo
+	parkingSpaceRepo  storage.ParkingSpaceRepo
+	parkingPolicyRepo storage.ParkingPolicyRepo
+	carService        CarService
+	banService        BanService
+	permitRuleService PermitRuleService
+	// location is the timezone of the community, whose calendar the dates of permits are days of
+	location *time.Location
 }
 
-func NewPermitService(permitRepo storage.PermitRepo, residentRepo storage.ResidentRepo, carService CarService) PermitService {
+func NewPermitService(permitRepo storage.PermitRepo, residentRepo storage.ResidentRepo, parkingSpaceRepo storage.ParkingSpaceRepo, parkingPolicyRepo storage.ParkingPolicyRepo, carService CarService, banService BanService, permitRuleService PermitRuleService, location *time.Location) PermitService {
 	return PermitService{
-		permitRepo:   permitRepo,
-		residentRepo: residentRepo,
-		carService:   carService,
+		permitRepo:        permitRepo,
+		residentRepo:      residentRepo,
+		parkingSpaceRepo:  parkingSpaceRepo,
+		parkingPolicyRepo: parkingPolicyRepo,
+		carService:        carService,
+		banService:        banService,
+		permitRuleService: permitRuleService,
+		location:          location,
 	}
 }
 
-func (s PermitService) GetAll(status models.Status, limit, page int, reversed bool, search, residentID string) (models.ListWithMetadata[models.Permit], error) {
-	boundedLimit, offset := getBoundedLimitAndOffset(limit, page)
+func (s PermitService) GetAll(ctx context.Context, status models.Status, params ListParams, residentID string) (models.ListWithMetadata[models.Permit], error) {
+	paging, err := newPaging(s.permitRepo, params)
+	if err != nil {
+		return models.ListWithMetadata[models.Permit]{}, err
+	}
 
-	allPermits, err := s.permitRepo.SelectWhere(models.Permit{ResidentID: residentID},
-		selectopts.WithStatus(status),
-		selectopts.WithLimitAndOffset(boundedLimit, offset),
-		selectopts.WithReversed(reversed),
-		selectopts.WithSearch(search),
+	allPermits, err := s.permitRepo.SelectWhere(ctx, models.Permit{ResidentID: residentID},
+		append(paging.selectOpts(), selectopts.WithStatus(status))...,
 	)
 	if err != nil {
 		return models.ListWithMetadata[models.Permit]{}, fmt.Errorf("error getting permits from permit repo: %v", err)
 	}
 
-	totalAmount, err := s.permitRepo.SelectCountWhere(models.Permit{ResidentID: residentID},
-		selectopts.WithStatus(status),
-		selectopts.WithSearch(search),
-	)
-	if err != nil {
-		return models.ListWithMetadata[models.Permit]{}, fmt.Errorf("error getting total amount from permit repo: %v", err)
+	var totalAmount *int
+	if !paging.byCursor() {
+		count, err := s.permitRepo.SelectCountWhere(ctx, models.Permit{ResidentID: residentID},
+			append(paging.countOpts(), selectopts.WithStatus(status))...,
+		)
+		if err != nil {
+			return models.ListWithMetadata[models.Permit]{}, fmt.Errorf("error getting total amount from permit repo: %v", err)
+		}
+		totalAmount = &count
 	}
 
-	return models.NewListWithMetadata(allPermits, totalAmount), nil
+	return newPagedList(paging, allPermits, func(permit models.Permit) string { return strconv.Itoa(permit.ID) }, totalAmount), nil
 }
 
-func (s PermitService) GetOne(id int) (models.Permit, error) {
+// Export calls each with every permit that GetAll lists with params, on every page
+func (s PermitService) Export(ctx context.Context, status models.Status, params ListParams, residentID string, each func(models.Permit) error) error {
+	selectPage := func(opts []selectopts.SelectOpt) ([]models.Permit, error) {
+		permits, err := s.permitRepo.SelectWhere(ctx, models.Permit{ResidentID: residentID}, append(opts, selectopts.WithStatus(status))...)
+		if err != nil {
+			return nil, fmt.Errorf("error getting permits from permit repo: %v", err)
+		}
+		return permits, nil
+	}
+
+	return forEachRow(s.permitRepo, params, selectPage, func(permit models.Permit) string { return strconv.Itoa(permit.ID) }, each)
+}
+
+func (s PermitService) GetOne(ctx context.Context, id int) (models.Permit, error) {
 	if id == 0 {
 		return models.Permit{}, errs.MissingIDField
 	}
 
-	return s.permitRepo.GetOne(id)
+	return s.permitRepo.GetOne(ctx, id)
 }
 
-func (s PermitService) Delete(id int) error {
+func (s PermitService) Delete(ctx context.Context, id int) error {
 	if id == 0 {
 		return errs.MissingIDField
 	}
 
-	permit, err := s.permitRepo.GetOne(id)
+	permit, err := s.permitRepo.GetOne(ctx, id)
 	if err != nil {
 		return err
 	}
 
-	if err = s.permitRepo.Delete(id); err != nil {
+	if err = s.permitRepo.Delete(ctx, id); err != nil {
 		return err
 	}
 
-	permitLength := int(permit.EndDate.Sub(permit.StartDate).Hours() / 24)
 	if permit.AffectsDays {
-		err = s.residentRepo.AddToAmtParkingDaysUsed(permit.ResidentID, -permitLength)
+		daysCharged, err := s.permitRuleService.DaysChargedFor(ctx, permit)
+		if err != nil {
+			return fmt.Errorf("error counting the days charged for permit: %v", err)
+		}
+
+		err = s.residentRepo.AddToAmtParkingDaysUsed(ctx, permit.ResidentID, -daysCharged)
 		if err != nil {
 			return fmt.Errorf("error subtracting amtParkingDaysUsed in residentRepo: %v", err)
 		}
 
-		err = s.carService.carRepo.AddToAmtParkingDaysUsed(permit.CarID, -permitLength)
+		err = s.carService.carRepo.AddToAmtParkingDaysUsed(ctx, permit.CarID, -daysCharged)
 		if err != nil && !errors.Is(err, errs.NotFound) {
 			return fmt.Errorf("error subtracting amtParkingDaysUsed in carRepo: %v", err)
 		}
@@ -93,24 +127,60 @@ func (s PermitService) Delete(id int) error {
 	return nil
 }
 
-func (s PermitService) Create(desiredPermit models.Permit) (models.Permit, error) {
-	if err := s.validateDates(desiredPermit); err != nil {
+func (s PermitService) Create(ctx context.Context, desiredPermit models.Permit) (models.Permit, error) {
+	// dates are days of the community's calendar, whatever the timezone that they were sent in
+	desiredPermit.StartDate = desiredPermit.StartDate.In(s.location)
+	desiredPermit.EndDate = desiredPermit.EndDate.In(s.location)
+
+	policy, err := s.parkingPolicyRepo.Get(ctx)
+	if err != nil {
+		return models.Permit{}, fmt.Errorf("error getting parking policy in parkingPolicyRepo: %v", err)
+	}
+
+	if err := s.validateDates(desiredPermit, policy); err != nil {
+		return models.Permit{}, err
+	}
+
+	// checked before populating the car fields so that no car is created for a banned license plate
+	if err := s.banService.CheckLicensePlate(ctx, desiredPermit.LicensePlate); err != nil {
 		return models.Permit{}, err
 	}
 
-	permitLength := util.GetAmtDays(desiredPermit.StartDate, desiredPermit.EndDate)
-	resident, err := s.getAndValidateResident(desiredPermit, permitLength)
+	daysCharged, err := s.permitRuleService.DaysCharged(ctx, desiredPermit.StartDate, desiredPermit.EndDate)
+	if err != nil {
+		return models.Permit{}, fmt.Errorf("error counting the days charged for permit: %v", err)
+	}
+	resident, err := s.getAndValidateResident(ctx, desiredPermit, daysCharged, policy)
 	if err != nil {
 		return models.Permit{}, err
 	}
 
-	populatedPermit, err := s.populatePermitCarFields(desiredPermit, *resident.UnlimDays, permitLength)
+	if err := s.permitRuleService.CheckPermit(ctx, desiredPermit); err != nil {
+		return models.Permit{}, err
+	}
+
+	if err := s.validateCapacity(ctx, desiredPermit); err != nil {
+		return models.Permit{}, err
+	}
+
+	populatedPermit, err := s.populatePermitCarFields(ctx, desiredPermit, *resident.UnlimDays)
 	if err != nil {
 		return models.Permit{}, err
 	}
 
+	// a permit requested by carID only gets its license plate after populating its car fields
+	if desiredPermit.LicensePlate == "" {
+		if err := s.banService.CheckLicensePlate(ctx, populatedPermit.LicensePlate); err != nil {
+			return models.Permit{}, err
+		}
+	}
+
 	populatedPermit.AffectsDays = populatedPermit.ExceptionReason == "" && !*resident.UnlimDays
-	createdPermit, err := s.create(populatedPermit)
+	if !populatedPermit.AffectsDays {
+		daysCharged = 0
+	}
+	populatedPermit.DaysCharged = &daysCharged
+	createdPermit, err := s.create(ctx, populatedPermit)
 	if err != nil {
 		return models.Permit{}, fmt.Errorf("error creating permit in permitservice: %v", err)
 	}
@@ -118,7 +188,93 @@ func (s PermitService) Create(desiredPermit models.Permit) (models.Permit, error
 	return createdPermit, nil
 }
 
-func (s PermitService) Update(updatedFields models.Permit) (models.Permit, error) {
+// Quote runs the validations of Create without creating anything. unlike Create, it doesn't stop at the first
+// check that fails. only errors that keep the checks from running are returned. failures are reported in lang
+func (s PermitService) Quote(ctx context.Context, desiredPermit models.Permit, lang i18n.Lang) (models.PermitQuote, error) {
+	// dates are days of the community's calendar, whatever the timezone that they were sent in
+	desiredPermit.StartDate = desiredPermit.StartDate.In(s.location)
+	desiredPermit.EndDate = desiredPermit.EndDate.In(s.location)
+
+	quote := models.PermitQuote{Failures: []models.PermitQuoteFailure{}}
+	addFailure := func(err error) error {
+		var apiErr *errs.APIErr
+		if !errors.As(err, &apiErr) {
+			return err
+		}
+		quote.Failures = append(quote.Failures, newPermitQuoteFailure(apiErr.In(lang)))
+		return nil
+	}
+
+	policy, err := s.parkingPolicyRepo.Get(ctx)
+	if err != nil {
+		return models.PermitQuote{}, fmt.Errorf("error getting parking policy in parkingPolicyRepo: %v", err)
+	}
+
+	if err := s.banService.CheckLicensePlate(ctx, desiredPermit.LicensePlate); err != nil {
+		if err := addFailure(err); err != nil {
+			return models.PermitQuote{}, err
+		}
+	}
+
+	// the rest of the checks depend on the dates of the permit
+	if err := s.validateDates(desiredPermit, policy); err != nil {
+		if err := addFailure(err); err != nil {
+			return models.PermitQuote{}, err
+		}
+		return quote, nil
+	}
+	daysCharged, err := s.permitRuleService.DaysCharged(ctx, desiredPermit.StartDate, desiredPermit.EndDate)
+	if err != nil {
+		return models.PermitQuote{}, fmt.Errorf("error counting the days charged for permit: %v", err)
+	}
+
+	resident, err := s.getResident(ctx, desiredPermit)
+	if err != nil {
+		if err := addFailure(err); err != nil {
+			return models.PermitQuote{}, err
+		}
+	} else {
+		residentErrs, err := s.validateResident(ctx, desiredPermit, resident, daysCharged, policy)
+		if err != nil {
+			return models.PermitQuote{}, err
+		}
+		for _, residentErr := range residentErrs {
+			quote.Failures = append(quote.Failures, newPermitQuoteFailure(residentErr.In(lang)))
+		}
+
+		if !*resident.UnlimDays {
+			if desiredPermit.ExceptionReason == "" {
+				quote.DaysCharged = daysCharged
+			}
+			quote.DaysRemaining = util.ToPtr(max(policy.MaxParkingDays-*resident.AmtParkingDaysUsed-quote.DaysCharged, 0))
+		}
+	}
+
+	violations, err := s.permitRuleService.EvaluatePermit(ctx, desiredPermit)
+	if err != nil {
+		return models.PermitQuote{}, err
+	}
+	if len(violations) != 0 {
+		quote.Failures = append(quote.Failures, newPermitQuoteFailure(errs.NewRulesBroken(violations).In(lang)))
+	}
+
+	if err := s.validateCapacity(ctx, desiredPermit); err != nil {
+		if err := addFailure(err); err != nil {
+			return models.PermitQuote{}, err
+		}
+	}
+
+	if err := s.checkCar(ctx, desiredPermit); err != nil {
+		if err := addFailure(err); err != nil {
+			return models.PermitQuote{}, err
+		}
+	}
+
+	quote.Valid = len(quote.Failures) == 0
+	return quote, nil
+}
+
+func (s PermitService) Update(ctx context.Context, updatedFields models.Permit) (models.Permit, error) {
 	if updatedFields.ID == 0 {
 		return models.Permit{}, errs.MissingIDField
 	}
@@ -132,11 +288,11 @@ func (s PermitService) Update(updatedFields models.Permit) (models.Permit, error
 		}
 	}
 
-	if err := s.permitRepo.Update(updatedFields); err != nil {
+	if err := s.permitRepo.Update(ctx, updatedFields); err != nil {
 		return models.Permit{}, fmt.Errorf("error updating permit from permitRepo: %w", err)
 	}
 
-	permit, err := s.permitRepo.GetOne(updatedFields.ID)
+	permit, err := s.permitRepo.GetOne(ctx, updatedFields.ID)
 	if err != nil {
 		return models.Permit{}, fmt.Errorf("error getting permit from permitRepo: %w", err)
 	}
@@ -145,8 +301,20 @@ func (s PermitService) Update(updatedFields models.Permit) (models.Permit, error
 }
 
 // helpers
-func (s PermitService) populatePermitCarFields(p models.Permit, residentUnlimDays bool, permitLength int) (models.Permit, error) {
-	associatedCar, err := s.findCar(p)
+// newPermitQuoteFailure copies apiErr into the failure of a quote
+func newPermitQuoteFailure(apiErr *errs.APIErr) models.PermitQuoteFailure {
+	failure := models.PermitQuoteFailure{Code: apiErr.Code, Status: apiErr.StatusCode, Message: apiErr.Error()}
+	for _, fieldErr := range apiErr.Fields {
+		failure.Fields = append(failure.Fields, models.PermitQuoteFailureDetail{Name: fieldErr.Field, Check: fieldErr.Rule, Message: fieldErr.Message})
+	}
+	for _, ruleErr := range apiErr.Rules {
+		failure.Rules = append(failure.Rules, models.PermitQuoteFailureDetail{Name: ruleErr.Rule, Check: ruleErr.Kind, Message: ruleErr.Message})
+	}
+	return failure
+}
+
+func (s PermitService) populatePermitCarFields(ctx context.Context, p models.Permit, residentUnlimDays bool) (models.Permit, error) {
+	associatedCar, err := s.findCar(ctx, p)
 	if !errors.Is(err, errs.NotFound) && err != nil {
 		return models.Permit{}, err
 	}
@@ -160,7 +328,7 @@ func (s PermitService) populatePermitCarFields(p models.Permit, residentUnlimDay
 
 		// otherwise, we will create a new car for this permit
 		desiredCar := models.Car{ResidentID: p.ResidentID, LicensePlate: p.LicensePlate, Color: p.Color, Make: p.Make, Model: p.Model}
-		createdCar, err := s.carService.Create(desiredCar)
+		createdCar, err := s.carService.Create(ctx, desiredCar)
 		if err != nil {
 			return models.Permit{}, fmt.Errorf("error creating car: %w", err)
 		}
@@ -172,7 +340,7 @@ func (s PermitService) populatePermitCarFields(p models.Permit, residentUnlimDay
 	}
 
 	// we found a car that already exists like this in the database, lets use that one
-	if err := s.validateCar(p, associatedCar, residentUnlimDays, permitLength); err != nil {
+	if err := s.validateCar(ctx, p, associatedCar, residentUnlimDays); err != nil {
 		return models.Permit{}, err
 	}
 	// get a snapshot of car and save it into the permit
@@ -184,17 +352,43 @@ func (s PermitService) populatePermitCarFields(p models.Permit, residentUnlimDay
 	return p, nil
 }
 
-func (s PermitService) findCar(p models.Permit) (models.Car, error) {
+// checkCar is the read-only counterpart of populatePermitCarFields: it errors out whenever
+// populatePermitCarFields would, without creating a car
+func (s PermitService) checkCar(ctx context.Context, p models.Permit) error {
+	associatedCar, err := s.findCar(ctx, p)
+	if errors.Is(err, errs.NotFound) && p.CarID != "" {
+		return errs.CarForPermitDNE
+	} else if errors.Is(err, errs.NotFound) {
+		desiredCar := models.Car{ResidentID: p.ResidentID, LicensePlate: p.LicensePlate, Color: p.Color, Make: p.Make, Model: p.Model}
+		if err := validator.CreateCar.Run(desiredCar); err != nil {
+			return err
+		}
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if p.LicensePlate == "" {
+		if err := s.banService.CheckLicensePlate(ctx, associatedCar.LicensePlate); err != nil {
+			return err
+		}
+	}
+
+	return s.validateCar(ctx, p, associatedCar, false)
+}
+
+func (s PermitService) findCar(ctx context.Context, p models.Permit) (models.Car, error) {
 	if p.CarID == "" {
-		return s.carService.GetOneByLicensePlate(p.LicensePlate)
+		return s.carService.GetOneByLicensePlate(ctx, p.LicensePlate)
 	} else {
-		return s.carService.GetOne(p.CarID)
+		return s.carService.GetOne(ctx, p.CarID)
 	}
 }
 
-func (s PermitService) validateCar(p models.Permit, c models.Car, residentUnlimDays bool, permitLength int) error {
+func (s PermitService) validateCar(ctx context.Context, p models.Permit, c models.Car, residentUnlimDays bool) error {
 	// error out if it has active permits during dates requested
 	carActivePermitsDuring, err := s.permitRepo.SelectWhere(
+		ctx,
 		models.Permit{CarID: c.ID},
 		selectopts.WithDateIntersect(p.StartDate, p.EndDate),
 	)
@@ -213,12 +407,77 @@ func (s PermitService) validateCar(p models.Permit, c models.Car, residentUnlimD
 	return nil
 }
 
-func (s PermitService) getAndValidateResident(desiredPermit models.Permit, permitLength int) (models.Resident, error) {
+// validateCapacity errors out if the parking space of desiredPermit is taken, or if there would be more permits
+// than guest parking spaces on any day of desiredPermit. if no parking spaces have been created, capacity is not enforced
+func (s PermitService) validateCapacity(ctx context.Context, desiredPermit models.Permit) error {
+	if desiredPermit.SpaceID != "" {
+		if !util.IsUUIDV4(desiredPermit.SpaceID) {
+			return errs.IDNotUUID
+		}
+
+		if _, err := s.parkingSpaceRepo.GetOne(ctx, desiredPermit.SpaceID); errors.Is(err, errs.NotFound) {
+			return errs.SpaceForPermitDNE
+		} else if err != nil {
+			return fmt.Errorf("error getting parking space in parkingSpaceRepo: %v", err)
+		}
+
+		spacePermitsDuring, err := s.permitRepo.SelectWhere(ctx, models.Permit{SpaceID: desiredPermit.SpaceID},
+			selectopts.WithDateIntersect(desiredPermit.StartDate, desiredPermit.EndDate),
+		)
+		if err != nil {
+			return fmt.Errorf("error getting permits of parking space during dates in permitRepo: %v", err)
+		}
+		for _, permit := range spacePermitsDuring {
+			if permit.StartDate.Before(desiredPermit.EndDate) && permit.EndDate.After(desiredPermit.StartDate) {
+				return errs.SpaceTaken
+			}
+		}
+	}
+
+	capacity, err := s.parkingSpaceRepo.SelectCountWhere(ctx, models.ParkingSpace{})
+	if err != nil {
+		return fmt.Errorf("error getting amount of parking spaces in parkingSpaceRepo: %v", err)
+	} else if capacity == 0 {
+		return nil
+	}
+
+	permitsDuring, err := s.permitRepo.SelectWhere(ctx, models.Permit{},
+		selectopts.WithDateIntersect(desiredPermit.StartDate, desiredPermit.EndDate),
+	)
+	if err != nil {
+		return fmt.Errorf("error getting permits during dates in permitRepo: %v", err)
+	}
+
+	for _, day := range dailyAvailability(capacity, permitsDuring, desiredPermit.StartDate, desiredPermit.EndDate) {
+		if day.Free == 0 {
+			return errs.LotAtCapacity
+		}
+	}
+
+	return nil
+}
+
+func (s PermitService) getAndValidateResident(ctx context.Context, desiredPermit models.Permit, daysCharged int, policy models.ParkingPolicy) (models.Resident, error) {
+	resident, err := s.getResident(ctx, desiredPermit)
+	if err != nil {
+		return models.Resident{}, err
+	}
+
+	if residentErrs, err := s.validateResident(ctx, desiredPermit, resident, daysCharged, policy); err != nil {
+		return models.Resident{}, err
+	} else if len(residentErrs) > 0 {
+		return models.Resident{}, residentErrs[0]
+	}
+
+	return resident, nil
+}
+
+func (s PermitService) getResident(ctx context.Context, desiredPermit models.Permit) (models.Resident, error) {
 	if err := models.IsResidentID(desiredPermit.ResidentID); err != nil {
 		return models.Resident{}, errs.InvalidResID
 	}
 
-	residents, err := s.residentRepo.SelectWhere(models.Resident{ID: desiredPermit.ResidentID})
+	residents, err := s.residentRepo.SelectWhere(ctx, models.Resident{ID: desiredPermit.ResidentID})
 	if err != nil {
 		return models.Resident{}, fmt.Errorf("error getting one from resident repo: %v", err)
 	} else if len(residents) == 0 {
@@ -226,82 +485,91 @@ func (s PermitService) getAndValidateResident(desiredPermit models.Permit, permi
 	}
 	resident := residents[0]
 
+	if resident.UnlimDays == nil || resident.AmtParkingDaysUsed == nil {
+		return models.Resident{}, fmt.Errorf("data type error in permit service validate create. unlimDays or amtParkingDaysUsed is nil")
+	}
+
+	return resident, nil
+}
+
+// validateResident returns every limit of the parking policy that resident would go over with desiredPermit,
+// which uses daysCharged parking days. the returned error is only set if the limits couldn't be checked
+func (s PermitService) validateResident(ctx context.Context, desiredPermit models.Permit, resident models.Resident, daysCharged int, policy models.ParkingPolicy) ([]*errs.APIErr, error) {
 	// if this is an exception, there are no more checks to be performed. so return no errors
 	if desiredPermit.ExceptionReason != "" {
-		return resident, nil
+		return nil, nil
 	}
 
-	if permitLength > config.MaxPermitLength {
-		return models.Resident{}, errs.PermitTooLong
+	var residentErrs []*errs.APIErr
+	if util.GetAmtDays(desiredPermit.StartDate, desiredPermit.EndDate) > policy.MaxPermitLength {
+		residentErrs = append(residentErrs, errs.NewPermitTooLong(policy.MaxPermitLength))
 	}
 
-	residentActivePermitsDuring, err := s.permitRepo.SelectWhere(models.Permit{ResidentID: resident.ID},
+	residentActivePermitsDuring, err := s.permitRepo.SelectWhere(ctx, models.Permit{ResidentID: resident.ID},
 		selectopts.WithDateIntersect(desiredPermit.StartDate, desiredPermit.EndDate),
 	)
 	if err != nil {
-		return models.Resident{}, fmt.Errorf("error getting active of resident during dates in permitRepo: %v", err)
-	} else if len(residentActivePermitsDuring) >= 2 {
-		return models.Resident{}, errs.ResidentTwoActivePermits
-	}
-
-	if resident.UnlimDays == nil || resident.AmtParkingDaysUsed == nil {
-		return models.Resident{}, fmt.Errorf("data type error in permit service validate create. unlimDays or amtParkingDaysUsed is nil")
+		return nil, fmt.Errorf("error getting active of resident during dates in permitRepo: %v", err)
+	} else if len(residentActivePermitsDuring) >= policy.MaxActivePermits {
+		residentErrs = append(residentErrs, errs.NewResidentTooManyActivePermits(policy.MaxActivePermits))
 	}
 
 	if !*resident.UnlimDays {
-		if *resident.AmtParkingDaysUsed >= config.MaxParkingDays {
-			return models.Resident{}, errs.EntityDaysTooLong("resident", *resident.AmtParkingDaysUsed)
-		} else if *resident.AmtParkingDaysUsed+permitLength > config.MaxParkingDays {
-			return models.Resident{}, errs.PermitPlusEntityDaysTooLong("resident", *resident.AmtParkingDaysUsed)
+		if *resident.AmtParkingDaysUsed >= policy.MaxParkingDays {
+			residentErrs = append(residentErrs, errs.EntityDaysTooLong("resident", *resident.AmtParkingDaysUsed, policy.MaxParkingDays))
+		} else if *resident.AmtParkingDaysUsed+daysCharged > policy.MaxParkingDays {
+			residentErrs = append(residentErrs, errs.PermitPlusEntityDaysTooLong("resident", *resident.AmtParkingDaysUsed, policy.MaxParkingDays))
 		}
 	}
 
-	return resident, nil
+	return residentErrs, nil
 }
 
-func (s PermitService) validateDates(desiredPermit models.Permit) error {
-	var errors []string
+func (s PermitService) validateDates(desiredPermit models.Permit, policy models.ParkingPolicy) error {
+	var fieldErrs []errs.FieldError
 
 	if desiredPermit.StartDate.IsZero() {
-		errors = append(errors, "startDate cannot be empty")
+		fieldErrs = append(fieldErrs, errs.NewFieldError("startDate", "required", "startDate cannot be empty"))
 	}
 	if desiredPermit.EndDate.IsZero() {
-		errors = append(errors, "endDate cannot be empty")
+		fieldErrs = append(fieldErrs, errs.NewFieldError("endDate", "required", "endDate cannot be empty"))
 	}
 	if desiredPermit.StartDate.After(desiredPermit.EndDate) {
-		errors = append(errors, "startDate cannot be after endDate")
+		fieldErrs = append(fieldErrs, errs.NewFieldError("startDate", "before", "startDate cannot be after endDate"))
 	}
 	if desiredPermit.StartDate.Equal(desiredPermit.EndDate) {
-		errors = append(errors, "startDate cannot be equal to endDate")
+		fieldErrs = append(fieldErrs, errs.NewFieldError("startDate", "before", "startDate cannot be equal to endDate"))
+	}
+	if desiredPermit.EndDate.After(policy.LatestEndDate) {
+		fieldErrs = append(fieldErrs, errs.NewFieldError("endDate", "max", "endDate cannot be after "+policy.LatestEndDate.In(s.location).Format(config.DateFormat)))
 	}
 
-	if len(errors) > 0 {
-		return errs.InvalidFields(strings.Join(errors, ". "))
+	if len(fieldErrs) > 0 {
+		return errs.InvalidFields(fieldErrs...)
 	}
 
 	return nil
 }
 
-func (s PermitService) create(desiredPermit models.Permit) (models.Permit, error) {
-	permitLength := util.GetAmtDays(desiredPermit.StartDate, desiredPermit.EndDate)
+func (s PermitService) create(ctx context.Context, desiredPermit models.Permit) (models.Permit, error) {
 	if desiredPermit.AffectsDays {
-		err := s.residentRepo.AddToAmtParkingDaysUsed(desiredPermit.ResidentID, permitLength)
+		err := s.residentRepo.AddToAmtParkingDaysUsed(ctx, desiredPermit.ResidentID, *desiredPermit.DaysCharged)
 		if err != nil {
 			return models.Permit{}, fmt.Errorf("error adding to amt parking days used in residentRepo: %v", err)
 		}
 
-		err = s.carService.carRepo.AddToAmtParkingDaysUsed(desiredPermit.CarID, permitLength)
+		err = s.carService.carRepo.AddToAmtParkingDaysUsed(ctx, desiredPermit.CarID, *desiredPermit.DaysCharged)
 		if err != nil {
 			return models.Permit{}, fmt.Errorf("error adding to amt parking days used in carRepo: %v", err)
 		}
 	}
 
-	permitID, err := s.permitRepo.Create(desiredPermit)
+	permitID, err := s.permitRepo.Create(ctx, desiredPermit)
 	if err != nil {
 		return models.Permit{}, fmt.Errorf("error create new permit in permitRepo: %v", err)
 	}
 
-	newPermit, err := s.permitRepo.GetOne(permitID)
+	newPermit, err := s.permitRepo.GetOne(ctx, permitID)
 	if err != nil {
 		return models.Permit{}, fmt.Errorf("error getting permit after having created it in permitRepo: %v", err)
 	}
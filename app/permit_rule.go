@@ -0,0 +1,329 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dannyvelas/parkspot-backend/config"
+	"github.com/dannyvelas/parkspot-backend/errs"
+	"github.com/dannyvelas/parkspot-backend/models"
+	"github.com/dannyvelas/parkspot-backend/models/validator"
+	"github.com/dannyvelas/parkspot-backend/storage"
+	"github.com/dannyvelas/parkspot-backend/storage/selectopts"
+	"github.com/dannyvelas/parkspot-backend/util"
+)
+
+type PermitRuleService struct {
+	permitRuleRepo    storage.PermitRuleRepo
+	permitRepo        storage.PermitRepo
+	visitorRepo       storage.VisitorRepo
+	residentRepo      storage.ResidentRepo
+	parkingPolicyRepo storage.ParkingPolicyRepo
+	// location is the timezone of the community, whose calendar the days of rules are days of
+	location *time.Location
+}
+
+func NewPermitRuleService(permitRuleRepo storage.PermitRuleRepo, permitRepo storage.PermitRepo, visitorRepo storage.VisitorRepo, residentRepo storage.ResidentRepo, parkingPolicyRepo storage.ParkingPolicyRepo, location *time.Location) PermitRuleService {
+	return PermitRuleService{
+		permitRuleRepo:    permitRuleRepo,
+		permitRepo:        permitRepo,
+		visitorRepo:       visitorRepo,
+		residentRepo:      residentRepo,
+		parkingPolicyRepo: parkingPolicyRepo,
+		location:          location,
+	}
+}
+
+func (s PermitRuleService) GetAll(ctx context.Context, target models.PermitRuleTarget) ([]models.PermitRule, error) {
+	return s.permitRuleRepo.SelectWhere(ctx, models.PermitRule{Target: target})
+}
+
+func (s PermitRuleService) Create(ctx context.Context, desiredPermitRule models.PermitRule) (models.PermitRule, error) {
+	desiredPermitRule.Name = strings.TrimSpace(desiredPermitRule.Name)
+	desiredPermitRule.Relationship = strings.TrimSpace(desiredPermitRule.Relationship)
+	if err := validator.CreatePermitRule.Run(desiredPermitRule); err != nil {
+		return models.PermitRule{}, err
+	}
+
+	existingRules, err := s.permitRuleRepo.SelectWhere(ctx, models.PermitRule{})
+	if err != nil {
+		return models.PermitRule{}, fmt.Errorf("error getting permit rules from permit rule repo: %v", err)
+	}
+	for _, existingRule := range existingRules {
+		if strings.EqualFold(existingRule.Name, desiredPermitRule.Name) {
+			return models.PermitRule{}, errs.NewAlreadyExists("a permit rule named " + desiredPermitRule.Name)
+		}
+	}
+
+	permitRuleID, err := s.permitRuleRepo.Create(ctx, desiredPermitRule)
+	if err != nil {
+		return models.PermitRule{}, fmt.Errorf("error creating permit rule in permit rule repo: %v", err)
+	}
+
+	permitRule, err := s.permitRuleRepo.GetOne(ctx, permitRuleID)
+	if err != nil {
+		return models.PermitRule{}, fmt.Errorf("error getting permit rule after creating in permit rule repo: %v", err)
+	}
+
+	return permitRule, nil
+}
+
+func (s PermitRuleService) Delete(ctx context.Context, id string) error {
+	if id == "" {
+		return errs.MissingIDField
+	}
+	if !util.IsUUIDV4(id) {
+		return errs.IDNotUUID
+	}
+	return s.permitRuleRepo.Delete(ctx, id)
+}
+
+// CheckPermit errors out if desiredPermit breaks any permit rule
+func (s PermitRuleService) CheckPermit(ctx context.Context, desiredPermit models.Permit) error {
+	violations, err := s.EvaluatePermit(ctx, desiredPermit)
+	if err != nil {
+		return err
+	}
+	return rulesBrokenErr(violations)
+}
+
+// CheckVisitor errors out if desiredVisitor breaks any visitor rule
+func (s PermitRuleService) CheckVisitor(ctx context.Context, desiredVisitor models.Visitor) error {
+	violations, err := s.EvaluateVisitor(ctx, desiredVisitor)
+	if err != nil {
+		return err
+	}
+	return rulesBrokenErr(violations)
+}
+
+// DaysCharged is how many parking days a permit from startDate to endDate uses. they are the days of
+// util.GetAmtDays, except that days on weekends count as many times as the weight of the weekend_weight rules
+func (s PermitRuleService) DaysCharged(ctx context.Context, startDate, endDate time.Time) (int, error) {
+	weekendWeight, err := s.WeekendWeight(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return chargedDays(startDate.In(s.location), endDate, weekendWeight), nil
+}
+
+// DaysChargedFor is how many parking days permit used when it was created. permits that were created before
+// their days were stored are counted with the weekend_weight rules of now
+func (s PermitRuleService) DaysChargedFor(ctx context.Context, permit models.Permit) (int, error) {
+	if permit.DaysCharged != nil {
+		return *permit.DaysCharged, nil
+	}
+	return s.DaysCharged(ctx, permit.StartDate, permit.EndDate)
+}
+
+// daysChargedWith is DaysChargedFor with a weekendWeight that was already read, for counting many permits at once
+func (s PermitRuleService) daysChargedWith(permit models.Permit, weekendWeight int) int {
+	if permit.DaysCharged != nil {
+		return *permit.DaysCharged
+	}
+	return chargedDays(permit.StartDate.In(s.location), permit.EndDate, weekendWeight)
+}
+
+// WeekendWeight is how many parking days each day on a weekend uses: the largest weight of the weekend_weight
+// rules, or 1 when there are none. permits keep the days that they were charged, so changing these rules only
+// changes what the permits that are created afterwards are charged
+func (s PermitRuleService) WeekendWeight(ctx context.Context) (int, error) {
+	rules, err := s.permitRuleRepo.SelectWhere(ctx, models.PermitRule{Target: models.PermitTarget})
+	if err != nil {
+		return 0, fmt.Errorf("error getting permit rules from permit rule repo: %v", err)
+	}
+
+	weekendWeight := 1
+	for _, rule := range rules {
+		if rule.Kind == models.WeekendWeightRule {
+			weekendWeight = max(weekendWeight, rule.Weight)
+		}
+	}
+	return weekendWeight, nil
+}
+
+// EvaluatePermit returns every permit rule that desiredPermit breaks. like the parking policy,
+// permit rules don't apply to exceptions
+func (s PermitRuleService) EvaluatePermit(ctx context.Context, desiredPermit models.Permit) ([]errs.RuleError, error) {
+	violations := []errs.RuleError{}
+	if desiredPermit.ExceptionReason != "" {
+		return violations, nil
+	}
+
+	// dates are days of the community's calendar, whatever the timezone that they were sent in
+	desiredPermit.StartDate = desiredPermit.StartDate.In(s.location)
+	desiredPermit.EndDate = desiredPermit.EndDate.In(s.location)
+
+	rules, err := s.permitRuleRepo.SelectWhere(ctx, models.PermitRule{Target: models.PermitTarget})
+	if err != nil {
+		return nil, fmt.Errorf("error getting permit rules from permit rule repo: %v", err)
+	}
+
+	for _, rule := range rules {
+		var violation *errs.RuleError
+		switch rule.Kind {
+		case models.BlackoutDatesRule:
+			violation = blackoutDateViolation(rule, desiredPermit.StartDate, desiredPermit.EndDate, s.location)
+		case models.MaxLengthRule:
+			violation = maxLengthViolation(rule, desiredPermit.StartDate, desiredPermit.EndDate)
+		case models.MaxPerMonthRule:
+			violation, err = s.permitsPerMonthViolation(ctx, rule, desiredPermit)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if violation != nil {
+			violations = append(violations, *violation)
+		}
+	}
+
+	return violations, nil
+}
+
+// EvaluateVisitor returns every visitor rule that desiredVisitor breaks
+func (s PermitRuleService) EvaluateVisitor(ctx context.Context, desiredVisitor models.Visitor) ([]errs.RuleError, error) {
+	desiredVisitor.AccessStart = desiredVisitor.AccessStart.In(s.location)
+	desiredVisitor.AccessEnd = desiredVisitor.AccessEnd.In(s.location)
+
+	rules, err := s.permitRuleRepo.SelectWhere(ctx, models.PermitRule{Target: models.VisitorTarget})
+	if err != nil {
+		return nil, fmt.Errorf("error getting visitor rules from permit rule repo: %v", err)
+	}
+
+	violations := []errs.RuleError{}
+	for _, rule := range rules {
+		if rule.Relationship != "" && !strings.EqualFold(rule.Relationship, desiredVisitor.Relationship) {
+			continue
+		}
+
+		var violation *errs.RuleError
+		switch rule.Kind {
+		case models.BlackoutDatesRule:
+			violation = blackoutDateViolation(rule, desiredVisitor.AccessStart, desiredVisitor.AccessEnd, s.location)
+		case models.MaxLengthRule:
+			violation = maxLengthViolation(rule, desiredVisitor.AccessStart, desiredVisitor.AccessEnd)
+		case models.MaxPerMonthRule:
+			violation, err = s.visitorsPerMonthViolation(ctx, rule, desiredVisitor)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if violation != nil {
+			violations = append(violations, *violation)
+		}
+	}
+
+	return violations, nil
+}
+
+// helpers
+// each of these returns how rule was broken, or nil if it wasn't
+
+func blackoutDateViolation(rule models.PermitRule, startDate, endDate time.Time, location *time.Location) *errs.RuleError {
+	for _, date := range rule.Dates {
+		dayStart, err := time.ParseInLocation(config.DateFormat, date, location)
+		if err != nil {
+			// dates are validated when a rule is created
+			continue
+		}
+
+		if startDate.Before(dayStart.AddDate(0, 0, 1)) && endDate.After(dayStart) {
+			return newRuleError(rule, "rules.blackout_dates", "guest parking is not allowed on %s", date)
+		}
+	}
+	return nil
+}
+
+func maxLengthViolation(rule models.PermitRule, startDate, endDate time.Time) *errs.RuleError {
+	if util.GetAmtDays(startDate, endDate) > rule.MaxDays {
+		return newRuleError(rule, "rules.max_length", "cannot be longer than %d days", rule.MaxDays)
+	}
+	return nil
+}
+
+func (s PermitRuleService) permitsPerMonthViolation(ctx context.Context, rule models.PermitRule, desiredPermit models.Permit) (*errs.RuleError, error) {
+	monthStart, monthEnd := monthOf(desiredPermit.StartDate)
+
+	permitsDuringMonth, err := s.permitRepo.SelectWhere(ctx, models.Permit{ResidentID: desiredPermit.ResidentID},
+		selectopts.WithDateIntersect(monthStart, monthEnd),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error getting permits of resident during month in permitRepo: %v", err)
+	}
+
+	amtStarting := 0
+	for _, permit := range permitsDuringMonth {
+		if !permit.StartDate.Before(monthStart) && permit.StartDate.Before(monthEnd) {
+			amtStarting++
+		}
+	}
+
+	if amtStarting >= rule.MaxCount {
+		return newRuleError(rule, "rules.max_permits_per_month", "a resident can have at most %d permits starting in the same month", rule.MaxCount), nil
+	}
+	return nil, nil
+}
+
+func (s PermitRuleService) visitorsPerMonthViolation(ctx context.Context, rule models.PermitRule, desiredVisitor models.Visitor) (*errs.RuleError, error) {
+	monthStart, monthEnd := monthOf(desiredVisitor.AccessStart)
+
+	residentVisitors, err := s.visitorRepo.SelectWhere(ctx, models.Visitor{ResidentID: desiredVisitor.ResidentID})
+	if err != nil {
+		return nil, fmt.Errorf("error getting visitors of resident in visitorRepo: %v", err)
+	}
+
+	amtStarting := 0
+	for _, visitor := range residentVisitors {
+		// a visitor that is being edited doesn't count against itself
+		if visitor.ID == desiredVisitor.ID {
+			continue
+		}
+		if rule.Relationship != "" && !strings.EqualFold(rule.Relationship, visitor.Relationship) {
+			continue
+		}
+		if !visitor.AccessStart.Before(monthStart) && visitor.AccessStart.Before(monthEnd) {
+			amtStarting++
+		}
+	}
+
+	if amtStarting >= rule.MaxCount {
+		return newRuleError(rule, "rules.max_visitors_per_month", "a resident can have at most %d visitors starting in the same month", rule.MaxCount), nil
+	}
+	return nil, nil
+}
+
+// chargedDays counts the days from startDate to endDate the same way as util.GetAmtDays, except that each day
+// starting on a saturday or sunday counts weekendWeight times
+func chargedDays(startDate, endDate time.Time, weekendWeight int) int {
+	days := 0
+	for i := 0; i < util.GetAmtDays(startDate, endDate); i++ {
+		switch startDate.AddDate(0, 0, i).Weekday() {
+		case time.Saturday, time.Sunday:
+			days += weekendWeight
+		default:
+			days++
+		}
+	}
+	return days
+}
+
+func monthOf(date time.Time) (time.Time, time.Time) {
+	monthStart := util.StartOfDay(date).AddDate(0, 0, 1-date.Day())
+	return monthStart, monthStart.AddDate(0, 1, 0)
+}
+
+func newRuleError(rule models.PermitRule, key, format string, params ...any) *errs.RuleError {
+	ruleErr := errs.NewRuleError(rule.Name, string(rule.Kind), key, format, params...)
+	return &ruleErr
+}
+
+func rulesBrokenErr(violations []errs.RuleError) error {
+	if len(violations) == 0 {
+		return nil
+	}
+
+	return errs.NewRulesBroken(violations)
+}
@@ -0,0 +1,27 @@
+package app
+
+import (
+	"testing"
+	"time"
+)
+
+func TestChargedDays(t *testing.T) {
+	// 2027-03-12 is a friday
+	friday := time.Date(2027, time.March, 12, 12, 0, 0, 0, time.Local)
+
+	tests := map[string]struct {
+		startDate     time.Time
+		endDate       time.Time
+		weekendWeight int
+		expected      int
+	}{
+		"weekdays are counted once":            {startDate: friday.AddDate(0, 0, -4), endDate: friday, weekendWeight: 2, expected: 4},
+		"weekend days are counted weighted":    {startDate: friday, endDate: friday.AddDate(0, 0, 3), weekendWeight: 2, expected: 5},
+		"without weight days are counted once": {startDate: friday, endDate: friday.AddDate(0, 0, 3), weekendWeight: 1, expected: 3},
+	}
+	for testName, test := range tests {
+		if result := chargedDays(test.startDate, test.endDate, test.weekendWeight); result != test.expected {
+			t.Errorf("%s failed: expected %d, got %d", testName, test.expected, result)
+		}
+	}
+}
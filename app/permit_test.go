@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/dannyvelas/parkspot-backend/config"
 	"github.com/dannyvelas/parkspot-backend/errs"
+	"github.com/dannyvelas/parkspot-backend/i18n"
 	"github.com/dannyvelas/parkspot-backend/models"
 	"github.com/dannyvelas/parkspot-backend/storage/psql"
 	"github.com/dannyvelas/parkspot-backend/util"
@@ -19,9 +20,12 @@ import (
 
 type permitTestSuite struct {
 	suite.Suite
-	container       testcontainers.Container
-	permitService   PermitService
-	residentService ResidentService
+	container           testcontainers.Container
+	permitService       PermitService
+	residentService     ResidentService
+	banService          BanService
+	parkingSpaceService ParkingSpaceService
+	permitRuleService   PermitRuleService
 
 	// this map is shared between multiple tests so it is kept here
 	desiredPermits map[string]models.Permit
@@ -33,7 +37,7 @@ func TestPermitRouter(t *testing.T) {
 
 func (suite *permitTestSuite) SetupSuite() {
 	// configure and start container
-	container, database, err := psql.NewSandboxDatabase()
+	container, database, err := psql.NewSandboxDatabase(time.Local)
 	if err != nil {
 		suite.T().Fatalf("error getting sandbox database: %v", err)
 	}
@@ -43,22 +47,25 @@ func (suite *permitTestSuite) SetupSuite() {
 	// service dependency
 	carService := NewCarService(database.CarRepo())
 	suite.residentService = NewResidentService(database.ResidentRepo())
-	suite.permitService = NewPermitService(database.PermitRepo(), database.ResidentRepo(), carService)
+	suite.banService = NewBanService(database.BanRepo())
+	suite.parkingSpaceService = NewParkingSpaceService(database.ParkingSpaceRepo(), database.PermitRepo())
+	suite.permitRuleService = NewPermitRuleService(database.PermitRuleRepo(), database.PermitRepo(), database.VisitorRepo(), database.ResidentRepo(), database.ParkingPolicyRepo(), time.Local)
+	suite.permitService = NewPermitService(database.PermitRepo(), database.ResidentRepo(), database.ParkingSpaceRepo(), database.ParkingPolicyRepo(), carService, suite.banService, suite.permitRuleService, time.Local)
 
 	{ // create residents
-		if _, err := suite.residentService.Create(models.TestResident); err != nil {
+		if _, err := suite.residentService.Create(context.Background(), models.TestResident); err != nil {
 			suite.TearDownSuite()
 			suite.T().Fatalf("tearing down because failed to create resident: %v", err)
 		}
 
-		if _, err := suite.residentService.Create(models.TestResidentUnlimDays); err != nil {
+		if _, err := suite.residentService.Create(context.Background(), models.TestResidentUnlimDays); err != nil {
 			suite.TearDownSuite()
 			suite.T().Fatalf("tearing down because failed to create resident: %v", err)
 		}
 	}
 
 	// create car
-	_, err = carService.Create(models.TestCar)
+	_, err = carService.Create(context.Background(), models.TestCar)
 	if err != nil {
 		suite.TearDownSuite()
 		suite.T().Fatalf("tearing down because failed to create resident: %v", err)
@@ -81,14 +88,14 @@ func (suite *permitTestSuite) TearDownSuite() {
 }
 
 func (suite *permitTestSuite) TearDownTest() {
-	err := suite.permitService.permitRepo.Reset()
+	err := suite.permitService.permitRepo.Reset(context.Background())
 	if err != nil {
 		suite.T().Fatalf("encountered error resetting permit repo in-between tests")
 	}
 }
 
 func (suite *permitTestSuite) TestCreate_ResidentMultipleActivePermits() {
-	_, err := suite.permitService.Create(activeFor24Hrs(models.Permit{ResidentID: models.TestResident.ID, CarID: models.TestCar.ID}, 0))
+	_, err := suite.permitService.Create(context.Background(), activeFor24Hrs(models.Permit{ResidentID: models.TestResident.ID, CarID: models.TestCar.ID}, 0))
 	if err != nil {
 		require.NoError(suite.T(), fmt.Errorf("error creating permit before test: %v", err))
 	}
@@ -115,7 +122,7 @@ func (suite *permitTestSuite) TestCreate_ResidentMultipleActivePermits() {
 
 	// see which permit creations succeed/fail
 	for _, test := range tests {
-		_, err := suite.permitService.Create(test.permit)
+		_, err := suite.permitService.Create(context.Background(), test.permit)
 		if err != nil && test.shouldBeOk {
 			suite.NoError(fmt.Errorf("%s failed: Error creating permit when it should've been okay: %v", test.name, err))
 		} else if err == nil && !test.shouldBeOk {
@@ -126,7 +133,7 @@ func (suite *permitTestSuite) TestCreate_ResidentMultipleActivePermits() {
 }
 
 func (suite *permitTestSuite) TestCreate_CarTwoActivePermits() {
-	ogPermit, err := suite.permitService.Create(activeFor24Hrs(models.Permit{ResidentID: models.TestResident.ID, CarID: models.TestCar.ID}, 0))
+	ogPermit, err := suite.permitService.Create(context.Background(), activeFor24Hrs(models.Permit{ResidentID: models.TestResident.ID, CarID: models.TestCar.ID}, 0))
 	if err != nil {
 		require.NoError(suite.T(), fmt.Errorf("error creating permit before test: %v", err))
 	}
@@ -136,7 +143,7 @@ func (suite *permitTestSuite) TestCreate_CarTwoActivePermits() {
 		2,
 	)
 
-	_, err = suite.permitService.Create(permitSameCar)
+	_, err = suite.permitService.Create(context.Background(), permitSameCar)
 	require.NotNil(suite.T(), err)
 	require.ErrorIs(suite.T(), err, errs.CarActivePermit, "expected error to be car active permit")
 }
@@ -151,7 +158,7 @@ func (suite *permitTestSuite) TestCreate_SameLicensePlateDifferentResident_Posit
 		Make:         sharedCarFields.Make,
 		Model:        sharedCarFields.Model,
 	}, 0)
-	_, err := suite.permitService.Create(residentAPermit)
+	_, err := suite.permitService.Create(context.Background(), residentAPermit)
 	require.NoError(suite.T(), err, "failed creating permit for resident A")
 
 	// give resident B's permit a start date well after resident A's permit ends,
@@ -164,17 +171,324 @@ func (suite *permitTestSuite) TestCreate_SameLicensePlateDifferentResident_Posit
 		Make:         sharedCarFields.Make,
 		Model:        sharedCarFields.Model,
 	}, 48)
-	_, err = suite.permitService.Create(residentBPermit)
+	_, err = suite.permitService.Create(context.Background(), residentBPermit)
 	require.NoError(suite.T(), err, "resident B should be able to create a permit for a car with the same licensePlate as resident A's car")
 }
 
+func (suite *permitTestSuite) TestCreate_BannedLicensePlate_Negative() {
+	_, err := suite.banService.Create(context.Background(), models.Ban{LicensePlate: "banned-lp", Reason: "some ban reason", CreatedBy: models.TestAdmin.ID})
+	if err != nil {
+		require.NoError(suite.T(), fmt.Errorf("error creating ban before test: %v", err))
+	}
+	defer func() { _ = suite.banService.banRepo.Reset(context.Background()) }()
+
+	desiredPermit := activeFor24Hrs(models.Permit{
+		ResidentID:   models.TestResident.ID,
+		LicensePlate: "BANNEDLP",
+		Color:        "color",
+		Make:         "make",
+		Model:        "model",
+	}, 0)
+
+	_, err = suite.permitService.Create(context.Background(), desiredPermit)
+	require.NotNil(suite.T(), err)
+	require.ErrorIs(suite.T(), err, errs.BannedLicensePlate, "expected a license plate that is banned to be rejected, regardless of its formatting")
+}
+
+func (suite *permitTestSuite) TestCreate_BannedCarID_Negative() {
+	_, err := suite.banService.Create(context.Background(), models.Ban{LicensePlate: models.TestCar.LicensePlate, Reason: "some ban reason", CreatedBy: models.TestAdmin.ID})
+	if err != nil {
+		require.NoError(suite.T(), fmt.Errorf("error creating ban before test: %v", err))
+	}
+	defer func() { _ = suite.banService.banRepo.Reset(context.Background()) }()
+
+	_, err = suite.permitService.Create(context.Background(), activeFor24Hrs(models.Permit{ResidentID: models.TestResident.ID, CarID: models.TestCar.ID}, 0))
+	require.NotNil(suite.T(), err)
+	require.ErrorIs(suite.T(), err, errs.BannedLicensePlate, "expected a car whose license plate is banned to be rejected")
+}
+
+func (suite *permitTestSuite) TestCreate_LiftedBan_Positive() {
+	expiresAt := time.Now().Add(time.Hour)
+	ban, err := suite.banService.Create(context.Background(), models.Ban{LicensePlate: models.TestCar.LicensePlate, Reason: "some ban reason", CreatedBy: models.TestAdmin.ID, ExpiresAt: &expiresAt})
+	if err != nil {
+		require.NoError(suite.T(), fmt.Errorf("error creating ban before test: %v", err))
+	}
+	defer func() { _ = suite.banService.banRepo.Reset(context.Background()) }()
+
+	// a ban that has not expired yet rejects permits
+	_, err = suite.permitService.Create(context.Background(), activeFor24Hrs(models.Permit{ResidentID: models.TestResident.ID, CarID: models.TestCar.ID}, 0))
+	require.ErrorIs(suite.T(), err, errs.BannedLicensePlate)
+
+	// once the ban is lifted, the permit can be created
+	require.NoError(suite.T(), suite.banService.Delete(context.Background(), ban.ID))
+	_, err = suite.permitService.Create(context.Background(), activeFor24Hrs(models.Permit{ResidentID: models.TestResident.ID, CarID: models.TestCar.ID}, 0))
+	require.NoError(suite.T(), err)
+}
+
+func (suite *permitTestSuite) TestCreate_PolicyMaxActivePermits_Negative() {
+	parkingPolicyRepo := suite.permitService.parkingPolicyRepo
+	err := parkingPolicyRepo.Set(context.Background(), models.ParkingPolicy{
+		MaxParkingDays:   models.DefaultParkingPolicy.MaxParkingDays,
+		MaxPermitLength:  models.DefaultParkingPolicy.MaxPermitLength,
+		MaxActivePermits: 1,
+		LatestEndDate:    models.DefaultParkingPolicy.LatestEndDate,
+	})
+	if err != nil {
+		require.NoError(suite.T(), fmt.Errorf("error setting parking policy before test: %v", err))
+	}
+	defer func() { _ = parkingPolicyRepo.Reset(context.Background()) }()
+
+	_, err = suite.permitService.Create(context.Background(), activeFor24Hrs(models.Permit{ResidentID: models.TestResidentUnlimDays.ID, CarID: models.TestCar.ID}, 0))
+	if err != nil {
+		require.NoError(suite.T(), fmt.Errorf("error creating permit before test: %v", err))
+	}
+
+	secondPermit := activeFor24Hrs(
+		models.Permit{ResidentID: models.TestResidentUnlimDays.ID, LicensePlate: "policy", Color: "color", Make: "make", Model: "model"},
+		2,
+	)
+	_, err = suite.permitService.Create(context.Background(), secondPermit)
+	require.NotNil(suite.T(), err)
+	require.ErrorIs(suite.T(), err, errs.ResidentTooManyActivePermits, "expected the parking policy's active permit limit to be enforced")
+}
+
+func (suite *permitTestSuite) TestCreate_BlackoutDateRule_Negative() {
+	desiredPermit := activeFor24Hrs(models.Permit{ResidentID: models.TestResidentUnlimDays.ID, CarID: models.TestCar.ID}, 0)
+
+	blackoutRule := models.PermitRule{
+		Name:   "no guest parking on holidays",
+		Kind:   models.BlackoutDatesRule,
+		Target: models.PermitTarget,
+		Dates:  []string{desiredPermit.StartDate.Format(config.DateFormat)},
+	}
+	if _, err := suite.permitRuleService.Create(context.Background(), blackoutRule); err != nil {
+		require.NoError(suite.T(), fmt.Errorf("error creating permit rule before test: %v", err))
+	}
+	defer func() { _ = suite.permitRuleService.permitRuleRepo.Reset(context.Background()) }()
+
+	violations, err := suite.permitRuleService.EvaluatePermit(context.Background(), desiredPermit)
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), violations, 1)
+	require.Equal(suite.T(), blackoutRule.Name, violations[0].Rule)
+	require.Equal(suite.T(), string(models.BlackoutDatesRule), violations[0].Kind)
+
+	_, err = suite.permitService.Create(context.Background(), desiredPermit)
+	require.ErrorIs(suite.T(), err, errs.RulesBroken, "expected a permit on a blackout date to be rejected")
+	var apiErr *errs.APIErr
+	require.ErrorAs(suite.T(), err, &apiErr)
+	require.Equal(suite.T(), violations, apiErr.Rules)
+
+	// exceptions are not subject to permit rules
+	desiredPermit.ExceptionReason = "some exception reason"
+	_, err = suite.permitService.Create(context.Background(), desiredPermit)
+	require.NoError(suite.T(), err)
+}
+
+func (suite *permitTestSuite) TestCreate_WeekendWeightRule_Negative() {
+	// a permit of 3 days starting on the next saturday: with weekend days counting double, it uses 5 days
+	now := time.Now()
+	saturday := time.Date(now.Year(), now.Month(), now.Day()+int(time.Saturday-now.Weekday()+7)%7, 12, 0, 0, 0, time.Local)
+	desiredPermit := models.Permit{
+		ResidentID: models.TestResident.ID,
+		CarID:      models.TestCar.ID,
+		StartDate:  saturday,
+		EndDate:    saturday.AddDate(0, 0, 3),
+	}
+
+	// leave the resident with 4 parking days, which is enough for this permit unless weekend days count double
+	resident, err := suite.residentService.GetOne(context.Background(), models.TestResident.ID)
+	require.NoError(suite.T(), err)
+	parkingPolicyRepo := suite.permitService.parkingPolicyRepo
+	err = parkingPolicyRepo.Set(context.Background(), models.ParkingPolicy{
+		MaxParkingDays:   *resident.AmtParkingDaysUsed + 4,
+		MaxPermitLength:  models.DefaultParkingPolicy.MaxPermitLength,
+		MaxActivePermits: models.DefaultParkingPolicy.MaxActivePermits,
+		LatestEndDate:    models.DefaultParkingPolicy.LatestEndDate,
+	})
+	if err != nil {
+		require.NoError(suite.T(), fmt.Errorf("error setting parking policy before test: %v", err))
+	}
+	defer func() { _ = parkingPolicyRepo.Reset(context.Background()) }()
+
+	quote, err := suite.permitService.Quote(context.Background(), desiredPermit, i18n.Default)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), 3, quote.DaysCharged, "expected every day to count once before any permit rule is created")
+
+	_, err = suite.permitRuleService.Create(context.Background(), models.PermitRule{Name: "weekends count double", Kind: models.WeekendWeightRule, Target: models.PermitTarget, Weight: 2})
+	if err != nil {
+		require.NoError(suite.T(), fmt.Errorf("error creating permit rule before test: %v", err))
+	}
+	defer func() { _ = suite.permitRuleService.permitRuleRepo.Reset(context.Background()) }()
+
+	quote, err = suite.permitService.Quote(context.Background(), desiredPermit, i18n.Default)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), 5, quote.DaysCharged, "expected weekend days to count double")
+
+	_, err = suite.permitService.Create(context.Background(), desiredPermit)
+	var apiErr *errs.APIErr
+	require.ErrorAs(suite.T(), err, &apiErr)
+	require.Equal(suite.T(), "resident.days_exceeded", apiErr.Code, "expected the weighted days to go over the resident's parking days")
+}
+
+func (suite *permitTestSuite) TestCreate_WeekendWeightRule_ChargesAndRefundsWeightedDays() {
+	// a permit of 3 days starting on the next saturday: with weekend days counting double, it uses 5 days
+	now := time.Now()
+	saturday := time.Date(now.Year(), now.Month(), now.Day()+int(time.Saturday-now.Weekday()+7)%7, 12, 0, 0, 0, time.Local)
+	desiredPermit := models.Permit{
+		ResidentID: models.TestResident.ID,
+		CarID:      models.TestCar.ID,
+		StartDate:  saturday,
+		EndDate:    saturday.AddDate(0, 0, 3),
+	}
+
+	_, err := suite.permitRuleService.Create(context.Background(), models.PermitRule{Name: "weekends count double", Kind: models.WeekendWeightRule, Target: models.PermitTarget, Weight: 2})
+	if err != nil {
+		require.NoError(suite.T(), fmt.Errorf("error creating permit rule before test: %v", err))
+	}
+	defer func() { _ = suite.permitRuleService.permitRuleRepo.Reset(context.Background()) }()
+
+	residentBefore, err := suite.residentService.GetOne(context.Background(), models.TestResident.ID)
+	require.NoError(suite.T(), err)
+
+	createdPermit, err := suite.permitService.Create(context.Background(), desiredPermit)
+	require.NoError(suite.T(), err)
+
+	residentNow, err := suite.residentService.GetOne(context.Background(), models.TestResident.ID)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), 5, *residentNow.AmtParkingDaysUsed-*residentBefore.AmtParkingDaysUsed, "expected weekend days to be charged twice")
+
+	require.NoError(suite.T(), suite.permitService.Delete(context.Background(), createdPermit.ID))
+	residentNow, err = suite.residentService.GetOne(context.Background(), models.TestResident.ID)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), *residentBefore.AmtParkingDaysUsed, *residentNow.AmtParkingDaysUsed, "expected the weighted days to be refunded")
+}
+
+func (suite *permitTestSuite) TestDelete_WeekendWeightRuleChanged_RefundsDaysCharged() {
+	// a permit of 3 days starting on the next saturday, created before weekend days count double
+	now := time.Now()
+	saturday := time.Date(now.Year(), now.Month(), now.Day()+int(time.Saturday-now.Weekday()+7)%7, 12, 0, 0, 0, time.Local)
+	desiredPermit := models.Permit{
+		ResidentID: models.TestResident.ID,
+		CarID:      models.TestCar.ID,
+		StartDate:  saturday,
+		EndDate:    saturday.AddDate(0, 0, 3),
+	}
+
+	residentBefore, err := suite.residentService.GetOne(context.Background(), models.TestResident.ID)
+	require.NoError(suite.T(), err)
+
+	createdPermit, err := suite.permitService.Create(context.Background(), desiredPermit)
+	require.NoError(suite.T(), err)
+	require.NotNil(suite.T(), createdPermit.DaysCharged)
+	require.Equal(suite.T(), 3, *createdPermit.DaysCharged)
+
+	_, err = suite.permitRuleService.Create(context.Background(), models.PermitRule{Name: "weekends count double", Kind: models.WeekendWeightRule, Target: models.PermitTarget, Weight: 2})
+	if err != nil {
+		require.NoError(suite.T(), fmt.Errorf("error creating permit rule before test: %v", err))
+	}
+	defer func() { _ = suite.permitRuleService.permitRuleRepo.Reset(context.Background()) }()
+
+	require.NoError(suite.T(), suite.permitService.Delete(context.Background(), createdPermit.ID))
+	residentNow, err := suite.residentService.GetOne(context.Background(), models.TestResident.ID)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), *residentBefore.AmtParkingDaysUsed, *residentNow.AmtParkingDaysUsed, "expected the days that the permit was charged to be refunded, not the days of the new rule")
+}
+
+func (suite *permitTestSuite) TestQuote_MultipleFailures() {
+	for _, offset := range []time.Duration{0, 2} {
+		desiredPermit := activeFor24Hrs(models.Permit{ResidentID: models.TestResidentUnlimDays.ID, LicensePlate: fmt.Sprintf("quote%d", offset), Color: "color", Make: "make", Model: "model"}, offset)
+		if _, err := suite.permitService.Create(context.Background(), desiredPermit); err != nil {
+			require.NoError(suite.T(), fmt.Errorf("error creating permit before test: %v", err))
+		}
+	}
+
+	residentBefore, err := suite.residentService.GetOne(context.Background(), models.TestResidentUnlimDays.ID)
+	require.NoError(suite.T(), err)
+
+	// too long, and during the other two permits of the resident
+	desiredPermit := models.Permit{ResidentID: models.TestResidentUnlimDays.ID, LicensePlate: "quote", Color: "color", Make: "make", Model: "model"}
+	desiredPermit.StartDate = time.Now().Truncate(time.Second)
+	desiredPermit.EndDate = desiredPermit.StartDate.AddDate(0, 0, models.DefaultParkingPolicy.MaxPermitLength+1)
+
+	quote, err := suite.permitService.Quote(context.Background(), desiredPermit, i18n.Default)
+	require.NoError(suite.T(), err)
+	require.False(suite.T(), quote.Valid)
+	require.Len(suite.T(), quote.Failures, 2, "expected both the length and the active permits of the resident to fail")
+	require.Equal(suite.T(), errs.PermitTooLong.Code, quote.Failures[0].Code)
+	require.Equal(suite.T(), errs.ResidentTooManyActivePermits.Code, quote.Failures[1].Code)
+	require.Nil(suite.T(), quote.DaysRemaining, "expected a resident with unlimited days to not have remaining days")
+
+	residentNow, err := suite.residentService.GetOne(context.Background(), models.TestResidentUnlimDays.ID)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), residentBefore, residentNow, "expected a quote to not change the resident")
+
+	_, err = suite.permitService.carService.GetOneByLicensePlate(context.Background(), "quote")
+	require.ErrorIs(suite.T(), err, errs.NotFound, "expected a quote to not create a car")
+}
+
+func (suite *permitTestSuite) TestCreate_LotAtCapacity_Negative() {
+	_, err := suite.parkingSpaceService.Create(context.Background(), models.ParkingSpace{Zone: "guest", Label: "1"})
+	if err != nil {
+		require.NoError(suite.T(), fmt.Errorf("error creating parking space before test: %v", err))
+	}
+	defer func() { _ = suite.parkingSpaceService.parkingSpaceRepo.Reset(context.Background()) }()
+
+	_, err = suite.permitService.Create(context.Background(), activeFor24Hrs(models.Permit{ResidentID: models.TestResidentUnlimDays.ID, CarID: models.TestCar.ID}, 0))
+	if err != nil {
+		require.NoError(suite.T(), fmt.Errorf("error creating permit before test: %v", err))
+	}
+
+	overlappingPermit := activeFor24Hrs(
+		models.Permit{ResidentID: models.TestResidentUnlimDays.ID, LicensePlate: "capacity", Color: "color", Make: "make", Model: "model"},
+		2,
+	)
+	_, err = suite.permitService.Create(context.Background(), overlappingPermit)
+	require.NotNil(suite.T(), err)
+	require.ErrorIs(suite.T(), err, errs.LotAtCapacity, "expected a permit to be rejected when every guest space is taken")
+
+	availability, err := suite.parkingSpaceService.GetAvailability(context.Background(), time.Now(), time.Now().Add(time.Hour))
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), availability, 1)
+	require.Equal(suite.T(), 0, availability[0].Free, "expected no free spaces today")
+}
+
+func (suite *permitTestSuite) TestCreate_SpaceTaken_Negative() {
+	space, err := suite.parkingSpaceService.Create(context.Background(), models.ParkingSpace{Zone: "guest", Label: "1"})
+	if err != nil {
+		require.NoError(suite.T(), fmt.Errorf("error creating parking space before test: %v", err))
+	}
+	if _, err := suite.parkingSpaceService.Create(context.Background(), models.ParkingSpace{Zone: "guest", Label: "2"}); err != nil {
+		require.NoError(suite.T(), fmt.Errorf("error creating parking space before test: %v", err))
+	}
+	defer func() { _ = suite.parkingSpaceService.parkingSpaceRepo.Reset(context.Background()) }()
+
+	createdPermit, err := suite.permitService.Create(context.Background(), activeFor24Hrs(models.Permit{ResidentID: models.TestResidentUnlimDays.ID, CarID: models.TestCar.ID, SpaceID: space.ID}, 0))
+	if err != nil {
+		require.NoError(suite.T(), fmt.Errorf("error creating permit before test: %v", err))
+	}
+	require.Equal(suite.T(), space.ID, createdPermit.SpaceID, "expected permit to be assigned the parking space that was requested")
+
+	overlappingPermit := activeFor24Hrs(
+		models.Permit{ResidentID: models.TestResidentUnlimDays.ID, LicensePlate: "spacetaken", Color: "color", Make: "make", Model: "model", SpaceID: space.ID},
+		2,
+	)
+	_, err = suite.permitService.Create(context.Background(), overlappingPermit)
+	require.NotNil(suite.T(), err)
+	require.ErrorIs(suite.T(), err, errs.SpaceTaken, "expected a permit to be rejected when its parking space is assigned to another permit")
+
+	// without asking for that specific space, there is still one space free
+	overlappingPermit.SpaceID = ""
+	_, err = suite.permitService.Create(context.Background(), overlappingPermit)
+	require.NoError(suite.T(), err)
+}
+
 func (suite *permitTestSuite) TestCreate_MalformedCarID_Negative() {
 	desiredPermit := activeFor24Hrs(models.Permit{
 		ResidentID: models.TestResident.ID,
 		CarID:      "not-a-uuid",
 	}, 0)
 
-	_, err := suite.permitService.Create(desiredPermit)
+	_, err := suite.permitService.Create(context.Background(), desiredPermit)
 	require.NotNil(suite.T(), err)
 
 	var apiErr *errs.APIErr
@@ -192,7 +506,7 @@ func (suite *permitTestSuite) TestCreate_NonexistentCarID_Negative() {
 		Model:        "model",
 	}, 0)
 
-	_, err := suite.permitService.Create(desiredPermit)
+	_, err := suite.permitService.Create(context.Background(), desiredPermit)
 	require.NotNil(suite.T(), err)
 	require.ErrorIs(suite.T(), err, errs.CarForPermitDNE, "expected a nonexistent CarID to error out instead of silently creating an unrelated car")
 }
@@ -207,7 +521,7 @@ func (suite *permitTestSuite) TestCreate_CarInvalidFields() {
 		Model:        "m*del",
 	}
 
-	_, err := suite.permitService.Create(activeFor24Hrs(desiredPermit, 0))
+	_, err := suite.permitService.Create(context.Background(), activeFor24Hrs(desiredPermit, 0))
 	require.NotNil(suite.T(), err)
 
 	var apiErr *errs.APIErr
@@ -224,7 +538,7 @@ func (suite *permitTestSuite) TestCreate_NoStartNoEnd_ErrMissing() {
 	}
 
 	var apiErr *errs.APIErr
-	_, err := suite.permitService.Create(desiredPermit)
+	_, err := suite.permitService.Create(context.Background(), desiredPermit)
 	require.ErrorAs(suite.T(), err, &apiErr, "expected error to be instance of apiErr")
 
 	suite.Equal(http.StatusBadRequest, apiErr.StatusCode)
@@ -232,17 +546,17 @@ func (suite *permitTestSuite) TestCreate_NoStartNoEnd_ErrMissing() {
 
 func (suite *permitTestSuite) TestCreate_AddsResDays() {
 	for testName, desiredPermit := range suite.desiredPermits {
-		residentBefore, err := suite.residentService.GetOne(desiredPermit.ResidentID)
+		residentBefore, err := suite.residentService.GetOne(context.Background(), desiredPermit.ResidentID)
 		if err != nil {
 			require.NoError(suite.T(), fmt.Errorf("%s failed: %v", testName, err))
 		}
 
-		createdPermit, err := suite.permitService.Create(desiredPermit)
+		createdPermit, err := suite.permitService.Create(context.Background(), desiredPermit)
 		if err != nil {
 			require.NoError(suite.T(), fmt.Errorf("%s failed: %v", testName, err))
 		}
 
-		residentNow, err := suite.residentService.GetOne(desiredPermit.ResidentID)
+		residentNow, err := suite.residentService.GetOne(context.Background(), desiredPermit.ResidentID)
 		if err != nil {
 			require.NoError(suite.T(), fmt.Errorf("%s failed: %v", testName, err))
 		}
@@ -259,31 +573,89 @@ func (suite *permitTestSuite) TestCreate_AddsResDays() {
 			require.NoError(suite.T(), err)
 		}
 
-		err = suite.permitService.Delete(createdPermit.ID)
+		err = suite.permitService.Delete(context.Background(), createdPermit.ID)
 		if err != nil {
 			require.NoError(suite.T(), fmt.Errorf("%s failed: deleting test permit failed: %v", testName, err))
 		}
 	}
 }
 
+func (suite *permitTestSuite) TestCreate_AcrossDSTTransition_AddsCalendarDays() {
+	miami, err := time.LoadLocation("America/New_York")
+	require.NoError(suite.T(), err)
+
+	// from the 13th to the 15th is 47 hours in miami, because clocks spring forward on the 14th
+	desiredPermit := models.Permit{
+		ResidentID: models.TestResident.ID,
+		CarID:      models.TestCar.ID,
+		StartDate:  time.Date(2027, time.March, 13, 0, 0, 0, 0, miami),
+		EndDate:    time.Date(2027, time.March, 15, 0, 0, 0, 0, miami),
+	}
+
+	// the community is in miami
+	permitService := suite.permitService
+	permitService.location, permitService.permitRuleService.location = miami, miami
+
+	residentBefore, err := suite.residentService.GetOne(context.Background(), models.TestResident.ID)
+	require.NoError(suite.T(), err)
+
+	createdPermit, err := permitService.Create(context.Background(), desiredPermit)
+	require.NoError(suite.T(), err)
+	defer func() { _ = permitService.Delete(context.Background(), createdPermit.ID) }()
+
+	residentNow, err := suite.residentService.GetOne(context.Background(), models.TestResident.ID)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), 2, *residentNow.AmtParkingDaysUsed-*residentBefore.AmtParkingDaysUsed, "expected a permit from the 13th to the 15th to use 2 days")
+	// a permit is expired once two days of the community's calendar have passed since the day that it ended
+	require.False(suite.T(), createdPermit.IsExpiredAt(time.Date(2027, time.March, 16, 23, 59, 59, 0, miami), miami))
+	require.True(suite.T(), createdPermit.IsExpiredAt(time.Date(2027, time.March, 17, 0, 0, 0, 0, miami), miami))
+}
+
+func (suite *permitTestSuite) TestDelete_AcrossDSTTransition_SubtractsCalendarDays() {
+	miami, err := time.LoadLocation("America/New_York")
+	require.NoError(suite.T(), err)
+
+	// from the 13th to the 15th is 47 hours in miami, because clocks spring forward on the 14th
+	desiredPermit := models.Permit{
+		ResidentID: models.TestResident.ID,
+		CarID:      models.TestCar.ID,
+		StartDate:  time.Date(2027, time.March, 13, 0, 0, 0, 0, miami),
+		EndDate:    time.Date(2027, time.March, 15, 0, 0, 0, 0, miami),
+	}
+
+	permitService := suite.permitService
+	permitService.location, permitService.permitRuleService.location = miami, miami
+
+	residentBefore, err := suite.residentService.GetOne(context.Background(), models.TestResident.ID)
+	require.NoError(suite.T(), err)
+
+	createdPermit, err := permitService.Create(context.Background(), desiredPermit)
+	require.NoError(suite.T(), err)
+	require.NoError(suite.T(), permitService.Delete(context.Background(), createdPermit.ID))
+
+	residentNow, err := suite.residentService.GetOne(context.Background(), models.TestResident.ID)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), *residentBefore.AmtParkingDaysUsed, *residentNow.AmtParkingDaysUsed, "expected deleting a permit to give back the days that creating it used")
+}
+
 func (suite *permitTestSuite) TestDelete_SubtractsResDays() {
 	for testName, desiredPermit := range suite.desiredPermits {
-		residentBefore, err := suite.residentService.GetOne(desiredPermit.ResidentID)
+		residentBefore, err := suite.residentService.GetOne(context.Background(), desiredPermit.ResidentID)
 		if err != nil {
 			require.NoError(suite.T(), fmt.Errorf("%s failed: %v", testName, err))
 		}
 
-		createdPermit, err := suite.permitService.Create(desiredPermit)
+		createdPermit, err := suite.permitService.Create(context.Background(), desiredPermit)
 		if err != nil {
 			require.NoError(suite.T(), fmt.Errorf("%s failed: %v", testName, err))
 		}
 
-		err = suite.permitService.Delete(createdPermit.ID)
+		err = suite.permitService.Delete(context.Background(), createdPermit.ID)
 		if err != nil {
 			require.NoError(suite.T(), fmt.Errorf("%s failed: %v", testName, err))
 		}
 
-		residentNow, err := suite.residentService.GetOne(desiredPermit.ResidentID)
+		residentNow, err := suite.residentService.GetOne(context.Background(), desiredPermit.ResidentID)
 		if err != nil {
 			require.NoError(suite.T(), fmt.Errorf("%s failed: %v", testName, err))
 		}
@@ -304,12 +676,12 @@ func (suite *permitTestSuite) TestCreate_AllFieldsMatch() {
 }
 
 func (suite *permitTestSuite) TestGetActivePermitsOfResident_Postive() {
-	createdPermit, err := suite.permitService.Create(activeFor24Hrs(models.Permit{ResidentID: models.TestResident.ID, CarID: models.TestCar.ID}, 0))
+	createdPermit, err := suite.permitService.Create(context.Background(), activeFor24Hrs(models.Permit{ResidentID: models.TestResident.ID, CarID: models.TestCar.ID}, 0))
 	if err != nil {
 		require.NoError(suite.T(), fmt.Errorf("error creating permit before test: %v", err))
 	}
 
-	permits, err := suite.permitService.GetAll(models.ActiveStatus, config.MaxLimit, 0, true, "", models.TestResident.ID)
+	permits, err := suite.permitService.GetAll(context.Background(), models.ActiveStatus, ListParams{Limit: config.MaxLimit, Reversed: true}, models.TestResident.ID)
 	require.NoError(suite.T(), err)
 	require.NotEmpty(suite.T(), permits.Records, "length of permits should not be zero")
 
@@ -322,17 +694,17 @@ func (suite *permitTestSuite) TestGetActivePermitsOfResident_Postive() {
 }
 
 func (suite *permitTestSuite) TestGetMaxExceptions_Positive() {
-	createdPermit, err := suite.permitService.Create(activeFor24Hrs(models.Permit{ResidentID: models.TestResident.ID, CarID: models.TestCar.ID, ExceptionReason: "an exception reason here"}, 0))
+	createdPermit, err := suite.permitService.Create(context.Background(), activeFor24Hrs(models.Permit{ResidentID: models.TestResident.ID, CarID: models.TestCar.ID, ExceptionReason: "an exception reason here"}, 0))
 	if err != nil {
 		require.NoError(suite.T(), fmt.Errorf("error creating permit before test: %v", err))
 	}
 
-	permits, err := suite.permitService.GetAll(models.ExceptionStatus, config.MaxLimit, 0, true, "", models.TestResident.ID)
+	permits, err := suite.permitService.GetAll(context.Background(), models.ExceptionStatus, ListParams{Limit: config.MaxLimit, Reversed: true}, models.TestResident.ID)
 	require.NoError(suite.T(), err)
 	require.NotEmpty(suite.T(), permits.Records, "length of permits should not be zero")
 
-	if permits.Metadata.TotalAmount < config.MaxLimit {
-		suite.Equal(permits.Metadata.TotalAmount, len(permits.Records), "The amount of records reported in metadata is lower than limit, so the amount of records in the payload should be equal to metadata.totalAmount")
+	if *permits.Metadata.TotalAmount < config.MaxLimit {
+		suite.Equal(*permits.Metadata.TotalAmount, len(permits.Records), "The amount of records reported in metadata is lower than limit, so the amount of records in the payload should be equal to metadata.totalAmount")
 	}
 
 	last := permits.Records[len(permits.Records)-1]
@@ -345,17 +717,17 @@ func (suite *permitTestSuite) TestGetMaxExceptions_Positive() {
 
 func (suite *permitTestSuite) TestGetMaxExpired_Positive() {
 	const twentyOneDays = 21 * 24
-	createdPermit, err := suite.permitService.Create(activeFor24Hrs(models.Permit{ResidentID: models.TestResident.ID, CarID: models.TestCar.ID}, -twentyOneDays))
+	createdPermit, err := suite.permitService.Create(context.Background(), activeFor24Hrs(models.Permit{ResidentID: models.TestResident.ID, CarID: models.TestCar.ID}, -twentyOneDays))
 	if err != nil {
 		require.NoError(suite.T(), fmt.Errorf("error creating permit before test: %v", err))
 	}
 
-	permits, err := suite.permitService.GetAll(models.ExpiredStatus, config.MaxLimit, 0, true, "", models.TestResident.ID)
+	permits, err := suite.permitService.GetAll(context.Background(), models.ExpiredStatus, ListParams{Limit: config.MaxLimit, Reversed: true}, models.TestResident.ID)
 	require.NoError(suite.T(), err)
 	require.NotEmpty(suite.T(), permits.Records, "length of permits should not be zero")
 
-	if permits.Metadata.TotalAmount < config.MaxLimit {
-		suite.Equal(permits.Metadata.TotalAmount, len(permits.Records), "The amount of records reported in metadata is lower than limit, so the amount of records in the payload should be equal to metadata.totalAmount")
+	if *permits.Metadata.TotalAmount < config.MaxLimit {
+		suite.Equal(*permits.Metadata.TotalAmount, len(permits.Records), "The amount of records reported in metadata is lower than limit, so the amount of records in the payload should be equal to metadata.totalAmount")
 	}
 
 	last := permits.Records[len(permits.Records)-1]
@@ -0,0 +1,83 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dannyvelas/parkspot-backend/config"
+	"github.com/dannyvelas/parkspot-backend/errs"
+	"github.com/dannyvelas/parkspot-backend/models"
+	"github.com/dannyvelas/parkspot-backend/storage"
+	"github.com/dannyvelas/parkspot-backend/storage/selectopts"
+	"github.com/dannyvelas/parkspot-backend/util"
+)
+
+type PlateService struct {
+	carRepo    storage.CarRepo
+	permitRepo storage.PermitRepo
+	banService BanService
+}
+
+func NewPlateService(carRepo storage.CarRepo, permitRepo storage.PermitRepo, banService BanService) PlateService {
+	return PlateService{
+		carRepo:    carRepo,
+		permitRepo: permitRepo,
+		banService: banService,
+	}
+}
+
+// GetStatus reports whether a car with licensePlate is allowed to be parked on the property right now.
+// licensePlate is compared after normalizing it with util.NormalizeLicensePlate
+func (s PlateService) GetStatus(ctx context.Context, licensePlate string) (models.PlateStatus, error) {
+	normalized := util.NormalizeLicensePlate(licensePlate)
+	if normalized == "" {
+		return models.PlateStatus{}, errs.EmptyFields("licensePlate")
+	}
+
+	plateStatus := models.PlateStatus{LicensePlate: normalized, Status: models.PlateUnknown}
+
+	cars, err := s.carRepo.SelectWhere(ctx, models.Car{}, selectopts.WithLicensePlate(normalized))
+	if err != nil {
+		return models.PlateStatus{}, fmt.Errorf("plate_service.GetStatus: error getting cars by licensePlate: %v", err)
+	} else if len(cars) != 0 {
+		plateStatus.Car = &cars[0]
+	}
+
+	now := time.Now()
+	permits, err := s.permitRepo.SelectWhere(ctx, models.Permit{},
+		selectopts.WithLicensePlate(normalized),
+		selectopts.WithDateIntersect(now, now.Add(config.ExpectedPermitWindow)),
+	)
+	if err != nil {
+		return models.PlateStatus{}, fmt.Errorf("plate_service.GetStatus: error getting permits by licensePlate: %v", err)
+	}
+	for i := range permits {
+		if permits[i].StartDate.After(now) {
+			if plateStatus.UpcomingPermit == nil {
+				plateStatus.UpcomingPermit = &permits[i]
+			}
+		} else if permits[i].IsActiveAt(now) && plateStatus.ActivePermit == nil {
+			plateStatus.ActivePermit = &permits[i]
+		}
+	}
+
+	ban, err := s.banService.GetActiveOfLicensePlate(ctx, normalized)
+	if err != nil {
+		return models.PlateStatus{}, fmt.Errorf("plate_service.GetStatus: error getting ban of licensePlate: %v", err)
+	}
+	plateStatus.Ban = ban
+
+	// a ban takes priority over any permit or car that the license plate has
+	if plateStatus.Ban != nil {
+		plateStatus.Status = models.PlateBanned
+	} else if plateStatus.ActivePermit != nil {
+		plateStatus.Status = models.PlatePermitted
+	} else if plateStatus.Car != nil {
+		plateStatus.Status = models.PlateResidentCar
+	} else if plateStatus.UpcomingPermit != nil {
+		plateStatus.Status = models.PlateExpected
+	}
+
+	return plateStatus, nil
+}
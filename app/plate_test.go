@@ -0,0 +1,105 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dannyvelas/parkspot-backend/errs"
+	"github.com/dannyvelas/parkspot-backend/models"
+	"github.com/dannyvelas/parkspot-backend/storage/memory"
+	"github.com/dannyvelas/parkspot-backend/util"
+	"github.com/stretchr/testify/suite"
+)
+
+type plateTestSuite struct {
+	suite.Suite
+	database     memory.Database
+	banService   BanService
+	plateService PlateService
+	resident     models.Resident
+}
+
+func TestPlateService(t *testing.T) {
+	suite.Run(t, new(plateTestSuite))
+}
+
+func (suite *plateTestSuite) SetupTest() {
+	suite.database = memory.NewDatabase(time.Local)
+	suite.resident = models.Resident{ID: "B1234567", FirstName: "john", LastName: "smith", Email: "john@example.com", UnlimDays: util.ToPtr(false), AmtParkingDaysUsed: util.ToPtr(0)}
+	suite.Require().NoError(suite.database.ResidentRepo().Create(context.Background(), suite.resident))
+
+	suite.banService = NewBanService(suite.database.BanRepo())
+	suite.plateService = NewPlateService(suite.database.CarRepo(), suite.database.PermitRepo(), suite.banService)
+}
+
+func (suite *plateTestSuite) createPermit(licensePlate string, startDate, endDate time.Time) {
+	_, err := suite.database.PermitRepo().Create(context.Background(), models.Permit{
+		ResidentID:   suite.resident.ID,
+		LicensePlate: licensePlate,
+		StartDate:    startDate,
+		EndDate:      endDate,
+	})
+	suite.Require().NoError(err, "error creating permit of %s", licensePlate)
+}
+
+func (suite *plateTestSuite) requireStatus(licensePlate string, expected models.PlateStatusKind) models.PlateStatus {
+	plateStatus, err := suite.plateService.GetStatus(context.Background(), licensePlate)
+	suite.Require().NoError(err)
+	suite.Require().Equal(expected, plateStatus.Status, "status of %s", licensePlate)
+	return plateStatus
+}
+
+func (suite *plateTestSuite) TestGetStatus_Precedence() {
+	now := time.Now()
+	suite.requireStatus("ABC123", models.PlateUnknown)
+
+	// a permit that starts soon makes its car expected
+	suite.createPermit("ABC123", now.Add(time.Hour), now.Add(48*time.Hour))
+	plateStatus := suite.requireStatus("ABC123", models.PlateExpected)
+	suite.NotNil(plateStatus.UpcomingPermit)
+
+	// a resident's car is known, even if it only has a permit that starts soon
+	_, err := suite.database.CarRepo().Create(context.Background(), models.Car{ResidentID: suite.resident.ID, LicensePlate: "ABC123", Color: "red"})
+	suite.Require().NoError(err)
+	plateStatus = suite.requireStatus("ABC123", models.PlateResidentCar)
+	suite.NotNil(plateStatus.Car)
+
+	suite.createPermit("ABC123", now.Add(-time.Hour), now.Add(time.Hour))
+	plateStatus = suite.requireStatus("ABC123", models.PlatePermitted)
+	suite.NotNil(plateStatus.ActivePermit)
+
+	// a ban takes priority over everything else
+	_, err = suite.banService.Create(context.Background(), models.Ban{LicensePlate: "ABC123", Reason: "towed twice", CreatedBy: "security"})
+	suite.Require().NoError(err)
+	plateStatus = suite.requireStatus("ABC123", models.PlateBanned)
+	suite.NotNil(plateStatus.Ban)
+	suite.NotNil(plateStatus.ActivePermit)
+	suite.NotNil(plateStatus.Car)
+}
+
+func (suite *plateTestSuite) TestGetStatus_PermitsOutsideOfWindow() {
+	now := time.Now()
+	suite.createPermit("ABC123", now.Add(-48*time.Hour), now.Add(-24*time.Hour))
+	suite.createPermit("ABC123", now.Add(72*time.Hour), now.Add(96*time.Hour))
+
+	// permits that ended or that don't start soon don't make a car permitted or expected
+	plateStatus := suite.requireStatus("ABC123", models.PlateUnknown)
+	suite.Nil(plateStatus.ActivePermit)
+	suite.Nil(plateStatus.UpcomingPermit)
+}
+
+func (suite *plateTestSuite) TestGetStatus_NormalizesLicensePlate() {
+	_, err := suite.database.CarRepo().Create(context.Background(), models.Car{ResidentID: suite.resident.ID, LicensePlate: "ABC123", Color: "red"})
+	suite.Require().NoError(err)
+
+	for _, licensePlate := range []string{"ABC123", "abc123", "abc-123", " ABC 123 ", "AbC-I23"} {
+		plateStatus := suite.requireStatus(licensePlate, models.PlateResidentCar)
+		suite.Equal("ABC123", plateStatus.LicensePlate)
+	}
+
+	_, err = suite.plateService.GetStatus(context.Background(), " - ")
+	var apiErr *errs.APIErr
+	suite.Require().ErrorAs(err, &apiErr)
+	suite.Equal("request.missing_fields", apiErr.Code)
+}
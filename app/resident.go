@@ -1,6 +1,7 @@
 package app
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/dannyvelas/parkspot-backend/errs"
@@ -21,30 +22,49 @@ func NewResidentService(residentRepo storage.ResidentRepo) ResidentService {
 	}
 }
 
-func (s ResidentService) GetAll(limit, page int, search string) (models.ListWithMetadata[models.Resident], error) {
-	boundedLimit, offset := getBoundedLimitAndOffset(limit, page)
+func (s ResidentService) GetAll(ctx context.Context, params ListParams) (models.ListWithMetadata[models.Resident], error) {
+	paging, err := newPaging(s.residentRepo, params)
+	if err != nil {
+		return models.ListWithMetadata[models.Resident]{}, err
+	}
 
-	allResidents, err := s.residentRepo.SelectWhere(models.Resident{},
-		selectopts.WithLimitAndOffset(boundedLimit, offset),
-		selectopts.WithSearch(search),
+	allResidents, err := s.residentRepo.SelectWhere(ctx, models.Resident{},
+		paging.selectOpts()...,
 	)
 	if err != nil {
 		return models.ListWithMetadata[models.Resident]{}, fmt.Errorf("resident_service.getAll: Error querying residentRepo: %v", err)
 	}
 
-	totalAmount, err := s.residentRepo.SelectCountWhere(models.Resident{}, selectopts.WithSearch(search))
-	if err != nil {
-		return models.ListWithMetadata[models.Resident]{}, fmt.Errorf("resident_service.getAll: Error getting total amount: %v", err)
+	var totalAmount *int
+	if !paging.byCursor() {
+		count, err := s.residentRepo.SelectCountWhere(ctx, models.Resident{}, paging.countOpts()...)
+		if err != nil {
+			return models.ListWithMetadata[models.Resident]{}, fmt.Errorf("resident_service.getAll: Error getting total amount: %v", err)
+		}
+		totalAmount = &count
+	}
+
+	return newPagedList(paging, allResidents, func(resident models.Resident) string { return resident.ID }, totalAmount), nil
+}
+
+// Export calls each with every resident that GetAll lists with params, on every page
+func (s ResidentService) Export(ctx context.Context, params ListParams, each func(models.Resident) error) error {
+	selectPage := func(opts []selectopts.SelectOpt) ([]models.Resident, error) {
+		residents, err := s.residentRepo.SelectWhere(ctx, models.Resident{}, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("resident_service.export: Error querying residentRepo: %v", err)
+		}
+		return residents, nil
 	}
 
-	return models.NewListWithMetadata(allResidents, totalAmount), nil
+	return forEachRow(s.residentRepo, params, selectPage, func(resident models.Resident) string { return resident.ID }, each)
 }
 
-func (s ResidentService) GetOne(id string) (models.Resident, error) {
+func (s ResidentService) GetOne(ctx context.Context, id string) (models.Resident, error) {
 	if id == "" {
 		return models.Resident{}, errs.MissingIDField
 	}
-	residents, err := s.residentRepo.SelectWhere(models.Resident{ID: id})
+	residents, err := s.residentRepo.SelectWhere(ctx, models.Resident{ID: id})
 	if err != nil {
 		return models.Resident{}, err
 	} else if len(residents) == 0 {
@@ -55,15 +75,15 @@ func (s ResidentService) GetOne(id string) (models.Resident, error) {
 	return resident, nil
 }
 
-func (s ResidentService) Update(desiredResident models.Resident) (models.Resident, error) {
+func (s ResidentService) Update(ctx context.Context, desiredResident models.Resident) (models.Resident, error) {
 	if desiredResident.ID == "" {
 		return models.Resident{}, errs.MissingIDField
 	}
 	// this check goes here; not in `validator.EditResident` bc this err is mut. exclusive w those errs
 	if desiredResident.FirstName == "" && desiredResident.LastName == "" &&
 		desiredResident.Phone == "" && desiredResident.Email == "" && desiredResident.Password == "" &&
-		desiredResident.UnlimDays == nil && desiredResident.AmtParkingDaysUsed == nil {
-		return models.Resident{}, errs.AllEditFieldsEmpty("firstName, lastName, phone, email, unlimDays, amtParkingDaysUsed")
+		desiredResident.UnlimDays == nil && desiredResident.AmtParkingDaysUsed == nil && desiredResident.PreferredLang == "" {
+		return models.Resident{}, errs.AllEditFieldsEmpty("firstName, lastName, phone, email, unlimDays, amtParkingDaysUsed, preferredLang")
 	}
 
 	if err := validator.EditResident.Run(desiredResident); err != nil {
@@ -79,12 +99,12 @@ func (s ResidentService) Update(desiredResident models.Resident) (models.Residen
 		desiredResident.Password = string(hashBytes)
 	}
 
-	err := s.residentRepo.Update(desiredResident)
+	err := s.residentRepo.Update(ctx, desiredResident)
 	if err != nil {
 		return models.Resident{}, fmt.Errorf("residentService.Update: Error updating resident: %w", err)
 	}
 
-	resident, err := s.GetOne(desiredResident.ID)
+	resident, err := s.GetOne(ctx, desiredResident.ID)
 	if err != nil {
 		return models.Resident{}, err
 	}
@@ -92,26 +112,26 @@ func (s ResidentService) Update(desiredResident models.Resident) (models.Residen
 	return resident, nil
 }
 
-func (s ResidentService) Delete(id string) error {
+func (s ResidentService) Delete(ctx context.Context, id string) error {
 	if id == "" {
 		return errs.MissingIDField
 	}
 
-	return s.residentRepo.Delete(id)
+	return s.residentRepo.Delete(ctx, id)
 }
 
-func (s ResidentService) Create(desiredRes models.Resident) (models.Resident, error) {
+func (s ResidentService) Create(ctx context.Context, desiredRes models.Resident) (models.Resident, error) {
 	if err := validator.CreateResident.Run(desiredRes); err != nil {
 		return models.Resident{}, err
 	}
 
-	if residents, err := s.residentRepo.SelectWhere(models.Resident{ID: desiredRes.ID}); err != nil {
+	if residents, err := s.residentRepo.SelectWhere(ctx, models.Resident{ID: desiredRes.ID}); err != nil {
 		return models.Resident{}, fmt.Errorf("resident_service.createResident: error getting resident by id: %v", err)
 	} else if len(residents) != 0 {
 		return models.Resident{}, errs.NewAlreadyExists("a resident with ID: " + desiredRes.ID)
 	}
 
-	if residents, err := s.residentRepo.SelectWhere(models.Resident{Email: desiredRes.Email}); err != nil {
+	if residents, err := s.residentRepo.SelectWhere(ctx, models.Resident{Email: desiredRes.Email}); err != nil {
 		return models.Resident{}, fmt.Errorf("resident_service.createResident error getting resident by email: %v", err)
 	} else if len(residents) != 0 {
 		return models.Resident{}, errs.NewAlreadyExists("a resident with this email: " + desiredRes.Email)
@@ -124,12 +144,12 @@ func (s ResidentService) Create(desiredRes models.Resident) (models.Resident, er
 	hashString := string(hashBytes)
 
 	desiredRes.Password = hashString
-	err = s.residentRepo.Create(desiredRes)
+	err = s.residentRepo.Create(ctx, desiredRes)
 	if err != nil {
 		return models.Resident{}, fmt.Errorf("resident_service.createResident: Error querying residentRepo: %v", err)
 	}
 
-	createdRes, err := s.GetOne(desiredRes.ID)
+	createdRes, err := s.GetOne(ctx, desiredRes.ID)
 	if err != nil {
 		return models.Resident{}, fmt.Errorf("error getting resident which was just created: %w", err)
 	}
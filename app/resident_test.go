@@ -1,16 +1,18 @@
 package app
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"github.com/dannyvelas/parkspot-backend/errs"
 	"github.com/dannyvelas/parkspot-backend/models"
-	"github.com/dannyvelas/parkspot-backend/storage"
+	"github.com/dannyvelas/parkspot-backend/storage/memory"
 	"github.com/dannyvelas/parkspot-backend/util"
 	"github.com/imdario/mergo"
 	"github.com/stretchr/testify/suite"
 	"net/http"
 	"testing"
+	"time"
 )
 
 type residentTestSuite struct {
@@ -23,12 +25,11 @@ func TestResidentService(t *testing.T) {
 }
 
 func (suite *residentTestSuite) SetupSuite() {
-	residentRepo := storage.NewResidentRepoMock()
-	suite.residentService = NewResidentService(&residentRepo)
+	suite.residentService = NewResidentService(memory.NewDatabase(time.Local).ResidentRepo())
 }
 
 func (suite *residentTestSuite) TearDownTest() {
-	suite.residentService.residentRepo.Reset()
+	suite.residentService.residentRepo.Reset(context.Background())
 }
 
 func (suite *residentTestSuite) TestCreate_ResidentDuplicateEmail_Negative() {
@@ -51,13 +52,13 @@ func (suite *residentTestSuite) TestCreate_ResidentDuplicateEmail_Negative() {
 		UnlimDays: util.ToPtr(false),
 	}
 
-	_, err := suite.residentService.Create(resident1)
+	_, err := suite.residentService.Create(context.Background(), resident1)
 	if err != nil {
 		suite.NoError(fmt.Errorf("error creating resident when setting up test"))
 		return
 	}
 
-	_, err = suite.residentService.Create(residentSameEmail)
+	_, err = suite.residentService.Create(context.Background(), residentSameEmail)
 	if err == nil {
 		suite.NoError(fmt.Errorf("successfully created resident with duplicate email when it shouldn't have"))
 		return
@@ -105,7 +106,7 @@ func (suite *residentTestSuite) TestEdit_Resident_Positive() {
 
 	// this func will execute one row of above table
 	executeTest := func(test test) error {
-		result, err := suite.residentService.Update(test.argument)
+		result, err := suite.residentService.Update(context.Background(), test.argument)
 		if err != nil {
 			return fmt.Errorf("error making request: %v", err)
 		}
@@ -121,7 +122,7 @@ func (suite *residentTestSuite) TestEdit_Resident_Positive() {
 	}
 
 	for testName, test := range tests {
-		if _, err := suite.residentService.Create(residentToEdit); err != nil {
+		if _, err := suite.residentService.Create(context.Background(), residentToEdit); err != nil {
 			suite.NoError(fmt.Errorf("error creating test resident before running test: %v", err))
 			break
 		}
@@ -130,7 +131,7 @@ func (suite *residentTestSuite) TestEdit_Resident_Positive() {
 			suite.NoError(fmt.Errorf("%s failed: %v", testName, err))
 		}
 
-		if err := suite.residentService.Delete(residentToEdit.ID); err != nil {
+		if err := suite.residentService.Delete(context.Background(), residentToEdit.ID); err != nil {
 			suite.NoError(fmt.Errorf("error deleting test resident after running test: %v", err))
 			break
 		}
@@ -140,7 +141,7 @@ func (suite *residentTestSuite) TestEdit_Resident_Positive() {
 func (suite *residentTestSuite) TestEdit_ResidentDNE_Negative() {
 	residentThatDNE := models.Resident{ID: "B0000000", FirstName: "NEWFIRST"}
 
-	_, err := suite.residentService.Update(residentThatDNE)
+	_, err := suite.residentService.Update(context.Background(), residentThatDNE)
 	if err == nil {
 		suite.NoError(fmt.Errorf("no error encountered when editing a non-existing resident"))
 		return
@@ -0,0 +1,276 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"time"
+
+	"github.com/dannyvelas/parkspot-backend/config"
+	"github.com/dannyvelas/parkspot-backend/errs"
+	"github.com/dannyvelas/parkspot-backend/i18n"
+	"github.com/dannyvelas/parkspot-backend/models"
+	"github.com/dannyvelas/parkspot-backend/models/validator"
+	"github.com/dannyvelas/parkspot-backend/storage"
+	"github.com/dannyvelas/parkspot-backend/storage/selectopts"
+	"github.com/dannyvelas/parkspot-backend/util"
+	"github.com/rs/zerolog/log"
+)
+
+type ViolationService struct {
+	violationRepo storage.ViolationRepo
+	residentRepo  storage.ResidentRepo
+	plateService  PlateService
+	mailService   mailSender
+	// location is the timezone of the community, which tow notices are dated in
+	location *time.Location
+}
+
+func NewViolationService(violationRepo storage.ViolationRepo, residentRepo storage.ResidentRepo, plateService PlateService, mailService MailService, location *time.Location) ViolationService {
+	return ViolationService{
+		violationRepo: violationRepo,
+		residentRepo:  residentRepo,
+		plateService:  plateService,
+		mailService:   mailService,
+		location:      location,
+	}
+}
+
+func (s ViolationService) GetAll(ctx context.Context, limit, page int, search, residentID string) (models.ListWithMetadata[models.Violation], error) {
+	boundedLimit, offset := getBoundedLimitAndOffset(limit, page)
+
+	allViolations, err := s.violationRepo.SelectWhere(ctx, models.Violation{ResidentID: residentID},
+		selectopts.WithLimitAndOffset(boundedLimit, offset),
+		selectopts.WithSearch(search),
+	)
+	if err != nil {
+		return models.ListWithMetadata[models.Violation]{}, fmt.Errorf("error getting violations from violation repo: %v", err)
+	}
+
+	totalAmount, err := s.violationRepo.SelectCountWhere(ctx, models.Violation{ResidentID: residentID},
+		selectopts.WithSearch(search),
+	)
+	if err != nil {
+		return models.ListWithMetadata[models.Violation]{}, fmt.Errorf("error getting total amount from violation repo: %v", err)
+	}
+
+	return models.NewListWithMetadata(allViolations, totalAmount), nil
+}
+
+func (s ViolationService) GetOne(ctx context.Context, id string) (models.Violation, error) {
+	if id == "" {
+		return models.Violation{}, errs.MissingIDField
+	}
+	if !util.IsUUIDV4(id) {
+		return models.Violation{}, errs.IDNotUUID
+	}
+	return s.violationRepo.GetOne(ctx, id)
+}
+
+// Create records a violation with the license plate that security wrote down. the first config.MaxViolationWarnings
+// violations of a license plate are warnings, the ones after that are tows. if the license plate belongs to a
+// resident's car or guest, that resident is notified
+func (s ViolationService) Create(ctx context.Context, desiredViolation models.Violation) (models.Violation, error) {
+	if err := validator.CreateViolation.Run(desiredViolation); err != nil {
+		return models.Violation{}, err
+	}
+	if desiredViolation.RecordedBy == "" {
+		return models.Violation{}, errs.EmptyFields("recordedBy")
+	}
+
+	plateStatus, err := s.plateService.GetStatus(ctx, desiredViolation.LicensePlate)
+	if err != nil {
+		return models.Violation{}, fmt.Errorf("violation_service.Create: error getting plate status: %w", err)
+	}
+
+	action, err := s.nextAction(ctx, plateStatus.LicensePlate)
+	if err != nil {
+		return models.Violation{}, err
+	}
+
+	desiredViolation.ResidentID = residentOfPlate(plateStatus)
+	desiredViolation.Action = action
+	desiredViolation.Status = models.ViolationOpen
+	desiredViolation.ResidentNotified = false
+	desiredViolation.Timestamp = time.Now()
+
+	violationID, err := s.violationRepo.Create(ctx, desiredViolation)
+	if err != nil {
+		return models.Violation{}, fmt.Errorf("violation_service.Create: error creating violation in violation repo: %v", err)
+	}
+
+	if desiredViolation.ResidentID != "" {
+		// purposely not returning an error here. the violation was already recorded,
+		// and a failed email should not make security record it again
+		if err := s.notifyResident(ctx, violationID, desiredViolation); err != nil {
+			log.Error().Msgf("violation_service.Create: error notifying resident: %v", err)
+		}
+	}
+
+	violation, err := s.violationRepo.GetOne(ctx, violationID)
+	if err != nil {
+		return models.Violation{}, fmt.Errorf("violation_service.Create: error getting violation after creating it: %v", err)
+	}
+
+	return violation, nil
+}
+
+func (s ViolationService) GetTowNotice(ctx context.Context, id string) (models.TowNotice, error) {
+	violation, err := s.GetOne(ctx, id)
+	if err != nil {
+		return models.TowNotice{}, err
+	}
+
+	if violation.Action != models.ViolationTow || violation.Status == models.ViolationDismissed {
+		return models.TowNotice{}, errs.ViolationNotTow
+	}
+
+	message := fmt.Sprintf("NOTICE OF TOW: The vehicle with license plate %s was found in violation (%s) at %s on %s."+
+		" This vehicle has received %d prior warning(s) and has been towed at the owner's expense.",
+		violation.LicensePlate,
+		violation.Type,
+		violation.Location,
+		violation.Timestamp.In(s.location).Format(config.DateFormat),
+		config.MaxViolationWarnings)
+
+	return models.TowNotice{
+		ViolationID:  violation.ID,
+		LicensePlate: violation.LicensePlate,
+		Location:     violation.Location,
+		IssuedAt:     violation.Timestamp,
+		Message:      message,
+	}, nil
+}
+
+func (s ViolationService) Dispute(ctx context.Context, id, disputeReason string) (models.Violation, error) {
+	if disputeReason == "" {
+		return models.Violation{}, errs.EmptyFields("disputeReason")
+	}
+
+	violation, err := s.GetOne(ctx, id)
+	if err != nil {
+		return models.Violation{}, err
+	} else if violation.Status != models.ViolationOpen {
+		return models.Violation{}, errs.ViolationNotOpen
+	}
+
+	return s.update(ctx, models.Violation{ID: id, Status: models.ViolationDisputed, DisputeReason: disputeReason})
+}
+
+// Resolve closes a violation. if upheld is false, the violation is dismissed and
+// no longer counts towards the warnings of its license plate
+func (s ViolationService) Resolve(ctx context.Context, id string, upheld bool, resolution string) (models.Violation, error) {
+	if resolution == "" {
+		return models.Violation{}, errs.EmptyFields("resolution")
+	}
+
+	violation, err := s.GetOne(ctx, id)
+	if err != nil {
+		return models.Violation{}, err
+	} else if violation.Status == models.ViolationUpheld || violation.Status == models.ViolationDismissed {
+		return models.Violation{}, errs.ViolationAlreadyResolved
+	}
+
+	status := models.ViolationDismissed
+	if upheld {
+		status = models.ViolationUpheld
+	}
+
+	return s.update(ctx, models.Violation{ID: id, Status: status, Resolution: resolution})
+}
+
+// helpers
+func (s ViolationService) nextAction(ctx context.Context, licensePlate string) (models.ViolationAction, error) {
+	priorViolations, err := s.violationRepo.SelectWhere(ctx, models.Violation{}, selectopts.WithLicensePlate(licensePlate))
+	if err != nil {
+		return "", fmt.Errorf("violation_service.nextAction: error getting violations of license plate: %v", err)
+	}
+
+	amtWarnings := 0
+	for _, violation := range priorViolations {
+		if violation.Status != models.ViolationDismissed {
+			amtWarnings++
+		}
+	}
+
+	if amtWarnings >= config.MaxViolationWarnings {
+		return models.ViolationTow, nil
+	}
+	return models.ViolationWarning, nil
+}
+
+func (s ViolationService) notifyResident(ctx context.Context, violationID string, violation models.Violation) error {
+	residents, err := s.residentRepo.SelectWhere(ctx, models.Resident{ID: violation.ResidentID})
+	if err != nil {
+		return fmt.Errorf("error getting resident: %v", err)
+	} else if len(residents) == 0 {
+		return errs.NewNotFound("resident")
+	}
+
+	user := residents[0].AsUser()
+	email := violationEmails[i18n.Negotiate(user.PreferredLang, "")]
+	htmlBody := fmt.Sprintf(email.body, html.EscapeString(violation.LicensePlate), html.EscapeString(violation.Type),
+		html.EscapeString(violation.Location), html.EscapeString(email.actions[violation.Action]))
+
+	if err := s.mailService.Send(ctx, user, email.subject, htmlBody); err != nil {
+		return err
+	}
+
+	return s.violationRepo.Update(ctx, models.Violation{ID: violationID, ResidentNotified: true})
+}
+
+type violationEmail struct {
+	subject string
+	// body is formatted with the license plate, type, location and action of the violation
+	body    string
+	actions map[models.ViolationAction]string
+}
+
+var violationEmails = map[i18n.Lang]violationEmail{
+	i18n.English: {
+		subject: "Parking Violation",
+		body: `
+    <body style='text-align: center;'>
+        <h1>Parking Violation</h1>
+        <p>Hi, a car with license plate %s that is registered to you or your guest was found in violation (%s) at %s.</p>
+        <p>Action taken: %s.</p>
+        <p>If you believe this is a mistake, please contact your community's administration office.</p>
+    </body>`,
+		actions: map[models.ViolationAction]string{models.ViolationWarning: "warning", models.ViolationTow: "tow"},
+	},
+	i18n.Spanish: {
+		subject: "Infracción de Estacionamiento",
+		body: `
+    <body style='text-align: center;'>
+        <h1>Infracción de Estacionamiento</h1>
+        <p>Hola, un carro con placa %s que está registrado a usted o a su visita fue encontrado en infracción (%s) en %s.</p>
+        <p>Acción tomada: %s.</p>
+        <p>Si cree que esto es un error, comuníquese con la oficina de administración de su comunidad.</p>
+    </body>`,
+		actions: map[models.ViolationAction]string{models.ViolationWarning: "advertencia", models.ViolationTow: "remolque"},
+	},
+}
+
+func (s ViolationService) update(ctx context.Context, violationFields models.Violation) (models.Violation, error) {
+	if err := s.violationRepo.Update(ctx, violationFields); err != nil {
+		return models.Violation{}, fmt.Errorf("error updating violation in violation repo: %w", err)
+	}
+
+	violation, err := s.violationRepo.GetOne(ctx, violationFields.ID)
+	if err != nil {
+		return models.Violation{}, fmt.Errorf("error getting violation from violation repo: %w", err)
+	}
+
+	return violation, nil
+}
+
+// residentOfPlate returns the resident whose guest or car has this license plate, giving
+// priority to the resident that gave the plate an active permit
+func residentOfPlate(plateStatus models.PlateStatus) string {
+	if plateStatus.ActivePermit != nil {
+		return plateStatus.ActivePermit.ResidentID
+	} else if plateStatus.Car != nil {
+		return plateStatus.Car.ResidentID
+	}
+	return ""
+}
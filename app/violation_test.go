@@ -0,0 +1,184 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dannyvelas/parkspot-backend/config"
+	"github.com/dannyvelas/parkspot-backend/errs"
+	"github.com/dannyvelas/parkspot-backend/models"
+	"github.com/dannyvelas/parkspot-backend/storage/memory"
+	"github.com/dannyvelas/parkspot-backend/util"
+	"github.com/stretchr/testify/suite"
+)
+
+type mailSpy struct {
+	sent []models.User
+	err  error
+}
+
+func (m *mailSpy) Send(ctx context.Context, toUser models.User, subject, htmlBody string) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.sent = append(m.sent, toUser)
+	return nil
+}
+
+type violationTestSuite struct {
+	suite.Suite
+	violationService ViolationService
+	mailSpy          *mailSpy
+	resident         models.Resident
+	car              models.Car
+}
+
+func TestViolationService(t *testing.T) {
+	suite.Run(t, new(violationTestSuite))
+}
+
+func (suite *violationTestSuite) SetupTest() {
+	database := memory.NewDatabase(time.Local)
+	suite.resident = models.Resident{ID: "B1234567", FirstName: "john", LastName: "smith", Email: "john@example.com", UnlimDays: util.ToPtr(false), AmtParkingDaysUsed: util.ToPtr(0)}
+	suite.Require().NoError(database.ResidentRepo().Create(context.Background(), suite.resident))
+	suite.car = models.Car{ResidentID: suite.resident.ID, LicensePlate: "CAR123", Color: "red"}
+	carID, err := database.CarRepo().Create(context.Background(), suite.car)
+	suite.Require().NoError(err)
+	suite.car.ID = carID
+
+	plateService := NewPlateService(database.CarRepo(), database.PermitRepo(), NewBanService(database.BanRepo()))
+	suite.mailSpy = &mailSpy{}
+	suite.violationService = ViolationService{
+		violationRepo: database.ViolationRepo(),
+		residentRepo:  database.ResidentRepo(),
+		plateService:  plateService,
+		mailService:   suite.mailSpy,
+		location:      time.Local,
+	}
+}
+
+func (suite *violationTestSuite) create(licensePlate string) models.Violation {
+	violation, err := suite.violationService.Create(context.Background(), models.Violation{
+		LicensePlate: licensePlate,
+		Location:     "lot a",
+		Type:         "noPermit",
+		RecordedBy:   "security",
+	})
+	suite.Require().NoError(err, "error creating violation of %s", licensePlate)
+	return violation
+}
+
+func (suite *violationTestSuite) requireCode(err error, code string) {
+	var apiErr *errs.APIErr
+	suite.Require().ErrorAs(err, &apiErr)
+	suite.Equal(code, apiErr.Code)
+}
+
+func (suite *violationTestSuite) TestCreate_KeepsRecordedLicensePlate() {
+	violation := suite.create("abc-123")
+	suite.Equal("abc-123", violation.LicensePlate)
+
+	// the license plate is still compared normalized when counting the warnings that it got
+	suite.Equal(models.ViolationTow, suite.create("ABC 123").Action)
+}
+
+func (suite *violationTestSuite) TestCreate_WarningsThenTow() {
+	for i := 0; i < config.MaxViolationWarnings; i++ {
+		suite.Equal(models.ViolationWarning, suite.create("ABC123").Action, "violation %d", i+1)
+	}
+	suite.Equal(models.ViolationTow, suite.create("ABC123").Action)
+
+	// other license plates start with a warning
+	suite.Equal(models.ViolationWarning, suite.create("XYZ789").Action)
+}
+
+func (suite *violationTestSuite) TestCreate_DismissedViolationsDontCount() {
+	for i := 0; i < config.MaxViolationWarnings; i++ {
+		warning := suite.create("ABC123")
+		_, err := suite.violationService.Resolve(context.Background(), warning.ID, false, "wrong car")
+		suite.Require().NoError(err)
+	}
+
+	suite.Equal(models.ViolationWarning, suite.create("ABC123").Action)
+}
+
+func (suite *violationTestSuite) TestGetTowNotice() {
+	warning := suite.create("ABC123")
+	for i := 1; i < config.MaxViolationWarnings; i++ {
+		suite.create("ABC123")
+	}
+	tow := suite.create("ABC123")
+	suite.Require().Equal(models.ViolationTow, tow.Action)
+
+	_, err := suite.violationService.GetTowNotice(context.Background(), warning.ID)
+	suite.requireCode(err, errs.ViolationNotTow.Code)
+
+	notice, err := suite.violationService.GetTowNotice(context.Background(), tow.ID)
+	suite.Require().NoError(err)
+	suite.Equal(tow.ID, notice.ViolationID)
+	suite.Equal("ABC123", notice.LicensePlate)
+
+	_, err = suite.violationService.Resolve(context.Background(), tow.ID, false, "the car had a permit")
+	suite.Require().NoError(err)
+	_, err = suite.violationService.GetTowNotice(context.Background(), tow.ID)
+	suite.requireCode(err, errs.ViolationNotTow.Code)
+}
+
+func (suite *violationTestSuite) TestDisputeAndResolve() {
+	violation := suite.create("ABC123")
+
+	_, err := suite.violationService.Dispute(context.Background(), violation.ID, "")
+	suite.requireCode(err, "request.missing_fields")
+
+	disputed, err := suite.violationService.Dispute(context.Background(), violation.ID, "i had a permit")
+	suite.Require().NoError(err)
+	suite.Equal(models.ViolationDisputed, disputed.Status)
+	suite.Equal("i had a permit", disputed.DisputeReason)
+
+	// only open violations can be disputed
+	_, err = suite.violationService.Dispute(context.Background(), violation.ID, "i had a permit")
+	suite.requireCode(err, errs.ViolationNotOpen.Code)
+
+	_, err = suite.violationService.Resolve(context.Background(), violation.ID, true, "")
+	suite.requireCode(err, "request.missing_fields")
+
+	upheld, err := suite.violationService.Resolve(context.Background(), violation.ID, true, "the permit had expired")
+	suite.Require().NoError(err)
+	suite.Equal(models.ViolationUpheld, upheld.Status)
+	suite.Equal("the permit had expired", upheld.Resolution)
+
+	_, err = suite.violationService.Resolve(context.Background(), violation.ID, false, "changed my mind")
+	suite.requireCode(err, errs.ViolationAlreadyResolved.Code)
+	_, err = suite.violationService.Dispute(context.Background(), violation.ID, "i had a permit")
+	suite.requireCode(err, errs.ViolationNotOpen.Code)
+
+	// open violations can be resolved without being disputed first
+	dismissed, err := suite.violationService.Resolve(context.Background(), suite.create("XYZ789").ID, false, "wrong car")
+	suite.Require().NoError(err)
+	suite.Equal(models.ViolationDismissed, dismissed.Status)
+}
+
+func (suite *violationTestSuite) TestCreate_NotifiesResident() {
+	violation := suite.create("car-123")
+	suite.Equal(suite.resident.ID, violation.ResidentID)
+	suite.True(violation.ResidentNotified)
+	suite.Require().Len(suite.mailSpy.sent, 1)
+	suite.Equal(suite.resident.Email, suite.mailSpy.sent[0].Email)
+
+	// nobody is notified of license plates that don't belong to a resident
+	violation = suite.create("XYZ789")
+	suite.Empty(violation.ResidentID)
+	suite.False(violation.ResidentNotified)
+	suite.Len(suite.mailSpy.sent, 1)
+}
+
+func (suite *violationTestSuite) TestCreate_NotificationFails() {
+	suite.mailSpy.err = errors.New("mail server is down")
+
+	// the violation is still recorded when its email can't be sent
+	violation := suite.create("CAR123")
+	suite.Equal(suite.resident.ID, violation.ResidentID)
+	suite.False(violation.ResidentNotified)
+}
@@ -1,64 +1,94 @@
 package app
 
 import (
+	"context"
 	"fmt"
+	"github.com/dannyvelas/parkspot-backend/config"
 	"github.com/dannyvelas/parkspot-backend/errs"
 	"github.com/dannyvelas/parkspot-backend/models"
 	"github.com/dannyvelas/parkspot-backend/storage"
 	"github.com/dannyvelas/parkspot-backend/storage/selectopts"
+	"github.com/dannyvelas/parkspot-backend/util"
+	"time"
 )
 
 type VisitorService struct {
-	visitorRepo storage.VisitorRepo
+	visitorRepo       storage.VisitorRepo
+	parkingPolicyRepo storage.ParkingPolicyRepo
+	banService        BanService
+	permitRuleService PermitRuleService
+	// location is the timezone of the community, whose calendar the dates of visitors are days of
+	location *time.Location
 }
 
-func NewVisitorService(visitorRepo storage.VisitorRepo) VisitorService {
+func NewVisitorService(visitorRepo storage.VisitorRepo, parkingPolicyRepo storage.ParkingPolicyRepo, banService BanService, permitRuleService PermitRuleService, location *time.Location) VisitorService {
 	return VisitorService{
-		visitorRepo: visitorRepo,
+		visitorRepo:       visitorRepo,
+		parkingPolicyRepo: parkingPolicyRepo,
+		banService:        banService,
+		permitRuleService: permitRuleService,
+		location:          location,
 	}
 }
 
-func (s VisitorService) Get(status models.Status, limit, page int, search, residentID string) (models.ListWithMetadata[models.Visitor], error) {
-	boundedLimit, offset := getBoundedLimitAndOffset(limit, page)
+func (s VisitorService) Get(ctx context.Context, status models.Status, params ListParams, residentID string) (models.ListWithMetadata[models.Visitor], error) {
+	paging, err := newPaging(s.visitorRepo, params)
+	if err != nil {
+		return models.ListWithMetadata[models.Visitor]{}, err
+	}
 
-	allVisitors, err := s.visitorRepo.SelectWhere(models.Visitor{ResidentID: residentID},
-		selectopts.WithStatus(status),
-		selectopts.WithSearch(search),
-		selectopts.WithLimitAndOffset(boundedLimit, offset),
+	allVisitors, err := s.visitorRepo.SelectWhere(ctx, models.Visitor{ResidentID: residentID},
+		append(paging.selectOpts(), selectopts.WithStatus(status))...,
 	)
 	if err != nil {
 		return models.ListWithMetadata[models.Visitor]{}, fmt.Errorf("error getting all visitors from visitor repo: %v", err)
 	}
 
-	totalAmount, err := s.visitorRepo.SelectCountWhere(models.Visitor{ResidentID: residentID},
-		selectopts.WithStatus(status),
-		selectopts.WithSearch(search),
-	)
-	if err != nil {
-		return models.ListWithMetadata[models.Visitor]{}, fmt.Errorf("error getting count of all visitors from visitor repo: %v", err)
+	var totalAmount *int
+	if !paging.byCursor() {
+		count, err := s.visitorRepo.SelectCountWhere(ctx, models.Visitor{ResidentID: residentID},
+			append(paging.countOpts(), selectopts.WithStatus(status))...,
+		)
+		if err != nil {
+			return models.ListWithMetadata[models.Visitor]{}, fmt.Errorf("error getting count of all visitors from visitor repo: %v", err)
+		}
+		totalAmount = &count
+	}
+
+	return newPagedList(paging, allVisitors, func(visitor models.Visitor) string { return visitor.ID }, totalAmount), nil
+}
+
+// Export calls each with every visitor that Get lists with params, on every page
+func (s VisitorService) Export(ctx context.Context, status models.Status, params ListParams, residentID string, each func(models.Visitor) error) error {
+	selectPage := func(opts []selectopts.SelectOpt) ([]models.Visitor, error) {
+		visitors, err := s.visitorRepo.SelectWhere(ctx, models.Visitor{ResidentID: residentID}, append(opts, selectopts.WithStatus(status))...)
+		if err != nil {
+			return nil, fmt.Errorf("error getting visitors from visitor repo: %v", err)
+		}
+		return visitors, nil
 	}
 
-	return models.NewListWithMetadata(allVisitors, totalAmount), nil
+	return forEachRow(s.visitorRepo, params, selectPage, func(visitor models.Visitor) string { return visitor.ID }, each)
 }
 
-func (s VisitorService) GetOne(id string) (models.Visitor, error) {
+func (s VisitorService) GetOne(ctx context.Context, id string) (models.Visitor, error) {
 	if id == "" {
 		return models.Visitor{}, errs.MissingIDField
 	}
-	return s.visitorRepo.GetOne(id)
+	return s.visitorRepo.GetOne(ctx, id)
 }
 
-func (s VisitorService) Create(desiredVisitor models.Visitor) (models.Visitor, error) {
-	if err := desiredVisitor.ValidateCreation(); err != nil {
+func (s VisitorService) Create(ctx context.Context, desiredVisitor models.Visitor) (models.Visitor, error) {
+	if err := s.check(ctx, desiredVisitor); err != nil {
 		return models.Visitor{}, err
 	}
 
-	visitorID, err := s.visitorRepo.Create(desiredVisitor)
+	visitorID, err := s.visitorRepo.Create(ctx, desiredVisitor)
 	if err != nil {
 		return models.Visitor{}, fmt.Errorf("error creating visitor in visitor repo: %v", err)
 	}
 
-	visitor, err := s.visitorRepo.GetOne(visitorID)
+	visitor, err := s.visitorRepo.GetOne(ctx, visitorID)
 	if err != nil {
 		return models.Visitor{}, fmt.Errorf("error getting visitor after creating in visitor repo: %v", err)
 	}
@@ -66,9 +96,79 @@ func (s VisitorService) Create(desiredVisitor models.Visitor) (models.Visitor, e
 	return visitor, nil
 }
 
-func (s VisitorService) Delete(id string) error {
+// Update edits the fields of updatedFields that aren't empty on the visitor of its ID. the edited visitor must
+// pass the same checks as a visitor that is being created
+func (s VisitorService) Update(ctx context.Context, updatedFields models.Visitor) (models.Visitor, error) {
+	if updatedFields.ID == "" {
+		return models.Visitor{}, errs.MissingIDField
+	}
+	if !util.IsUUIDV4(updatedFields.ID) {
+		return models.Visitor{}, errs.IDNotUUID
+	}
+	if updatedFields.FirstName == "" && updatedFields.LastName == "" && updatedFields.Relationship == "" &&
+		updatedFields.AccessStart.IsZero() && updatedFields.AccessEnd.IsZero() {
+		return models.Visitor{}, errs.AllEditFieldsEmpty("firstName, lastName, relationship, accessStart, accessEnd")
+	}
+
+	editedVisitor, err := s.visitorRepo.GetOne(ctx, updatedFields.ID)
+	if err != nil {
+		return models.Visitor{}, fmt.Errorf("error getting visitor from visitorRepo: %w", err)
+	}
+	if updatedFields.FirstName != "" {
+		editedVisitor.FirstName = updatedFields.FirstName
+	}
+	if updatedFields.LastName != "" {
+		editedVisitor.LastName = updatedFields.LastName
+	}
+	if updatedFields.Relationship != "" {
+		editedVisitor.Relationship = updatedFields.Relationship
+	}
+	if !updatedFields.AccessStart.IsZero() {
+		editedVisitor.AccessStart = updatedFields.AccessStart
+	}
+	if !updatedFields.AccessEnd.IsZero() {
+		editedVisitor.AccessEnd = updatedFields.AccessEnd
+	}
+	if err := s.check(ctx, editedVisitor); err != nil {
+		return models.Visitor{}, err
+	}
+
+	if err := s.visitorRepo.Update(ctx, updatedFields); err != nil {
+		return models.Visitor{}, fmt.Errorf("error updating visitor from visitorRepo: %w", err)
+	}
+
+	visitor, err := s.visitorRepo.GetOne(ctx, updatedFields.ID)
+	if err != nil {
+		return models.Visitor{}, fmt.Errorf("error getting visitor from visitorRepo: %w", err)
+	}
+
+	return visitor, nil
+}
+
+func (s VisitorService) Delete(ctx context.Context, id string) error {
 	if id == "" {
 		return errs.MissingIDField
 	}
-	return s.visitorRepo.Delete(id)
+	return s.visitorRepo.Delete(ctx, id)
+}
+
+// check rejects visitors that are invalid, that end after the latest end date of the parking policy, that are
+// banned, or that break the visitor rules
+func (s VisitorService) check(ctx context.Context, visitor models.Visitor) error {
+	if err := visitor.ValidateCreation(); err != nil {
+		return err
+	}
+
+	policy, err := s.parkingPolicyRepo.Get(ctx)
+	if err != nil {
+		return fmt.Errorf("error getting parking policy from parking policy repo: %v", err)
+	} else if visitor.AccessEnd.After(policy.LatestEndDate) {
+		return errs.InvalidFields(errs.NewFieldError("accessEnd", "max", "accessEnd cannot be after "+policy.LatestEndDate.In(s.location).Format(config.DateFormat)))
+	}
+
+	if err := s.banService.CheckVisitor(ctx, visitor.FirstName, visitor.LastName); err != nil {
+		return err
+	}
+
+	return s.permitRuleService.CheckVisitor(ctx, visitor)
 }
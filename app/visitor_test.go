@@ -0,0 +1,101 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dannyvelas/parkspot-backend/errs"
+	"github.com/dannyvelas/parkspot-backend/models"
+	"github.com/dannyvelas/parkspot-backend/storage/memory"
+	"github.com/dannyvelas/parkspot-backend/util"
+	"github.com/stretchr/testify/suite"
+)
+
+type visitorTestSuite struct {
+	suite.Suite
+	database       memory.Database
+	visitorService VisitorService
+	resident       models.Resident
+}
+
+func TestVisitorService(t *testing.T) {
+	suite.Run(t, new(visitorTestSuite))
+}
+
+func (suite *visitorTestSuite) SetupTest() {
+	suite.database = memory.NewDatabase(time.Local)
+	suite.resident = models.Resident{ID: "B1234567", FirstName: "john", LastName: "smith", Email: "john@example.com", UnlimDays: util.ToPtr(false), AmtParkingDaysUsed: util.ToPtr(0)}
+	suite.Require().NoError(suite.database.ResidentRepo().Create(context.Background(), suite.resident))
+
+	banService := NewBanService(suite.database.BanRepo())
+	permitRuleService := NewPermitRuleService(suite.database.PermitRuleRepo(), suite.database.PermitRepo(), suite.database.VisitorRepo(), suite.database.ResidentRepo(), suite.database.ParkingPolicyRepo(), time.Local)
+	suite.visitorService = NewVisitorService(suite.database.VisitorRepo(), suite.database.ParkingPolicyRepo(), banService, permitRuleService, time.Local)
+}
+
+func (suite *visitorTestSuite) create(firstName string) models.Visitor {
+	now := time.Now()
+	visitor, err := suite.visitorService.Create(context.Background(), models.Visitor{
+		ResidentID:   suite.resident.ID,
+		FirstName:    firstName,
+		LastName:     "guest",
+		Relationship: "fam/fri",
+		AccessStart:  now,
+		AccessEnd:    now.Add(48 * time.Hour),
+	})
+	suite.Require().NoError(err, "error creating visitor %s", firstName)
+	return visitor
+}
+
+func (suite *visitorTestSuite) requireCode(err error, code string) {
+	var apiErr *errs.APIErr
+	suite.Require().ErrorAs(err, &apiErr)
+	suite.Equal(code, apiErr.Code)
+}
+
+func (suite *visitorTestSuite) TestUpdate() {
+	visitor := suite.create("jane")
+	suite.Equal(1, visitor.Version)
+
+	edited, err := suite.visitorService.Update(context.Background(), models.Visitor{ID: visitor.ID, LastName: "doe", Relationship: "contractor", Version: visitor.Version})
+	suite.Require().NoError(err)
+	suite.Equal("jane", edited.FirstName)
+	suite.Equal("doe", edited.LastName)
+	suite.Equal("contractor", edited.Relationship)
+	suite.Equal(visitor.Version+1, edited.Version)
+
+	// edits of a version that was edited since are rejected
+	_, err = suite.visitorService.Update(context.Background(), models.Visitor{ID: visitor.ID, LastName: "roe", Version: visitor.Version})
+	suite.requireCode(err, "visitor.conflict")
+}
+
+func (suite *visitorTestSuite) TestUpdate_ChecksEditedVisitor() {
+	visitor := suite.create("jane")
+
+	_, err := suite.visitorService.Update(context.Background(), models.Visitor{ID: visitor.ID, Version: visitor.Version})
+	suite.requireCode(err, "request.edit_fields_empty")
+
+	// the fields that weren't edited are checked together with the ones that were
+	_, err = suite.visitorService.Update(context.Background(), models.Visitor{ID: visitor.ID, AccessStart: visitor.AccessEnd.Add(time.Hour), Version: visitor.Version})
+	suite.requireCode(err, errs.InvalidFields().Code)
+
+	_, err = suite.visitorService.Update(context.Background(), models.Visitor{ID: visitor.ID, Relationship: "neighbor", Version: visitor.Version})
+	suite.requireCode(err, errs.InvalidFields().Code)
+
+	_, err = suite.visitorService.Update(context.Background(), models.Visitor{ID: "9b6d89a6-0b66-4170-be8d-eba43f8bf478", LastName: "doe", Version: 1})
+	suite.requireCode(err, errs.NewNotFound("visitor").Code)
+}
+
+func (suite *visitorTestSuite) TestUpdate_DoesntCountItselfAgainstRules() {
+	_, err := suite.database.PermitRuleRepo().Create(context.Background(), models.PermitRule{
+		Name:     "one visitor a month",
+		Kind:     models.MaxPerMonthRule,
+		Target:   models.VisitorTarget,
+		MaxCount: 1,
+	})
+	suite.Require().NoError(err)
+
+	visitor := suite.create("jane")
+	_, err = suite.visitorService.Update(context.Background(), models.Visitor{ID: visitor.ID, LastName: "doe", Version: visitor.Version})
+	suite.NoError(err)
+}
@@ -0,0 +1,263 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"sync"
+	"time"
+
+	"github.com/dannyvelas/parkspot-backend/config"
+	"github.com/dannyvelas/parkspot-backend/errs"
+	"github.com/dannyvelas/parkspot-backend/i18n"
+	"github.com/dannyvelas/parkspot-backend/models"
+	"github.com/dannyvelas/parkspot-backend/storage"
+	"github.com/dannyvelas/parkspot-backend/storage/selectopts"
+	"github.com/dannyvelas/parkspot-backend/util"
+	"github.com/rs/zerolog/log"
+)
+
+type WaitlistService struct {
+	waitlistRepo  storage.WaitlistRepo
+	residentRepo  storage.ResidentRepo
+	permitService PermitService
+	mailService   MailService
+	// location is the timezone of the community, whose calendar the dates of permits are days of
+	location *time.Location
+	// promoteMu makes sure that two promotions never create a permit for the same space at the same time
+	promoteMu *sync.Mutex
+	// promoteSoon wakes up PromoteEvery before its next tick
+	promoteSoon chan struct{}
+}
+
+func NewWaitlistService(waitlistRepo storage.WaitlistRepo, residentRepo storage.ResidentRepo, permitService PermitService, mailService MailService, location *time.Location) WaitlistService {
+	return WaitlistService{
+		waitlistRepo:  waitlistRepo,
+		residentRepo:  residentRepo,
+		permitService: permitService,
+		mailService:   mailService,
+		location:      location,
+		promoteMu:     &sync.Mutex{},
+		promoteSoon:   make(chan struct{}, 1),
+	}
+}
+
+// IsWaitlistable reports whether err from PermitService.Create only happened because the lot
+// or the resident's quota is full right now, which can change once other permits end
+func IsWaitlistable(err error) bool {
+	return errors.Is(err, errs.LotAtCapacity) ||
+		errors.Is(err, errs.SpaceTaken) ||
+		errors.Is(err, errs.ResidentTooManyActivePermits)
+}
+
+func (s WaitlistService) GetAll(ctx context.Context, status models.WaitlistStatus, limit, page int, residentID string) (models.ListWithMetadata[models.WaitlistEntry], error) {
+	boundedLimit, offset := getBoundedLimitAndOffset(limit, page)
+
+	allEntries, err := s.waitlistRepo.SelectWhere(ctx, models.WaitlistEntry{ResidentID: residentID, Status: status},
+		selectopts.WithLimitAndOffset(boundedLimit, offset),
+	)
+	if err != nil {
+		return models.ListWithMetadata[models.WaitlistEntry]{}, fmt.Errorf("error getting waitlist entries from waitlist repo: %v", err)
+	}
+
+	totalAmount, err := s.waitlistRepo.SelectCountWhere(ctx, models.WaitlistEntry{ResidentID: residentID, Status: status})
+	if err != nil {
+		return models.ListWithMetadata[models.WaitlistEntry]{}, fmt.Errorf("error getting total amount from waitlist repo: %v", err)
+	}
+
+	return models.NewListWithMetadata(allEntries, totalAmount), nil
+}
+
+func (s WaitlistService) GetOne(ctx context.Context, id string) (models.WaitlistEntry, error) {
+	if id == "" {
+		return models.WaitlistEntry{}, errs.MissingIDField
+	}
+	if !util.IsUUIDV4(id) {
+		return models.WaitlistEntry{}, errs.IDNotUUID
+	}
+	return s.waitlistRepo.GetOne(ctx, id)
+}
+
+// Add puts desiredPermit on the waitlist. blockedBy is the error that PermitService.Create returned for it,
+// and must be waitlistable
+func (s WaitlistService) Add(ctx context.Context, desiredPermit models.Permit, blockedBy error) (models.WaitlistEntry, error) {
+	if !IsWaitlistable(blockedBy) {
+		return models.WaitlistEntry{}, blockedBy
+	}
+
+	entryID, err := s.waitlistRepo.Create(ctx, models.WaitlistEntry{
+		ResidentID:      desiredPermit.ResidentID,
+		CarID:           desiredPermit.CarID,
+		LicensePlate:    desiredPermit.LicensePlate,
+		Color:           desiredPermit.Color,
+		Make:            desiredPermit.Make,
+		Model:           desiredPermit.Model,
+		StartDate:       desiredPermit.StartDate,
+		EndDate:         desiredPermit.EndDate,
+		ExceptionReason: desiredPermit.ExceptionReason,
+		SpaceID:         desiredPermit.SpaceID,
+		Status:          models.WaitlistWaiting,
+		Reason:          reasonOf(blockedBy),
+		CreatedAt:       time.Now(),
+	})
+	if err != nil {
+		return models.WaitlistEntry{}, fmt.Errorf("error creating entry in waitlist repo: %v", err)
+	}
+
+	entry, err := s.waitlistRepo.GetOne(ctx, entryID)
+	if err != nil {
+		return models.WaitlistEntry{}, fmt.Errorf("error getting entry after creating in waitlist repo: %v", err)
+	}
+
+	return entry, nil
+}
+
+func (s WaitlistService) Cancel(ctx context.Context, id string) error {
+	entry, err := s.GetOne(ctx, id)
+	if err != nil {
+		return err
+	} else if entry.Status != models.WaitlistWaiting {
+		return errs.WaitlistEntryNotWaiting
+	}
+
+	return s.waitlistRepo.Update(ctx, models.WaitlistEntry{ID: id, Status: models.WaitlistCancelled})
+}
+
+// PromoteWaiting tries to turn every waiting entry into a permit, oldest first. it should be called whenever
+// a permit is deleted or expires. entries whose dates already passed are expired, and entries that fail
+// PermitService.Create for a reason that waiting won't fix are rejected
+func (s WaitlistService) PromoteWaiting(ctx context.Context) error {
+	s.promoteMu.Lock()
+	defer s.promoteMu.Unlock()
+
+	waitingEntries, err := s.waitlistRepo.SelectWhere(ctx, models.WaitlistEntry{Status: models.WaitlistWaiting})
+	if err != nil {
+		return fmt.Errorf("waitlist_service.PromoteWaiting: error getting waiting entries: %v", err)
+	}
+
+	now := time.Now()
+	for _, entry := range waitingEntries {
+		if !entry.EndDate.After(now) {
+			if err := s.waitlistRepo.Update(ctx, models.WaitlistEntry{ID: entry.ID, Status: models.WaitlistExpired}); err != nil {
+				return fmt.Errorf("waitlist_service.PromoteWaiting: error expiring entry: %v", err)
+			}
+			continue
+		}
+
+		// an entry that waited past its start date only gets the days that are left of it, and is charged for those
+		desiredPermit := entry.AsPermit()
+		if desiredPermit.StartDate.Before(now) {
+			desiredPermit.StartDate = now
+		}
+
+		permit, err := s.permitService.Create(ctx, desiredPermit)
+		var apiErr *errs.APIErr
+		if IsWaitlistable(err) {
+			continue
+		} else if errors.As(err, &apiErr) {
+			if err := s.waitlistRepo.Update(ctx, models.WaitlistEntry{ID: entry.ID, Status: models.WaitlistRejected, Reason: apiErr.Code}); err != nil {
+				return fmt.Errorf("waitlist_service.PromoteWaiting: error rejecting entry: %v", err)
+			}
+			continue
+		} else if err != nil {
+			return fmt.Errorf("waitlist_service.PromoteWaiting: error creating permit for entry: %v", err)
+		}
+
+		if err := s.waitlistRepo.Update(ctx, models.WaitlistEntry{ID: entry.ID, Status: models.WaitlistPromoted, PermitID: permit.ID}); err != nil {
+			return fmt.Errorf("waitlist_service.PromoteWaiting: error promoting entry: %v", err)
+		}
+
+		// purposely not returning an error here. the permit was already created
+		if err := s.notifyResident(ctx, permit); err != nil {
+			log.Error().Msgf("waitlist_service.PromoteWaiting: error notifying resident: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// PromoteEvery calls PromoteWaiting every interval until ctx is done, so that entries are promoted as permits expire.
+// it also calls it as soon as PromoteSoon is called
+func (s WaitlistService) PromoteEvery(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-s.promoteSoon:
+		}
+
+		if err := s.PromoteWaiting(ctx); err != nil {
+			log.Error().Msgf("waitlist_service.PromoteEvery: %v", err)
+		}
+	}
+}
+
+// PromoteSoon asks PromoteEvery to promote waiting entries without waiting for its next tick, like after a permit
+// is deleted. it doesn't wait for them to be promoted, so that creating permits and emailing residents never
+// slows down or fails the request that called it
+func (s WaitlistService) PromoteSoon() {
+	select {
+	case s.promoteSoon <- struct{}{}:
+	default:
+		// a promotion was already asked for, and it will see the permit that was deleted too
+	}
+}
+
+// helpers
+
+// reasonOf is the code of err, so that clients can translate why an entry is waiting or was rejected
+func reasonOf(err error) string {
+	var apiErr *errs.APIErr
+	if errors.As(err, &apiErr) {
+		return apiErr.Code
+	}
+	return err.Error()
+}
+
+func (s WaitlistService) notifyResident(ctx context.Context, permit models.Permit) error {
+	residents, err := s.residentRepo.SelectWhere(ctx, models.Resident{ID: permit.ResidentID})
+	if err != nil {
+		return fmt.Errorf("error getting resident: %v", err)
+	} else if len(residents) == 0 {
+		return errs.NewNotFound("resident")
+	}
+
+	user := residents[0].AsUser()
+	email := permitFromWaitlistEmails[i18n.Negotiate(user.PreferredLang, "")]
+	htmlBody := fmt.Sprintf(email.body, html.EscapeString(permit.LicensePlate), permit.ID,
+		permit.StartDate.In(s.location).Format(config.DateFormat), permit.EndDate.In(s.location).Format(config.DateFormat))
+
+	return s.mailService.Send(ctx, user, email.subject, htmlBody)
+}
+
+type permitFromWaitlistEmail struct {
+	subject string
+	// body is formatted with the license plate, id, start date and end date of the permit
+	body string
+}
+
+var permitFromWaitlistEmails = map[i18n.Lang]permitFromWaitlistEmail{
+	i18n.English: {
+		subject: "Guest Parking Permit Created From Waitlist",
+		body: `
+    <body style='text-align: center;'>
+        <h1>Your Guest Parking Permit Is Ready</h1>
+        <p>Hi, a guest parking space opened up, so your waitlisted request for the car with license plate %s is now permit #%d.</p>
+        <p>It is valid from %s until %s.</p>
+    </body>`,
+	},
+	i18n.Spanish: {
+		subject: "Permiso de Estacionamiento Creado Desde la Lista de Espera",
+		body: `
+    <body style='text-align: center;'>
+        <h1>Su Permiso de Estacionamiento Para Visitas Está Listo</h1>
+        <p>Hola, se liberó un espacio de estacionamiento para visitas, así que su solicitud en lista de espera para el carro con placa %s ahora es el permiso #%d.</p>
+        <p>Es válido desde el %s hasta el %s.</p>
+    </body>`,
+	},
+}
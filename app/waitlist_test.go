@@ -0,0 +1,177 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dannyvelas/parkspot-backend/config"
+	"github.com/dannyvelas/parkspot-backend/errs"
+	"github.com/dannyvelas/parkspot-backend/models"
+	"github.com/dannyvelas/parkspot-backend/storage/psql"
+	"github.com/dannyvelas/parkspot-backend/util"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/testcontainers/testcontainers-go"
+)
+
+type waitlistTestSuite struct {
+	suite.Suite
+	container           testcontainers.Container
+	waitlistService     WaitlistService
+	permitService       PermitService
+	parkingSpaceService ParkingSpaceService
+}
+
+func TestWaitlistService(t *testing.T) {
+	suite.Run(t, new(waitlistTestSuite))
+}
+
+func (suite *waitlistTestSuite) SetupSuite() {
+	// configure and start container
+	container, database, err := psql.NewSandboxDatabase(time.Local)
+	if err != nil {
+		suite.T().Fatalf("error getting sandbox database: %v", err)
+	}
+	// save container in suite struct so we can terminate it on suite teardown
+	suite.container = container
+
+	residentService := NewResidentService(database.ResidentRepo())
+	carService := NewCarService(database.CarRepo())
+	banService := NewBanService(database.BanRepo())
+	permitRuleService := NewPermitRuleService(database.PermitRuleRepo(), database.PermitRepo(), database.VisitorRepo(), database.ResidentRepo(), database.ParkingPolicyRepo(), time.Local)
+	suite.parkingSpaceService = NewParkingSpaceService(database.ParkingSpaceRepo(), database.PermitRepo())
+	suite.permitService = NewPermitService(database.PermitRepo(), database.ResidentRepo(), database.ParkingSpaceRepo(), database.ParkingPolicyRepo(), carService, banService, permitRuleService, time.Local)
+	// notifications will fail without oauth credentials, but failing to notify does not fail a promotion
+	suite.waitlistService = NewWaitlistService(database.WaitlistRepo(), database.ResidentRepo(), suite.permitService, NewMailService(config.OAuthConfig{}), time.Local)
+
+	if _, err := residentService.Create(context.Background(), models.TestResidentUnlimDays); err != nil {
+		suite.TearDownSuite()
+		suite.T().Fatalf("tearing down because failed to create resident: %v", err)
+	}
+
+	if _, err := carService.Create(context.Background(), models.NewCar(models.TestCar.ID, models.TestResidentUnlimDays.ID, "lp1", "color", "make", "model", 0, 0)); err != nil {
+		suite.TearDownSuite()
+		suite.T().Fatalf("tearing down because failed to create car: %v", err)
+	}
+
+	if _, err := suite.parkingSpaceService.Create(context.Background(), models.ParkingSpace{Zone: "guest", Label: "1"}); err != nil {
+		suite.TearDownSuite()
+		suite.T().Fatalf("tearing down because failed to create parking space: %v", err)
+	}
+}
+
+func (suite *waitlistTestSuite) TearDownSuite() {
+	err := suite.container.Terminate(context.Background())
+	if err != nil {
+		require.NoError(suite.T(), fmt.Errorf("error tearing down container: %v", err))
+	}
+}
+
+func (suite *waitlistTestSuite) TearDownTest() {
+	if err := suite.waitlistService.waitlistRepo.Reset(context.Background()); err != nil {
+		suite.T().Fatalf("encountered error resetting waitlist repo in-between tests")
+	}
+	if err := suite.permitService.permitRepo.Reset(context.Background()); err != nil {
+		suite.T().Fatalf("encountered error resetting permit repo in-between tests")
+	}
+}
+
+func (suite *waitlistTestSuite) TestPromoteWaiting_AfterPermitDeleted() {
+	occupyingPermit, err := suite.permitService.Create(context.Background(), activeFor24Hrs(models.Permit{ResidentID: models.TestResidentUnlimDays.ID, CarID: models.TestCar.ID}, 0))
+	if err != nil {
+		require.NoError(suite.T(), fmt.Errorf("error creating permit before test: %v", err))
+	}
+
+	desiredPermit := activeFor24Hrs(models.Permit{ResidentID: models.TestResidentUnlimDays.ID, LicensePlate: "waiting", Color: "color", Make: "make", Model: "model"}, 2)
+	_, err = suite.permitService.Create(context.Background(), desiredPermit)
+	require.ErrorIs(suite.T(), err, errs.LotAtCapacity)
+
+	entry, err := suite.waitlistService.Add(context.Background(), desiredPermit, err)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), models.WaitlistWaiting, entry.Status)
+	require.Equal(suite.T(), errs.LotAtCapacity.Code, entry.Reason)
+
+	// the lot is still full, so the entry keeps waiting
+	require.NoError(suite.T(), suite.waitlistService.PromoteWaiting(context.Background()))
+	entry, err = suite.waitlistService.GetOne(context.Background(), entry.ID)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), models.WaitlistWaiting, entry.Status)
+
+	require.NoError(suite.T(), suite.permitService.Delete(context.Background(), occupyingPermit.ID))
+	require.NoError(suite.T(), suite.waitlistService.PromoteWaiting(context.Background()))
+
+	entry, err = suite.waitlistService.GetOne(context.Background(), entry.ID)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), models.WaitlistPromoted, entry.Status, "expected entry to be promoted once a space opened up")
+
+	permit, err := suite.permitService.GetOne(context.Background(), entry.PermitID)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), desiredPermit.LicensePlate, permit.LicensePlate)
+}
+
+func (suite *waitlistTestSuite) TestAdd_NotWaitlistable_Negative() {
+	desiredPermit := models.Permit{ResidentID: models.TestResidentUnlimDays.ID, CarID: models.TestCar.ID}
+
+	_, err := suite.waitlistService.Add(context.Background(), desiredPermit, errs.PermitTooLong)
+	require.ErrorIs(suite.T(), err, errs.PermitTooLong, "expected requests that waiting won't fix to not be waitlisted")
+}
+
+func (suite *waitlistTestSuite) TestPromoteWaiting_ExpiresPastEntries() {
+	pastPermit := models.Permit{
+		ResidentID: models.TestResidentUnlimDays.ID,
+		CarID:      models.TestCar.ID,
+		StartDate:  time.Now().Add(-48 * time.Hour),
+		EndDate:    time.Now().Add(-24 * time.Hour),
+	}
+	entry, err := suite.waitlistService.Add(context.Background(), pastPermit, errs.LotAtCapacity)
+	require.NoError(suite.T(), err)
+
+	require.NoError(suite.T(), suite.waitlistService.PromoteWaiting(context.Background()))
+
+	entry, err = suite.waitlistService.GetOne(context.Background(), entry.ID)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), models.WaitlistExpired, entry.Status)
+}
+
+func (suite *waitlistTestSuite) TestPromoteWaiting_StartsLateEntriesNow() {
+	resident := models.Resident{ID: "B7654321", FirstName: "late", LastName: "resident", Email: "late@example.com", UnlimDays: util.ToPtr(false), AmtParkingDaysUsed: util.ToPtr(0)}
+	require.NoError(suite.T(), suite.permitService.residentRepo.Create(context.Background(), resident))
+
+	latePermit := models.Permit{
+		ResidentID:   resident.ID,
+		LicensePlate: "late",
+		Color:        "color",
+		Make:         "make",
+		Model:        "model",
+		StartDate:    time.Now().Add(-72 * time.Hour).Truncate(time.Second),
+		EndDate:      time.Now().Add(48 * time.Hour).Truncate(time.Second),
+	}
+	entry, err := suite.waitlistService.Add(context.Background(), latePermit, errs.LotAtCapacity)
+	require.NoError(suite.T(), err)
+
+	require.NoError(suite.T(), suite.waitlistService.PromoteWaiting(context.Background()))
+
+	entry, err = suite.waitlistService.GetOne(context.Background(), entry.ID)
+	require.NoError(suite.T(), err)
+	require.Equal(suite.T(), models.WaitlistPromoted, entry.Status)
+
+	permit, err := suite.permitService.GetOne(context.Background(), entry.PermitID)
+	require.NoError(suite.T(), err)
+	require.WithinDuration(suite.T(), time.Now(), permit.StartDate, time.Minute, "expected the permit to start when it was promoted")
+
+	// the resident is only charged for the days that were left of the entry
+	daysLeft, err := suite.permitService.permitRuleService.DaysCharged(context.Background(), permit.StartDate, permit.EndDate)
+	require.NoError(suite.T(), err)
+	daysRequested, err := suite.permitService.permitRuleService.DaysCharged(context.Background(), latePermit.StartDate, latePermit.EndDate)
+	require.NoError(suite.T(), err)
+	require.Less(suite.T(), daysLeft, daysRequested)
+	require.NotNil(suite.T(), permit.DaysCharged)
+	require.Equal(suite.T(), daysLeft, *permit.DaysCharged)
+
+	residents, err := suite.permitService.residentRepo.SelectWhere(context.Background(), models.Resident{ID: resident.ID})
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), residents, 1)
+	require.Equal(suite.T(), daysLeft, *residents[0].AmtParkingDaysUsed)
+}
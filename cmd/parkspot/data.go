@@ -0,0 +1,148 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/dannyvelas/parkspot-backend/app"
+	"github.com/dannyvelas/parkspot-backend/export"
+	"github.com/dannyvelas/parkspot-backend/i18n"
+	"github.com/dannyvelas/parkspot-backend/models"
+)
+
+var importCommand = command{
+	args:    "[-dry-run] <csv file>",
+	summary: "import residents and their cars from a CSV, like POST /api/residents/import",
+	run: func(ctx context.Context, args []string) error {
+		flags := newFlagSet("import")
+		dryRun := flags.Bool("dry-run", false, "")
+		if err := flags.Parse(args); err != nil || flags.NArg() != 1 {
+			return errUsage
+		}
+
+		file, err := os.Open(flags.Arg(0))
+		if err != nil {
+			return fmt.Errorf("error opening CSV: %v", err)
+		}
+		defer file.Close()
+
+		parkspot, err := newApp()
+		if err != nil {
+			return err
+		}
+
+		report, err := parkspot.ImportService.Import(ctx, file, *dryRun)
+		if err != nil {
+			return err
+		}
+
+		for _, rowErr := range report.RowErrs {
+			fmt.Printf("row %d: %v\n", rowErr.Row, rowErr.Err)
+		}
+		switch {
+		case len(report.RowErrs) != 0:
+			return fmt.Errorf("nothing was imported: %d row error(s)", len(report.RowErrs))
+		case report.DryRun:
+			fmt.Printf("dry run: %d resident(s) and %d car(s) would be imported\n", report.Residents, report.Cars)
+		default:
+			fmt.Printf("imported %d resident(s) and %d car(s)\n", report.Residents, report.Cars)
+		}
+		return nil
+	},
+}
+
+var exportStatuses = map[string]models.Status{
+	"all":        models.AnyStatus,
+	"active":     models.ActiveStatus,
+	"exceptions": models.ExceptionStatus,
+	"expired":    models.ExpiredStatus,
+}
+
+var exportCommand = command{
+	args:    "[-status all|active|exceptions|expired] [-format csv|xlsx] [-lang en|es] [-o <file>] permits|cars|residents|visitors",
+	summary: "export every permit, car, resident or visitor, like GET /api/permits/all/export. visitors are active by default",
+	run: func(ctx context.Context, args []string) error {
+		flags := newFlagSet("export")
+		rawStatus := flags.String("status", "", "")
+		rawFormat := flags.String("format", "", "")
+		lang := flags.String("lang", string(i18n.Default), "")
+		output := flags.String("o", "", "")
+		if err := flags.Parse(args); err != nil || flags.NArg() != 1 {
+			return errUsage
+		}
+
+		format, ok := export.ParseFormat(*rawFormat)
+		if !ok {
+			return errUsage
+		}
+		status, ok := exportStatuses[*rawStatus]
+		if *rawStatus == "" {
+			status, ok = models.AnyStatus, true
+			if flags.Arg(0) == "visitors" {
+				status = models.ActiveStatus
+			}
+		}
+		if !ok {
+			return errUsage
+		}
+
+		c, database, err := connect()
+		if err != nil {
+			return err
+		}
+		parkspot := app.NewApp(c, database)
+
+		var w io.Writer = os.Stdout
+		if *output != "" {
+			file, err := os.Create(*output)
+			if err != nil {
+				return fmt.Errorf("error creating %s: %v", *output, err)
+			}
+			defer file.Close()
+			w = file
+		}
+
+		writer := export.NewWriter(w, format)
+		switch flags.Arg(0) {
+		case "permits":
+			err = exportRows(writer, export.Permits(c.Community.Location), i18n.Lang(*lang), func(each func(models.Permit) error) error {
+				return parkspot.PermitService.Export(ctx, status, app.ListParams{}, "", each)
+			})
+		case "cars":
+			err = exportRows(writer, export.Cars, i18n.Lang(*lang), func(each func(models.Car) error) error {
+				return parkspot.CarService.Export(ctx, app.ListParams{}, "", each)
+			})
+		case "residents":
+			err = exportRows(writer, export.Residents, i18n.Lang(*lang), func(each func(models.Resident) error) error {
+				return parkspot.ResidentService.Export(ctx, app.ListParams{}, each)
+			})
+		case "visitors":
+			err = exportRows(writer, export.Visitors(c.Community.Location), i18n.Lang(*lang), func(each func(models.Visitor) error) error {
+				return parkspot.VisitorService.Export(ctx, status, app.ListParams{}, "", each)
+			})
+		default:
+			return errUsage
+		}
+		if err != nil {
+			return err
+		}
+
+		return writer.Close()
+	},
+}
+
+func exportRows[T any](writer export.Writer, table export.Table[T], lang i18n.Lang, forEach func(each func(T) error) error) error {
+	if err := writer.WriteRow(table.HeadersIn(lang)); err != nil {
+		return fmt.Errorf("error writing headers: %v", err)
+	}
+
+	err := forEach(func(row T) error {
+		return writer.WriteRow(table.Row(row))
+	})
+	if err != nil {
+		return fmt.Errorf("error exporting: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dannyvelas/parkspot-backend/app"
+)
+
+var resetDaysCommand = command{
+	args:    "",
+	summary: "set the parking days used by every resident and car to 0, for the start of a new year",
+	run: func(ctx context.Context, args []string) error {
+		if len(args) != 0 {
+			return errUsage
+		}
+
+		parkspot, err := newApp()
+		if err != nil {
+			return err
+		}
+
+		report, err := parkspot.ParkingDaysService.Reset(ctx)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("reset %d resident(s) and %d car(s)\n", report.Residents, report.Cars)
+		return nil
+	},
+}
+
+var recomputeDaysCommand = command{
+	args:    "[-year <year>]",
+	summary: "set the parking days used by every resident and car to the days of their permits that start in a year",
+	run: func(ctx context.Context, args []string) error {
+		flags := newFlagSet("recompute-days")
+		// the year is read after the app is created, since it is a year of the community's timezone
+		year := flags.Int("year", 0, "")
+		if err := flags.Parse(args); err != nil || flags.NArg() != 0 {
+			return errUsage
+		}
+
+		c, database, err := connect()
+		if err != nil {
+			return err
+		}
+		parkspot := app.NewApp(c, database)
+		if *year == 0 {
+			*year = time.Now().In(c.Community.Location).Year()
+		}
+
+		report, err := parkspot.ParkingDaysService.Recompute(ctx, *year)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("recomputed the days of %d: changed %d resident(s) and %d car(s)\n", *year, report.Residents, report.Cars)
+		return nil
+	},
+}
@@ -0,0 +1,129 @@
+// Command parkspot runs the operational tasks of a Park Spot deployment, like migrating its database or
+// creating its first admin. run parkspot help to list them.
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+
+	"github.com/dannyvelas/parkspot-backend/app"
+	"github.com/dannyvelas/parkspot-backend/config"
+	"github.com/dannyvelas/parkspot-backend/storage/sqldb"
+)
+
+type command struct {
+	args    string // the arguments of the command, like <csv file>
+	summary string
+	run     func(ctx context.Context, args []string) error
+}
+
+// errUsage is returned by commands that were run with the wrong arguments
+var errUsage = errors.New("wrong arguments")
+
+var commands = map[string]command{
+	"migrate":         migrateCommand,
+	"seed":            seedCommand,
+	"create-admin":    createAdminCommand,
+	"reset-password":  resetPasswordCommand,
+	"revoke-sessions": revokeSessionsCommand,
+	"reset-days":      resetDaysCommand,
+	"recompute-days":  recomputeDaysCommand,
+	"import":          importCommand,
+	"export":          exportCommand,
+}
+
+func main() {
+	if len(os.Args) < 2 || os.Args[1] == "help" || os.Args[1] == "-h" || os.Args[1] == "--help" {
+		printUsage(os.Stdout)
+		return
+	}
+
+	name := os.Args[1]
+	cmd, ok := commands[name]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "parkspot: unknown command %q\n\n", name)
+		printUsage(os.Stderr)
+		os.Exit(2)
+	}
+
+	// interrupting a command cancels the queries that it is running
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := cmd.run(ctx, os.Args[2:]); errors.Is(err, errUsage) || errors.Is(err, flag.ErrHelp) {
+		fmt.Fprintf(os.Stderr, "usage: parkspot %s %s\n", name, cmd.args)
+		os.Exit(2)
+	} else if err != nil {
+		fmt.Fprintf(os.Stderr, "parkspot %s: %v\n", name, err)
+		os.Exit(1)
+	}
+}
+
+func printUsage(w io.Writer) {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintln(w, "usage: parkspot <command> [arguments]")
+	fmt.Fprintln(w, "\ncommands:")
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-16s %s\n", name, commands[name].summary)
+	}
+	fmt.Fprintln(w, "\ncommands are configured with the same environment as the server")
+}
+
+// newFlagSet returns flags that don't print or exit by themselves, so that main prints the usage of commands
+func newFlagSet(name string) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	return flags
+}
+
+// connect connects to the database of the environment
+func connect() (config.Config, sqldb.Database, error) {
+	c, err := config.NewConfig()
+	if err != nil {
+		return config.Config{}, nil, fmt.Errorf("error loading config: %v", err)
+	}
+
+	database, err := sqldb.Open(c.Database, c.Community.Location)
+	if err != nil {
+		return config.Config{}, nil, fmt.Errorf("failed to start database: %v", err)
+	}
+
+	return c, database, nil
+}
+
+// newApp connects to the database of the environment and creates the services of the app
+func newApp() (app.App, error) {
+	c, database, err := connect()
+	if err != nil {
+		return app.App{}, err
+	}
+	return app.NewApp(c, database), nil
+}
+
+// readPassword reads a password from the first line of stdin, so that it stays out of the history of the shell
+func readPassword(prompt string) (string, error) {
+	fmt.Fprint(os.Stderr, prompt)
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("error reading password: %v", err)
+	}
+
+	password := strings.TrimRight(line, "\r\n")
+	if password == "" {
+		return "", errors.New("password cannot be empty")
+	}
+	return password, nil
+}
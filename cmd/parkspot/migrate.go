@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/golang-migrate/migrate/v4"
+)
+
+const migrateArgs = "up | down | steps <n> | version | force <version>"
+
+var migrateCommand = command{
+	args:    migrateArgs,
+	summary: "migrate the schema of the database with the migrations embedded in this binary. down rolls back every migration and steps rolls back when n is negative",
+	run: func(_ context.Context, args []string) error {
+		if err := checkMigrateArgs(args); err != nil {
+			return err
+		}
+
+		_, database, err := connect()
+		if err != nil {
+			return err
+		}
+		migrator, err := database.Migrator()
+		if err != nil {
+			return err
+		}
+		defer migrator.Close()
+
+		return runMigrate(migrator, args)
+	},
+}
+
+var seedCommand = command{
+	args:    "[-dir <folder>] " + migrateArgs,
+	summary: "migrate the sample data of local development, or the seeds of a folder, apart from the schema",
+	run: func(_ context.Context, args []string) error {
+		flags := newFlagSet("seed")
+		dir := flags.String("dir", "", "folder of seed migrations to run instead of the sample data")
+		if err := flags.Parse(args); err != nil {
+			return err
+		}
+		if err := checkMigrateArgs(flags.Args()); err != nil {
+			return err
+		}
+
+		_, database, err := connect()
+		if err != nil {
+			return err
+		}
+		migrator, err := database.SeedMigrator(*dir)
+		if err != nil {
+			return err
+		}
+		defer migrator.Close()
+
+		return runMigrate(migrator, flags.Args())
+	},
+}
+
+func checkMigrateArgs(args []string) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+
+	switch args[0] {
+	case "up", "down", "version":
+		if len(args) != 1 {
+			return errUsage
+		}
+	case "steps", "force":
+		if len(args) != 2 {
+			return errUsage
+		}
+		if _, err := strconv.Atoi(args[1]); err != nil {
+			return errUsage
+		}
+	default:
+		return errUsage
+	}
+
+	return nil
+}
+
+// runMigrate runs the migration of args, which were checked by checkMigrateArgs, and prints the version it ends on
+func runMigrate(migrator *migrate.Migrate, args []string) error {
+	var err error
+	switch args[0] {
+	case "up":
+		err = migrator.Up()
+	case "down":
+		err = migrator.Down()
+	case "steps":
+		n, _ := strconv.Atoi(args[1])
+		err = migrator.Steps(n)
+	case "force":
+		n, _ := strconv.Atoi(args[1])
+		err = migrator.Force(n)
+	}
+	if errors.Is(err, migrate.ErrNoChange) {
+		fmt.Println("no migrations to apply")
+	} else if err != nil {
+		return fmt.Errorf("error migrating: %v", err)
+	}
+
+	version, dirty, err := migrator.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		fmt.Println("no migrations are applied")
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("error getting version: %v", err)
+	}
+	fmt.Printf("version: %d, dirty: %t\n", version, dirty)
+	return nil
+}
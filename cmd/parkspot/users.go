@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dannyvelas/parkspot-backend/i18n"
+	"github.com/dannyvelas/parkspot-backend/models"
+)
+
+var createAdminCommand = command{
+	args:    "-id <id> -first-name <name> -last-name <name> -email <email> [-privileged=false] [-lang en|es] < password",
+	summary: "create an admin, like the first admin of a community. the password is read from stdin",
+	run: func(ctx context.Context, args []string) error {
+		flags := newFlagSet("create-admin")
+		id := flags.String("id", "", "")
+		firstName := flags.String("first-name", "", "")
+		lastName := flags.String("last-name", "", "")
+		email := flags.String("email", "", "")
+		privileged := flags.Bool("privileged", true, "")
+		lang := flags.String("lang", "", "")
+		if err := flags.Parse(args); err != nil || flags.NArg() != 0 {
+			return errUsage
+		}
+
+		password, err := readPassword("password: ")
+		if err != nil {
+			return err
+		}
+
+		parkspot, err := newApp()
+		if err != nil {
+			return err
+		}
+
+		admin, err := parkspot.AdminService.Create(ctx, models.Admin{
+			ID:            *id,
+			FirstName:     *firstName,
+			LastName:      *lastName,
+			Email:         *email,
+			Password:      password,
+			IsPrivileged:  *privileged,
+			PreferredLang: i18n.Lang(*lang),
+		})
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("created admin %s\n", admin.ID)
+		return nil
+	},
+}
+
+var resetPasswordCommand = command{
+	args:    "<id> < password",
+	summary: "set the password of an admin or a resident. the password is read from stdin",
+	run: func(ctx context.Context, args []string) error {
+		if len(args) != 1 {
+			return errUsage
+		}
+
+		password, err := readPassword("new password: ")
+		if err != nil {
+			return err
+		}
+
+		parkspot, err := newApp()
+		if err != nil {
+			return err
+		}
+
+		if err := parkspot.AuthService.ResetPassword(ctx, args[0], password); err != nil {
+			return err
+		}
+
+		fmt.Printf("reset the password of %s\n", args[0])
+		return nil
+	},
+}
+
+var revokeSessionsCommand = command{
+	args:    "<id>",
+	summary: "sign an admin or a resident out of every device once their access tokens expire",
+	run: func(ctx context.Context, args []string) error {
+		if len(args) != 1 {
+			return errUsage
+		}
+
+		parkspot, err := newApp()
+		if err != nil {
+			return err
+		}
+
+		if err := parkspot.AuthService.RevokeSessions(ctx, args[0]); err != nil {
+			return err
+		}
+
+		fmt.Printf("revoked the sessions of %s\n", args[0])
+		return nil
+	},
+}
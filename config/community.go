@@ -0,0 +1,17 @@
+package config
+
+import (
+	"time"
+	_ "time/tzdata" // so that timezones can be loaded on hosts without a timezone database
+)
+
+type CommunityConfig struct {
+	// Location is the timezone of the community. the dates of permits and visitors are days of its calendar
+	Location *time.Location
+}
+
+func newCommunityConfig() CommunityConfig {
+	return CommunityConfig{
+		Location: readEnvLocation("COMMUNITY_TIMEZONE", "America/New_York"),
+	}
+}
@@ -9,10 +9,11 @@ import (
 )
 
 type Config struct {
-	HTTP     HTTPConfig
-	Postgres PostgresConfig
-	Token    TokenConfig
-	OAuth    OAuthConfig
+	HTTP      HTTPConfig
+	Database  DatabaseConfig
+	Token     TokenConfig
+	OAuth     OAuthConfig
+	Community CommunityConfig
 }
 
 func NewConfig() (Config, error) {
@@ -31,10 +32,11 @@ func NewConfig() (Config, error) {
 	}
 
 	return Config{
-		HTTP:     httpConfig,
-		Postgres: newPostgresConfig(),
-		Token:    newTokenConfig(),
-		OAuth:    oauthConfig,
+		HTTP:      httpConfig,
+		Database:  newDatabaseConfig(),
+		Token:     newTokenConfig(),
+		OAuth:     oauthConfig,
+		Community: newCommunityConfig(),
 	}, nil
 }
 
@@ -1,10 +1,18 @@
 package config
 
+import (
+	"time"
+)
+
 const (
-	DateFormat       = "2006-01-02"
-	DefaultLimit     = 100
-	MaxLimit         = 1000
-	MaxParkingDays   = 20
-	MaxPermitLength  = 15 // in days
-	RefreshCookieKey = "refresh"
+	DateFormat              = "2006-01-02"
+	DefaultLimit            = 100
+	MaxLimit                = 1000
+	RefreshCookieKey        = "refresh"
+	ExpectedPermitWindow    = 24 * time.Hour // how soon a permit must start for its car to be expected
+	MaxViolationWarnings    = 1              // warnings a license plate gets before it is towed
+	MaxAvailabilityDays     = 31
+	DefaultAvailabilityDays = 7
+	WaitlistPromoteInterval = 5 * time.Minute // how often waitlisted permit requests are retried, to catch permits that expired
+	MaxImportBytes          = 10 << 20        // size of the largest CSV of residents that can be imported
 )
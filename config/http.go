@@ -12,6 +12,9 @@ type HTTPConfig struct {
 	WriteTimeout       time.Duration
 	IdleTimeout        time.Duration
 	CORSAllowedOrigins []string
+	// V1DeprecatedAt and V1Sunset are sent on the v1 routes that have a v2 equivalent
+	V1DeprecatedAt time.Time
+	V1Sunset       time.Time
 }
 
 func newHTTPConfig() (HTTPConfig, error) {
@@ -23,5 +26,7 @@ func newHTTPConfig() (HTTPConfig, error) {
 		WriteTimeout:       readEnvDuration("WRITETIMEOUT", 10),
 		IdleTimeout:        readEnvDuration("IDLETIMEOUT", 120),
 		CORSAllowedOrigins: readEnvStringList("CORSALLOWEDORIGINS", []string{"http://*"}),
+		V1DeprecatedAt:     readEnvDate("V1DEPRECATEDAT", "2026-11-01"),
+		V1Sunset:           readEnvDate("V1SUNSET", "2027-05-01"),
 	}, nil
 }
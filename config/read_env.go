@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"github.com/rs/zerolog/log"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -35,6 +36,23 @@ func readEnvDuration(envKey string, defaultValue uint) time.Duration {
 	return parsed
 }
 
+func readEnvBool(envKey string, defaultValue bool) bool {
+	envValueString := os.Getenv(envKey)
+	if envValueString == "" {
+		log.Warn().Msg(newNotFoundError(envKey, defaultValue).Error())
+		return defaultValue
+	}
+
+	parsed, err := strconv.ParseBool(envValueString)
+	if err != nil {
+		conversionErr := newConversionError(envKey, "bool", defaultValue)
+		log.Warn().Msg(fmt.Sprintf("%v: %v", conversionErr, err))
+		return defaultValue
+	}
+
+	return parsed
+}
+
 func readEnvStringList(envKey string, defaultValue []string) []string {
 	envValue := os.Getenv(envKey)
 	if envValue == "" {
@@ -49,3 +67,37 @@ func readEnvStringList(envKey string, defaultValue []string) []string {
 
 	return values
 }
+
+func readEnvLocation(envKey string, defaultValue string) *time.Location {
+	envValue := os.Getenv(envKey)
+	if envValue == "" {
+		log.Warn().Msg(newNotFoundError(envKey, defaultValue).Error())
+		envValue = defaultValue
+	}
+
+	location, err := time.LoadLocation(envValue)
+	if err != nil {
+		conversionErr := newConversionError(envKey, "timezone", defaultValue)
+		log.Warn().Msg(fmt.Sprintf("%v: %v", conversionErr, err))
+		location, _ = time.LoadLocation(defaultValue)
+	}
+
+	return location
+}
+
+func readEnvDate(envKey string, defaultValue string) time.Time {
+	envValue := os.Getenv(envKey)
+	if envValue == "" {
+		log.Warn().Msg(newNotFoundError(envKey, defaultValue).Error())
+		envValue = defaultValue
+	}
+
+	date, err := time.Parse(DateFormat, envValue)
+	if err != nil {
+		conversionErr := newConversionError(envKey, "date", defaultValue)
+		log.Warn().Msg(fmt.Sprintf("%v: %v", conversionErr, err))
+		date, _ = time.Parse(DateFormat, defaultValue)
+	}
+
+	return date
+}
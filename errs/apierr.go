@@ -2,20 +2,43 @@ package errs
 
 import (
 	"errors"
+	"fmt"
+
+	"github.com/dannyvelas/parkspot-backend/i18n"
 )
 
 type APIErr struct {
 	StatusCode int
+	Code       string       // stable identifier of this error that clients can rely on, like permit.car_active
+	Fields     []FieldError // the fields of the request that caused this error, if any
+	Rules      []RuleError  // the parking rules that the request broke, if any
+	params     []any        // the values that translations of this error are formatted with
 	err        error
 }
 
-func NewAPIErr(statusCode int, message string) *APIErr {
+// FieldError is a problem with one field of a request. Rule is the check that the field failed, like required or format
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+func NewAPIErr(statusCode int, code string, message string) *APIErr {
 	return &APIErr{
 		StatusCode: statusCode,
+		Code:       code,
 		err:        errors.New(message),
 	}
 }
 
+func NewFieldError(field string, rule string, message string) FieldError {
+	return FieldError{
+		Field:   field,
+		Rule:    rule,
+		Message: message,
+	}
+}
+
 // Error implements error interface
 func (e *APIErr) Error() string {
 	return e.err.Error()
@@ -24,3 +47,55 @@ func (e *APIErr) Error() string {
 func (e *APIErr) Unwrap() error {
 	return e.err
 }
+
+// wrap returns an error with the status code and code of e, whose message is built from format.
+// format is expected to wrap e with %w, so that errors.Is still matches e
+func (e *APIErr) wrap(format string, a ...any) *APIErr {
+	return &APIErr{
+		StatusCode: e.StatusCode,
+		Code:       e.Code,
+		err:        fmt.Errorf(format, a...),
+	}
+}
+
+// withParams sets the values that the translations of e are formatted with
+func (e *APIErr) withParams(params ...any) *APIErr {
+	e.params = params
+	return e
+}
+
+// In returns e with its message and the messages of its fields translated to lang.
+// messages are left in english when lang has no translation for them
+func (e *APIErr) In(lang i18n.Lang) *APIErr {
+	catalog, ok := catalogs[lang]
+	if !ok {
+		return e
+	}
+
+	translated := *e
+	translated.Fields = make([]FieldError, 0, len(e.Fields))
+	for _, fieldErr := range e.Fields {
+		if format, ok := catalog.fieldRules[fieldErr.Rule]; ok {
+			fieldErr.Message = fmt.Sprintf(format, fieldErr.Field)
+		}
+		translated.Fields = append(translated.Fields, fieldErr)
+	}
+
+	if e.Rules != nil {
+		translated.Rules = make([]RuleError, 0, len(e.Rules))
+		for _, ruleErr := range e.Rules {
+			translated.Rules = append(translated.Rules, ruleErr.In(lang))
+		}
+	}
+
+	params := e.params
+	if e.Code == invalidFieldsCode {
+		params = []any{joinFieldMessages(translated.Fields)}
+	}
+
+	if format, ok := catalog.messages[e.Code]; ok {
+		translated.err = errors.New(fmt.Sprintf(format, params...))
+	}
+
+	return &translated
+}
@@ -0,0 +1,94 @@
+package errs
+
+import (
+	"testing"
+
+	"github.com/dannyvelas/parkspot-backend/i18n"
+)
+
+func TestIn(t *testing.T) {
+	tests := map[string]struct {
+		err      *APIErr
+		lang     i18n.Lang
+		expected string
+	}{
+		"english is left as is": {
+			err:      CarActivePermit,
+			lang:     i18n.English,
+			expected: CarActivePermit.Error(),
+		},
+		"unsupported language is left in english": {
+			err:      CarActivePermit,
+			lang:     "fr",
+			expected: CarActivePermit.Error(),
+		},
+		"spanish": {
+			err:      NotFound,
+			lang:     i18n.Spanish,
+			expected: "No encontrado",
+		},
+		"spanish with params": {
+			err:      NewErrCarWithLPAlreadyExists("ABC123"),
+			lang:     i18n.Spanish,
+			expected: "Ya existe un carro con la placa ABC123",
+		},
+		"spanish without translation is left in english": {
+			err:      BadRequest("test.untranslated", "untranslated"),
+			lang:     i18n.Spanish,
+			expected: "untranslated",
+		},
+		"spanish invalid fields are rebuilt from their fields": {
+			err:      InvalidFields(NewFieldError("startDate", "format", "startDate must be in YYYY-MM-DD format")),
+			lang:     i18n.Spanish,
+			expected: "Uno o más campos no son válidos: startDate no tiene un formato válido",
+		},
+	}
+
+	for testName, test := range tests {
+		translated := test.err.In(test.lang)
+		if translated.Error() != test.expected {
+			t.Errorf("%s failed: expected %q, got %q", testName, test.expected, translated.Error())
+		}
+		if translated.Code != test.err.Code || translated.StatusCode != test.err.StatusCode {
+			t.Errorf("%s failed: expected translation to keep code %q and status %d", testName, test.err.Code, test.err.StatusCode)
+		}
+	}
+}
+
+func TestIn_DoesNotModifyOriginal(t *testing.T) {
+	err := InvalidFields(NewFieldError("startDate", "format", "startDate must be in YYYY-MM-DD format"))
+	_ = err.In(i18n.Spanish)
+
+	if err.Fields[0].Message != "startDate must be in YYYY-MM-DD format" {
+		t.Errorf("expected the fields of the original error to stay in english, got %q", err.Fields[0].Message)
+	}
+}
+
+func TestIn_RulesBroken(t *testing.T) {
+	err := NewRulesBroken([]RuleError{
+		NewRuleError("no parking on holidays", "blackout_dates", "rules.blackout_dates", "guest parking is not allowed on %s", "2026-12-25"),
+		NewRuleError("short stays", "max_length", "rules.max_length", "cannot be longer than %d days", 3),
+	})
+	if err.Error() != `This request breaks one or more of your community's parking rules: "no parking on holidays", "short stays".` {
+		t.Errorf("expected the message to name every rule that was broken, got %q", err.Error())
+	}
+
+	translated := err.In(i18n.Spanish)
+	expectedRules := []RuleError{
+		{Rule: "no parking on holidays", Kind: "blackout_dates", Message: "no se permite estacionar invitados el 2026-12-25"},
+		{Rule: "short stays", Kind: "max_length", Message: "no puede durar más de 3 días"},
+	}
+	if len(translated.Rules) != len(expectedRules) {
+		t.Fatalf("expected %d rules, got %d", len(expectedRules), len(translated.Rules))
+	}
+	for i, expected := range expectedRules {
+		got := translated.Rules[i]
+		if got.Rule != expected.Rule || got.Kind != expected.Kind || got.Message != expected.Message {
+			t.Errorf("expected rule %d to be %+v, got %+v", i, expected, got)
+		}
+	}
+
+	if err.Rules[1].Message != "cannot be longer than 3 days" {
+		t.Errorf("expected the rules of the original error to stay in english, got %q", err.Rules[1].Message)
+	}
+}
@@ -0,0 +1,20 @@
+package errs
+
+import (
+	"net/http"
+)
+
+var (
+	BanNoTarget = NewAPIErr(
+		http.StatusBadRequest,
+		"ban.no_target",
+		"A ban must be for exactly one of: a licensePlate, or a visitor's firstName and lastName")
+	BannedLicensePlate = NewAPIErr(
+		http.StatusBadRequest,
+		"ban.license_plate_banned",
+		"This license plate is banned from the property. Please contact your community's administration office.")
+	BannedVisitor = NewAPIErr(
+		http.StatusBadRequest,
+		"ban.visitor_banned",
+		"This visitor is banned from the property. Please contact your community's administration office.")
+)
@@ -10,7 +10,9 @@ var ErrCarWithLPAlreadyExists = errors.New("a car with this licensePlate")
 
 func NewErrCarWithLPAlreadyExists(licensePlate string) *APIErr {
 	return &APIErr{
-		http.StatusBadRequest,
-		fmt.Errorf("%w %s %w", ErrCarWithLPAlreadyExists, licensePlate, AlreadyExists),
+		StatusCode: http.StatusBadRequest,
+		Code:       "car.license_plate_exists",
+		params:     []any{licensePlate},
+		err:        fmt.Errorf("%w %s %w", ErrCarWithLPAlreadyExists, licensePlate, AlreadyExists),
 	}
 }
@@ -0,0 +1,20 @@
+package errs
+
+import (
+	"github.com/dannyvelas/parkspot-backend/i18n"
+)
+
+// catalog has the translations of errors to one language. english has no catalog,
+// since the messages that errors are created with are already in english
+type catalog struct {
+	// messages are keyed by error code. they are formatted with the params of their error
+	messages map[string]string
+	// fieldRules are keyed by the rule of a field error. they are formatted with the name of the field
+	fieldRules map[string]string
+	// rules are keyed by the key of a rule error. they are formatted with the params of their rule error
+	rules map[string]string
+}
+
+var catalogs = map[i18n.Lang]catalog{
+	i18n.Spanish: spanishCatalog,
+}
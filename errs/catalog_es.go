@@ -0,0 +1,148 @@
+package errs
+
+import (
+	"fmt"
+
+	"github.com/dannyvelas/parkspot-backend/config"
+)
+
+var spanishCatalog = catalog{
+	messages: map[string]string{
+		// request
+		"unauthorized":                  "No autorizado",
+		"not_found":                     "No encontrado",
+		"request.missing_id":            "El campo ID es obligatorio pero no se envió",
+		"request.id_not_uuid":           "El campo ID no es un UUID",
+		"resident.invalid_id":           "El ID de un residente debe empezar con una 'B' o una 'T', seguida de 7 números",
+		"already_exists":                "Ya existe un registro con estos datos",
+		"request.method_not_allowed":    "Método no permitido",
+		"internal":                      "Error interno del servidor",
+		"request.missing_fields":        "Faltan uno o más campos: %s",
+		invalidFieldsCode:               "Uno o más campos no son válidos: %s",
+		"request.malformed":             "%s mal formado",
+		"request.edit_fields_empty":     "Los campos a editar (%s) no pueden estar todos vacíos",
+		"request.invalid_cursor":        "El cursor no es válido",
+		"request.invalid_sort":          "No se puede ordenar por %s. Campos ordenables: %s",
+		"request.invalid_export_format": "El formato debe ser csv o xlsx",
+		"conflict":                      "Fue editado desde que se leyó",
+		"request.missing_version":       "Se requiere un encabezado If-Match o un campo version",
+		"request.invalid_if_match":      "If-Match debe ser el ETag de una respuesta",
+
+		// not found
+		"admin.not_found":          "No se encontró el administrador",
+		"ban.not_found":            "No se encontró la prohibición",
+		"car.not_found":            "No se encontró el carro",
+		"gate_event.not_found":     "No se encontró la entrada o salida",
+		"parking_space.not_found":  "No se encontró el espacio de estacionamiento",
+		"permit.not_found":         "No se encontró el permiso",
+		"permit_rule.not_found":    "No se encontró la regla de permisos",
+		"resident.not_found":       "No se encontró el residente",
+		"violation.not_found":      "No se encontró la infracción",
+		"visitor.not_found":        "No se encontró el visitante",
+		"waitlist_entry.not_found": "No se encontró la solicitud en lista de espera",
+
+		// conflicts
+		"car.conflict":      "El carro fue editado desde que se leyó. Vuelva a cargarlo antes de editarlo",
+		"permit.conflict":   "El permiso fue editado desde que se leyó. Vuelva a cargarlo antes de editarlo",
+		"resident.conflict": "El residente fue editado desde que se leyó. Vuelva a cargarlo antes de editarlo",
+
+		// ban
+		"ban.no_target": "Una prohibición debe ser para exactamente uno de: una placa (licensePlate)," +
+			" o el nombre y apellido de un visitante (firstName y lastName)",
+		"ban.license_plate_banned": "Esta placa tiene prohibida la entrada a la propiedad." +
+			" Por favor comuníquese con la oficina de administración de su comunidad.",
+		"ban.visitor_banned": "Este visitante tiene prohibida la entrada a la propiedad." +
+			" Por favor comuníquese con la oficina de administración de su comunidad.",
+
+		// car
+		"car.license_plate_exists": "Ya existe un carro con la placa %s",
+
+		// gate event
+		"gate_event.no_pass": "Una entrada o salida debe registrarse con exactamente uno de: visitorID, permitID o licensePlate",
+		"gate_event.pass_not_active": "No se puede registrar la entrada" +
+			" porque este invitado no tiene un pase activo en este momento.",
+		"gate_event.already_on_property": "No se puede registrar la entrada" +
+			" porque este invitado ya entró y no ha salido.",
+		"gate_event.not_on_property": "No se puede registrar la salida porque este invitado no ha entrado.",
+
+		// import
+		"import.empty":        "El CSV debe tener una fila de encabezados y al menos una fila de residentes",
+		"import.invalid_rows": "No se importó nada porque una o más filas del CSV no son válidas",
+		"import.malformed":    "El CSV está mal formado en la línea %d",
+		"import.too_large":    fmt.Sprintf("El CSV puede pesar como máximo %d MB", config.MaxImportBytes>>20),
+
+		// parking space
+		"permit.space_dne": "El espacio de estacionamiento que eligió para este permiso no existe. Por favor elija otro espacio.",
+		"permit.space_taken": "No se puede crear un permiso en estas fechas" +
+			" porque el espacio de estacionamiento que eligió está asignado a otro permiso durante ese tiempo.",
+		"permit.lot_at_capacity": "No se puede crear un permiso en estas fechas" +
+			" porque todos los espacios para invitados están ocupados en al menos uno de esos días.",
+		"parking_space.availability_range_too_long": fmt.Sprintf(
+			"La disponibilidad se puede consultar por un máximo de %d días a la vez.", config.MaxAvailabilityDays),
+
+		// permit
+		"permit.resident_dne": "Los usuarios deben tener una cuenta registrada para solicitar un permiso de" +
+			" estacionamiento para invitados. Por favor cree su cuenta antes de solicitar su permiso.",
+		"permit.car_dne": "El carro que eligió para este permiso no" +
+			" existe. Por favor cree o elija otro carro.",
+		"permit.car_active": "No se puede crear un permiso en estas fechas" +
+			" porque este carro tiene al menos un permiso activo durante ese tiempo.",
+		"permit.too_long": "Error: Las solicitudes no pueden durar más de la duración máxima de un permiso, %[1]d días," +
+			" a menos que haya una excepción." +
+			"\nSi este residente quiere que su invitado se estacione por más de %[1]d días," +
+			" puede hacer otra solicitud cuando esta venza.",
+		"permit.resident_too_many_active": "No se puede crear un permiso en estas fechas" +
+			" porque este residente alcanzó la cantidad máxima de permisos activos (%d) durante ese tiempo.",
+		"resident.days_used_up": "Error: Este residente ha dado permisos de estacionamiento que suman" +
+			" un total de %d días." +
+			"\nLos residentes pueden dar un máximo de %d días de pases de estacionamiento, a menos que haya una excepción." +
+			"\nEste residente debe esperar hasta el próximo año para dar nuevos pases de estacionamiento.",
+		"resident.days_exceeded": "Error: Esta solicitud excedería el límite anual de pases de estacionamiento" +
+			" para invitados del residente, de %d días." +
+			"\nEste residente ha dado permisos de estacionamiento por un total de %d días." +
+			"\nEste residente puede dar como máximo %d día(s) más antes de llegar a su límite." +
+			"\nEste residente solo puede dar más permisos si tiene días ilimitados o si" +
+			" los permisos que solicita son excepciones",
+		"permit.resident_exception": "Los residentes no pueden solicitar permisos de estacionamiento con excepciones",
+		"permit.other_resident":     "Los residentes no pueden solicitar un permiso de estacionamiento para otro residente",
+
+		// permit rules
+		"rules.broken": "Esta solicitud incumple una o más de las reglas de estacionamiento de su comunidad: %s.",
+
+		// resident
+		"resident.password_not_editable": "Las contraseñas de los residentes no se pueden editar." +
+			" Solo se pueden cambiar si el residente solicita restablecer su contraseña.",
+
+		// violation
+		"violation.not_tow": "Solo se puede generar un aviso de remolque para una infracción" +
+			" que terminó en remolque y que no fue descartada.",
+		"violation.not_open":         "Solo se pueden disputar las infracciones abiertas.",
+		"violation.already_resolved": "Esta infracción ya fue resuelta.",
+
+		// visitor
+		"visitor.other_resident": "Los residentes no pueden crear un visitante para otro residente",
+
+		// waitlist
+		"waitlist.entry_not_waiting": "Solo se pueden cancelar las solicitudes en lista de espera que siguen esperando.",
+	},
+	fieldRules: map[string]string{
+		"required":   "%s no puede estar vacío",
+		"format":     "%s no tiene un formato válido",
+		"max_length": "%s es demasiado largo",
+		"min":        "%s es menor que el mínimo permitido",
+		"max":        "%s es mayor que el máximo permitido",
+		"one_of":     "%s no es uno de los valores permitidos",
+		"future":     "%s debe ser en el futuro",
+		"before":     "%s debe ser anterior a la fecha final",
+		"forbidden":  "%s no está permitido",
+		"filterable": "No se puede filtrar por %s",
+		"operator":   "%s no se puede comparar con ese operador",
+		"conflict":   "%s no coincide con la primera fila de este residente",
+	},
+	rules: map[string]string{
+		"rules.blackout_dates":         "no se permite estacionar invitados el %s",
+		"rules.max_length":             "no puede durar más de %d días",
+		"rules.max_permits_per_month":  "un residente puede tener como máximo %d permisos que empiecen en el mismo mes",
+		"rules.max_visitors_per_month": "un residente puede tener como máximo %d visitantes que empiecen en el mismo mes",
+	},
+}
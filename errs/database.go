@@ -13,4 +13,8 @@ var (
 	ErrDBBuildingQuery   = errors.New("error building database query")
 	ErrDBGetRowsAffected = errors.New("error getting rows affected by database query")
 	ErrDBPinging         = errors.New("error pinging database")
+	ErrDBMigrating       = errors.New("error migrating database")
+	ErrDBSchemaBehind    = errors.New("database schema is missing migrations")
+	ErrDBSchemaDirty     = errors.New("database schema is dirty")
+	ErrDBSchemaUnknown   = errors.New("database schema is at a migration that is not embedded")
 )
@@ -0,0 +1,24 @@
+package errs
+
+import (
+	"net/http"
+)
+
+var (
+	GateEventNoPass = NewAPIErr(
+		http.StatusBadRequest,
+		"gate_event.no_pass",
+		"A check-in or check-out must be recorded against exactly one of: visitorID, permitID or licensePlate")
+	GateEventPassNotActive = NewAPIErr(
+		http.StatusBadRequest,
+		"gate_event.pass_not_active",
+		"Cannot check in because this guest does not have an active pass right now.")
+	GateEventAlreadyOnProperty = NewAPIErr(
+		http.StatusBadRequest,
+		"gate_event.already_on_property",
+		"Cannot check in because this guest is already checked in and has not checked out.")
+	GateEventNotOnProperty = NewAPIErr(
+		http.StatusBadRequest,
+		"gate_event.not_on_property",
+		"Cannot check out because this guest has not checked in.")
+)
@@ -0,0 +1,28 @@
+package errs
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/dannyvelas/parkspot-backend/config"
+)
+
+var (
+	ImportEmpty = NewAPIErr(
+		http.StatusBadRequest,
+		"import.empty",
+		"The CSV must have a header row and at least one row of residents")
+	ImportTooLarge = NewAPIErr(
+		http.StatusRequestEntityTooLarge,
+		"import.too_large",
+		fmt.Sprintf("The CSV can be at most %d MB", config.MaxImportBytes>>20))
+	ImportInvalidRows = NewAPIErr(
+		http.StatusBadRequest,
+		"import.invalid_rows",
+		"Nothing was imported because one or more rows of the CSV are invalid")
+)
+
+func NewImportMalformed(line int) *APIErr {
+	return NewAPIErr(http.StatusBadRequest, "import.malformed", fmt.Sprintf("The CSV is malformed at line %d", line)).
+		withParams(line)
+}
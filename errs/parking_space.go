@@ -0,0 +1,28 @@
+package errs
+
+import (
+	"fmt"
+	"github.com/dannyvelas/parkspot-backend/config"
+	"net/http"
+)
+
+var (
+	SpaceForPermitDNE = NewAPIErr(
+		http.StatusBadRequest,
+		"permit.space_dne",
+		"The parking space that you chose for this permit does not exist. Please choose another space.")
+	SpaceTaken = NewAPIErr(
+		http.StatusBadRequest,
+		"permit.space_taken",
+		"Cannot create a permit during these dates"+
+			" because the parking space that you chose is assigned to another permit during that time.")
+	LotAtCapacity = NewAPIErr(
+		http.StatusBadRequest,
+		"permit.lot_at_capacity",
+		"Cannot create a permit during these dates"+
+			" because every guest parking space is taken on at least one of those days.")
+	AvailabilityRangeTooLong = NewAPIErr(
+		http.StatusBadRequest,
+		"parking_space.availability_range_too_long",
+		fmt.Sprintf("Availability can be requested for at most %d days at a time.", config.MaxAvailabilityDays))
+)
@@ -2,7 +2,6 @@ package errs
 
 import (
 	"fmt"
-	"github.com/dannyvelas/parkspot-backend/config"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 	"net/http"
@@ -11,60 +10,74 @@ import (
 var (
 	ResidentForPermitDNE = NewAPIErr(
 		http.StatusBadRequest,
+		"permit.resident_dne",
 		"Users must have a registered account to request a guest parking"+
 			" permit. Please create their account before requesting their permit.")
 	CarForPermitDNE = NewAPIErr(
 		http.StatusBadRequest,
+		"permit.car_dne",
 		"The car that you chose for this permit does not"+
 			" exist. Please create or choose another car.")
 	CarActivePermit = NewAPIErr(
 		http.StatusBadRequest,
+		"permit.car_active",
 		"Cannot create a permit during these dates"+
 			" because this car has at least one active permit during that time.")
 	PermitTooLong = NewAPIErr(
 		http.StatusBadRequest,
-		fmt.Sprintf("Error: Requests cannot be longer than %d days,"+
-			" unless there is an exception."+
-			"\nIf this resident wants their guest to park for more than %d days, they"+
-			" can apply for another request once that one expires.",
-			config.MaxPermitLength,
-			config.MaxPermitLength),
-	)
-	ResidentTwoActivePermits = NewAPIErr(
+		"permit.too_long",
+		"Error: Requests cannot be longer than the maximum permit length")
+	ResidentTooManyActivePermits = NewAPIErr(
 		http.StatusBadRequest,
+		"permit.resident_too_many_active",
 		"Cannot create a permit during these dates"+
-			" because this resident has at least two active permits during that time.")
+			" because this resident has reached the maximum amount of active permits")
 )
 
-func EntityDaysTooLong(entity string, amtDaysUsed int) *APIErr {
+func NewPermitTooLong(maxPermitLength int) *APIErr {
+	return PermitTooLong.wrap("%w of %d days,"+
+		" unless there is an exception."+
+		"\nIf this resident wants their guest to park for more than %d days, they"+
+		" can apply for another request once that one expires.",
+		PermitTooLong, maxPermitLength, maxPermitLength).withParams(maxPermitLength)
+}
+
+func NewResidentTooManyActivePermits(maxActivePermits int) *APIErr {
+	return ResidentTooManyActivePermits.wrap("%w (%d) during that time.", ResidentTooManyActivePermits, maxActivePermits).
+		withParams(maxActivePermits)
+}
+
+func EntityDaysTooLong(entity string, amtDaysUsed, maxParkingDays int) *APIErr {
 	entityLower := cases.Lower(language.English).String(entity)
 	entityTitle := cases.Title(language.English).String(entity)
 
 	return NewAPIErr(
 		http.StatusBadRequest,
+		entityLower+".days_used_up",
 		fmt.Sprintf("Error: This %s has used parking permits that have lasted"+
 			" a combined total of %d days."+
 			"\n%ss are allowed maximum %d days of parking passes, unless there is an exception."+
 			"\nThis %s must wait until next year to give out new parking passes.",
 			entityLower, amtDaysUsed,
-			entityTitle, config.MaxParkingDays,
+			entityTitle, maxParkingDays,
 			entityLower),
-	)
+	).withParams(amtDaysUsed, maxParkingDays)
 }
 
-func PermitPlusEntityDaysTooLong(entity string, amtDaysUsed int) *APIErr {
+func PermitPlusEntityDaysTooLong(entity string, amtDaysUsed, maxParkingDays int) *APIErr {
 	entityLower := cases.Lower(language.English).String(entity)
 
 	return NewAPIErr(
 		http.StatusBadRequest,
+		entityLower+".days_exceeded",
 		fmt.Sprintf("Error: This request would exceed the %s's"+
 			" yearly guest parking pass limit of %d days."+
 			"\nThis %s has given out parking permits for a total of %d days."+
 			"\nThis %s can give out max %d more day(s) before reaching their limit."+
 			"\nThis %s can only give more permits if they have unlimited days or if"+
 			" their requested permites are exceptions",
-			entityLower, config.MaxParkingDays,
+			entityLower, maxParkingDays,
 			entityLower, amtDaysUsed,
-			entityLower, config.MaxParkingDays-amtDaysUsed,
-			entityLower))
+			entityLower, maxParkingDays-amtDaysUsed,
+			entityLower)).withParams(maxParkingDays, amtDaysUsed, maxParkingDays-amtDaysUsed)
 }
@@ -0,0 +1,59 @@
+package errs
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/dannyvelas/parkspot-backend/i18n"
+)
+
+var (
+	RulesBroken = NewAPIErr(
+		http.StatusBadRequest,
+		"rules.broken",
+		"This request breaks one or more of your community's parking rules")
+)
+
+// RuleError is a parking rule of a community that a request breaks. Rule is the name that the community gave it,
+// and Kind is what it checks, like blackout_dates
+type RuleError struct {
+	Rule    string `json:"rule"`
+	Kind    string `json:"kind"`
+	Message string `json:"message"`
+	key     string // the key of the translations of Message, like rules.max_length
+	params  []any  // the values that the translations of Message are formatted with
+}
+
+// NewRuleError is a break of the rule named rule, whose message is format formatted with params.
+// key picks the translations of the message
+func NewRuleError(rule, kind, key, format string, params ...any) RuleError {
+	return RuleError{
+		Rule:    rule,
+		Kind:    kind,
+		Message: fmt.Sprintf(format, params...),
+		key:     key,
+		params:  params,
+	}
+}
+
+// In returns e with its message translated to lang. it is left in english when lang has no translation for it
+func (e RuleError) In(lang i18n.Lang) RuleError {
+	if format, ok := catalogs[lang].rules[e.key]; ok {
+		e.Message = fmt.Sprintf(format, e.params...)
+	}
+	return e
+}
+
+// NewRulesBroken lists every rule that was broken in its Rules, and names them in its message
+func NewRulesBroken(ruleErrs []RuleError) *APIErr {
+	names := make([]string, 0, len(ruleErrs))
+	for _, ruleErr := range ruleErrs {
+		names = append(names, fmt.Sprintf("%q", ruleErr.Rule))
+	}
+	joinedNames := strings.Join(names, ", ")
+
+	rulesBroken := RulesBroken.wrap("%w: %s.", RulesBroken, joinedNames).withParams(joinedNames)
+	rulesBroken.Rules = ruleErrs
+	return rulesBroken
+}
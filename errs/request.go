@@ -3,45 +3,97 @@ package errs
 import (
 	"fmt"
 	"net/http"
+	"strings"
 )
 
 var (
-	Unauthorized   = NewAPIErr(http.StatusUnauthorized, "unauthorized")
-	NotFound       = NewAPIErr(http.StatusNotFound, "not found")
-	MissingIDField = NewAPIErr(http.StatusBadRequest, "ID field is required but missing")
-	IDNotUUID      = NewAPIErr(http.StatusBadRequest, "ID field is not a UUID")
-	InvalidResID   = NewAPIErr(http.StatusBadRequest, "ResidentID must start be a 'B' or a 'T', followed by 7 numbers")
-	AlreadyExists  = NewAPIErr(http.StatusBadRequest, "already exists")
+	Unauthorized     = NewAPIErr(http.StatusUnauthorized, "unauthorized", "unauthorized")
+	NotFound         = NewAPIErr(http.StatusNotFound, "not_found", "not found")
+	MissingIDField   = NewAPIErr(http.StatusBadRequest, "request.missing_id", "ID field is required but missing")
+	IDNotUUID        = NewAPIErr(http.StatusBadRequest, "request.id_not_uuid", "ID field is not a UUID")
+	InvalidResID     = NewAPIErr(http.StatusBadRequest, "resident.invalid_id", "ResidentID must start be a 'B' or a 'T', followed by 7 numbers")
+	AlreadyExists    = NewAPIErr(http.StatusBadRequest, "already_exists", "already exists")
+	MethodNotAllowed = NewAPIErr(http.StatusMethodNotAllowed, "request.method_not_allowed", "Method Not Allowed")
+	Internal         = NewAPIErr(http.StatusInternalServerError, "internal", "Internal Server Error")
+	InvalidCursor    = NewAPIErr(http.StatusBadRequest, "request.invalid_cursor", "cursor is invalid")
+	InvalidExport    = NewAPIErr(http.StatusBadRequest, "request.invalid_export_format", "format must be csv or xlsx")
+	Conflict         = NewAPIErr(http.StatusConflict, "conflict", "was edited since it was read")
+	MissingVersion   = NewAPIErr(http.StatusPreconditionRequired, "request.missing_version", "an If-Match header or a version field is required")
+	InvalidIfMatch   = NewAPIErr(http.StatusBadRequest, "request.invalid_if_match", "If-Match must be the ETag of a response")
 )
 
-func BadRequest(message string) *APIErr {
-	return NewAPIErr(http.StatusBadRequest, message)
+func BadRequest(code string, message string) *APIErr {
+	return NewAPIErr(http.StatusBadRequest, code, message)
 }
 
+// NewNotFound has a code of the form <resource>.not_found, like parking_space.not_found
 func NewNotFound(resource string) *APIErr {
-	return &APIErr{http.StatusNotFound, fmt.Errorf("%s %w", resource, NotFound)}
+	notFound := NotFound.wrap("%s %w", resource, NotFound)
+	notFound.Code = strings.ReplaceAll(resource, " ", "_") + "." + NotFound.Code
+	return notFound
+}
+
+// NewConflict is an edit of a resource that was made to an older version of it. it has a code of the form
+// <resource>.conflict, like car.conflict
+func NewConflict(resource string) *APIErr {
+	conflict := Conflict.wrap("%s %w", resource, Conflict)
+	conflict.Code = strings.ReplaceAll(resource, " ", "_") + "." + Conflict.Code
+	return conflict
+}
+
+// PreconditionFailed is a conflict whose version was sent in an If-Match header. it keeps the code of conflict
+func PreconditionFailed(conflict *APIErr) *APIErr {
+	preconditionFailed := *conflict
+	preconditionFailed.StatusCode = http.StatusPreconditionFailed
+	return &preconditionFailed
 }
 
 func NewUnauthorized(message string) *APIErr {
-	return &APIErr{http.StatusUnauthorized, fmt.Errorf("%s %w", message, Unauthorized)}
+	return Unauthorized.wrap("%s %w", message, Unauthorized)
 }
 
-func EmptyFields(fields string) *APIErr {
-	return NewAPIErr(http.StatusBadRequest, "One or more missing fields: "+fields)
+func EmptyFields(fields ...string) *APIErr {
+	joinedFields := strings.Join(fields, ", ")
+	emptyFieldsErr := NewAPIErr(http.StatusBadRequest, "request.missing_fields", "One or more missing fields: "+joinedFields)
+	for _, field := range fields {
+		emptyFieldsErr.Fields = append(emptyFieldsErr.Fields, NewFieldError(field, "required", field+" cannot be empty"))
+	}
+	return emptyFieldsErr.withParams(joinedFields)
 }
 
-func InvalidFields(fields string) *APIErr {
-	return NewAPIErr(http.StatusBadRequest, "One or more invalid fields: "+fields)
+// the message of an error with this code is rebuilt from its fields when it is translated
+const invalidFieldsCode = "request.invalid_fields"
+
+func InvalidFields(fieldErrs ...FieldError) *APIErr {
+	invalidFieldsErr := NewAPIErr(http.StatusBadRequest, invalidFieldsCode, "One or more invalid fields: "+joinFieldMessages(fieldErrs))
+	invalidFieldsErr.Fields = fieldErrs
+	return invalidFieldsErr
+}
+
+func joinFieldMessages(fieldErrs []FieldError) string {
+	messages := make([]string, 0, len(fieldErrs))
+	for _, fieldErr := range fieldErrs {
+		messages = append(messages, fieldErr.Message)
+	}
+	return strings.Join(messages, ". ")
 }
 
 func Malformed(payload string) *APIErr {
-	return NewAPIErr(http.StatusBadRequest, payload+" malformed")
+	return NewAPIErr(http.StatusBadRequest, "request.malformed", payload+" malformed").withParams(payload)
+}
+
+// InvalidSort has the fields that a list can be sorted by
+func InvalidSort(sort string, sortable []string) *APIErr {
+	joinedSortable := strings.Join(sortable, ", ")
+	return NewAPIErr(http.StatusBadRequest, "request.invalid_sort", fmt.Sprintf("cannot sort by %s. sortable fields: %s", sort, joinedSortable)).
+		withParams(sort, joinedSortable)
 }
 
 func NewAlreadyExists(resource string) *APIErr {
-	return &APIErr{http.StatusBadRequest, fmt.Errorf("%s %w", resource, AlreadyExists)}
+	return AlreadyExists.wrap("%s %w", resource, AlreadyExists)
 }
 
 func AllEditFieldsEmpty(fields string) *APIErr {
-	return NewAPIErr(http.StatusBadRequest, fmt.Sprintf("All edit fields (%s) cannot be empty", fields))
+	return NewAPIErr(http.StatusBadRequest, "request.edit_fields_empty", fmt.Sprintf("All edit fields (%s) cannot be empty", fields)).
+		withParams(fields)
 }
@@ -0,0 +1,20 @@
+package errs
+
+import (
+	"net/http"
+)
+
+var (
+	ViolationNotTow = NewAPIErr(
+		http.StatusBadRequest,
+		"violation.not_tow",
+		"A tow notice can only be generated for a violation that escalated to a tow and was not dismissed.")
+	ViolationNotOpen = NewAPIErr(
+		http.StatusBadRequest,
+		"violation.not_open",
+		"Only open violations can be disputed.")
+	ViolationAlreadyResolved = NewAPIErr(
+		http.StatusBadRequest,
+		"violation.already_resolved",
+		"This violation has already been resolved.")
+)
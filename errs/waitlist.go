@@ -0,0 +1,12 @@
+package errs
+
+import (
+	"net/http"
+)
+
+var (
+	WaitlistEntryNotWaiting = NewAPIErr(
+		http.StatusBadRequest,
+		"waitlist.entry_not_waiting",
+		"Only waitlisted permit requests that are still waiting can be cancelled.")
+)
@@ -0,0 +1,2 @@
+// Package export writes the rows of lists as CSV or XLSX spreadsheets, a row at a time.
+package export
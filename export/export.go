@@ -0,0 +1,61 @@
+package export
+
+import (
+	"io"
+
+	"github.com/dannyvelas/parkspot-backend/i18n"
+)
+
+// Format is a format of spreadsheet
+type Format string
+
+const (
+	CSV  Format = "csv"
+	XLSX Format = "xlsx"
+)
+
+// ParseFormat reads a format, like xlsx. formats that are empty are CSV
+func ParseFormat(format string) (Format, bool) {
+	switch Format(format) {
+	case "", CSV:
+		return CSV, true
+	case XLSX:
+		return XLSX, true
+	default:
+		return "", false
+	}
+}
+
+func (f Format) ContentType() string {
+	if f == XLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// Table is how the rows of a list are exported. Headers are keyed by language
+type Table[T any] struct {
+	Headers map[i18n.Lang][]string
+	Row     func(T) []string
+}
+
+// HeadersIn are the headers of t in lang, or in english when t has none in lang
+func (t Table[T]) HeadersIn(lang i18n.Lang) []string {
+	if headers, ok := t.Headers[lang]; ok {
+		return headers
+	}
+	return t.Headers[i18n.English]
+}
+
+// Writer writes the rows of a spreadsheet as they come. Close must be called after the last row
+type Writer interface {
+	WriteRow(cells []string) error
+	Close() error
+}
+
+func NewWriter(w io.Writer, format Format) Writer {
+	if format == XLSX {
+		return newXLSXWriter(w)
+	}
+	return newCSVWriter(w)
+}
@@ -0,0 +1,90 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"testing"
+)
+
+func TestXLSXWriter(t *testing.T) {
+	var buf bytes.Buffer
+	writer := NewWriter(&buf, XLSX)
+	for _, row := range [][]string{{"Name", "Plate"}, {"Ana", "ABC123"}, {"<Luis & Co>", ""}} {
+		if err := writer.WriteRow(row); err != nil {
+			t.Fatalf("error writing row: %v", err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("error closing writer: %v", err)
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("error reading workbook: %v", err)
+	}
+	for _, part := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels"} {
+		if _, err := archive.Open(part); err != nil {
+			t.Errorf("expected workbook to have %s: %v", part, err)
+		}
+	}
+
+	sheet, err := archive.Open("xl/worksheets/sheet1.xml")
+	if err != nil {
+		t.Fatalf("error opening sheet: %v", err)
+	}
+	content, err := io.ReadAll(sheet)
+	if err != nil {
+		t.Fatalf("error reading sheet: %v", err)
+	}
+
+	expected := xlsxSheetStart +
+		`<row><c t="inlineStr"><is><t xml:space="preserve">Name</t></is></c><c t="inlineStr"><is><t xml:space="preserve">Plate</t></is></c></row>` +
+		`<row><c t="inlineStr"><is><t xml:space="preserve">Ana</t></is></c><c t="inlineStr"><is><t xml:space="preserve">ABC123</t></is></c></row>` +
+		`<row><c t="inlineStr"><is><t xml:space="preserve">&lt;Luis &amp; Co&gt;</t></is></c><c t="inlineStr"><is><t xml:space="preserve"></t></is></c></row>` +
+		xlsxSheetEnd
+	if string(content) != expected {
+		t.Errorf("expected %q, got %q", expected, string(content))
+	}
+}
+
+func TestCSVWriter(t *testing.T) {
+	var buf bytes.Buffer
+	writer := NewWriter(&buf, CSV)
+	for _, row := range [][]string{{"Name", "Plate"}, {"=HYPERLINK(\"http://evil\")", "+1"}, {"-2", "@SUM(A1)"}, {"Ana", ""}} {
+		if err := writer.WriteRow(row); err != nil {
+			t.Fatalf("error writing row: %v", err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("error closing writer: %v", err)
+	}
+
+	expected := "Name,Plate\n" +
+		"\"'=HYPERLINK(\"\"http://evil\"\")\",'+1\n" +
+		"'-2,'@SUM(A1)\n" +
+		"Ana,\n"
+	if buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	tests := map[string]struct {
+		format       string
+		expected     Format
+		expectedOkay bool
+	}{
+		"empty":   {format: "", expected: CSV, expectedOkay: true},
+		"csv":     {format: "csv", expected: CSV, expectedOkay: true},
+		"xlsx":    {format: "xlsx", expected: XLSX, expectedOkay: true},
+		"unknown": {format: "pdf", expected: "", expectedOkay: false},
+	}
+
+	for name, test := range tests {
+		got, ok := ParseFormat(test.format)
+		if got != test.expected || ok != test.expectedOkay {
+			t.Errorf("%s failed: expected %q, %t, got %q, %t", name, test.expected, test.expectedOkay, got, ok)
+		}
+	}
+}
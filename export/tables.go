@@ -0,0 +1,118 @@
+package export
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/dannyvelas/parkspot-backend/config"
+	"github.com/dannyvelas/parkspot-backend/i18n"
+	"github.com/dannyvelas/parkspot-backend/models"
+)
+
+// Permits exports the dates of permits as days of the calendar of location, the timezone of the community
+func Permits(location *time.Location) Table[models.Permit] {
+	return Table[models.Permit]{
+		Headers: map[i18n.Lang][]string{
+			i18n.English: {"ID", "Resident ID", "License Plate", "Color", "Make", "Model", "Start Date", "End Date", "Requested On", "Affects Days", "Exception Reason"},
+			i18n.Spanish: {"ID", "ID del residente", "Placa", "Color", "Marca", "Modelo", "Fecha de inicio", "Fecha de fin", "Solicitado el", "Afecta los días", "Motivo de la excepción"},
+		},
+		Row: func(permit models.Permit) []string {
+			var requestedOn string
+			if permit.RequestTS != 0 {
+				requestedOn = formatDate(time.Unix(permit.RequestTS, 0), location)
+			}
+
+			return []string{
+				strconv.Itoa(permit.ID),
+				permit.ResidentID,
+				permit.LicensePlate,
+				permit.Color,
+				permit.Make,
+				permit.Model,
+				formatDate(permit.StartDate, location),
+				formatDate(permit.EndDate, location),
+				requestedOn,
+				strconv.FormatBool(permit.AffectsDays),
+				permit.ExceptionReason,
+			}
+		},
+	}
+}
+
+var Cars = Table[models.Car]{
+	Headers: map[i18n.Lang][]string{
+		i18n.English: {"ID", "Resident ID", "License Plate", "Color", "Make", "Model", "Parking Days Used"},
+		i18n.Spanish: {"ID", "ID del residente", "Placa", "Color", "Marca", "Modelo", "Días de estacionamiento usados"},
+	},
+	Row: func(car models.Car) []string {
+		return []string{
+			car.ID,
+			car.ResidentID,
+			car.LicensePlate,
+			car.Color,
+			car.Make,
+			car.Model,
+			formatOptionalInt(car.AmtParkingDaysUsed),
+		}
+	},
+}
+
+// Residents leaves out the password hash and the token version of residents
+var Residents = Table[models.Resident]{
+	Headers: map[i18n.Lang][]string{
+		i18n.English: {"ID", "First Name", "Last Name", "Phone", "Email", "Unlimited Days", "Parking Days Used"},
+		i18n.Spanish: {"ID", "Nombre", "Apellido", "Teléfono", "Correo electrónico", "Días ilimitados", "Días de estacionamiento usados"},
+	},
+	Row: func(resident models.Resident) []string {
+		var unlimDays string
+		if resident.UnlimDays != nil {
+			unlimDays = strconv.FormatBool(*resident.UnlimDays)
+		}
+
+		return []string{
+			resident.ID,
+			resident.FirstName,
+			resident.LastName,
+			resident.Phone,
+			resident.Email,
+			unlimDays,
+			formatOptionalInt(resident.AmtParkingDaysUsed),
+		}
+	},
+}
+
+// Visitors exports the dates of visitors as days of the calendar of location, the timezone of the community
+func Visitors(location *time.Location) Table[models.Visitor] {
+	return Table[models.Visitor]{
+		Headers: map[i18n.Lang][]string{
+			i18n.English: {"ID", "Resident ID", "First Name", "Last Name", "Relationship", "Access Start", "Access End"},
+			i18n.Spanish: {"ID", "ID del residente", "Nombre", "Apellido", "Parentesco", "Inicio del acceso", "Fin del acceso"},
+		},
+		Row: func(visitor models.Visitor) []string {
+			return []string{
+				visitor.ID,
+				visitor.ResidentID,
+				visitor.FirstName,
+				visitor.LastName,
+				visitor.Relationship,
+				formatDate(visitor.AccessStart, location),
+				formatDate(visitor.AccessEnd, location),
+			}
+		},
+	}
+}
+
+func formatOptionalInt(i *int) string {
+	if i == nil {
+		return ""
+	}
+	return strconv.Itoa(*i)
+}
+
+// formatDate formats a day of the calendar of location, like 2026-01-10
+func formatDate(date time.Time, location *time.Location) string {
+	if date.IsZero() {
+		return ""
+	}
+	return date.In(location).Format(config.DateFormat)
+}
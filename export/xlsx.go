@@ -0,0 +1,94 @@
+package export
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"io"
+)
+
+// xlsxWriter writes a workbook with one sheet. the parts of the workbook that don't depend on the rows are
+// written first, so that the sheet can be written last, a row at a time
+type xlsxWriter struct {
+	zip   *zip.Writer
+	sheet io.Writer
+	err   error
+}
+
+const (
+	xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`</Types>`
+	xlsxRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`
+	xlsxWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="Sheet1" sheetId="1" r:id="rId1"/></sheets>` +
+		`</workbook>`
+	xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`</Relationships>`
+	xlsxSheetStart = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+	xlsxSheetEnd = `</sheetData></worksheet>`
+)
+
+func newXLSXWriter(w io.Writer) *xlsxWriter {
+	x := &xlsxWriter{zip: zip.NewWriter(w)}
+	for _, part := range []struct{ name, content string }{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRels},
+		{"xl/workbook.xml", xlsxWorkbook},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+		{"xl/worksheets/sheet1.xml", xlsxSheetStart},
+	} {
+		x.sheet, x.err = x.zip.Create(part.name)
+		if x.err != nil {
+			return x
+		}
+		if _, x.err = io.WriteString(x.sheet, part.content); x.err != nil {
+			return x
+		}
+	}
+	return x
+}
+
+// WriteRow writes cells as inline strings, so that the workbook needs no table of shared strings
+func (x *xlsxWriter) WriteRow(cells []string) error {
+	if x.err != nil {
+		return x.err
+	}
+
+	if _, x.err = io.WriteString(x.sheet, "<row>"); x.err != nil {
+		return x.err
+	}
+	for _, cell := range cells {
+		if _, x.err = io.WriteString(x.sheet, `<c t="inlineStr"><is><t xml:space="preserve">`); x.err != nil {
+			return x.err
+		}
+		if x.err = xml.EscapeText(x.sheet, []byte(cell)); x.err != nil {
+			return x.err
+		}
+		if _, x.err = io.WriteString(x.sheet, "</t></is></c>"); x.err != nil {
+			return x.err
+		}
+	}
+	_, x.err = io.WriteString(x.sheet, "</row>")
+	return x.err
+}
+
+func (x *xlsxWriter) Close() error {
+	if x.err != nil {
+		return x.err
+	}
+	if _, err := io.WriteString(x.sheet, xlsxSheetEnd); err != nil {
+		return err
+	}
+	return x.zip.Close()
+}
@@ -0,0 +1,2 @@
+// Package i18n provides the languages that the API can respond in, and picks the language of each request
+package i18n
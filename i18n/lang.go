@@ -0,0 +1,49 @@
+package i18n
+
+import (
+	"golang.org/x/text/language"
+)
+
+type Lang string
+
+const (
+	English Lang = "en"
+	Spanish Lang = "es"
+)
+
+// Default is used when neither the user nor the request prefer a language that we support
+const Default = English
+
+// Supported is in the same order as the tags of matcher
+var Supported = []Lang{English, Spanish}
+
+var matcher = language.NewMatcher([]language.Tag{language.English, language.Spanish})
+
+func (l Lang) IsSupported() bool {
+	for _, supported := range Supported {
+		if l == supported {
+			return true
+		}
+	}
+	return false
+}
+
+// Negotiate picks the language of a request. the language that a user chose for their account
+// takes precedence over the Accept-Language header of their request
+func Negotiate(preferred Lang, acceptLanguage string) Lang {
+	if preferred.IsSupported() {
+		return preferred
+	}
+
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(tags) == 0 {
+		return Default
+	}
+
+	_, index, confidence := matcher.Match(tags...)
+	if confidence == language.No {
+		return Default
+	}
+
+	return Supported[index]
+}
@@ -0,0 +1,27 @@
+package i18n
+
+import (
+	"testing"
+)
+
+func TestNegotiate(t *testing.T) {
+	tests := map[string]struct {
+		preferred      Lang
+		acceptLanguage string
+		expected       Lang
+	}{
+		"no preference":                     {"", "", English},
+		"spanish header":                    {"", "es-US,es;q=0.9,en;q=0.8", Spanish},
+		"english before spanish":            {"", "en-US,en;q=0.9,es;q=0.8", English},
+		"unsupported header":                {"", "fr-FR,fr;q=0.9", English},
+		"malformed header":                  {"", ";;;", English},
+		"preference wins over header":       {Spanish, "en-US,en;q=0.9", Spanish},
+		"unsupported preference is ignored": {"fr", "es", Spanish},
+	}
+
+	for testName, test := range tests {
+		if result := Negotiate(test.preferred, test.acceptLanguage); result != test.expected {
+			t.Errorf("%s failed: expected %q, got %q", testName, test.expected, result)
+		}
+	}
+}
@@ -1,11 +1,12 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"github.com/dannyvelas/parkspot-backend/api"
 	"github.com/dannyvelas/parkspot-backend/app"
 	"github.com/dannyvelas/parkspot-backend/config"
-	"github.com/dannyvelas/parkspot-backend/storage/psql"
+	"github.com/dannyvelas/parkspot-backend/storage/sqldb"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"os"
@@ -27,12 +28,23 @@ func main() {
 
 	// connect to database
 	// no defer close() because connection closes automatically on program exit
-	database, err := psql.NewDatabase(c.Postgres)
+	database, err := sqldb.Open(c.Database, c.Community.Location)
 	if err != nil {
 		log.Fatal().Msgf("Failed to start database: %v", err)
 	}
 	log.Info().Msg("Connected to Database.")
 
+	// migrate when it's opted into, and never serve with a schema that is missing migrations
+	if c.Database.MigrateOnStart {
+		if err := database.MigrateUp(); err != nil {
+			log.Fatal().Msgf("Failed to migrate database: %v", err)
+		}
+		log.Info().Msg("Migrated Database.")
+	}
+	if err := database.CheckSchema(); err != nil {
+		log.Fatal().Msgf("Refusing to serve: %v. Run parkspot migrate up, or set DATABASE_MIGRATEONSTART=true", err)
+	}
+
 	// create app
 	app := app.NewApp(c, database)
 
@@ -44,12 +56,18 @@ func main() {
 	server := api.NewServer(c, app)
 	go server.Start(errChannel)
 
+	// retry waitlisted permit requests as permits expire
+	promoteCtx, stopPromoting := context.WithCancel(context.Background())
+	go app.WaitlistService.PromoteEvery(promoteCtx, config.WaitlistPromoteInterval)
+
 	// listen to signal interrupt
 	go listenToInterrupt(errChannel)
 
 	fatalErr := <-errChannel
 	log.Info().Msgf("Closing server: %v", fatalErr)
 
+	stopPromoting()
+
 	server.ShutdownGracefully(30 * time.Second)
 }
 
@@ -0,0 +1,4 @@
+-- versions 2 to 6 were the sample data that is now in the seed folder. they are kept, empty, so that
+-- databases that were migrated before the sample data was split from the schema, which are at version 6,
+-- keep migrating up from a version that this folder has
+SELECT 1;
@@ -0,0 +1,9 @@
+BEGIN;
+
+DROP INDEX IF EXISTS permit_search_idx;
+DROP INDEX IF EXISTS car_search_idx;
+DROP INDEX IF EXISTS resident_search_idx;
+DROP INDEX IF EXISTS visitor_search_idx;
+DROP EXTENSION IF EXISTS pg_trgm;
+
+COMMIT;
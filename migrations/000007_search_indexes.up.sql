@@ -0,0 +1,25 @@
+BEGIN;
+
+-- trigram indexes make searching for parts of words fast, like "toy" for a Toyota.
+-- each expression must stay equal to the search document of its repo in storage/psql
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS permit_search_idx ON permit USING GIN (
+  LOWER(CAST(permit.id AS TEXT) || ' ' || permit.resident_id || ' ' || permit.license_plate || ' ' ||
+    permit.color || ' ' || COALESCE(permit.make, '') || ' ' || COALESCE(permit.model, '')) gin_trgm_ops
+);
+
+CREATE INDEX IF NOT EXISTS car_search_idx ON car USING GIN (
+  LOWER(car.resident_id || ' ' || car.license_plate || ' ' || car.color || ' ' ||
+    COALESCE(car.make, '') || ' ' || COALESCE(car.model, '')) gin_trgm_ops
+);
+
+CREATE INDEX IF NOT EXISTS resident_search_idx ON resident USING GIN (
+  LOWER(resident.id || ' ' || resident.first_name || ' ' || resident.last_name) gin_trgm_ops
+);
+
+CREATE INDEX IF NOT EXISTS visitor_search_idx ON visitor USING GIN (
+  LOWER(visitor.resident_id || ' ' || visitor.first_name || ' ' || visitor.last_name) gin_trgm_ops
+);
+
+COMMIT;
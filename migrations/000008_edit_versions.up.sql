@@ -0,0 +1,10 @@
+BEGIN;
+
+-- the version of a row counts the edits that users made to it, so that edits that were made to an older version than the one in the
+-- database are rejected instead of overwriting the edits that came before them
+ALTER TABLE resident ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE car ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE permit ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE visitor ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+DROP TABLE IF EXISTS gate_event CASCADE;
+
+COMMIT;
@@ -0,0 +1,15 @@
+BEGIN;
+
+-- like permits, the license_plate here is a snapshot of the car at the time of check-in
+CREATE TABLE IF NOT EXISTS gate_event(
+  id UUID PRIMARY KEY UNIQUE NOT NULL DEFAULT uuid_generate_v4(),
+  resident_id CHAR(8) REFERENCES resident(id) ON DELETE CASCADE NOT NULL,
+  visitor_id UUID REFERENCES visitor(id) ON DELETE SET NULL,
+  permit_id INTEGER REFERENCES permit(id) ON DELETE SET NULL,
+  license_plate VARCHAR(10),
+  recorded_by TEXT NOT NULL,
+  check_in_ts BIGINT NOT NULL,
+  check_out_ts BIGINT
+);
+
+COMMIT;
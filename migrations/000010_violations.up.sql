@@ -0,0 +1,19 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS violation(
+  id UUID PRIMARY KEY UNIQUE NOT NULL DEFAULT uuid_generate_v4(),
+  license_plate VARCHAR(10) NOT NULL,
+  location TEXT NOT NULL,
+  photo_ref TEXT,
+  type TEXT NOT NULL,
+  recorded_by TEXT NOT NULL,
+  resident_id CHAR(8) REFERENCES resident(id) ON DELETE SET NULL,
+  action TEXT NOT NULL,
+  status TEXT NOT NULL DEFAULT 'open',
+  resident_notified BOOLEAN NOT NULL DEFAULT FALSE,
+  dispute_reason TEXT,
+  resolution TEXT,
+  ts BIGINT NOT NULL
+);
+
+COMMIT;
@@ -0,0 +1,15 @@
+BEGIN;
+
+-- a ban is either for a license_plate or for a visitor's first_name and last_name
+CREATE TABLE IF NOT EXISTS ban(
+  id UUID PRIMARY KEY UNIQUE NOT NULL DEFAULT uuid_generate_v4(),
+  license_plate VARCHAR(10),
+  first_name TEXT,
+  last_name TEXT,
+  reason TEXT NOT NULL,
+  created_by TEXT NOT NULL,
+  created_ts BIGINT NOT NULL,
+  expires_ts BIGINT
+);
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE permit DROP COLUMN IF EXISTS space_id;
+DROP TABLE IF EXISTS parking_space CASCADE;
+
+COMMIT;
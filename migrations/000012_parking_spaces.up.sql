@@ -0,0 +1,13 @@
+BEGIN;
+
+-- a zone's capacity is the amount of parking spaces in it
+CREATE TABLE IF NOT EXISTS parking_space(
+  id UUID PRIMARY KEY UNIQUE NOT NULL DEFAULT uuid_generate_v4(),
+  zone TEXT NOT NULL,
+  label TEXT NOT NULL,
+  UNIQUE(zone, label)
+);
+
+ALTER TABLE permit ADD COLUMN space_id UUID REFERENCES parking_space(id) ON DELETE SET NULL;
+
+COMMIT;
@@ -0,0 +1,23 @@
+BEGIN;
+
+-- a permit request that is waiting for a guest parking space or for the resident's quota to free up.
+-- permit_id is the permit that was created once this request was promoted
+CREATE TABLE IF NOT EXISTS waitlist_entry(
+  id UUID PRIMARY KEY UNIQUE NOT NULL DEFAULT uuid_generate_v4(),
+  resident_id CHAR(8) REFERENCES resident(id) ON DELETE CASCADE NOT NULL,
+  car_id UUID,
+  license_plate VARCHAR(10),
+  color TEXT,
+  make TEXT,
+  model TEXT,
+  start_ts BIGINT NOT NULL,
+  end_ts BIGINT NOT NULL,
+  exception_reason TEXT,
+  space_id UUID REFERENCES parking_space(id) ON DELETE SET NULL,
+  status TEXT NOT NULL DEFAULT 'waiting',
+  reason TEXT,
+  permit_id INTEGER REFERENCES permit(id) ON DELETE SET NULL,
+  created_ts BIGINT NOT NULL
+);
+
+COMMIT;
@@ -0,0 +1,13 @@
+BEGIN;
+
+-- this table has at most one row, since there is one parking policy per community.
+-- while it is empty, models.DefaultParkingPolicy is used
+CREATE TABLE IF NOT EXISTS parking_policy(
+  id BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (id),
+  max_parking_days INTEGER NOT NULL,
+  max_permit_length INTEGER NOT NULL,
+  max_active_permits INTEGER NOT NULL,
+  latest_end_ts BIGINT NOT NULL
+);
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+DROP TABLE IF EXISTS permit_rule CASCADE;
+
+COMMIT;
@@ -0,0 +1,17 @@
+BEGIN;
+
+-- a rule that a community places on permits or visitors, on top of its parking policy.
+-- which of the nullable columns are used depends on kind
+CREATE TABLE IF NOT EXISTS permit_rule(
+  id UUID PRIMARY KEY UNIQUE NOT NULL DEFAULT uuid_generate_v4(),
+  name TEXT UNIQUE NOT NULL,
+  kind TEXT NOT NULL,
+  target TEXT NOT NULL,
+  relationship TEXT,
+  dates TEXT[],
+  max_days INTEGER,
+  max_count INTEGER,
+  weight INTEGER
+);
+
+COMMIT;
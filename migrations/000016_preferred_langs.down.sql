@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE admin DROP COLUMN preferred_lang;
+ALTER TABLE resident DROP COLUMN preferred_lang;
+
+COMMIT;
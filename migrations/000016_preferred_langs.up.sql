@@ -0,0 +1,7 @@
+BEGIN;
+
+-- the language that a user chose for the emails and errors that are sent to them
+ALTER TABLE admin ADD COLUMN preferred_lang VARCHAR(2);
+ALTER TABLE resident ADD COLUMN preferred_lang VARCHAR(2);
+
+COMMIT;
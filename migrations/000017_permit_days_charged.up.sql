@@ -0,0 +1,7 @@
+BEGIN;
+
+-- the parking days that creating a permit used, so that deleting it gives back those days even after the weekend_weight
+-- rules changed. it is null for the permits that were created before it was stored
+ALTER TABLE permit ADD COLUMN days_charged INTEGER;
+
+COMMIT;
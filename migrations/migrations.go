@@ -0,0 +1,22 @@
+// Package migrations embeds the SQL migrations of the database, so that they can be run by binaries that are
+// deployed without this folder.
+package migrations
+
+import "embed"
+
+// FS is the schema of the database
+//
+//go:embed *.sql
+var FS embed.FS
+
+// SeedFS is the sample data of local development, in the seed folder. it is migrated apart from the schema,
+// so that deployments never get it
+//
+//go:embed seed/*.sql
+var SeedFS embed.FS
+
+// SQLiteFS is the schema of the database when it is sqlite, in the sqlite folder. the seeds of SeedFS are
+// written to run on both databases
+//
+//go:embed sqlite/*.sql
+var SQLiteFS embed.FS
@@ -0,0 +1,16 @@
+BEGIN;
+
+DROP TABLE IF EXISTS gate_event;
+DROP TABLE IF EXISTS violation;
+DROP TABLE IF EXISTS ban;
+DROP TABLE IF EXISTS visitor;
+DROP TABLE IF EXISTS waitlist_entry;
+DROP TABLE IF EXISTS permit;
+DROP TABLE IF EXISTS parking_space;
+DROP TABLE IF EXISTS car;
+DROP TABLE IF EXISTS permit_rule;
+DROP TABLE IF EXISTS parking_policy;
+DROP TABLE IF EXISTS resident;
+DROP TABLE IF EXISTS admin;
+
+COMMIT;
@@ -0,0 +1,178 @@
+-- the sqlite counterpart of the schema of postgres in the parent folder. it has no extensions and no enums,
+-- so ids are generated by a default expression and relationship is checked by a constraint.
+-- foreign keys are only enforced by connections that turn them on, like the ones of storage/sqlite
+
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS admin(
+  id TEXT PRIMARY KEY UNIQUE NOT NULL,
+  first_name TEXT NOT NULL,
+  last_name TEXT NOT NULL,
+  email VARCHAR(255) UNIQUE NOT NULL,
+  password VARCHAR(255) NOT NULL,
+  is_privileged BOOLEAN NOT NULL,
+  preferred_lang VARCHAR(2),
+  token_version INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS resident(
+  id CHAR(8) PRIMARY KEY UNIQUE NOT NULL,
+  first_name TEXT NOT NULL,
+  last_name TEXT NOT NULL,
+  phone VARCHAR(20) NOT NULL,
+  email VARCHAR(255) NOT NULL,
+  password VARCHAR(255) NOT NULL,
+  unlim_days BOOLEAN NOT NULL DEFAULT FALSE,
+  amt_parking_days_used SMALLINT NOT NULL DEFAULT 0,
+  preferred_lang VARCHAR(2),
+  token_version INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS car(
+  id TEXT PRIMARY KEY UNIQUE NOT NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' ||
+    substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + abs(random()) % 4, 1) ||
+    substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+  resident_id CHAR(8) REFERENCES resident(id) ON DELETE CASCADE NOT NULL,
+  license_plate VARCHAR(10) UNIQUE NOT NULL,
+  color TEXT NOT NULL,
+  make TEXT,
+  model TEXT,
+  amt_parking_days_used SMALLINT NOT NULL DEFAULT 0
+);
+
+-- this table has at most one row, since there is one parking policy per community.
+-- while it is empty, models.DefaultParkingPolicy is used
+CREATE TABLE IF NOT EXISTS parking_policy(
+  id BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (id),
+  max_parking_days INTEGER NOT NULL,
+  max_permit_length INTEGER NOT NULL,
+  max_active_permits INTEGER NOT NULL,
+  latest_end_ts BIGINT NOT NULL
+);
+
+-- a rule that a community places on permits or visitors, on top of its parking policy.
+-- which of the nullable columns are used depends on kind
+CREATE TABLE IF NOT EXISTS permit_rule(
+  id TEXT PRIMARY KEY UNIQUE NOT NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' ||
+    substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + abs(random()) % 4, 1) ||
+    substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+  name TEXT UNIQUE NOT NULL,
+  kind TEXT NOT NULL,
+  target TEXT NOT NULL,
+  relationship TEXT,
+  dates TEXT, -- a JSON array
+  max_days INTEGER,
+  max_count INTEGER,
+  weight INTEGER
+);
+
+-- a zone's capacity is the amount of parking spaces in it
+CREATE TABLE IF NOT EXISTS parking_space(
+  id TEXT PRIMARY KEY UNIQUE NOT NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' ||
+    substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + abs(random()) % 4, 1) ||
+    substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+  zone TEXT NOT NULL,
+  label TEXT NOT NULL,
+  UNIQUE(zone, label)
+);
+
+-- we are purposely NOT adding a `car`.id foreign key here
+-- we don't want changes to a given car to affect the history of permits created
+-- thus, we want the car information in each permit to be a "snapshot", at the time the permit was created
+CREATE TABLE IF NOT EXISTS permit(
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  resident_id CHAR(8) REFERENCES resident(id) ON DELETE CASCADE NOT NULL,
+  car_id TEXT NOT NULL,
+  license_plate VARCHAR(10) NOT NULL,
+  color TEXT NOT NULL,
+  make TEXT,
+  model TEXT,
+  start_ts BIGINT NOT NULL,
+  end_ts BIGINT NOT NULL,
+  request_ts BIGINT,
+  affects_days BOOLEAN NOT NULL,
+  exception_reason TEXT,
+  space_id TEXT REFERENCES parking_space(id) ON DELETE SET NULL
+);
+
+-- a permit request that is waiting for a guest parking space or for the resident's quota to free up.
+-- permit_id is the permit that was created once this request was promoted
+CREATE TABLE IF NOT EXISTS waitlist_entry(
+  id TEXT PRIMARY KEY UNIQUE NOT NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' ||
+    substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + abs(random()) % 4, 1) ||
+    substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+  resident_id CHAR(8) REFERENCES resident(id) ON DELETE CASCADE NOT NULL,
+  car_id TEXT,
+  license_plate VARCHAR(10),
+  color TEXT,
+  make TEXT,
+  model TEXT,
+  start_ts BIGINT NOT NULL,
+  end_ts BIGINT NOT NULL,
+  exception_reason TEXT,
+  space_id TEXT REFERENCES parking_space(id) ON DELETE SET NULL,
+  status TEXT NOT NULL DEFAULT 'waiting',
+  reason TEXT,
+  permit_id INTEGER REFERENCES permit(id) ON DELETE SET NULL,
+  created_ts BIGINT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS visitor(
+  id TEXT PRIMARY KEY UNIQUE NOT NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' ||
+    substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + abs(random()) % 4, 1) ||
+    substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+  resident_id CHAR(8) REFERENCES resident(id) ON DELETE CASCADE NOT NULL,
+  first_name TEXT NOT NULL,
+  last_name TEXT NOT NULL,
+  relationship TEXT NOT NULL CHECK (relationship IN ('fam/fri', 'contractor')),
+  access_start BIGINT NOT NULL,
+  access_end BIGINT NOT NULL
+);
+
+-- like permits, the license_plate here is a snapshot of the car at the time of check-in
+CREATE TABLE IF NOT EXISTS gate_event(
+  id TEXT PRIMARY KEY UNIQUE NOT NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' ||
+    substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + abs(random()) % 4, 1) ||
+    substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+  resident_id CHAR(8) REFERENCES resident(id) ON DELETE CASCADE NOT NULL,
+  visitor_id TEXT REFERENCES visitor(id) ON DELETE SET NULL,
+  permit_id INTEGER REFERENCES permit(id) ON DELETE SET NULL,
+  license_plate VARCHAR(10),
+  recorded_by TEXT NOT NULL,
+  check_in_ts BIGINT NOT NULL,
+  check_out_ts BIGINT
+);
+
+CREATE TABLE IF NOT EXISTS violation(
+  id TEXT PRIMARY KEY UNIQUE NOT NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' ||
+    substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + abs(random()) % 4, 1) ||
+    substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+  license_plate VARCHAR(10) NOT NULL,
+  location TEXT NOT NULL,
+  photo_ref TEXT,
+  type TEXT NOT NULL,
+  recorded_by TEXT NOT NULL,
+  resident_id CHAR(8) REFERENCES resident(id) ON DELETE SET NULL,
+  action TEXT NOT NULL,
+  status TEXT NOT NULL DEFAULT 'open',
+  resident_notified BOOLEAN NOT NULL DEFAULT FALSE,
+  dispute_reason TEXT,
+  resolution TEXT,
+  ts BIGINT NOT NULL
+);
+
+-- a ban is either for a license_plate or for a visitor's first_name and last_name
+CREATE TABLE IF NOT EXISTS ban(
+  id TEXT PRIMARY KEY UNIQUE NOT NULL DEFAULT (lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' ||
+    substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + abs(random()) % 4, 1) ||
+    substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)))),
+  license_plate VARCHAR(10),
+  first_name TEXT,
+  last_name TEXT,
+  reason TEXT NOT NULL,
+  created_by TEXT NOT NULL,
+  created_ts BIGINT NOT NULL,
+  expires_ts BIGINT
+);
+
+COMMIT;
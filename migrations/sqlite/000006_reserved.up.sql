@@ -0,0 +1,3 @@
+-- versions 2 to 6 of postgres were the sample data that is now in the seed folder. this migration keeps the
+-- versions of the schema of sqlite equal to the versions of the schema of postgres
+SELECT 1;
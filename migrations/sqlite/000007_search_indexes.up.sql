@@ -0,0 +1,3 @@
+-- sqlite has no trigram indexes, so searches scan every row. this migration keeps the versions of the schema
+-- of sqlite equal to the versions of the schema of postgres
+SELECT 1;
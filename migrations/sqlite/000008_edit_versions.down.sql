@@ -0,0 +1,8 @@
+BEGIN;
+
+ALTER TABLE resident DROP COLUMN version;
+ALTER TABLE car DROP COLUMN version;
+ALTER TABLE permit DROP COLUMN version;
+ALTER TABLE visitor DROP COLUMN version;
+
+COMMIT;
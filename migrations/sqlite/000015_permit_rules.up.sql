@@ -0,0 +1,3 @@
+-- sqlite was first migrated with this table in its first migration. this migration keeps the versions of the
+-- schema of sqlite equal to the versions of the schema of postgres
+SELECT 1;
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE permit DROP COLUMN days_charged;
+
+COMMIT;
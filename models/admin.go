@@ -1,24 +1,30 @@
 package models
 
+import (
+	"github.com/dannyvelas/parkspot-backend/i18n"
+)
+
 type Admin struct {
-	ID           string `json:"id"`
-	FirstName    string `json:"firstName"`
-	LastName     string `json:"lastName"`
-	Email        string `json:"email"`
-	Password     string `json:"-"`
-	IsPrivileged bool   `json:"isPrivileged"`
-	TokenVersion *int   `json:"-"`
+	ID            string    `json:"id"`
+	FirstName     string    `json:"firstName"`
+	LastName      string    `json:"lastName"`
+	Email         string    `json:"email"`
+	Password      string    `json:"-"`
+	IsPrivileged  bool      `json:"isPrivileged"`
+	PreferredLang i18n.Lang `json:"preferredLang"`
+	TokenVersion  *int      `json:"-"`
 }
 
-func NewAdmin(id, firstName, lastName, email, password string, isPrivileged bool, tokenVersion int) Admin {
+func NewAdmin(id, firstName, lastName, email, password string, isPrivileged bool, preferredLang i18n.Lang, tokenVersion int) Admin {
 	return Admin{
-		ID:           id,
-		FirstName:    firstName,
-		LastName:     lastName,
-		Email:        email,
-		Password:     password,
-		IsPrivileged: isPrivileged,
-		TokenVersion: &tokenVersion,
+		ID:            id,
+		FirstName:     firstName,
+		LastName:      lastName,
+		Email:         email,
+		Password:      password,
+		IsPrivileged:  isPrivileged,
+		PreferredLang: preferredLang,
+		TokenVersion:  &tokenVersion,
 	}
 }
 
@@ -32,5 +38,5 @@ func (a Admin) AsUser() User {
 		role = AdminRole
 	}
 
-	return NewUser(a.ID, a.FirstName, a.LastName, a.Email, role, *a.TokenVersion)
+	return NewUser(a.ID, a.FirstName, a.LastName, a.Email, role, a.PreferredLang, *a.TokenVersion)
 }
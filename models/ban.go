@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+)
+
+// Ban keeps a license plate or a visitor off the property. exactly one of LicensePlate
+// or FirstName and LastName is set. a ban without ExpiresAt lasts until it is deleted
+type Ban struct {
+	ID           string     `json:"id"`
+	LicensePlate string     `json:"licensePlate,omitempty"`
+	FirstName    string     `json:"firstName,omitempty"`
+	LastName     string     `json:"lastName,omitempty"`
+	Reason       string     `json:"reason"`
+	CreatedBy    string     `json:"createdBy"`
+	CreatedAt    time.Time  `json:"createdAt"`
+	ExpiresAt    *time.Time `json:"expiresAt,omitempty"`
+}
+
+func NewBan(
+	id string,
+	licensePlate string,
+	firstName string,
+	lastName string,
+	reason string,
+	createdBy string,
+	createdAt time.Time,
+	expiresAt *time.Time,
+) Ban {
+	return Ban{
+		ID:           id,
+		LicensePlate: licensePlate,
+		FirstName:    firstName,
+		LastName:     lastName,
+		Reason:       reason,
+		CreatedBy:    createdBy,
+		CreatedAt:    createdAt,
+		ExpiresAt:    expiresAt,
+	}
+}
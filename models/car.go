@@ -8,9 +8,10 @@ type Car struct {
 	Make               string `json:"make"`
 	Model              string `json:"model"`
 	AmtParkingDaysUsed *int   `json:"amtParkingDaysUsed"`
+	Version            int    `json:"version"` // counts the edits of this car, so that edits can be made to the version that they read
 }
 
-func NewCar(id, residentID, licensePlate, color, make, model string, amtParkingDaysUsed int) Car {
+func NewCar(id, residentID, licensePlate, color, make, model string, amtParkingDaysUsed, version int) Car {
 	return Car{
 		ID:                 id,
 		ResidentID:         residentID,
@@ -19,6 +20,7 @@ func NewCar(id, residentID, licensePlate, color, make, model string, amtParkingD
 		Make:               make,
 		Model:              model,
 		AmtParkingDaysUsed: &amtParkingDaysUsed,
+		Version:            version,
 	}
 }
 
@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+)
+
+// GateEvent is a record of a guest arriving at (and possibly leaving) the property.
+// Exactly one of VisitorID or PermitID identifies the pass the guest arrived with
+type GateEvent struct {
+	ID           string     `json:"id"`
+	ResidentID   string     `json:"residentID"`
+	VisitorID    string     `json:"visitorID,omitempty"`
+	PermitID     int        `json:"permitID,omitempty"`
+	LicensePlate string     `json:"licensePlate,omitempty"`
+	RecordedBy   string     `json:"recordedBy"`
+	CheckIn      time.Time  `json:"checkIn"`
+	CheckOut     *time.Time `json:"checkOut"`
+	AllowedUntil time.Time  `json:"allowedUntil"` // end of the visitor's access or of the permit
+}
+
+func NewGateEvent(
+	id string,
+	residentID string,
+	visitorID string,
+	permitID int,
+	licensePlate string,
+	recordedBy string,
+	checkIn time.Time,
+	checkOut *time.Time,
+	allowedUntil time.Time,
+) GateEvent {
+	return GateEvent{
+		ID:           id,
+		ResidentID:   residentID,
+		VisitorID:    visitorID,
+		PermitID:     permitID,
+		LicensePlate: licensePlate,
+		RecordedBy:   recordedBy,
+		CheckIn:      checkIn,
+		CheckOut:     checkOut,
+		AllowedUntil: allowedUntil,
+	}
+}
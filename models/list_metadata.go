@@ -1,7 +1,12 @@
 package models
 
+// Metadata describes a page of a list. TotalAmount is left out of pages that start at a cursor, since
+// counting the rows of a list takes a second query. Next and Prev are cursors to the pages around this one,
+// if there are any
 type Metadata struct {
-	TotalAmount int `json:"totalAmount"`
+	TotalAmount *int   `json:"totalAmount,omitempty"`
+	Next        string `json:"next,omitempty"`
+	Prev        string `json:"prev,omitempty"`
 }
 
 type ListWithMetadata[T any] struct {
@@ -13,7 +18,7 @@ func NewListWithMetadata[T any](list []T, totalAmount int) ListWithMetadata[T] {
 	return ListWithMetadata[T]{
 		Records: list,
 		Metadata: Metadata{
-			TotalAmount: totalAmount,
+			TotalAmount: &totalAmount,
 		},
 	}
 }
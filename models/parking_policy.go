@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+)
+
+// ParkingPolicy holds the parking rules of this community
+type ParkingPolicy struct {
+	MaxParkingDays   int       `json:"maxParkingDays"`   // days of guest parking that a resident can give out per year
+	MaxPermitLength  int       `json:"maxPermitLength"`  // in days
+	MaxActivePermits int       `json:"maxActivePermits"` // permits that a resident can have active at the same time
+	LatestEndDate    time.Time `json:"latestEndDate"`    // no permit or visitor pass can end after this
+}
+
+// DefaultParkingPolicy is used until an admin saves a parking policy
+var DefaultParkingPolicy = ParkingPolicy{
+	MaxParkingDays:   20,
+	MaxPermitLength:  15,
+	MaxActivePermits: 2,
+	LatestEndDate:    EndOfTime,
+}
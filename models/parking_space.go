@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+)
+
+type ParkingSpace struct {
+	ID    string `json:"id"`
+	Zone  string `json:"zone"`
+	Label string `json:"label"`
+}
+
+func NewParkingSpace(id string, zone string, label string) ParkingSpace {
+	return ParkingSpace{
+		ID:    id,
+		Zone:  zone,
+		Label: label,
+	}
+}
+
+type ZoneCapacity struct {
+	Zone     string `json:"zone"`
+	Capacity int    `json:"capacity"`
+}
+
+// DayAvailability is how many guest parking spaces are free on Date. a space is occupied
+// on a day if a permit is active at any moment of that day
+type DayAvailability struct {
+	Date     time.Time `json:"date"`
+	Capacity int       `json:"capacity"`
+	Occupied int       `json:"occupied"`
+	Free     int       `json:"free"`
+}
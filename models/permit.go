@@ -17,6 +17,9 @@ type Permit struct {
 	RequestTS       int64     `json:"requestTS"` // int64: type used by time package for unix time
 	AffectsDays     bool      `json:"affectsDays"`
 	ExceptionReason string    `json:"exceptionReason,omitempty"`
+	SpaceID         string    `json:"spaceID,omitempty"`
+	DaysCharged     *int      `json:"daysCharged,omitempty"` // the parking days that creating this permit used, which deleting it gives back
+	Version         int       `json:"version"`               // counts the edits of this permit, so that edits can be made to the version that they read
 }
 
 func NewPermit(
@@ -32,6 +35,9 @@ func NewPermit(
 	requestTS int64,
 	affectsDays bool,
 	exceptionReason string,
+	spaceID string,
+	daysCharged *int,
+	version int,
 ) Permit {
 	return Permit{
 		ID:              id,
@@ -46,6 +52,9 @@ func NewPermit(
 		RequestTS:       requestTS,
 		AffectsDays:     affectsDays,
 		ExceptionReason: exceptionReason,
+		SpaceID:         spaceID,
+		DaysCharged:     daysCharged,
+		Version:         version,
 	}
 }
 
@@ -74,7 +83,56 @@ func (p Permit) Equal(other Permit) bool {
 		return false
 	} else if p.ExceptionReason != other.ExceptionReason {
 		return false
+	} else if p.SpaceID != other.SpaceID {
+		return false
 	}
 
 	return true
 }
+
+// IsActiveAt is the Go counterpart of the ActiveStatus filter of the permit repo
+func (p Permit) IsActiveAt(t time.Time) bool {
+	return !p.StartDate.After(t) && !p.EndDate.Before(t)
+}
+
+// ExpiredGraceDays is how many days a permit that ended stays out of the list of expired permits. until then, a
+// permit that ended is neither active nor expired
+const ExpiredGraceDays = 2
+
+// ExpiredBefore is when permits that are expired at t ended by: the start of the day of the calendar of
+// location, the community's timezone, that is ExpiredGraceDays days before the day of t
+func ExpiredBefore(t time.Time, location *time.Location) time.Time {
+	year, month, day := t.In(location).Date()
+	return time.Date(year, month, day-ExpiredGraceDays, 0, 0, 0, 0, location)
+}
+
+// IsExpiredAt is the Go counterpart of the ExpiredStatus filter of the permit repo
+func (p Permit) IsExpiredAt(t time.Time, location *time.Location) bool {
+	return !p.EndDate.After(ExpiredBefore(t, location))
+}
+
+// PermitQuote is what creating a permit would do, without creating it.
+// Failures are the errors that creating it would respond with, in the language that the quote was made in
+type PermitQuote struct {
+	Valid         bool                 `json:"valid"`
+	DaysCharged   int                  `json:"daysCharged"`
+	DaysRemaining *int                 `json:"daysRemaining,omitempty"` // nil if the resident has unlimited days
+	Failures      []PermitQuoteFailure `json:"-"`
+}
+
+// PermitQuoteFailure is one of the errors that creating a permit would respond with
+type PermitQuoteFailure struct {
+	Code    string // the stable identifier of the error, like permit.too_long
+	Status  int
+	Message string
+	Fields  []PermitQuoteFailureDetail // the fields of the permit that caused the error, if any
+	Rules   []PermitQuoteFailureDetail // the parking rules that the permit broke, if any
+}
+
+// PermitQuoteFailureDetail is a field or a parking rule of a PermitQuoteFailure. Name is the name of the field or
+// rule, and Check is what it failed, like required for fields or blackout_dates for rules
+type PermitQuoteFailureDetail struct {
+	Name    string
+	Check   string
+	Message string
+}
@@ -0,0 +1,62 @@
+package models
+
+type PermitRuleKind string
+
+const (
+	// BlackoutDatesRule is broken when a permit or visit is active on any of Dates
+	BlackoutDatesRule PermitRuleKind = "blackout_dates"
+	// MaxLengthRule is broken when a permit or visit is longer than MaxDays
+	MaxLengthRule PermitRuleKind = "max_length"
+	// MaxPerMonthRule is broken when a resident would have more than MaxCount permits or visitors
+	// starting in the same calendar month
+	MaxPerMonthRule PermitRuleKind = "max_per_month"
+	// WeekendWeightRule charges each weekend day of a permit Weight parking days, instead of one. it is never
+	// broken by itself, but it can put a resident over their yearly parking days
+	WeekendWeightRule PermitRuleKind = "weekend_weight"
+)
+
+type PermitRuleTarget string
+
+const (
+	PermitTarget  PermitRuleTarget = "permit"
+	VisitorTarget PermitRuleTarget = "visitor"
+)
+
+// PermitRule is a rule that a community places on permits or visitors, on top of its parking policy.
+// which of the optional fields are used depends on Kind. if Relationship is set, the rule
+// only applies to visitors with that relationship
+type PermitRule struct {
+	ID           string           `json:"id"`
+	Name         string           `json:"name"`
+	Kind         PermitRuleKind   `json:"kind"`
+	Target       PermitRuleTarget `json:"target"`
+	Relationship string           `json:"relationship,omitempty"`
+	Dates        []string         `json:"dates,omitempty"`
+	MaxDays      int              `json:"maxDays,omitempty"`
+	MaxCount     int              `json:"maxCount,omitempty"`
+	Weight       int              `json:"weight,omitempty"`
+}
+
+func NewPermitRule(
+	id string,
+	name string,
+	kind PermitRuleKind,
+	target PermitRuleTarget,
+	relationship string,
+	dates []string,
+	maxDays int,
+	maxCount int,
+	weight int,
+) PermitRule {
+	return PermitRule{
+		ID:           id,
+		Name:         name,
+		Kind:         kind,
+		Target:       target,
+		Relationship: relationship,
+		Dates:        dates,
+		MaxDays:      maxDays,
+		MaxCount:     maxCount,
+		Weight:       weight,
+	}
+}
@@ -0,0 +1,21 @@
+package models
+
+type PlateStatusKind string
+
+const (
+	PlateBanned      PlateStatusKind = "banned"      // has a ban that has not expired
+	PlatePermitted   PlateStatusKind = "permitted"   // has a permit that is active right now
+	PlateResidentCar PlateStatusKind = "residentCar" // is registered as a resident's car, but has no active permit
+	PlateExpected    PlateStatusKind = "expected"    // has a permit that starts soon
+	PlateUnknown     PlateStatusKind = "unknown"
+)
+
+// PlateStatus is the answer to "is this license plate allowed here right now?"
+type PlateStatus struct {
+	LicensePlate   string          `json:"licensePlate"`
+	Status         PlateStatusKind `json:"status"`
+	Car            *Car            `json:"car,omitempty"`
+	ActivePermit   *Permit         `json:"activePermit,omitempty"`
+	UpcomingPermit *Permit         `json:"upcomingPermit,omitempty"`
+	Ban            *Ban            `json:"ban,omitempty"`
+}
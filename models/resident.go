@@ -4,18 +4,21 @@ import (
 	"regexp"
 
 	"github.com/dannyvelas/parkspot-backend/errs"
+	"github.com/dannyvelas/parkspot-backend/i18n"
 )
 
 type Resident struct {
-	ID                 string `json:"id"`
-	FirstName          string `json:"firstName"`
-	LastName           string `json:"lastName"`
-	Phone              string `json:"phone"`
-	Email              string `json:"email"`
-	Password           string `json:"password"`
-	UnlimDays          *bool  `json:"unlimDays"`
-	AmtParkingDaysUsed *int   `json:"amtParkingDaysUsed"`
-	TokenVersion       *int   `json:"-"`
+	ID                 string    `json:"id"`
+	FirstName          string    `json:"firstName"`
+	LastName           string    `json:"lastName"`
+	Phone              string    `json:"phone"`
+	Email              string    `json:"email"`
+	Password           string    `json:"password"`
+	UnlimDays          *bool     `json:"unlimDays"`
+	AmtParkingDaysUsed *int      `json:"amtParkingDaysUsed"`
+	PreferredLang      i18n.Lang `json:"preferredLang"`
+	TokenVersion       *int      `json:"-"`
+	Version            int       `json:"version"` // counts the edits of this resident, so that edits can be made to the version that they read
 }
 
 func NewResident(
@@ -27,7 +30,9 @@ func NewResident(
 	password string,
 	unlimDays bool,
 	amtParkingDaysUsed int,
+	preferredLang i18n.Lang,
 	tokenVersion int,
+	version int,
 ) Resident {
 	return Resident{
 		ID:                 id,
@@ -38,7 +43,9 @@ func NewResident(
 		Password:           password,
 		UnlimDays:          &unlimDays,
 		AmtParkingDaysUsed: &amtParkingDaysUsed,
+		PreferredLang:      preferredLang,
 		TokenVersion:       &tokenVersion,
+		Version:            version,
 	}
 }
 
@@ -47,7 +54,7 @@ func (m Resident) GetPassword() string {
 }
 
 func (m Resident) AsUser() User {
-	return NewUser(m.ID, m.FirstName, m.LastName, m.Email, ResidentRole, *m.TokenVersion)
+	return NewUser(m.ID, m.FirstName, m.LastName, m.Email, ResidentRole, m.PreferredLang, *m.TokenVersion)
 }
 
 func IsResidentID(s string) error {
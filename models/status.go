@@ -7,4 +7,5 @@ const (
 	ActiveStatus
 	ExpiredStatus
 	ExceptionStatus
+	OverstayStatus
 )
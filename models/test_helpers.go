@@ -38,6 +38,7 @@ var (
 		"color",
 		"make",
 		"model",
+		0,
 		0)
 	// this is the default test admin.
 	TestAdmin = NewAdmin(
@@ -47,6 +48,7 @@ var (
 		"email@example.com",
 		"notapassword",
 		true,
+		"",
 		0,
 	)
 	// this is the default test security.
@@ -57,6 +59,7 @@ var (
 		"email@example.com",
 		"notapassword",
 		false,
+		"",
 		0,
 	)
 )
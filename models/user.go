@@ -1,22 +1,28 @@
 package models
 
+import (
+	"github.com/dannyvelas/parkspot-backend/i18n"
+)
+
 type User struct {
-	ID           string `json:"id"`
-	FirstName    string `json:"firstName"`
-	LastName     string `json:"lastName"`
-	Email        string `json:"email"`
-	Role         Role   `json:"role"`
-	TokenVersion int    `json:"-"`
+	ID            string    `json:"id"`
+	FirstName     string    `json:"firstName"`
+	LastName      string    `json:"lastName"`
+	Email         string    `json:"email"`
+	Role          Role      `json:"role"`
+	PreferredLang i18n.Lang `json:"preferredLang"`
+	TokenVersion  int       `json:"-"`
 }
 
-func NewUser(id string, firstName string, lastName string, email string, role Role, tokenVersion int) User {
+func NewUser(id string, firstName string, lastName string, email string, role Role, preferredLang i18n.Lang, tokenVersion int) User {
 	return User{
-		ID:           id,
-		FirstName:    firstName,
-		LastName:     lastName,
-		Email:        email,
-		Role:         role,
-		TokenVersion: tokenVersion,
+		ID:            id,
+		FirstName:     firstName,
+		LastName:      lastName,
+		Email:         email,
+		Role:          role,
+		PreferredLang: preferredLang,
+		TokenVersion:  tokenVersion,
 	}
 }
 
@@ -31,6 +37,8 @@ func (u User) Equal(other User) bool {
 		return false
 	} else if u.Role != other.Role {
 		return false
+	} else if u.PreferredLang != other.PreferredLang {
+		return false
 	} else if u.TokenVersion != other.TokenVersion {
 		return false
 	}
@@ -4,7 +4,6 @@ import (
 	"github.com/dannyvelas/parkspot-backend/errs"
 	"github.com/dannyvelas/parkspot-backend/models"
 	"regexp"
-	"strings"
 )
 
 type adminValidator struct {
@@ -20,23 +19,23 @@ var (
 )
 
 func (v adminValidator) Run(admin models.Admin) *errs.APIErr {
-	var errors []string
+	var fieldErrs []errs.FieldError
 
 	if admin.ID == "" {
-		errors = append(errors, "id cannot be empty")
+		fieldErrs = append(fieldErrs, errs.NewFieldError("id", "required", "id cannot be empty"))
 	}
 	if !v.firstLastRe.MatchString(admin.FirstName) {
-		errors = append(errors, "first name can only be alphabetic letters and spaces")
+		fieldErrs = append(fieldErrs, errs.NewFieldError("firstName", "format", "first name can only be alphabetic letters and spaces"))
 	}
 	if !v.firstLastRe.MatchString(admin.LastName) {
-		errors = append(errors, "last name can only be alphabetic letters and spaces")
+		fieldErrs = append(fieldErrs, errs.NewFieldError("lastName", "format", "last name can only be alphabetic letters and spaces"))
 	}
 	if !v.emailRe.MatchString(admin.Email) {
-		errors = append(errors, "email must be a sequence of characters separated by an '@' character.")
+		fieldErrs = append(fieldErrs, errs.NewFieldError("email", "format", "email must be a sequence of characters separated by an '@' character."))
 	}
 
-	if len(errors) != 0 {
-		return errs.InvalidFields(strings.Join(errors, ". "))
+	if len(fieldErrs) != 0 {
+		return errs.InvalidFields(fieldErrs...)
 	}
 
 	return nil
@@ -0,0 +1,53 @@
+package validator
+
+import (
+	"github.com/dannyvelas/parkspot-backend/errs"
+	"github.com/dannyvelas/parkspot-backend/models"
+	"regexp"
+	"strings"
+	"time"
+)
+
+type banValidator struct {
+	licensePlateRe *regexp.Regexp
+}
+
+var (
+	CreateBan = banValidator{
+		regexp.MustCompile("^[A-Za-z0-9 -]+$"),
+	}
+)
+
+func (v banValidator) Run(ban models.Ban) *errs.APIErr {
+	bansPlate := ban.LicensePlate != ""
+	bansVisitor := ban.FirstName != "" || ban.LastName != ""
+	if bansPlate == bansVisitor {
+		return errs.BanNoTarget
+	}
+
+	if strings.TrimSpace(ban.Reason) == "" {
+		return errs.EmptyFields("reason")
+	}
+
+	var fieldErrs []errs.FieldError
+
+	if bansPlate {
+		if !v.licensePlateRe.MatchString(ban.LicensePlate) {
+			fieldErrs = append(fieldErrs, errs.NewFieldError("licensePlate", "format", "licensePlate can only be letters, numbers, spaces or dashes"))
+		}
+		if len(ban.LicensePlate) > 10 {
+			fieldErrs = append(fieldErrs, errs.NewFieldError("licensePlate", "max_length", "licensePlate can be maximum 10 characters"))
+		}
+	} else if strings.TrimSpace(ban.FirstName) == "" || strings.TrimSpace(ban.LastName) == "" {
+		fieldErrs = append(fieldErrs, errs.NewFieldError("lastName", "required", "both firstName and lastName are required to ban a visitor"))
+	}
+	if ban.ExpiresAt != nil && !ban.ExpiresAt.After(time.Now()) {
+		fieldErrs = append(fieldErrs, errs.NewFieldError("expiresAt", "future", "expiresAt must be in the future"))
+	}
+
+	if len(fieldErrs) != 0 {
+		return errs.InvalidFields(fieldErrs...)
+	}
+
+	return nil
+}
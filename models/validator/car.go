@@ -5,7 +5,6 @@ import (
 	"github.com/dannyvelas/parkspot-backend/models"
 	"github.com/dannyvelas/parkspot-backend/util"
 	"regexp"
-	"strings"
 )
 
 type carValidator struct {
@@ -37,41 +36,41 @@ var (
 )
 
 func (v carValidator) Run(car models.Car) *errs.APIErr {
-	var errors []string
+	var fieldErrs []errs.FieldError
 
 	if v.validateIDFn != nil {
 		if err := v.validateIDFn(car.ID); err != nil {
-			errors = append(errors, err.Error())
+			fieldErrs = append(fieldErrs, errs.NewFieldError("id", "format", err.Error()))
 		}
 	}
 	if v.validateResIDFn != nil {
 		if err := v.validateResIDFn(car.ResidentID); err != nil {
-			errors = append(errors, err.Error())
+			fieldErrs = append(fieldErrs, errs.NewFieldError("residentID", "format", err.Error()))
 		}
 	}
 	if !v.licensePlateRe.MatchString(car.LicensePlate) {
-		errors = append(errors, "licensePlate can only be letters or numbers")
+		fieldErrs = append(fieldErrs, errs.NewFieldError("licensePlate", "format", "licensePlate can only be letters or numbers"))
 	}
 	if len(car.LicensePlate) > 8 {
-		errors = append(errors, "licensePlate can be maximum 8 characters")
+		fieldErrs = append(fieldErrs, errs.NewFieldError("licensePlate", "max_length", "licensePlate can be maximum 8 characters"))
 	}
 	if !v.colorRe.MatchString(car.Color) {
-		errors = append(errors, "color must be one word only letters")
+		fieldErrs = append(fieldErrs, errs.NewFieldError("color", "format", "color must be one word only letters"))
 	}
 	if !v.makeModelRe.MatchString(car.Make) {
-		errors = append(errors, "make can only have spaces, letters, numbers, and dashes")
+		fieldErrs = append(fieldErrs, errs.NewFieldError("make", "format", "make can only have spaces, letters, numbers, and dashes"))
 	}
 	if !v.makeModelRe.MatchString(car.Model) {
-		errors = append(errors, "model can only have spaces, letters, numbers, and dashes")
+		fieldErrs = append(fieldErrs, errs.NewFieldError("model", "format", "model can only have spaces, letters, numbers, and dashes"))
 	}
 	if v.validateAmtDaysFn != nil {
 		if err := v.validateAmtDaysFn(car.AmtParkingDaysUsed); err != nil {
-			errors = append(errors, err.Error())
+			fieldErrs = append(fieldErrs, errs.NewFieldError("amtParkingDaysUsed", "min", err.Error()))
 		}
 	}
 
-	if len(errors) != 0 {
-		return errs.InvalidFields(strings.Join(errors, ". "))
+	if len(fieldErrs) != 0 {
+		return errs.InvalidFields(fieldErrs...)
 	}
 
 	return nil
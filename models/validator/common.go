@@ -1,7 +1,7 @@
 package validator
 
 import (
-	"github.com/dannyvelas/parkspot-backend/errs"
+	"errors"
 )
 
 func validateEditAmtDays(amtDays *int) error {
@@ -10,7 +10,7 @@ func validateEditAmtDays(amtDays *int) error {
 	}
 
 	if *amtDays < 0 {
-		return errs.InvalidFields("amtDays cannot be lower than 0")
+		return errors.New("amtParkingDaysUsed cannot be lower than 0")
 	}
 
 	return nil
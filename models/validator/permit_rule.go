@@ -0,0 +1,69 @@
+package validator
+
+import (
+	"github.com/dannyvelas/parkspot-backend/config"
+	"github.com/dannyvelas/parkspot-backend/errs"
+	"github.com/dannyvelas/parkspot-backend/models"
+	"strings"
+	"time"
+)
+
+type permitRuleValidator struct{}
+
+var (
+	CreatePermitRule = permitRuleValidator{}
+)
+
+func (v permitRuleValidator) Run(permitRule models.PermitRule) *errs.APIErr {
+	if strings.TrimSpace(permitRule.Name) == "" {
+		return errs.EmptyFields("name")
+	}
+
+	var fieldErrs []errs.FieldError
+
+	switch permitRule.Target {
+	case models.PermitTarget:
+		if permitRule.Relationship != "" {
+			fieldErrs = append(fieldErrs, errs.NewFieldError("relationship", "forbidden", "relationship can only be set on rules for visitors"))
+		}
+	case models.VisitorTarget:
+	default:
+		fieldErrs = append(fieldErrs, errs.NewFieldError("target", "one_of", "target must be one of: permit, visitor"))
+	}
+
+	switch permitRule.Kind {
+	case models.BlackoutDatesRule:
+		if len(permitRule.Dates) == 0 {
+			fieldErrs = append(fieldErrs, errs.NewFieldError("dates", "required", "dates cannot be empty"))
+		}
+		for _, date := range permitRule.Dates {
+			if _, err := time.Parse(config.DateFormat, date); err != nil {
+				fieldErrs = append(fieldErrs, errs.NewFieldError("dates", "format", "dates must be in YYYY-MM-DD format"))
+				break
+			}
+		}
+	case models.MaxLengthRule:
+		if permitRule.MaxDays <= 0 {
+			fieldErrs = append(fieldErrs, errs.NewFieldError("maxDays", "min", "maxDays must be greater than 0"))
+		}
+	case models.MaxPerMonthRule:
+		if permitRule.MaxCount <= 0 {
+			fieldErrs = append(fieldErrs, errs.NewFieldError("maxCount", "min", "maxCount must be greater than 0"))
+		}
+	case models.WeekendWeightRule:
+		if permitRule.Weight <= 1 {
+			fieldErrs = append(fieldErrs, errs.NewFieldError("weight", "min", "weight must be greater than 1"))
+		}
+		if permitRule.Target != models.PermitTarget {
+			fieldErrs = append(fieldErrs, errs.NewFieldError("target", "one_of", "weekend_weight rules can only target permits"))
+		}
+	default:
+		fieldErrs = append(fieldErrs, errs.NewFieldError("kind", "one_of", "kind must be one of: blackout_dates, max_length, max_per_month, weekend_weight"))
+	}
+
+	if len(fieldErrs) != 0 {
+		return errs.InvalidFields(fieldErrs...)
+	}
+
+	return nil
+}
@@ -4,7 +4,6 @@ import (
 	"github.com/dannyvelas/parkspot-backend/errs"
 	"github.com/dannyvelas/parkspot-backend/models"
 	"regexp"
-	"strings"
 )
 
 type residentValidator struct {
@@ -33,34 +32,37 @@ var (
 )
 
 func (v residentValidator) Run(resident models.Resident) *errs.APIErr {
-	var errors []string
+	var fieldErrs []errs.FieldError
 
 	if err := models.IsResidentID(resident.ID); err != nil {
-		errors = append(errors, err.Error())
+		fieldErrs = append(fieldErrs, errs.NewFieldError("id", "format", err.Error()))
 	}
 	if !v.firstLastRe.MatchString(resident.FirstName) {
-		errors = append(errors, "first name can only be alphabetic letters and spaces")
+		fieldErrs = append(fieldErrs, errs.NewFieldError("firstName", "format", "first name can only be alphabetic letters and spaces"))
 	}
 	if !v.firstLastRe.MatchString(resident.LastName) {
-		errors = append(errors, "last name can only be alphabetic letters and spaces")
+		fieldErrs = append(fieldErrs, errs.NewFieldError("lastName", "format", "last name can only be alphabetic letters and spaces"))
 	}
 	if !v.phoneRe.MatchString(resident.Phone) {
-		errors = append(errors, "phone must be only numbers, at most 20")
+		fieldErrs = append(fieldErrs, errs.NewFieldError("phone", "format", "phone must be only numbers, at most 20"))
 	}
 	if !v.emailRe.MatchString(resident.Email) {
-		errors = append(errors, "email must be a sequence of characters separated by an '@' character.")
+		fieldErrs = append(fieldErrs, errs.NewFieldError("email", "format", "email must be a sequence of characters separated by an '@' character."))
 	}
 	if !v.passwordEmptyOk && resident.Password == "" {
-		errors = append(errors, "password must not be empty")
+		fieldErrs = append(fieldErrs, errs.NewFieldError("password", "required", "password must not be empty"))
+	}
+	if resident.PreferredLang != "" && !resident.PreferredLang.IsSupported() {
+		fieldErrs = append(fieldErrs, errs.NewFieldError("preferredLang", "one_of", "preferredLang must be one of: en, es"))
 	}
 	if v.validateAmtDaysFn != nil {
 		if err := v.validateAmtDaysFn(resident.AmtParkingDaysUsed); err != nil {
-			errors = append(errors, err.Error())
+			fieldErrs = append(fieldErrs, errs.NewFieldError("amtParkingDaysUsed", "min", err.Error()))
 		}
 	}
 
-	if len(errors) != 0 {
-		return errs.InvalidFields(strings.Join(errors, ". "))
+	if len(fieldErrs) != 0 {
+		return errs.InvalidFields(fieldErrs...)
 	}
 
 	return nil
@@ -0,0 +1,42 @@
+package validator
+
+import (
+	"github.com/dannyvelas/parkspot-backend/errs"
+	"github.com/dannyvelas/parkspot-backend/models"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+type violationValidator struct {
+	licensePlateRe *regexp.Regexp
+}
+
+var (
+	CreateViolation = violationValidator{
+		regexp.MustCompile("^[A-Za-z0-9 -]+$"),
+	}
+)
+
+func (v violationValidator) Run(violation models.Violation) *errs.APIErr {
+	var fieldErrs []errs.FieldError
+
+	if !v.licensePlateRe.MatchString(violation.LicensePlate) {
+		fieldErrs = append(fieldErrs, errs.NewFieldError("licensePlate", "format", "licensePlate can only be letters, numbers, spaces or dashes"))
+	}
+	if len(violation.LicensePlate) > 10 {
+		fieldErrs = append(fieldErrs, errs.NewFieldError("licensePlate", "max_length", "licensePlate can be maximum 10 characters"))
+	}
+	if strings.TrimSpace(violation.Location) == "" {
+		fieldErrs = append(fieldErrs, errs.NewFieldError("location", "required", "location cannot be empty"))
+	}
+	if !slices.Contains(models.ViolationTypes, violation.Type) {
+		fieldErrs = append(fieldErrs, errs.NewFieldError("type", "one_of", "type must be one of: "+strings.Join(models.ViolationTypes, ", ")))
+	}
+
+	if len(fieldErrs) != 0 {
+		return errs.InvalidFields(fieldErrs...)
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package models
+
+import (
+	"time"
+)
+
+type ViolationAction string
+
+const (
+	ViolationWarning ViolationAction = "warning"
+	ViolationTow     ViolationAction = "tow"
+)
+
+type ViolationStatus string
+
+const (
+	ViolationOpen      ViolationStatus = "open"
+	ViolationDisputed  ViolationStatus = "disputed"
+	ViolationUpheld    ViolationStatus = "upheld"
+	ViolationDismissed ViolationStatus = "dismissed"
+)
+
+// ViolationTypes are the kinds of violations that security can record
+var ViolationTypes = []string{"noPermit", "expiredPermit", "unauthorizedSpace", "blockingAccess", "other"}
+
+type Violation struct {
+	ID               string          `json:"id"`
+	LicensePlate     string          `json:"licensePlate"`
+	Location         string          `json:"location"`
+	PhotoRef         string          `json:"photoRef,omitempty"`
+	Type             string          `json:"type"`
+	RecordedBy       string          `json:"recordedBy"`
+	ResidentID       string          `json:"residentID,omitempty"` // resident whose car or guest committed the violation, if known
+	Action           ViolationAction `json:"action"`
+	Status           ViolationStatus `json:"status"`
+	ResidentNotified bool            `json:"residentNotified"`
+	DisputeReason    string          `json:"disputeReason,omitempty"`
+	Resolution       string          `json:"resolution,omitempty"`
+	Timestamp        time.Time       `json:"timestamp"`
+}
+
+func NewViolation(
+	id string,
+	licensePlate string,
+	location string,
+	photoRef string,
+	violationType string,
+	recordedBy string,
+	residentID string,
+	action ViolationAction,
+	status ViolationStatus,
+	residentNotified bool,
+	disputeReason string,
+	resolution string,
+	timestamp time.Time,
+) Violation {
+	return Violation{
+		ID:               id,
+		LicensePlate:     licensePlate,
+		Location:         location,
+		PhotoRef:         photoRef,
+		Type:             violationType,
+		RecordedBy:       recordedBy,
+		ResidentID:       residentID,
+		Action:           action,
+		Status:           status,
+		ResidentNotified: residentNotified,
+		DisputeReason:    disputeReason,
+		Resolution:       resolution,
+		Timestamp:        timestamp,
+	}
+}
+
+type TowNotice struct {
+	ViolationID  string    `json:"violationID"`
+	LicensePlate string    `json:"licensePlate"`
+	Location     string    `json:"location"`
+	IssuedAt     time.Time `json:"issuedAt"`
+	Message      string    `json:"message"`
+}
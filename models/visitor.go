@@ -2,7 +2,6 @@ package models
 
 import (
 	"github.com/dannyvelas/parkspot-backend/errs"
-	"strings"
 	"time"
 )
 
@@ -14,6 +13,7 @@ type Visitor struct {
 	Relationship string    `json:"relationship"`
 	AccessStart  time.Time `json:"accessStart"`
 	AccessEnd    time.Time `json:"accessEnd"`
+	Version      int       `json:"version"` // counts the edits of this visitor, so that edits can be made to the version that they read
 }
 
 func NewVisitor(
@@ -24,6 +24,7 @@ func NewVisitor(
 	relationship string,
 	accessStart time.Time,
 	accessEnd time.Time,
+	version int,
 ) Visitor {
 	return Visitor{
 		ID:           id,
@@ -33,6 +34,7 @@ func NewVisitor(
 		Relationship: relationship,
 		AccessStart:  accessStart,
 		AccessEnd:    accessEnd,
+		Version:      version,
 	}
 }
 
@@ -68,30 +70,27 @@ func (m Visitor) emptyFields() *errs.APIErr {
 	}
 
 	if len(emptyFields) > 0 {
-		return errs.EmptyFields(strings.Join(emptyFields, ", "))
+		return errs.EmptyFields(emptyFields...)
 	}
 
 	return nil
 }
 
 func (m Visitor) invalidFields() *errs.APIErr {
-	errors := []string{}
+	fieldErrs := []errs.FieldError{}
 
 	if m.Relationship != "fam/fri" && m.Relationship != "contractor" {
-		errors = append(errors, "relationship must be either \"fam/fri\" or \"contractor\"")
+		fieldErrs = append(fieldErrs, errs.NewFieldError("relationship", "one_of", "relationship must be either \"fam/fri\" or \"contractor\""))
 	}
 	if m.AccessStart.After(m.AccessEnd) {
-		errors = append(errors, "accessStart cannot be after accessEnd")
+		fieldErrs = append(fieldErrs, errs.NewFieldError("accessStart", "before", "accessStart cannot be after accessEnd"))
 	}
 	if m.AccessStart.Equal(m.AccessEnd) {
-		errors = append(errors, "accessStart cannot be equal to accessEnd")
-	}
-	if m.AccessEnd.After(EndOfTime) {
-		errors = append(errors, "accessEnd cannot be after 9999/12/31")
+		fieldErrs = append(fieldErrs, errs.NewFieldError("accessStart", "before", "accessStart cannot be equal to accessEnd"))
 	}
 
-	if len(errors) > 0 {
-		return errs.InvalidFields(strings.Join(errors, ". "))
+	if len(fieldErrs) > 0 {
+		return errs.InvalidFields(fieldErrs...)
 	}
 
 	return nil
@@ -0,0 +1,87 @@
+package models
+
+import (
+	"time"
+)
+
+type WaitlistStatus string
+
+const (
+	WaitlistWaiting   WaitlistStatus = "waiting"
+	WaitlistPromoted  WaitlistStatus = "promoted"  // a permit was created for this request
+	WaitlistRejected  WaitlistStatus = "rejected"  // this request can no longer become a permit, see Reason
+	WaitlistExpired   WaitlistStatus = "expired"   // the dates of this request passed before a space opened up
+	WaitlistCancelled WaitlistStatus = "cancelled" // the resident or an admin took this request off the waitlist
+)
+
+// WaitlistEntry is a permit request that was blocked because the lot or the resident's quota was full.
+// Reason is the code of the error that most recently kept it from becoming a permit, like permit.lot_at_capacity
+type WaitlistEntry struct {
+	ID              string         `json:"id"`
+	ResidentID      string         `json:"residentID"`
+	CarID           string         `json:"carID,omitempty"`
+	LicensePlate    string         `json:"licensePlate,omitempty"`
+	Color           string         `json:"color,omitempty"`
+	Make            string         `json:"make,omitempty"`
+	Model           string         `json:"model,omitempty"`
+	StartDate       time.Time      `json:"startDate"`
+	EndDate         time.Time      `json:"endDate"`
+	ExceptionReason string         `json:"exceptionReason,omitempty"`
+	SpaceID         string         `json:"spaceID,omitempty"`
+	Status          WaitlistStatus `json:"status"`
+	Reason          string         `json:"reason,omitempty"`
+	PermitID        int            `json:"permitID,omitempty"`
+	CreatedAt       time.Time      `json:"createdAt"`
+}
+
+func NewWaitlistEntry(
+	id string,
+	residentID string,
+	carID string,
+	licensePlate string,
+	color string,
+	make string,
+	model string,
+	startDate time.Time,
+	endDate time.Time,
+	exceptionReason string,
+	spaceID string,
+	status WaitlistStatus,
+	reason string,
+	permitID int,
+	createdAt time.Time,
+) WaitlistEntry {
+	return WaitlistEntry{
+		ID:              id,
+		ResidentID:      residentID,
+		CarID:           carID,
+		LicensePlate:    licensePlate,
+		Color:           color,
+		Make:            make,
+		Model:           model,
+		StartDate:       startDate,
+		EndDate:         endDate,
+		ExceptionReason: exceptionReason,
+		SpaceID:         spaceID,
+		Status:          status,
+		Reason:          reason,
+		PermitID:        permitID,
+		CreatedAt:       createdAt,
+	}
+}
+
+// AsPermit returns the permit request that this entry is waiting on
+func (m WaitlistEntry) AsPermit() Permit {
+	return Permit{
+		ResidentID:      m.ResidentID,
+		CarID:           m.CarID,
+		LicensePlate:    m.LicensePlate,
+		Color:           m.Color,
+		Make:            m.Make,
+		Model:           m.Model,
+		StartDate:       m.StartDate,
+		EndDate:         m.EndDate,
+		ExceptionReason: m.ExceptionReason,
+		SpaceID:         m.SpaceID,
+	}
+}
@@ -313,23 +313,23 @@ with open(file_name, 'r', encoding='latin-1') as file_in:
                 ' Refusing to run the admin prod migration as the resulting file would be empty.'
                 ' Please update id_to_props')
         else:
-            with open(migration_file_name(2, 'admin'), 'w') as file_out:
+            with open(migration_file_name(1, 'admin'), 'w') as file_out:
                 for row in reader:
                     admin = row_to_admin(row, id_to_props)
                     if admin is not None:
                         file_out.write(f'{admin.as_sql()}\n')
     elif model == 'car':
-        with open(migration_file_name(3, 'car'), 'w') as file_out:
+        with open(migration_file_name(2, 'car'), 'w') as file_out:
             for row in reader:
                 car = row_to_car(row)
                 file_out.write(f'{car.as_sql()}\n')
     elif model == 'resident':
-        with open(migration_file_name(4, 'resident'), 'w') as file_out:
+        with open(migration_file_name(3, 'resident'), 'w') as file_out:
             for row in reader:
                 resident = row_to_resident(row)
                 file_out.write(f'{resident.as_sql()}\n')
     elif model == 'permit':
-        with open(migration_file_name(5, 'permit'), 'w') as file_out:
+        with open(migration_file_name(4, 'permit'), 'w') as file_out:
             last_id = -1
             for row in reader:
                 permit = row_to_permit(row)
@@ -340,7 +340,7 @@ with open(file_name, 'r', encoding='latin-1') as file_in:
             file_out.write(
                 f'\nALTER SEQUENCE permit_id_seq RESTART WITH {last_id+1};\n')
     elif model == 'visitor':
-        with open(migration_file_name(6, 'visitor'), 'w') as file_out:
+        with open(migration_file_name(5, 'visitor'), 'w') as file_out:
             for row in reader:
                 visitor = row_to_visitor(row)
                 file_out.write(f'{visitor.as_sql()}\n')
@@ -2,7 +2,7 @@ import re
 
 permit_lps = set()
 car_lps = set()
-with open('./.prodmigrations/000005_seed_permit.up.sql', 'r') as pfile_in:
+with open('./.prodmigrations/000004_seed_permit.up.sql', 'r') as pfile_in:
     amt_matches = 0
     for line in pfile_in:
         match = re.findall(r"= '([^']+)'", line)
@@ -11,7 +11,7 @@ with open('./.prodmigrations/000005_seed_permit.up.sql', 'r') as pfile_in:
             permit_lps.add(first_match)
             amt_matches += 1
     print(amt_matches)
-with open('./.prodmigrations/000003_seed_car.up.sql', 'r') as cfile_in:
+with open('./.prodmigrations/000002_seed_car.up.sql', 'r') as cfile_in:
     amt_matches = 0
     for line in cfile_in:
         match = re.findall(r", '([^']+)'", line)
@@ -2,7 +2,7 @@ import re
 
 permit_resids = set()
 resident_resids = set()
-with open('./.prodmigrations/000005_seed_permit.up.sql', 'r') as pfile_in:
+with open('./.prodmigrations/000004_seed_permit.up.sql', 'r') as pfile_in:
     amt_lines_read = 0
     amt_matches = 0
     for line in pfile_in:
@@ -15,7 +15,7 @@ with open('./.prodmigrations/000005_seed_permit.up.sql', 'r') as pfile_in:
         amt_lines_read += 1
     print(f'amt_lines_read: {amt_lines_read}. amt_matches: {amt_matches}')
 
-with open('./.prodmigrations/000004_seed_resident.up.sql', 'r') as rfile_in:
+with open('./.prodmigrations/000003_seed_resident.up.sql', 'r') as rfile_in:
     amt_lines_read = 0
     amt_matches = 0
     for line in rfile_in:
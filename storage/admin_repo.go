@@ -1,12 +1,14 @@
 package storage
 
 import (
+	"context"
+
 	"github.com/dannyvelas/parkspot-backend/models"
 )
 
 type AdminRepo interface {
-	GetOne(id string) (models.Admin, error)
-	Update(adminFields models.Admin) error
-	Create(desiredAdmin models.Admin) error
-	Delete(id string) error
+	GetOne(ctx context.Context, id string) (models.Admin, error)
+	Update(ctx context.Context, adminFields models.Admin) error
+	Create(ctx context.Context, desiredAdmin models.Admin) error
+	Delete(ctx context.Context, id string) error
 }
@@ -0,0 +1,17 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/dannyvelas/parkspot-backend/models"
+	"github.com/dannyvelas/parkspot-backend/storage/selectopts"
+)
+
+type BanRepo interface {
+	SelectWhere(ctx context.Context, banFields models.Ban, selectOpts ...selectopts.SelectOpt) ([]models.Ban, error)
+	SelectCountWhere(ctx context.Context, banFields models.Ban, selectOpts ...selectopts.SelectOpt) (int, error)
+	GetOne(ctx context.Context, id string) (models.Ban, error)
+	Create(ctx context.Context, desiredBan models.Ban) (string, error)
+	Delete(ctx context.Context, id string) error
+	Reset(ctx context.Context) error // for testing purposes
+}
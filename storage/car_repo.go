@@ -1,17 +1,21 @@
 package storage
 
 import (
+	"context"
+
 	"github.com/dannyvelas/parkspot-backend/models"
 	"github.com/dannyvelas/parkspot-backend/storage/selectopts"
 )
 
 type CarRepo interface {
-	GetOne(id string) (models.Car, error)
-	SelectWhere(carFields models.Car, selectOpts ...selectopts.SelectOpt) ([]models.Car, error)
-	SelectCountWhere(carFields models.Car, selectOpts ...selectopts.SelectOpt) (int, error)
-	Create(desiredCar models.Car) (string, error)
-	AddToAmtParkingDaysUsed(id string, days int) error
-	Update(carFields models.Car) error
-	Delete(id string) error
-	Reset() error // for testing purposes
+	GetOne(ctx context.Context, id string) (models.Car, error)
+	SelectWhere(ctx context.Context, carFields models.Car, selectOpts ...selectopts.SelectOpt) ([]models.Car, error)
+	SelectCountWhere(ctx context.Context, carFields models.Car, selectOpts ...selectopts.SelectOpt) (int, error)
+	Create(ctx context.Context, desiredCar models.Car) (string, error)
+	AddToAmtParkingDaysUsed(ctx context.Context, id string, days int) error
+	// Update edits the car of the ID of carFields, and increments its version. when carFields has a version,
+	// the car is only edited if it is still at that version, and a conflict is returned otherwise
+	Update(ctx context.Context, carFields models.Car) error
+	Delete(ctx context.Context, id string) error
+	Reset(ctx context.Context) error // for testing purposes
 }
@@ -6,4 +6,12 @@ type Database interface {
 	CarRepo() CarRepo
 	PermitRepo() PermitRepo
 	VisitorRepo() VisitorRepo
+	GateEventRepo() GateEventRepo
+	ViolationRepo() ViolationRepo
+	BanRepo() BanRepo
+	ParkingSpaceRepo() ParkingSpaceRepo
+	WaitlistRepo() WaitlistRepo
+	ParkingPolicyRepo() ParkingPolicyRepo
+	PermitRuleRepo() PermitRuleRepo
+	ImportRepo() ImportRepo
 }
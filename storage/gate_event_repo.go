@@ -0,0 +1,18 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/dannyvelas/parkspot-backend/models"
+	"github.com/dannyvelas/parkspot-backend/storage/selectopts"
+)
+
+type GateEventRepo interface {
+	SelectWhere(ctx context.Context, gateEventFields models.GateEvent, selectOpts ...selectopts.SelectOpt) ([]models.GateEvent, error)
+	SelectCountWhere(ctx context.Context, gateEventFields models.GateEvent, selectOpts ...selectopts.SelectOpt) (int, error)
+	GetOne(ctx context.Context, id string) (models.GateEvent, error)
+	Create(ctx context.Context, desiredGateEvent models.GateEvent) (string, error)
+	SetCheckOut(ctx context.Context, id string, checkOut time.Time) error
+	Reset(ctx context.Context) error // for testing purposes
+}
@@ -0,0 +1,12 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/dannyvelas/parkspot-backend/models"
+)
+
+type ImportRepo interface {
+	// Import creates residents and cars all at once. when one of them can't be created, none are
+	Import(ctx context.Context, residents []models.Resident, cars []models.Car) error
+}
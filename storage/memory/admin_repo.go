@@ -0,0 +1,115 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/dannyvelas/parkspot-backend/errs"
+	"github.com/dannyvelas/parkspot-backend/models"
+	"github.com/dannyvelas/parkspot-backend/util"
+)
+
+type AdminRepo struct {
+	store *store
+}
+
+func newAdminRepo(store *store) AdminRepo {
+	return AdminRepo{store}
+}
+
+func (adminRepo AdminRepo) GetOne(ctx context.Context, id string) (models.Admin, error) {
+	if id == "" {
+		return models.Admin{}, fmt.Errorf("admin_repo.GetOne: %w: Empty ID", errs.ErrDBInvalidArg)
+	}
+
+	adminRepo.store.mu.Lock()
+	defer adminRepo.store.mu.Unlock()
+
+	i := util.Find(adminRepo.store.admins, func(admin models.Admin) bool { return strings.EqualFold(admin.ID, id) })
+	if i < 0 {
+		return models.Admin{}, fmt.Errorf("admin_repo.GetOne: %w", errs.NewNotFound("admin"))
+	}
+
+	return cloneAdmin(adminRepo.store.admins[i]), nil
+}
+
+func (adminRepo AdminRepo) Update(ctx context.Context, adminFields models.Admin) error {
+	adminRepo.store.mu.Lock()
+	defer adminRepo.store.mu.Unlock()
+
+	i := util.Find(adminRepo.store.admins, func(admin models.Admin) bool { return admin.ID == adminFields.ID })
+	if i < 0 {
+		return nil
+	}
+	if adminFields.Email != "" && adminFields.Email != adminRepo.store.admins[i].Email &&
+		adminRepo.emailExists(adminFields.Email) {
+		return fmt.Errorf("admin_repo.Update: %w: %v", errs.ErrDBExec, errUniqueViolation)
+	}
+
+	admin := &adminRepo.store.admins[i]
+	setIfNotEmpty(&admin.FirstName, adminFields.FirstName)
+	setIfNotEmpty(&admin.LastName, adminFields.LastName)
+	setIfNotEmpty(&admin.Email, adminFields.Email)
+	setIfNotEmpty(&admin.Password, adminFields.Password)
+	setIfNotEmpty(&admin.PreferredLang, adminFields.PreferredLang)
+	if adminFields.IsPrivileged {
+		admin.IsPrivileged = true
+	}
+	if adminFields.TokenVersion != nil {
+		*admin.TokenVersion = *adminFields.TokenVersion
+	}
+
+	return nil
+}
+
+func (adminRepo AdminRepo) Create(ctx context.Context, desiredAdmin models.Admin) error {
+	adminRepo.store.mu.Lock()
+	defer adminRepo.store.mu.Unlock()
+
+	idExists := util.Find(adminRepo.store.admins, func(admin models.Admin) bool { return admin.ID == desiredAdmin.ID }) >= 0
+	if idExists || adminRepo.emailExists(desiredAdmin.Email) {
+		return fmt.Errorf("admin_repo.Create: %w: %v", errs.ErrDBExec, errUniqueViolation)
+	}
+
+	adminRepo.store.admins = append(adminRepo.store.admins, models.NewAdmin(
+		desiredAdmin.ID,
+		desiredAdmin.FirstName,
+		desiredAdmin.LastName,
+		desiredAdmin.Email,
+		desiredAdmin.Password,
+		desiredAdmin.IsPrivileged,
+		desiredAdmin.PreferredLang,
+		0,
+	))
+
+	return nil
+}
+
+func (adminRepo AdminRepo) Delete(ctx context.Context, id string) error {
+	adminRepo.store.mu.Lock()
+	defer adminRepo.store.mu.Unlock()
+
+	if deleted := deleteWhere(&adminRepo.store.admins, func(admin models.Admin) bool { return admin.ID == id }); len(deleted) == 0 {
+		return fmt.Errorf("admin_repo.Delete: %w", errs.NewNotFound("admin"))
+	}
+
+	return nil
+}
+
+func (adminRepo AdminRepo) emailExists(email string) bool {
+	return util.Find(adminRepo.store.admins, func(admin models.Admin) bool { return admin.Email == email }) >= 0
+}
+
+func cloneAdmin(admin models.Admin) models.Admin {
+	return models.NewAdmin(
+		admin.ID,
+		admin.FirstName,
+		admin.LastName,
+		admin.Email,
+		admin.Password,
+		admin.IsPrivileged,
+		admin.PreferredLang,
+		*admin.TokenVersion,
+	)
+}
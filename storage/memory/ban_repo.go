@@ -0,0 +1,137 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dannyvelas/parkspot-backend/errs"
+	"github.com/dannyvelas/parkspot-backend/models"
+	"github.com/dannyvelas/parkspot-backend/storage/selectopts"
+	"github.com/dannyvelas/parkspot-backend/util"
+	"github.com/google/uuid"
+)
+
+type BanRepo struct {
+	table[models.Ban]
+	store *store
+}
+
+func newBanRepo(store *store) BanRepo {
+	return BanRepo{
+		table: table[models.Ban]{
+			name: "ban",
+			id:   func(ban models.Ban) any { return ban.ID },
+			search: func(ban models.Ban, query string) bool {
+				return matchesAny(query, ban.LicensePlate, ban.FirstName, ban.LastName)
+			},
+			// an active ban is one that has not expired
+			status: func(ban models.Ban, status models.Status, now time.Time) (bool, bool) {
+				switch status {
+				case models.ActiveStatus:
+					return ban.ExpiresAt == nil || ban.ExpiresAt.Unix() > now.Unix(), true
+				case models.ExpiredStatus:
+					return ban.ExpiresAt != nil && ban.ExpiresAt.Unix() <= now.Unix(), true
+				default:
+					return false, false
+				}
+			},
+			licensePlate: func(ban models.Ban) string { return ban.LicensePlate },
+			orderBy: func(a, b models.Ban) int {
+				return b.CreatedAt.Compare(a.CreatedAt)
+			},
+		},
+		store: store,
+	}
+}
+
+func (banRepo BanRepo) SelectWhere(ctx context.Context, banFields models.Ban, selectOpts ...selectopts.SelectOpt) ([]models.Ban, error) {
+	banRepo.store.mu.Lock()
+	defer banRepo.store.mu.Unlock()
+
+	bans, err := banRepo.selectWhere(banRepo.store.bans, banWhere(banFields), selectOpts)
+	if err != nil {
+		return nil, fmt.Errorf("ban_repo.SelectWhere: %w", err)
+	}
+
+	return cloneAll(bans, cloneBan), nil
+}
+
+func (banRepo BanRepo) SelectCountWhere(ctx context.Context, banFields models.Ban, selectOpts ...selectopts.SelectOpt) (int, error) {
+	banRepo.store.mu.Lock()
+	defer banRepo.store.mu.Unlock()
+
+	totalAmount, err := banRepo.countWhere(banRepo.store.bans, banWhere(banFields), selectOpts)
+	if err != nil {
+		return 0, fmt.Errorf("ban_repo.SelectCountWhere: %w", err)
+	}
+
+	return totalAmount, nil
+}
+
+// banWhere matches visitor names case-insensitively, since they are typed in by hand
+func banWhere(banFields models.Ban) func(models.Ban) bool {
+	firstName := strings.ToLower(strings.TrimSpace(banFields.FirstName))
+	lastName := strings.ToLower(strings.TrimSpace(banFields.LastName))
+	return func(ban models.Ban) bool {
+		return (banFields.CreatedBy == "" || banFields.CreatedBy == ban.CreatedBy) &&
+			(banFields.FirstName == "" || firstName == strings.ToLower(ban.FirstName)) &&
+			(banFields.LastName == "" || lastName == strings.ToLower(ban.LastName))
+	}
+}
+
+func (banRepo BanRepo) GetOne(ctx context.Context, id string) (models.Ban, error) {
+	banRepo.store.mu.Lock()
+	defer banRepo.store.mu.Unlock()
+
+	i := util.Find(banRepo.store.bans, func(ban models.Ban) bool { return ban.ID == id })
+	if i < 0 {
+		return models.Ban{}, fmt.Errorf("ban_repo.GetOne: %w", errs.NewNotFound("ban"))
+	}
+
+	return cloneBan(banRepo.store.bans[i]), nil
+}
+
+func (banRepo BanRepo) Create(ctx context.Context, desiredBan models.Ban) (string, error) {
+	banRepo.store.mu.Lock()
+	defer banRepo.store.mu.Unlock()
+
+	banID := uuid.NewString()
+	banRepo.store.bans = append(banRepo.store.bans, models.NewBan(
+		banID,
+		desiredBan.LicensePlate,
+		desiredBan.FirstName,
+		desiredBan.LastName,
+		desiredBan.Reason,
+		desiredBan.CreatedBy,
+		toStoredTime(desiredBan.CreatedAt),
+		toStoredTimePtr(desiredBan.ExpiresAt),
+	))
+
+	return banID, nil
+}
+
+func (banRepo BanRepo) Delete(ctx context.Context, id string) error {
+	banRepo.store.mu.Lock()
+	defer banRepo.store.mu.Unlock()
+
+	if deleted := deleteWhere(&banRepo.store.bans, func(ban models.Ban) bool { return ban.ID == id }); len(deleted) == 0 {
+		return fmt.Errorf("ban_repo.Delete: %w", errs.NewNotFound("ban"))
+	}
+
+	return nil
+}
+
+func (banRepo BanRepo) Reset(ctx context.Context) error {
+	banRepo.store.mu.Lock()
+	defer banRepo.store.mu.Unlock()
+
+	banRepo.store.bans = nil
+	return nil
+}
+
+func cloneBan(ban models.Ban) models.Ban {
+	ban.ExpiresAt = toStoredTimePtr(ban.ExpiresAt)
+	return ban
+}
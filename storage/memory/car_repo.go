@@ -0,0 +1,203 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/dannyvelas/parkspot-backend/errs"
+	"github.com/dannyvelas/parkspot-backend/models"
+	"github.com/dannyvelas/parkspot-backend/storage/selectopts"
+	"github.com/dannyvelas/parkspot-backend/util"
+	"github.com/google/uuid"
+)
+
+type CarRepo struct {
+	table[models.Car]
+	store *store
+}
+
+func newCarRepo(store *store) CarRepo {
+	return CarRepo{
+		table: table[models.Car]{
+			name: "car",
+			id:   func(car models.Car) any { return car.ID },
+			fields: map[string]field[models.Car]{
+				"residentID": {Type: selectopts.StringField, sortable: true, filterable: true,
+					value: func(car models.Car) any { return car.ResidentID }},
+				"licensePlate": {Type: selectopts.StringField, sortable: true, filterable: true,
+					value: func(car models.Car) any { return car.LicensePlate }},
+				"color": {Type: selectopts.StringField, sortable: true, filterable: true,
+					value: func(car models.Car) any { return car.Color }},
+				"make": {Type: selectopts.StringField, filterable: true,
+					value: func(car models.Car) any { return car.Make }},
+				"model": {Type: selectopts.StringField, filterable: true,
+					value: func(car models.Car) any { return car.Model }},
+				"amtParkingDaysUsed": {Type: selectopts.IntField, filterable: true,
+					value: func(car models.Car) any { return int64(*car.AmtParkingDaysUsed) }},
+			},
+			search: func(car models.Car, query string) bool {
+				return matchesTerms(carSearchDocument(car), query)
+			},
+			document:     carSearchDocument,
+			licensePlate: func(car models.Car) string { return car.LicensePlate },
+		},
+		store: store,
+	}
+}
+
+func (carRepo CarRepo) GetOne(ctx context.Context, id string) (models.Car, error) {
+	carRepo.store.mu.Lock()
+	defer carRepo.store.mu.Unlock()
+
+	i := util.Find(carRepo.store.cars, func(car models.Car) bool { return car.ID == id })
+	if i < 0 {
+		return models.Car{}, fmt.Errorf("car_repo.GetOne: %w", errs.NewNotFound("car"))
+	}
+
+	return cloneCar(carRepo.store.cars[i]), nil
+}
+
+func (carRepo CarRepo) SelectWhere(ctx context.Context, carFields models.Car, selectOpts ...selectopts.SelectOpt) ([]models.Car, error) {
+	carRepo.store.mu.Lock()
+	defer carRepo.store.mu.Unlock()
+
+	cars, err := carRepo.selectWhere(carRepo.store.cars, carWhere(carFields), selectOpts)
+	if err != nil {
+		return nil, fmt.Errorf("car_repo.SelectWhere: %w", err)
+	}
+
+	return cloneAll(cars, cloneCar), nil
+}
+
+func (carRepo CarRepo) SelectCountWhere(ctx context.Context, carFields models.Car, selectOpts ...selectopts.SelectOpt) (int, error) {
+	carRepo.store.mu.Lock()
+	defer carRepo.store.mu.Unlock()
+
+	totalAmount, err := carRepo.countWhere(carRepo.store.cars, carWhere(carFields), selectOpts)
+	if err != nil {
+		return 0, fmt.Errorf("car_repo.SelectCountWhere: %w", err)
+	}
+
+	return totalAmount, nil
+}
+
+func carWhere(carFields models.Car) func(models.Car) bool {
+	return func(car models.Car) bool {
+		return (carFields.ResidentID == "" || carFields.ResidentID == car.ResidentID) &&
+			(carFields.LicensePlate == "" || carFields.LicensePlate == car.LicensePlate) &&
+			(carFields.Color == "" || carFields.Color == car.Color) &&
+			(carFields.Make == "" || carFields.Make == car.Make) &&
+			(carFields.Model == "" || carFields.Model == car.Model)
+	}
+}
+
+func (carRepo CarRepo) Create(ctx context.Context, desiredCar models.Car) (string, error) {
+	carRepo.store.mu.Lock()
+	defer carRepo.store.mu.Unlock()
+
+	id, err := carRepo.store.createCar(desiredCar)
+	if err != nil {
+		return "", fmt.Errorf("car_repo.Create: %w: %v", errs.ErrDBExec, err)
+	}
+
+	return id, nil
+}
+
+func (s *store) createCar(desiredCar models.Car) (string, error) {
+	if desiredCar.ID == "" {
+		desiredCar.ID = uuid.NewString()
+	}
+
+	if !s.residentExists(desiredCar.ResidentID) {
+		return "", errForeignKeyViolation
+	} else if s.carExists(desiredCar.ID, desiredCar.LicensePlate) {
+		return "", errUniqueViolation
+	}
+
+	s.cars = append(s.cars, models.NewCar(
+		desiredCar.ID,
+		desiredCar.ResidentID,
+		desiredCar.LicensePlate,
+		desiredCar.Color,
+		desiredCar.Make,
+		desiredCar.Model,
+		0,
+		1,
+	))
+
+	return desiredCar.ID, nil
+}
+
+// carExists is true when a car has id or licensePlate, which are unique
+func (s *store) carExists(id string, licensePlate string) bool {
+	return util.Find(s.cars, func(car models.Car) bool { return car.ID == id || car.LicensePlate == licensePlate }) >= 0
+}
+
+func (carRepo CarRepo) AddToAmtParkingDaysUsed(ctx context.Context, id string, days int) error {
+	carRepo.store.mu.Lock()
+	defer carRepo.store.mu.Unlock()
+
+	if i := util.Find(carRepo.store.cars, func(car models.Car) bool { return car.ID == id }); i >= 0 {
+		*carRepo.store.cars[i].AmtParkingDaysUsed += days
+	}
+
+	return nil
+}
+
+func (carRepo CarRepo) Update(ctx context.Context, carFields models.Car) error {
+	carRepo.store.mu.Lock()
+	defer carRepo.store.mu.Unlock()
+
+	i := util.Find(carRepo.store.cars, func(car models.Car) bool { return car.ID == carFields.ID })
+	if i < 0 && carFields.Version != 0 {
+		return fmt.Errorf("car_repo.Update: %w", errs.NewNotFound("car"))
+	} else if i < 0 {
+		return nil
+	} else if carFields.Version != 0 && carFields.Version != carRepo.store.cars[i].Version {
+		return fmt.Errorf("car_repo.Update: %w", errs.NewConflict("car"))
+	}
+	if carFields.LicensePlate != "" && carFields.LicensePlate != carRepo.store.cars[i].LicensePlate &&
+		carRepo.store.carExists("", carFields.LicensePlate) {
+		return fmt.Errorf("car_repo.Update: %w: %v", errs.ErrDBExec, errUniqueViolation)
+	}
+
+	car := &carRepo.store.cars[i]
+	setIfNotEmpty(&car.LicensePlate, carFields.LicensePlate)
+	setIfNotEmpty(&car.Color, carFields.Color)
+	setIfNotEmpty(&car.Make, carFields.Make)
+	setIfNotEmpty(&car.Model, carFields.Model)
+	if carFields.AmtParkingDaysUsed != nil {
+		*car.AmtParkingDaysUsed = *carFields.AmtParkingDaysUsed
+	}
+	if carFields.Version != 0 {
+		car.Version++
+	}
+
+	return nil
+}
+
+func (carRepo CarRepo) Delete(ctx context.Context, id string) error {
+	carRepo.store.mu.Lock()
+	defer carRepo.store.mu.Unlock()
+
+	deleteWhere(&carRepo.store.cars, func(car models.Car) bool { return car.ID == id })
+	return nil
+}
+
+func (carRepo CarRepo) Reset(ctx context.Context) error {
+	carRepo.store.mu.Lock()
+	defer carRepo.store.mu.Unlock()
+
+	carRepo.store.cars = nil
+	return nil
+}
+
+// carSearchDocument is the counterpart of the carSearchDocument of storage/sqlrepo
+func carSearchDocument(car models.Car) string {
+	return strings.ToLower(car.ResidentID + " " + car.LicensePlate + " " + car.Color + " " + car.Make + " " + car.Model)
+}
+
+func cloneCar(car models.Car) models.Car {
+	return models.NewCar(car.ID, car.ResidentID, car.LicensePlate, car.Color, car.Make, car.Model, *car.AmtParkingDaysUsed, car.Version)
+}
@@ -0,0 +1,97 @@
+package memory
+
+import (
+	"time"
+
+	"github.com/dannyvelas/parkspot-backend/storage"
+)
+
+type Database struct {
+	adminRepo         storage.AdminRepo
+	residentRepo      storage.ResidentRepo
+	carRepo           storage.CarRepo
+	permitRepo        storage.PermitRepo
+	visitorRepo       storage.VisitorRepo
+	gateEventRepo     storage.GateEventRepo
+	violationRepo     storage.ViolationRepo
+	banRepo           storage.BanRepo
+	parkingSpaceRepo  storage.ParkingSpaceRepo
+	waitlistRepo      storage.WaitlistRepo
+	parkingPolicyRepo storage.ParkingPolicyRepo
+	permitRuleRepo    storage.PermitRuleRepo
+	importRepo        storage.ImportRepo
+}
+
+// NewDatabase returns an empty Database. its repos share one store, so that they see each other's rows.
+// location is the timezone of the community, whose calendar the days of permits and visitors are days of
+func NewDatabase(location *time.Location) Database {
+	store := &store{}
+
+	return Database{
+		adminRepo:         newAdminRepo(store),
+		residentRepo:      newResidentRepo(store),
+		carRepo:           newCarRepo(store),
+		permitRepo:        newPermitRepo(store, location),
+		visitorRepo:       newVisitorRepo(store, location),
+		gateEventRepo:     newGateEventRepo(store),
+		violationRepo:     newViolationRepo(store),
+		banRepo:           newBanRepo(store),
+		parkingSpaceRepo:  newParkingSpaceRepo(store),
+		waitlistRepo:      newWaitlistRepo(store),
+		parkingPolicyRepo: newParkingPolicyRepo(store),
+		permitRuleRepo:    newPermitRuleRepo(store),
+		importRepo:        newImportRepo(store),
+	}
+}
+
+func (database Database) AdminRepo() storage.AdminRepo {
+	return database.adminRepo
+}
+
+func (database Database) ResidentRepo() storage.ResidentRepo {
+	return database.residentRepo
+}
+
+func (database Database) CarRepo() storage.CarRepo {
+	return database.carRepo
+}
+
+func (database Database) PermitRepo() storage.PermitRepo {
+	return database.permitRepo
+}
+
+func (database Database) VisitorRepo() storage.VisitorRepo {
+	return database.visitorRepo
+}
+
+func (database Database) GateEventRepo() storage.GateEventRepo {
+	return database.gateEventRepo
+}
+
+func (database Database) ViolationRepo() storage.ViolationRepo {
+	return database.violationRepo
+}
+
+func (database Database) BanRepo() storage.BanRepo {
+	return database.banRepo
+}
+
+func (database Database) ParkingSpaceRepo() storage.ParkingSpaceRepo {
+	return database.parkingSpaceRepo
+}
+
+func (database Database) WaitlistRepo() storage.WaitlistRepo {
+	return database.waitlistRepo
+}
+
+func (database Database) ParkingPolicyRepo() storage.ParkingPolicyRepo {
+	return database.parkingPolicyRepo
+}
+
+func (database Database) PermitRuleRepo() storage.PermitRuleRepo {
+	return database.permitRuleRepo
+}
+
+func (database Database) ImportRepo() storage.ImportRepo {
+	return database.importRepo
+}
@@ -0,0 +1,12 @@
+package memory
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dannyvelas/parkspot-backend/storage/storagetest"
+)
+
+func TestDatabase(t *testing.T) {
+	storagetest.Run(t, NewDatabase(time.Local))
+}
@@ -0,0 +1,5 @@
+// Package memory provides an in-memory implementation of the storage interface, for fast tests and demos.
+// Its repos behave like the ones of package psql, down to their select options, constraints and cascades,
+// except that text is sorted byte by byte instead of by the collation of the database, and that their methods
+// never wait, so they ignore the contexts that they are called with.
+package memory
@@ -0,0 +1,166 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dannyvelas/parkspot-backend/errs"
+	"github.com/dannyvelas/parkspot-backend/models"
+	"github.com/dannyvelas/parkspot-backend/storage/selectopts"
+	"github.com/dannyvelas/parkspot-backend/util"
+	"github.com/google/uuid"
+)
+
+type GateEventRepo struct {
+	table[models.GateEvent]
+	store *store
+}
+
+func newGateEventRepo(store *store) GateEventRepo {
+	return GateEventRepo{
+		table: table[models.GateEvent]{
+			name: "gate_event",
+			id:   func(gateEvent models.GateEvent) any { return gateEvent.ID },
+			search: func(gateEvent models.GateEvent, query string) bool {
+				return matchesAny(query, gateEvent.ResidentID, gateEvent.LicensePlate)
+			},
+			// for gate events, active means that the guest is still on the property
+			status: func(gateEvent models.GateEvent, status models.Status, now time.Time) (bool, bool) {
+				switch status {
+				case models.ActiveStatus:
+					return gateEvent.CheckOut == nil, true
+				case models.OverstayStatus:
+					return gateEvent.CheckOut == nil && !gateEvent.AllowedUntil.IsZero() && gateEvent.AllowedUntil.Before(now), true
+				default:
+					return false, false
+				}
+			},
+			licensePlate: func(gateEvent models.GateEvent) string { return gateEvent.LicensePlate },
+			orderBy: func(a, b models.GateEvent) int {
+				return b.CheckIn.Compare(a.CheckIn)
+			},
+		},
+		store: store,
+	}
+}
+
+func (gateEventRepo GateEventRepo) SelectWhere(ctx context.Context, gateEventFields models.GateEvent, selectOpts ...selectopts.SelectOpt) ([]models.GateEvent, error) {
+	gateEventRepo.store.mu.Lock()
+	defer gateEventRepo.store.mu.Unlock()
+
+	gateEvents, err := gateEventRepo.selectWhere(gateEventRepo.store.joinedGateEvents(), gateEventWhere(gateEventFields), selectOpts)
+	if err != nil {
+		return nil, fmt.Errorf("gate_event_repo.SelectWhere: %w", err)
+	}
+
+	return gateEvents, nil
+}
+
+func (gateEventRepo GateEventRepo) SelectCountWhere(ctx context.Context, gateEventFields models.GateEvent, selectOpts ...selectopts.SelectOpt) (int, error) {
+	gateEventRepo.store.mu.Lock()
+	defer gateEventRepo.store.mu.Unlock()
+
+	totalAmount, err := gateEventRepo.countWhere(gateEventRepo.store.joinedGateEvents(), gateEventWhere(gateEventFields), selectOpts)
+	if err != nil {
+		return 0, fmt.Errorf("gate_event_repo.SelectCountWhere: %w", err)
+	}
+
+	return totalAmount, nil
+}
+
+func gateEventWhere(gateEventFields models.GateEvent) func(models.GateEvent) bool {
+	return func(gateEvent models.GateEvent) bool {
+		return (gateEventFields.ID == "" || gateEventFields.ID == gateEvent.ID) &&
+			(gateEventFields.ResidentID == "" || gateEventFields.ResidentID == gateEvent.ResidentID) &&
+			(gateEventFields.VisitorID == "" || gateEventFields.VisitorID == gateEvent.VisitorID) &&
+			(gateEventFields.PermitID == 0 || gateEventFields.PermitID == gateEvent.PermitID) &&
+			(gateEventFields.LicensePlate == "" || gateEventFields.LicensePlate == gateEvent.LicensePlate)
+	}
+}
+
+func (gateEventRepo GateEventRepo) GetOne(ctx context.Context, id string) (models.GateEvent, error) {
+	gateEventRepo.store.mu.Lock()
+	defer gateEventRepo.store.mu.Unlock()
+
+	gateEvents := gateEventRepo.store.joinedGateEvents()
+	i := util.Find(gateEvents, func(gateEvent models.GateEvent) bool { return gateEvent.ID == id })
+	if i < 0 {
+		return models.GateEvent{}, fmt.Errorf("gate_event_repo.GetOne: %w", errs.NewNotFound("gate event"))
+	}
+
+	return gateEvents[i], nil
+}
+
+func (gateEventRepo GateEventRepo) Create(ctx context.Context, desiredGateEvent models.GateEvent) (string, error) {
+	gateEventRepo.store.mu.Lock()
+	defer gateEventRepo.store.mu.Unlock()
+
+	// visitors arrive without a license plate and permits arrive without a visitor id
+	if !gateEventRepo.store.residentExists(desiredGateEvent.ResidentID) ||
+		(desiredGateEvent.VisitorID != "" && !gateEventRepo.store.visitorExists(desiredGateEvent.VisitorID)) ||
+		(desiredGateEvent.PermitID != 0 && !gateEventRepo.store.permitExists(desiredGateEvent.PermitID)) {
+		return "", fmt.Errorf("gate_event_repo.Create: %w: %v", errs.ErrDBExec, errForeignKeyViolation)
+	}
+
+	gateEventID := uuid.NewString()
+	gateEventRepo.store.gateEvents = append(gateEventRepo.store.gateEvents, models.NewGateEvent(
+		gateEventID,
+		desiredGateEvent.ResidentID,
+		desiredGateEvent.VisitorID,
+		desiredGateEvent.PermitID,
+		desiredGateEvent.LicensePlate,
+		desiredGateEvent.RecordedBy,
+		toStoredTime(desiredGateEvent.CheckIn),
+		nil,
+		time.Time{},
+	))
+
+	return gateEventID, nil
+}
+
+func (gateEventRepo GateEventRepo) SetCheckOut(ctx context.Context, id string, checkOut time.Time) error {
+	gateEventRepo.store.mu.Lock()
+	defer gateEventRepo.store.mu.Unlock()
+
+	i := util.Find(gateEventRepo.store.gateEvents, func(gateEvent models.GateEvent) bool { return gateEvent.ID == id })
+	if i < 0 {
+		return fmt.Errorf("gate_event_repo.SetCheckOut: %w", errs.NewNotFound("gate event"))
+	}
+
+	gateEventRepo.store.gateEvents[i].CheckOut = toStoredTimePtr(&checkOut)
+	return nil
+}
+
+func (gateEventRepo GateEventRepo) Reset(ctx context.Context) error {
+	gateEventRepo.store.mu.Lock()
+	defer gateEventRepo.store.mu.Unlock()
+
+	gateEventRepo.store.gateEvents = nil
+	return nil
+}
+
+// joinedGateEvents are the gate events of s with the end of the visitor or permit they were recorded against,
+// like the joins of the gate event select of storage/sqlrepo
+func (s *store) joinedGateEvents() []models.GateEvent {
+	return cloneAll(s.gateEvents, func(gateEvent models.GateEvent) models.GateEvent {
+		allowedUntil := time.Time{}
+		if i := util.Find(s.visitors, func(visitor models.Visitor) bool { return visitor.ID == gateEvent.VisitorID }); gateEvent.VisitorID != "" && i >= 0 {
+			allowedUntil = s.visitors[i].AccessEnd
+		} else if i := util.Find(s.permits, func(permit models.Permit) bool { return permit.ID == gateEvent.PermitID }); gateEvent.PermitID != 0 && i >= 0 {
+			allowedUntil = s.permits[i].EndDate
+		}
+
+		return models.NewGateEvent(
+			gateEvent.ID,
+			gateEvent.ResidentID,
+			gateEvent.VisitorID,
+			gateEvent.PermitID,
+			gateEvent.LicensePlate,
+			gateEvent.RecordedBy,
+			gateEvent.CheckIn,
+			toStoredTimePtr(gateEvent.CheckOut),
+			allowedUntil,
+		)
+	})
+}
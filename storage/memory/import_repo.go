@@ -0,0 +1,45 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/dannyvelas/parkspot-backend/errs"
+	"github.com/dannyvelas/parkspot-backend/models"
+)
+
+type ImportRepo struct {
+	store *store
+}
+
+func newImportRepo(store *store) ImportRepo {
+	return ImportRepo{store}
+}
+
+func (importRepo ImportRepo) Import(ctx context.Context, residents []models.Resident, cars []models.Car) error {
+	importRepo.store.mu.Lock()
+	defer importRepo.store.mu.Unlock()
+
+	// like a transaction, the store is put back the way it was when any row can't be created
+	savedResidents, savedCars := slices.Clone(importRepo.store.residents), slices.Clone(importRepo.store.cars)
+	rollback := func() {
+		importRepo.store.residents, importRepo.store.cars = savedResidents, savedCars
+	}
+
+	for _, resident := range residents {
+		if err := importRepo.store.createResident(resident); err != nil {
+			rollback()
+			return fmt.Errorf("import_repo.Import: %w: error creating resident %s: %v", errs.ErrDBExec, resident.ID, err)
+		}
+	}
+
+	for _, car := range cars {
+		if _, err := importRepo.store.createCar(car); err != nil {
+			rollback()
+			return fmt.Errorf("import_repo.Import: %w: error creating car %s: %v", errs.ErrDBExec, car.LicensePlate, err)
+		}
+	}
+
+	return nil
+}
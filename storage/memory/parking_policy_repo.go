@@ -0,0 +1,43 @@
+package memory
+
+import (
+	"context"
+	"github.com/dannyvelas/parkspot-backend/models"
+)
+
+type ParkingPolicyRepo struct {
+	store *store
+}
+
+func newParkingPolicyRepo(store *store) ParkingPolicyRepo {
+	return ParkingPolicyRepo{store}
+}
+
+// Get returns models.DefaultParkingPolicy if no parking policy has been saved yet
+func (parkingPolicyRepo ParkingPolicyRepo) Get(ctx context.Context) (models.ParkingPolicy, error) {
+	parkingPolicyRepo.store.mu.Lock()
+	defer parkingPolicyRepo.store.mu.Unlock()
+
+	if parkingPolicyRepo.store.parkingPolicy == nil {
+		return models.DefaultParkingPolicy, nil
+	}
+
+	return *parkingPolicyRepo.store.parkingPolicy, nil
+}
+
+func (parkingPolicyRepo ParkingPolicyRepo) Set(ctx context.Context, policy models.ParkingPolicy) error {
+	parkingPolicyRepo.store.mu.Lock()
+	defer parkingPolicyRepo.store.mu.Unlock()
+
+	policy.LatestEndDate = toStoredTime(policy.LatestEndDate)
+	parkingPolicyRepo.store.parkingPolicy = &policy
+	return nil
+}
+
+func (parkingPolicyRepo ParkingPolicyRepo) Reset(ctx context.Context) error {
+	parkingPolicyRepo.store.mu.Lock()
+	defer parkingPolicyRepo.store.mu.Unlock()
+
+	parkingPolicyRepo.store.parkingPolicy = nil
+	return nil
+}
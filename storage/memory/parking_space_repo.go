@@ -0,0 +1,114 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+
+	"github.com/dannyvelas/parkspot-backend/errs"
+	"github.com/dannyvelas/parkspot-backend/models"
+	"github.com/dannyvelas/parkspot-backend/storage/selectopts"
+	"github.com/dannyvelas/parkspot-backend/util"
+	"github.com/google/uuid"
+)
+
+type ParkingSpaceRepo struct {
+	table[models.ParkingSpace]
+	store *store
+}
+
+func newParkingSpaceRepo(store *store) ParkingSpaceRepo {
+	return ParkingSpaceRepo{
+		table: table[models.ParkingSpace]{
+			name: "parking_space",
+			id:   func(parkingSpace models.ParkingSpace) any { return parkingSpace.ID },
+			search: func(parkingSpace models.ParkingSpace, query string) bool {
+				return matchesAny(query, parkingSpace.Zone, parkingSpace.Label)
+			},
+			orderBy: func(a, b models.ParkingSpace) int {
+				return cmp.Or(cmp.Compare(a.Zone, b.Zone), cmp.Compare(a.Label, b.Label))
+			},
+		},
+		store: store,
+	}
+}
+
+func (parkingSpaceRepo ParkingSpaceRepo) SelectWhere(ctx context.Context, parkingSpaceFields models.ParkingSpace, selectOpts ...selectopts.SelectOpt) ([]models.ParkingSpace, error) {
+	parkingSpaceRepo.store.mu.Lock()
+	defer parkingSpaceRepo.store.mu.Unlock()
+
+	parkingSpaces, err := parkingSpaceRepo.selectWhere(parkingSpaceRepo.store.parkingSpaces, parkingSpaceWhere(parkingSpaceFields), selectOpts)
+	if err != nil {
+		return nil, fmt.Errorf("parking_space_repo.SelectWhere: %w", err)
+	}
+
+	return parkingSpaces, nil
+}
+
+func (parkingSpaceRepo ParkingSpaceRepo) SelectCountWhere(ctx context.Context, parkingSpaceFields models.ParkingSpace, selectOpts ...selectopts.SelectOpt) (int, error) {
+	parkingSpaceRepo.store.mu.Lock()
+	defer parkingSpaceRepo.store.mu.Unlock()
+
+	totalAmount, err := parkingSpaceRepo.countWhere(parkingSpaceRepo.store.parkingSpaces, parkingSpaceWhere(parkingSpaceFields), selectOpts)
+	if err != nil {
+		return 0, fmt.Errorf("parking_space_repo.SelectCountWhere: %w", err)
+	}
+
+	return totalAmount, nil
+}
+
+func parkingSpaceWhere(parkingSpaceFields models.ParkingSpace) func(models.ParkingSpace) bool {
+	return func(parkingSpace models.ParkingSpace) bool {
+		return (parkingSpaceFields.Zone == "" || parkingSpaceFields.Zone == parkingSpace.Zone) &&
+			(parkingSpaceFields.Label == "" || parkingSpaceFields.Label == parkingSpace.Label)
+	}
+}
+
+func (parkingSpaceRepo ParkingSpaceRepo) GetOne(ctx context.Context, id string) (models.ParkingSpace, error) {
+	parkingSpaceRepo.store.mu.Lock()
+	defer parkingSpaceRepo.store.mu.Unlock()
+
+	i := util.Find(parkingSpaceRepo.store.parkingSpaces, func(parkingSpace models.ParkingSpace) bool { return parkingSpace.ID == id })
+	if i < 0 {
+		return models.ParkingSpace{}, fmt.Errorf("parking_space_repo.GetOne: %w", errs.NewNotFound("parking space"))
+	}
+
+	return parkingSpaceRepo.store.parkingSpaces[i], nil
+}
+
+func (parkingSpaceRepo ParkingSpaceRepo) Create(ctx context.Context, desiredParkingSpace models.ParkingSpace) (string, error) {
+	parkingSpaceRepo.store.mu.Lock()
+	defer parkingSpaceRepo.store.mu.Unlock()
+
+	// there is one space for each label of a zone
+	if util.Find(parkingSpaceRepo.store.parkingSpaces, func(parkingSpace models.ParkingSpace) bool {
+		return parkingSpace.Zone == desiredParkingSpace.Zone && parkingSpace.Label == desiredParkingSpace.Label
+	}) >= 0 {
+		return "", fmt.Errorf("parking_space_repo.Create: %w: %v", errs.ErrDBExec, errUniqueViolation)
+	}
+
+	parkingSpaceID := uuid.NewString()
+	parkingSpaceRepo.store.parkingSpaces = append(parkingSpaceRepo.store.parkingSpaces,
+		models.NewParkingSpace(parkingSpaceID, desiredParkingSpace.Zone, desiredParkingSpace.Label))
+
+	return parkingSpaceID, nil
+}
+
+func (parkingSpaceRepo ParkingSpaceRepo) Delete(ctx context.Context, id string) error {
+	parkingSpaceRepo.store.mu.Lock()
+	defer parkingSpaceRepo.store.mu.Unlock()
+
+	if deleted := parkingSpaceRepo.store.deleteParkingSpaces(func(parkingSpace models.ParkingSpace) bool { return parkingSpace.ID == id }); deleted == 0 {
+		return fmt.Errorf("parking_space_repo.Delete: %w", errs.NewNotFound("parking space"))
+	}
+
+	return nil
+}
+
+func (parkingSpaceRepo ParkingSpaceRepo) Reset(ctx context.Context) error {
+	parkingSpaceRepo.store.mu.Lock()
+	defer parkingSpaceRepo.store.mu.Unlock()
+
+	parkingSpaceRepo.store.deleteParkingSpaces(func(models.ParkingSpace) bool { return true })
+	return nil
+}
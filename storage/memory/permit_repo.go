@@ -0,0 +1,203 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dannyvelas/parkspot-backend/errs"
+	"github.com/dannyvelas/parkspot-backend/models"
+	"github.com/dannyvelas/parkspot-backend/storage/selectopts"
+	"github.com/dannyvelas/parkspot-backend/util"
+)
+
+type PermitRepo struct {
+	table[models.Permit]
+	store *store
+}
+
+func newPermitRepo(store *store, location *time.Location) PermitRepo {
+	return PermitRepo{
+		table: table[models.Permit]{
+			name:     "permit",
+			location: location,
+			id:       func(permit models.Permit) any { return int64(permit.ID) },
+			fields: map[string]field[models.Permit]{
+				"id": {Type: selectopts.IntField, filterable: true,
+					value: func(permit models.Permit) any { return int64(permit.ID) }},
+				"residentID": {Type: selectopts.StringField, sortable: true, filterable: true,
+					value: func(permit models.Permit) any { return permit.ResidentID }},
+				"licensePlate": {Type: selectopts.StringField, sortable: true, filterable: true,
+					value: func(permit models.Permit) any { return permit.LicensePlate }},
+				"color": {Type: selectopts.StringField, filterable: true,
+					value: func(permit models.Permit) any { return permit.Color }},
+				"make": {Type: selectopts.StringField, filterable: true,
+					value: func(permit models.Permit) any { return permit.Make }},
+				"model": {Type: selectopts.StringField, filterable: true,
+					value: func(permit models.Permit) any { return permit.Model }},
+				"startDate": {Type: selectopts.DateField, sortable: true, filterable: true,
+					value: func(permit models.Permit) any { return permit.StartDate.Unix() }},
+				"endDate": {Type: selectopts.DateField, sortable: true, filterable: true,
+					value: func(permit models.Permit) any { return permit.EndDate.Unix() }},
+				"affectsDays": {Type: selectopts.BoolField, filterable: true,
+					value: func(permit models.Permit) any { return permit.AffectsDays }},
+			},
+			search: func(permit models.Permit, query string) bool {
+				return matchesTerms(permitSearchDocument(permit), query)
+			},
+			document: permitSearchDocument,
+			status: func(permit models.Permit, status models.Status, now time.Time) (bool, bool) {
+				switch status {
+				case models.ActiveStatus:
+					return permit.IsActiveAt(now), true
+				case models.ExceptionStatus:
+					return permit.ExceptionReason != "", true
+				case models.ExpiredStatus:
+					return permit.IsExpiredAt(now, location), true
+				default:
+					return false, false
+				}
+			},
+			licensePlate: func(permit models.Permit) string { return permit.LicensePlate },
+			dates:        func(permit models.Permit) (time.Time, time.Time) { return permit.StartDate, permit.EndDate },
+		},
+		store: store,
+	}
+}
+
+func (permitRepo PermitRepo) SelectWhere(ctx context.Context, permitFields models.Permit, selectOpts ...selectopts.SelectOpt) ([]models.Permit, error) {
+	permitRepo.store.mu.Lock()
+	defer permitRepo.store.mu.Unlock()
+
+	permits, err := permitRepo.selectWhere(permitRepo.store.permits, permitWhere(permitFields), selectOpts)
+	if err != nil {
+		return nil, fmt.Errorf("permit_repo.SelectWhere: %w", err)
+	}
+
+	return permits, nil
+}
+
+func (permitRepo PermitRepo) SelectCountWhere(ctx context.Context, permitFields models.Permit, selectOpts ...selectopts.SelectOpt) (int, error) {
+	permitRepo.store.mu.Lock()
+	defer permitRepo.store.mu.Unlock()
+
+	totalAmount, err := permitRepo.countWhere(permitRepo.store.permits, permitWhere(permitFields), selectOpts)
+	if err != nil {
+		return 0, fmt.Errorf("permit_repo.GetCount: %w", err)
+	}
+
+	return totalAmount, nil
+}
+
+func permitWhere(permitFields models.Permit) func(models.Permit) bool {
+	return func(permit models.Permit) bool {
+		return (permitFields.ResidentID == "" || permitFields.ResidentID == permit.ResidentID) &&
+			(permitFields.CarID == "" || permitFields.CarID == permit.CarID) &&
+			(permitFields.LicensePlate == "" || permitFields.LicensePlate == permit.LicensePlate) &&
+			(permitFields.Color == "" || permitFields.Color == permit.Color) &&
+			(permitFields.Make == "" || permitFields.Make == permit.Make) &&
+			(permitFields.Model == "" || permitFields.Model == permit.Model) &&
+			(permitFields.SpaceID == "" || permitFields.SpaceID == permit.SpaceID)
+	}
+}
+
+func (permitRepo PermitRepo) GetOne(ctx context.Context, id int) (models.Permit, error) {
+	permitRepo.store.mu.Lock()
+	defer permitRepo.store.mu.Unlock()
+
+	i := util.Find(permitRepo.store.permits, func(permit models.Permit) bool { return permit.ID == id })
+	if i < 0 {
+		return models.Permit{}, fmt.Errorf("permit_repo.GetOne: %w", errs.NewNotFound("permit"))
+	}
+
+	return permitRepo.store.permits[i], nil
+}
+
+func (permitRepo PermitRepo) Create(ctx context.Context, desiredPermit models.Permit) (int, error) {
+	permitRepo.store.mu.Lock()
+	defer permitRepo.store.mu.Unlock()
+
+	if !permitRepo.store.residentExists(desiredPermit.ResidentID) ||
+		(desiredPermit.SpaceID != "" && !permitRepo.store.parkingSpaceExists(desiredPermit.SpaceID)) {
+		return 0, fmt.Errorf("permit_repo.Create: %w: %v", errs.ErrDBExec, errForeignKeyViolation)
+	}
+
+	// copied so that the stored permit doesn't change with the permit of the caller
+	var daysCharged *int
+	if desiredPermit.DaysCharged != nil {
+		daysCharged = util.ToPtr(*desiredPermit.DaysCharged)
+	}
+
+	permitRepo.store.lastPermitID++
+	permitRepo.store.permits = append(permitRepo.store.permits, models.NewPermit(
+		permitRepo.store.lastPermitID,
+		desiredPermit.ResidentID,
+		desiredPermit.CarID,
+		desiredPermit.LicensePlate,
+		desiredPermit.Color,
+		desiredPermit.Make,
+		desiredPermit.Model,
+		toStoredTime(desiredPermit.StartDate),
+		toStoredTime(desiredPermit.EndDate),
+		time.Now().Unix(),
+		desiredPermit.AffectsDays,
+		desiredPermit.ExceptionReason,
+		desiredPermit.SpaceID,
+		daysCharged,
+		1,
+	))
+
+	return permitRepo.store.lastPermitID, nil
+}
+
+func (permitRepo PermitRepo) Delete(ctx context.Context, id int) error {
+	permitRepo.store.mu.Lock()
+	defer permitRepo.store.mu.Unlock()
+
+	if deleted := permitRepo.store.deletePermits(func(permit models.Permit) bool { return permit.ID == id }); deleted == 0 {
+		return fmt.Errorf("permit_repo.Delete: %w", errs.NewNotFound("permit"))
+	}
+
+	return nil
+}
+
+func (permitRepo PermitRepo) Update(ctx context.Context, permitFields models.Permit) error {
+	permitRepo.store.mu.Lock()
+	defer permitRepo.store.mu.Unlock()
+
+	i := util.Find(permitRepo.store.permits, func(permit models.Permit) bool { return permit.ID == permitFields.ID })
+	if i < 0 && permitFields.Version != 0 {
+		return fmt.Errorf("permit_repo.Update: %w", errs.NewNotFound("permit"))
+	} else if i < 0 {
+		return nil
+	} else if permitFields.Version != 0 && permitFields.Version != permitRepo.store.permits[i].Version {
+		return fmt.Errorf("permit_repo.Update: %w", errs.NewConflict("permit"))
+	}
+
+	permit := &permitRepo.store.permits[i]
+	setIfNotEmpty(&permit.LicensePlate, permitFields.LicensePlate)
+	setIfNotEmpty(&permit.Color, permitFields.Color)
+	setIfNotEmpty(&permit.Make, permitFields.Make)
+	setIfNotEmpty(&permit.Model, permitFields.Model)
+	if permitFields.Version != 0 {
+		permit.Version++
+	}
+
+	return nil
+}
+
+func (permitRepo PermitRepo) Reset(ctx context.Context) error {
+	permitRepo.store.mu.Lock()
+	defer permitRepo.store.mu.Unlock()
+
+	permitRepo.store.deletePermits(func(models.Permit) bool { return true })
+	return nil
+}
+
+// permitSearchDocument is the counterpart of the permitSearchDocument of storage/sqlrepo
+func permitSearchDocument(permit models.Permit) string {
+	return strings.ToLower(strconv.Itoa(permit.ID) + " " + permit.ResidentID + " " + permit.LicensePlate + " " +
+		permit.Color + " " + permit.Make + " " + permit.Model)
+}
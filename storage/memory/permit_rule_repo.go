@@ -0,0 +1,88 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/dannyvelas/parkspot-backend/errs"
+	"github.com/dannyvelas/parkspot-backend/models"
+	"github.com/dannyvelas/parkspot-backend/util"
+	"github.com/google/uuid"
+)
+
+type PermitRuleRepo struct {
+	store *store
+}
+
+func newPermitRuleRepo(store *store) PermitRuleRepo {
+	return PermitRuleRepo{store}
+}
+
+func (permitRuleRepo PermitRuleRepo) SelectWhere(ctx context.Context, permitRuleFields models.PermitRule) ([]models.PermitRule, error) {
+	permitRuleRepo.store.mu.Lock()
+	defer permitRuleRepo.store.mu.Unlock()
+
+	permitRules := []models.PermitRule{}
+	for _, permitRule := range permitRuleRepo.store.permitRules {
+		if (permitRuleFields.Kind == "" || permitRuleFields.Kind == permitRule.Kind) &&
+			(permitRuleFields.Target == "" || permitRuleFields.Target == permitRule.Target) {
+			permitRules = append(permitRules, clonePermitRule(permitRule))
+		}
+	}
+	slices.SortStableFunc(permitRules, func(a, b models.PermitRule) int { return strings.Compare(a.Name, b.Name) })
+
+	return permitRules, nil
+}
+
+func (permitRuleRepo PermitRuleRepo) GetOne(ctx context.Context, id string) (models.PermitRule, error) {
+	permitRuleRepo.store.mu.Lock()
+	defer permitRuleRepo.store.mu.Unlock()
+
+	i := util.Find(permitRuleRepo.store.permitRules, func(permitRule models.PermitRule) bool { return permitRule.ID == id })
+	if i < 0 {
+		return models.PermitRule{}, fmt.Errorf("permit_rule_repo.GetOne: %w", errs.NewNotFound("permit rule"))
+	}
+
+	return clonePermitRule(permitRuleRepo.store.permitRules[i]), nil
+}
+
+func (permitRuleRepo PermitRuleRepo) Create(ctx context.Context, desiredPermitRule models.PermitRule) (string, error) {
+	permitRuleRepo.store.mu.Lock()
+	defer permitRuleRepo.store.mu.Unlock()
+
+	// the name of a rule is what residents are told when they break it, so it is unique
+	if util.Find(permitRuleRepo.store.permitRules, func(permitRule models.PermitRule) bool { return permitRule.Name == desiredPermitRule.Name }) >= 0 {
+		return "", fmt.Errorf("permit_rule_repo.Create: %w: %v", errs.ErrDBExec, errUniqueViolation)
+	}
+
+	desiredPermitRule.ID = uuid.NewString()
+	permitRuleRepo.store.permitRules = append(permitRuleRepo.store.permitRules, clonePermitRule(desiredPermitRule))
+
+	return desiredPermitRule.ID, nil
+}
+
+func (permitRuleRepo PermitRuleRepo) Delete(ctx context.Context, id string) error {
+	permitRuleRepo.store.mu.Lock()
+	defer permitRuleRepo.store.mu.Unlock()
+
+	if deleted := deleteWhere(&permitRuleRepo.store.permitRules, func(permitRule models.PermitRule) bool { return permitRule.ID == id }); len(deleted) == 0 {
+		return fmt.Errorf("permit_rule_repo.Delete: %w", errs.NewNotFound("permit rule"))
+	}
+
+	return nil
+}
+
+func (permitRuleRepo PermitRuleRepo) Reset(ctx context.Context) error {
+	permitRuleRepo.store.mu.Lock()
+	defer permitRuleRepo.store.mu.Unlock()
+
+	permitRuleRepo.store.permitRules = nil
+	return nil
+}
+
+func clonePermitRule(permitRule models.PermitRule) models.PermitRule {
+	permitRule.Dates = slices.Clone(permitRule.Dates)
+	return permitRule
+}
@@ -0,0 +1,295 @@
+package memory
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dannyvelas/parkspot-backend/models"
+	"github.com/dannyvelas/parkspot-backend/storage/selectopts"
+	"github.com/dannyvelas/parkspot-backend/util"
+)
+
+// field is a field that rows can be filtered or sorted by, like a column. value is a string, a bool, an int64,
+// or a unix timestamp for dates. it is nil when the field is NULL
+type field[T any] struct {
+	Type     selectopts.FieldType
+	value    func(T) any
+	sortable bool
+	// filterable fields are the fields of selectopts.FilterRepo
+	filterable bool
+}
+
+// table describes how the rows of a repo are selected with the options of selectopts, the way the repos of
+// storage/sqlrepo select them with SQL. options that a table has no function for are skipped
+type table[T any] struct {
+	name string
+	// location is the timezone of the days of the selectopts.DateField fields
+	location *time.Location
+	// id is the id of a row, a string or an int64
+	id     func(T) any
+	fields map[string]field[T]
+	// search matches the rows that query finds
+	search func(row T, query string) bool
+	// document is the search document of tables that can rank rows by how relevant they are to a search
+	document func(T) string
+	// status is false when row doesn't have status. ok is false when status doesn't apply to the rows of this table
+	status       func(row T, status models.Status, now time.Time) (matches, ok bool)
+	licensePlate func(T) string
+	dates        func(T) (startDate, endDate time.Time)
+	// orderBy sorts the rows that the options of a select leave tied, like the ORDER BY of a repo
+	orderBy func(a, b T) int
+}
+
+// SortColumns implements selectopts.SortRepo. the columns of a table are the names of its fields
+func (table table[T]) SortColumns() map[string]string {
+	columns := map[string]string{}
+	for name, field := range table.fields {
+		if field.sortable {
+			columns[name] = name
+		}
+	}
+	return columns
+}
+
+// Table implements selectopts.SortRepo
+func (table table[T]) Table() string {
+	return table.name
+}
+
+// IDType implements selectopts.IDRepo
+func (table table[T]) IDType() selectopts.FieldType {
+	var zero T
+	if _, ok := table.id(zero).(int64); ok {
+		return selectopts.IntField
+	}
+	return selectopts.StringField
+}
+
+// FilterFields implements selectopts.FilterRepo
+func (table table[T]) FilterFields() map[string]selectopts.FilterField {
+	fields := map[string]selectopts.FilterField{}
+	for name, field := range table.fields {
+		if field.filterable {
+			fields[name] = selectopts.FilterField{Column: name, Type: field.Type, Location: table.location}
+		}
+	}
+	return fields
+}
+
+// selectWhere selects the rows that match where and selectOpts, sorted and paged by selectOpts.
+// where is nil when every row matches
+func (table table[T]) selectWhere(rows []T, where func(T) bool, selectOpts []selectopts.SelectOpt) ([]T, error) {
+	options := selectopts.NewOptions(selectOpts...)
+
+	selected := table.filter(rows, where, options)
+
+	var rank map[string]float64
+	if table.document != nil && strings.TrimSpace(options.SearchRank) != "" {
+		rank = map[string]float64{}
+		for _, row := range selected {
+			rank[fmt.Sprint(table.id(row))] = util.Similarity(table.document(row), strings.ToLower(options.SearchRank))
+		}
+	}
+
+	slices.SortStableFunc(selected, func(a, b T) int {
+		if rank != nil {
+			if c := cmp.Compare(rank[fmt.Sprint(table.id(b))], rank[fmt.Sprint(table.id(a))]); c != 0 {
+				return c
+			}
+		}
+		if options.Cursor != nil {
+			if c := table.compareSorted(a, b, *options.Cursor); c != 0 {
+				return c
+			}
+		}
+		if table.orderBy != nil {
+			return table.orderBy(a, b)
+		}
+		return 0
+	})
+
+	if options.Offset >= len(selected) {
+		return []T{}, nil
+	}
+	selected = selected[options.Offset:]
+	if options.Limit >= 0 && options.Limit < len(selected) {
+		selected = selected[:options.Limit]
+	}
+	return selected, nil
+}
+
+// countWhere counts the rows that match where and selectOpts. like SELECT count(*), it is not limited
+func (table table[T]) countWhere(rows []T, where func(T) bool, selectOpts []selectopts.SelectOpt) (int, error) {
+	return len(table.filter(rows, where, selectopts.NewOptions(selectOpts...))), nil
+}
+
+// filter keeps the rows that match where and the options that select rows, in order
+func (table table[T]) filter(rows []T, where func(T) bool, options selectopts.Options) []T {
+	now := time.Now()
+	afterCursor := table.afterCursor(rows, options.Cursor)
+
+	selected := []T{}
+	for _, row := range rows {
+		if where != nil && !where(row) {
+			continue
+		}
+		if table.status != nil && options.Status != models.AnyStatus {
+			if matches, ok := table.status(row, options.Status, now); ok && !matches {
+				continue
+			}
+		}
+		if table.search != nil && strings.TrimSpace(options.Search) != "" && !table.search(row, options.Search) {
+			continue
+		}
+		if table.dates != nil && options.DateIntersect != nil {
+			startDate, endDate := table.dates(row)
+			if startDate.Unix() > options.DateIntersect.EndDate.Unix() || endDate.Unix() < options.DateIntersect.StartDate.Unix() {
+				continue
+			}
+		}
+		if table.licensePlate != nil && options.LicensePlate != "" &&
+			util.NormalizeLicensePlate(table.licensePlate(row)) != options.LicensePlate {
+			continue
+		}
+		if !table.passesFilters(row, options.Filters) || !afterCursor(row) {
+			continue
+		}
+		selected = append(selected, row)
+	}
+	return selected
+}
+
+// passesFilters is like selectopts.WithFilters, which skips the filters that don't pass selectopts.CheckFilter
+func (table table[T]) passesFilters(row T, filters []selectopts.Filter) bool {
+	for _, filter := range filters {
+		value, err := selectopts.FilterValue(table, filter)
+		if err != nil {
+			continue
+		}
+
+		rowValue := table.fields[filter.Field].value(row)
+		if s, ok := rowValue.(string); ok {
+			rowValue = strings.ToLower(s)
+		}
+		if rowValue == nil {
+			// a comparison with NULL is never true
+			return false
+		}
+
+		c := compareValues(rowValue, value)
+		passes := map[selectopts.Op]bool{
+			selectopts.Eq:    c == 0,
+			selectopts.NotEq: c != 0,
+			selectopts.Gt:    c > 0,
+			selectopts.Gte:   c >= 0,
+			selectopts.Lt:    c < 0,
+			selectopts.Lte:   c <= 0,
+		}
+		if !passes[filter.Op] {
+			return false
+		}
+	}
+	return true
+}
+
+// afterCursor returns whether a row comes after the row of cursor, like selectopts.WithCursor. the row of a
+// cursor is looked up among every row, and no row comes after a cursor whose row doesn't exist
+func (table table[T]) afterCursor(rows []T, cursor *selectopts.Cursor) func(T) bool {
+	if cursor == nil || cursor.ID == "" {
+		return func(T) bool { return true }
+	}
+
+	desc := cursor.Sort.Desc != cursor.Before
+	comesAfter := func(c int) bool {
+		if desc {
+			return c < 0
+		}
+		return c > 0
+	}
+
+	sortField, sortable := table.fields[cursor.Sort.Field]
+	if !sortable || !sortField.sortable {
+		cursorID, ok := table.parseID(cursor.ID)
+		if !ok {
+			return func(T) bool { return false }
+		}
+		return func(row T) bool { return comesAfter(compareValues(table.id(row), cursorID)) }
+	}
+
+	i := util.Find(rows, func(row T) bool { return fmt.Sprint(table.id(row)) == cursor.ID })
+	if i < 0 {
+		return func(T) bool { return false }
+	}
+	cursorRow := rows[i]
+	return func(row T) bool {
+		value, cursorValue := sortField.value(row), sortField.value(cursorRow)
+		if value == nil || cursorValue == nil {
+			return false
+		}
+		c := compareValues(value, cursorValue)
+		if c == 0 {
+			c = compareValues(table.id(row), table.id(cursorRow))
+		}
+		return comesAfter(c)
+	}
+}
+
+// compareSorted compares rows by the sort of cursor, breaking ties by id
+func (table table[T]) compareSorted(a, b T, cursor selectopts.Cursor) int {
+	c := 0
+	if sortField, ok := table.fields[cursor.Sort.Field]; ok && sortField.sortable {
+		c = compareValues(sortField.value(a), sortField.value(b))
+	}
+	if c == 0 {
+		c = compareValues(table.id(a), table.id(b))
+	}
+	if cursor.Sort.Desc != cursor.Before {
+		return -c
+	}
+	return c
+}
+
+// parseID turns the id of a cursor into a value of the type of the ids of table. ok is false when id can't be
+// the id of a row of table, which selectopts.IsValidID keeps from happening
+func (table table[T]) parseID(id string) (value any, ok bool) {
+	if table.IDType() != selectopts.IntField {
+		return id, true
+	}
+
+	intID, err := strconv.ParseInt(id, 10, 64)
+	return intID, err == nil
+}
+
+// compareValues compares the values of fields. like postgres, NULL comes after every other value
+func compareValues(a, b any) int {
+	if a == nil || b == nil {
+		switch {
+		case a == nil && b == nil:
+			return 0
+		case a == nil:
+			return 1
+		default:
+			return -1
+		}
+	}
+
+	switch a := a.(type) {
+	case string:
+		return strings.Compare(a, b.(string))
+	case int64:
+		return cmp.Compare(a, b.(int64))
+	case bool:
+		if a == b.(bool) {
+			return 0
+		} else if !a {
+			return -1
+		}
+		return 1
+	default:
+		panic(fmt.Sprintf("memory.compareValues: cannot compare values of type %T", a))
+	}
+}
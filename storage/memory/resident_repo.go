@@ -0,0 +1,207 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/dannyvelas/parkspot-backend/errs"
+	"github.com/dannyvelas/parkspot-backend/models"
+	"github.com/dannyvelas/parkspot-backend/storage/selectopts"
+	"github.com/dannyvelas/parkspot-backend/util"
+)
+
+type ResidentRepo struct {
+	table[models.Resident]
+	store *store
+}
+
+func newResidentRepo(store *store) ResidentRepo {
+	return ResidentRepo{
+		table: table[models.Resident]{
+			name: "resident",
+			id:   func(resident models.Resident) any { return resident.ID },
+			fields: map[string]field[models.Resident]{
+				"firstName": {Type: selectopts.StringField, sortable: true, filterable: true,
+					value: func(resident models.Resident) any { return resident.FirstName }},
+				"lastName": {Type: selectopts.StringField, sortable: true, filterable: true,
+					value: func(resident models.Resident) any { return resident.LastName }},
+				"email": {Type: selectopts.StringField, sortable: true, filterable: true,
+					value: func(resident models.Resident) any { return resident.Email }},
+				"unlimDays": {Type: selectopts.BoolField, filterable: true,
+					value: func(resident models.Resident) any { return *resident.UnlimDays }},
+				"amtParkingDaysUsed": {Type: selectopts.IntField, filterable: true,
+					value: func(resident models.Resident) any { return int64(*resident.AmtParkingDaysUsed) }},
+			},
+			search: func(resident models.Resident, query string) bool {
+				return matchesTerms(residentSearchDocument(resident), query)
+			},
+			document: residentSearchDocument,
+			orderBy: func(a, b models.Resident) int {
+				return strings.Compare(a.FirstName, b.FirstName)
+			},
+		},
+		store: store,
+	}
+}
+
+func (residentRepo ResidentRepo) SelectWhere(ctx context.Context, residentFields models.Resident, selectOpts ...selectopts.SelectOpt) ([]models.Resident, error) {
+	residentRepo.store.mu.Lock()
+	defer residentRepo.store.mu.Unlock()
+
+	residents, err := residentRepo.selectWhere(residentRepo.store.residents, residentWhere(residentFields), selectOpts)
+	if err != nil {
+		return nil, fmt.Errorf("resident_repo.SelectWhere: %w", err)
+	}
+
+	return cloneAll(residents, cloneResident), nil
+}
+
+func (residentRepo ResidentRepo) SelectCountWhere(ctx context.Context, residentFields models.Resident, selectOpts ...selectopts.SelectOpt) (int, error) {
+	residentRepo.store.mu.Lock()
+	defer residentRepo.store.mu.Unlock()
+
+	totalAmount, err := residentRepo.countWhere(residentRepo.store.residents, residentWhere(residentFields), selectOpts)
+	if err != nil {
+		return 0, fmt.Errorf("resident_repo.SelectCountWhere: %w", err)
+	}
+
+	return totalAmount, nil
+}
+
+func residentWhere(residentFields models.Resident) func(models.Resident) bool {
+	return func(resident models.Resident) bool {
+		return (residentFields.ID == "" || residentFields.ID == resident.ID) &&
+			(residentFields.FirstName == "" || residentFields.FirstName == resident.FirstName) &&
+			(residentFields.LastName == "" || residentFields.LastName == resident.LastName) &&
+			(residentFields.Phone == "" || residentFields.Phone == resident.Phone) &&
+			(residentFields.Email == "" || residentFields.Email == resident.Email)
+	}
+}
+
+func (residentRepo ResidentRepo) AddToAmtParkingDaysUsed(ctx context.Context, id string, days int) error {
+	residentRepo.store.mu.Lock()
+	defer residentRepo.store.mu.Unlock()
+
+	if i := util.Find(residentRepo.store.residents, func(resident models.Resident) bool { return resident.ID == id }); i >= 0 {
+		*residentRepo.store.residents[i].AmtParkingDaysUsed += days
+	}
+
+	return nil
+}
+
+func (residentRepo ResidentRepo) Create(ctx context.Context, resident models.Resident) error {
+	residentRepo.store.mu.Lock()
+	defer residentRepo.store.mu.Unlock()
+
+	if err := residentRepo.store.createResident(resident); err != nil {
+		return fmt.Errorf("resident_repo.Create: %w: %v", errs.ErrDBExec, err)
+	}
+
+	return nil
+}
+
+func (s *store) createResident(resident models.Resident) error {
+	if s.residentExists(resident.ID) {
+		return errUniqueViolation
+	}
+
+	// cast *resident.UnlimDays to bool
+	unlimDays := false
+	if resident.UnlimDays != nil {
+		unlimDays = *resident.UnlimDays
+	}
+
+	s.residents = append(s.residents, models.NewResident(
+		resident.ID,
+		resident.FirstName,
+		resident.LastName,
+		resident.Phone,
+		resident.Email,
+		resident.Password,
+		unlimDays,
+		0,
+		resident.PreferredLang,
+		0,
+		1,
+	))
+
+	return nil
+}
+
+func (residentRepo ResidentRepo) Delete(ctx context.Context, residentID string) error {
+	residentRepo.store.mu.Lock()
+	defer residentRepo.store.mu.Unlock()
+
+	if deleted := residentRepo.store.deleteResidents(func(resident models.Resident) bool { return resident.ID == residentID }); deleted == 0 {
+		return fmt.Errorf("resident_repo.Delete: %w", errs.NewNotFound("resident"))
+	}
+
+	return nil
+}
+
+func (residentRepo ResidentRepo) Update(ctx context.Context, residentFields models.Resident) error {
+	residentRepo.store.mu.Lock()
+	defer residentRepo.store.mu.Unlock()
+
+	i := util.Find(residentRepo.store.residents, func(resident models.Resident) bool { return resident.ID == residentFields.ID })
+	if i < 0 && residentFields.Version != 0 {
+		return fmt.Errorf("resident_repo.Update: %w", errs.NewNotFound("resident"))
+	} else if i < 0 {
+		return nil
+	} else if residentFields.Version != 0 && residentFields.Version != residentRepo.store.residents[i].Version {
+		return fmt.Errorf("resident_repo.Update: %w", errs.NewConflict("resident"))
+	}
+
+	resident := cloneResident(residentRepo.store.residents[i])
+	setIfNotEmpty(&resident.FirstName, residentFields.FirstName)
+	setIfNotEmpty(&resident.LastName, residentFields.LastName)
+	setIfNotEmpty(&resident.Phone, residentFields.Phone)
+	setIfNotEmpty(&resident.Email, residentFields.Email)
+	setIfNotEmpty(&resident.Password, residentFields.Password)
+	setIfNotEmpty(&resident.PreferredLang, residentFields.PreferredLang)
+	if residentFields.UnlimDays != nil {
+		*resident.UnlimDays = *residentFields.UnlimDays
+	}
+	if residentFields.AmtParkingDaysUsed != nil {
+		*resident.AmtParkingDaysUsed = *residentFields.AmtParkingDaysUsed
+	}
+	if residentFields.TokenVersion != nil {
+		*resident.TokenVersion = *residentFields.TokenVersion
+	}
+	if residentFields.Version != 0 {
+		resident.Version++
+	}
+	residentRepo.store.residents[i] = resident
+
+	return nil
+}
+
+func (residentRepo ResidentRepo) Reset(ctx context.Context) error {
+	residentRepo.store.mu.Lock()
+	defer residentRepo.store.mu.Unlock()
+
+	residentRepo.store.deleteResidents(func(models.Resident) bool { return true })
+	return nil
+}
+
+// residentSearchDocument is the counterpart of the residentSearchDocument of storage/sqlrepo
+func residentSearchDocument(resident models.Resident) string {
+	return strings.ToLower(resident.ID + " " + resident.FirstName + " " + resident.LastName)
+}
+
+func cloneResident(resident models.Resident) models.Resident {
+	return models.NewResident(
+		resident.ID,
+		resident.FirstName,
+		resident.LastName,
+		resident.Phone,
+		resident.Email,
+		resident.Password,
+		*resident.UnlimDays,
+		*resident.AmtParkingDaysUsed,
+		resident.PreferredLang,
+		*resident.TokenVersion,
+		resident.Version,
+	)
+}
@@ -0,0 +1,28 @@
+package memory
+
+import (
+	"strings"
+)
+
+// matchesTerms is the counterpart of the searchAsSQL of storage/sqlrepo: document, which joins the searchable
+// fields of a row, contains every term of query, ignoring case
+func matchesTerms(document string, query string) bool {
+	document = strings.ToLower(document)
+	for _, term := range strings.Fields(strings.ToLower(query)) {
+		if !strings.Contains(document, term) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesAny is the search of the repos of storage/sqlrepo that match the whole query against some columns,
+// ignoring case
+func matchesAny(query string, values ...string) bool {
+	for _, value := range values {
+		if value != "" && strings.EqualFold(value, query) {
+			return true
+		}
+	}
+	return false
+}
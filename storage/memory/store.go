@@ -0,0 +1,181 @@
+package memory
+
+import (
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/dannyvelas/parkspot-backend/models"
+)
+
+// the errors of the constraints of the schema, which storage/psql gets from postgres
+var (
+	errUniqueViolation     = errors.New("duplicate key value violates unique constraint")
+	errForeignKeyViolation = errors.New("insert or update violates foreign key constraint")
+)
+
+// store holds the tables of a Database. its repos share it, and lock it for every call, so that what one repo
+// changes is seen by the others, like the foreign keys of the schema
+type store struct {
+	mu            sync.Mutex
+	admins        []models.Admin
+	residents     []models.Resident
+	cars          []models.Car
+	permits       []models.Permit
+	lastPermitID  int // like a sequence, permit ids are never reused, even after their permits are deleted
+	visitors      []models.Visitor
+	gateEvents    []models.GateEvent
+	violations    []models.Violation
+	bans          []models.Ban
+	parkingSpaces []models.ParkingSpace
+	waitlist      []models.WaitlistEntry
+	parkingPolicy *models.ParkingPolicy
+	permitRules   []models.PermitRule
+}
+
+func (s *store) residentExists(id string) bool {
+	return slices.ContainsFunc(s.residents, func(resident models.Resident) bool { return resident.ID == id })
+}
+
+func (s *store) permitExists(id int) bool {
+	return slices.ContainsFunc(s.permits, func(permit models.Permit) bool { return permit.ID == id })
+}
+
+func (s *store) visitorExists(id string) bool {
+	return slices.ContainsFunc(s.visitors, func(visitor models.Visitor) bool { return visitor.ID == id })
+}
+
+func (s *store) parkingSpaceExists(id string) bool {
+	return slices.ContainsFunc(s.parkingSpaces, func(parkingSpace models.ParkingSpace) bool { return parkingSpace.ID == id })
+}
+
+// deleteResidents deletes the residents that match, with the rows that reference them, like the foreign keys
+// of the schema do. it returns how many residents were deleted
+func (s *store) deleteResidents(match func(models.Resident) bool) int {
+	deleted := deleteWhere(&s.residents, match)
+	isDeleted := func(residentID string) bool {
+		return slices.ContainsFunc(deleted, func(resident models.Resident) bool { return resident.ID == residentID })
+	}
+
+	deleteWhere(&s.cars, func(car models.Car) bool { return isDeleted(car.ResidentID) })
+	s.deletePermits(func(permit models.Permit) bool { return isDeleted(permit.ResidentID) })
+	deleteWhere(&s.waitlist, func(entry models.WaitlistEntry) bool { return isDeleted(entry.ResidentID) })
+	s.deleteVisitors(func(visitor models.Visitor) bool { return isDeleted(visitor.ResidentID) })
+	deleteWhere(&s.gateEvents, func(gateEvent models.GateEvent) bool { return isDeleted(gateEvent.ResidentID) })
+	for i := range s.violations {
+		if isDeleted(s.violations[i].ResidentID) {
+			s.violations[i].ResidentID = ""
+		}
+	}
+
+	return len(deleted)
+}
+
+// deletePermits deletes the permits that match, and unsets the references to them
+func (s *store) deletePermits(match func(models.Permit) bool) int {
+	deleted := deleteWhere(&s.permits, match)
+	isDeleted := func(permitID int) bool {
+		return slices.ContainsFunc(deleted, func(permit models.Permit) bool { return permit.ID == permitID })
+	}
+
+	for i := range s.waitlist {
+		if isDeleted(s.waitlist[i].PermitID) {
+			s.waitlist[i].PermitID = 0
+		}
+	}
+	for i := range s.gateEvents {
+		if isDeleted(s.gateEvents[i].PermitID) {
+			s.gateEvents[i].PermitID = 0
+		}
+	}
+
+	return len(deleted)
+}
+
+// deleteVisitors deletes the visitors that match, and unsets the references to them. the gate events of them that
+// are still open are checked out, like the Delete of the VisitorRepo of storage/sqlrepo
+func (s *store) deleteVisitors(match func(models.Visitor) bool) int {
+	deleted := deleteWhere(&s.visitors, match)
+	now := toStoredTime(time.Now())
+	for i := range s.gateEvents {
+		visitorID := s.gateEvents[i].VisitorID
+		if slices.ContainsFunc(deleted, func(visitor models.Visitor) bool { return visitor.ID == visitorID }) {
+			s.gateEvents[i].VisitorID = ""
+			if s.gateEvents[i].CheckOut == nil {
+				s.gateEvents[i].CheckOut = &now
+			}
+		}
+	}
+
+	return len(deleted)
+}
+
+// deleteParkingSpaces deletes the parking spaces that match, and unsets the references to them
+func (s *store) deleteParkingSpaces(match func(models.ParkingSpace) bool) int {
+	deleted := deleteWhere(&s.parkingSpaces, match)
+	isDeleted := func(spaceID string) bool {
+		return slices.ContainsFunc(deleted, func(parkingSpace models.ParkingSpace) bool { return parkingSpace.ID == spaceID })
+	}
+
+	for i := range s.permits {
+		if isDeleted(s.permits[i].SpaceID) {
+			s.permits[i].SpaceID = ""
+		}
+	}
+	for i := range s.waitlist {
+		if isDeleted(s.waitlist[i].SpaceID) {
+			s.waitlist[i].SpaceID = ""
+		}
+	}
+
+	return len(deleted)
+}
+
+// helpers
+
+// deleteWhere removes the rows that match from rows, keeping the order of the others, and returns them
+func deleteWhere[T any](rows *[]T, match func(T) bool) []T {
+	var deleted []T
+	kept := (*rows)[:0]
+	for _, row := range *rows {
+		if match(row) {
+			deleted = append(deleted, row)
+		} else {
+			kept = append(kept, row)
+		}
+	}
+	*rows = kept
+	return deleted
+}
+
+// toStoredTime drops what a unix timestamp can't hold, like storage/sqlrepo does
+func toStoredTime(t time.Time) time.Time {
+	return time.Unix(t.Unix(), 0) // time.Unix() returns time in local tz
+}
+
+// toStoredTimePtr is toStoredTime for optional times
+func toStoredTimePtr(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	storedTime := toStoredTime(*t)
+	return &storedTime
+}
+
+// setIfNotEmpty sets dst to value, unless value is empty, like the rmEmptyVals of the updates of storage/sqlrepo
+func setIfNotEmpty[T comparable](dst *T, value T) {
+	var empty T
+	if value != empty {
+		*dst = value
+	}
+}
+
+// cloneAll copies rows with clone, so that callers can't change the rows of a store through their pointers
+func cloneAll[T any](rows []T, clone func(T) T) []T {
+	cloned := make([]T, 0, len(rows))
+	for _, row := range rows {
+		cloned = append(cloned, clone(row))
+	}
+	return cloned
+}
@@ -0,0 +1,135 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dannyvelas/parkspot-backend/errs"
+	"github.com/dannyvelas/parkspot-backend/models"
+	"github.com/dannyvelas/parkspot-backend/storage/selectopts"
+	"github.com/dannyvelas/parkspot-backend/util"
+	"github.com/google/uuid"
+)
+
+type ViolationRepo struct {
+	table[models.Violation]
+	store *store
+}
+
+func newViolationRepo(store *store) ViolationRepo {
+	return ViolationRepo{
+		table: table[models.Violation]{
+			name: "violation",
+			id:   func(violation models.Violation) any { return violation.ID },
+			search: func(violation models.Violation, query string) bool {
+				return matchesAny(query, violation.LicensePlate, violation.ResidentID, violation.Location)
+			},
+			licensePlate: func(violation models.Violation) string { return violation.LicensePlate },
+			orderBy: func(a, b models.Violation) int {
+				return b.Timestamp.Compare(a.Timestamp)
+			},
+		},
+		store: store,
+	}
+}
+
+func (violationRepo ViolationRepo) SelectWhere(ctx context.Context, violationFields models.Violation, selectOpts ...selectopts.SelectOpt) ([]models.Violation, error) {
+	violationRepo.store.mu.Lock()
+	defer violationRepo.store.mu.Unlock()
+
+	violations, err := violationRepo.selectWhere(violationRepo.store.violations, violationWhere(violationFields), selectOpts)
+	if err != nil {
+		return nil, fmt.Errorf("violation_repo.SelectWhere: %w", err)
+	}
+
+	return violations, nil
+}
+
+func (violationRepo ViolationRepo) SelectCountWhere(ctx context.Context, violationFields models.Violation, selectOpts ...selectopts.SelectOpt) (int, error) {
+	violationRepo.store.mu.Lock()
+	defer violationRepo.store.mu.Unlock()
+
+	totalAmount, err := violationRepo.countWhere(violationRepo.store.violations, violationWhere(violationFields), selectOpts)
+	if err != nil {
+		return 0, fmt.Errorf("violation_repo.SelectCountWhere: %w", err)
+	}
+
+	return totalAmount, nil
+}
+
+func violationWhere(violationFields models.Violation) func(models.Violation) bool {
+	return func(violation models.Violation) bool {
+		return (violationFields.ResidentID == "" || violationFields.ResidentID == violation.ResidentID) &&
+			(violationFields.Type == "" || violationFields.Type == violation.Type) &&
+			(violationFields.Action == "" || violationFields.Action == violation.Action) &&
+			(violationFields.Status == "" || violationFields.Status == violation.Status) &&
+			(violationFields.RecordedBy == "" || violationFields.RecordedBy == violation.RecordedBy)
+	}
+}
+
+func (violationRepo ViolationRepo) GetOne(ctx context.Context, id string) (models.Violation, error) {
+	violationRepo.store.mu.Lock()
+	defer violationRepo.store.mu.Unlock()
+
+	i := util.Find(violationRepo.store.violations, func(violation models.Violation) bool { return violation.ID == id })
+	if i < 0 {
+		return models.Violation{}, fmt.Errorf("violation_repo.GetOne: %w", errs.NewNotFound("violation"))
+	}
+
+	return violationRepo.store.violations[i], nil
+}
+
+func (violationRepo ViolationRepo) Create(ctx context.Context, desiredViolation models.Violation) (string, error) {
+	violationRepo.store.mu.Lock()
+	defer violationRepo.store.mu.Unlock()
+
+	// the resident of a violation is optional, since a car of a stranger can be reported too
+	if desiredViolation.ResidentID != "" && !violationRepo.store.residentExists(desiredViolation.ResidentID) {
+		return "", fmt.Errorf("violation_repo.Create: %w: %v", errs.ErrDBExec, errForeignKeyViolation)
+	}
+
+	violationID := uuid.NewString()
+	violationRepo.store.violations = append(violationRepo.store.violations, models.NewViolation(
+		violationID,
+		desiredViolation.LicensePlate,
+		desiredViolation.Location,
+		desiredViolation.PhotoRef,
+		desiredViolation.Type,
+		desiredViolation.RecordedBy,
+		desiredViolation.ResidentID,
+		desiredViolation.Action,
+		desiredViolation.Status,
+		desiredViolation.ResidentNotified,
+		"",
+		"",
+		toStoredTime(desiredViolation.Timestamp),
+	))
+
+	return violationID, nil
+}
+
+func (violationRepo ViolationRepo) Update(ctx context.Context, violationFields models.Violation) error {
+	violationRepo.store.mu.Lock()
+	defer violationRepo.store.mu.Unlock()
+
+	i := util.Find(violationRepo.store.violations, func(violation models.Violation) bool { return violation.ID == violationFields.ID })
+	if i < 0 {
+		return fmt.Errorf("violation_repo.Update: %w", errs.NewNotFound("violation"))
+	}
+
+	violation := &violationRepo.store.violations[i]
+	setIfNotEmpty(&violation.Status, violationFields.Status)
+	setIfNotEmpty(&violation.ResidentNotified, violationFields.ResidentNotified)
+	setIfNotEmpty(&violation.DisputeReason, violationFields.DisputeReason)
+	setIfNotEmpty(&violation.Resolution, violationFields.Resolution)
+
+	return nil
+}
+
+func (violationRepo ViolationRepo) Reset(ctx context.Context) error {
+	violationRepo.store.mu.Lock()
+	defer violationRepo.store.mu.Unlock()
+
+	violationRepo.store.violations = nil
+	return nil
+}
@@ -0,0 +1,168 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dannyvelas/parkspot-backend/errs"
+	"github.com/dannyvelas/parkspot-backend/models"
+	"github.com/dannyvelas/parkspot-backend/storage/selectopts"
+	"github.com/dannyvelas/parkspot-backend/util"
+	"github.com/google/uuid"
+)
+
+type VisitorRepo struct {
+	table[models.Visitor]
+	store *store
+}
+
+func newVisitorRepo(store *store, location *time.Location) VisitorRepo {
+	return VisitorRepo{
+		table: table[models.Visitor]{
+			name:     "visitor",
+			location: location,
+			id:       func(visitor models.Visitor) any { return visitor.ID },
+			fields: map[string]field[models.Visitor]{
+				"residentID": {Type: selectopts.StringField, filterable: true,
+					value: func(visitor models.Visitor) any { return visitor.ResidentID }},
+				"firstName": {Type: selectopts.StringField, sortable: true, filterable: true,
+					value: func(visitor models.Visitor) any { return visitor.FirstName }},
+				"lastName": {Type: selectopts.StringField, sortable: true, filterable: true,
+					value: func(visitor models.Visitor) any { return visitor.LastName }},
+				"relationship": {Type: selectopts.StringField, filterable: true,
+					value: func(visitor models.Visitor) any { return visitor.Relationship }},
+				"accessStart": {Type: selectopts.DateField, sortable: true, filterable: true,
+					value: func(visitor models.Visitor) any { return visitor.AccessStart.Unix() }},
+				"accessEnd": {Type: selectopts.DateField, sortable: true, filterable: true,
+					value: func(visitor models.Visitor) any { return visitor.AccessEnd.Unix() }},
+			},
+			search: func(visitor models.Visitor, query string) bool {
+				return matchesTerms(visitorSearchDocument(visitor), query)
+			},
+			document: visitorSearchDocument,
+			status: func(visitor models.Visitor, status models.Status, now time.Time) (bool, bool) {
+				if status != models.ActiveStatus {
+					return false, false
+				}
+				return !visitor.AccessStart.After(now) && !visitor.AccessEnd.Before(now), true
+			},
+		},
+		store: store,
+	}
+}
+
+func (visitorRepo VisitorRepo) SelectWhere(ctx context.Context, visitorFields models.Visitor, selectOpts ...selectopts.SelectOpt) ([]models.Visitor, error) {
+	visitorRepo.store.mu.Lock()
+	defer visitorRepo.store.mu.Unlock()
+
+	visitors, err := visitorRepo.selectWhere(visitorRepo.store.visitors, visitorWhere(visitorFields), selectOpts)
+	if err != nil {
+		return nil, fmt.Errorf("visitor_repo.SelectWhere: %w", err)
+	}
+
+	return visitors, nil
+}
+
+func (visitorRepo VisitorRepo) SelectCountWhere(ctx context.Context, visitorFields models.Visitor, selectOpts ...selectopts.SelectOpt) (int, error) {
+	visitorRepo.store.mu.Lock()
+	defer visitorRepo.store.mu.Unlock()
+
+	totalAmount, err := visitorRepo.countWhere(visitorRepo.store.visitors, visitorWhere(visitorFields), selectOpts)
+	if err != nil {
+		return 0, fmt.Errorf("visitor_repo.SelectCountWhere: %w", err)
+	}
+
+	return totalAmount, nil
+}
+
+func visitorWhere(visitorFields models.Visitor) func(models.Visitor) bool {
+	return func(visitor models.Visitor) bool {
+		return (visitorFields.ID == "" || visitorFields.ID == visitor.ID) &&
+			(visitorFields.ResidentID == "" || visitorFields.ResidentID == visitor.ResidentID) &&
+			(visitorFields.FirstName == "" || visitorFields.FirstName == visitor.FirstName) &&
+			(visitorFields.LastName == "" || visitorFields.LastName == visitor.LastName)
+	}
+}
+
+func (visitorRepo VisitorRepo) Create(ctx context.Context, desiredVisitor models.Visitor) (string, error) {
+	visitorRepo.store.mu.Lock()
+	defer visitorRepo.store.mu.Unlock()
+
+	if !visitorRepo.store.residentExists(desiredVisitor.ResidentID) {
+		return "", fmt.Errorf("visitor_repo.Create: %w: %v", errs.ErrDBExec, errForeignKeyViolation)
+	}
+
+	visitorID := uuid.NewString()
+	visitorRepo.store.visitors = append(visitorRepo.store.visitors, models.NewVisitor(
+		visitorID,
+		desiredVisitor.ResidentID,
+		desiredVisitor.FirstName,
+		desiredVisitor.LastName,
+		desiredVisitor.Relationship,
+		toStoredTime(desiredVisitor.AccessStart),
+		toStoredTime(desiredVisitor.AccessEnd),
+		1,
+	))
+
+	return visitorID, nil
+}
+
+func (visitorRepo VisitorRepo) Update(ctx context.Context, visitorFields models.Visitor) error {
+	visitorRepo.store.mu.Lock()
+	defer visitorRepo.store.mu.Unlock()
+
+	i := util.Find(visitorRepo.store.visitors, func(visitor models.Visitor) bool { return visitor.ID == visitorFields.ID })
+	if i < 0 && visitorFields.Version != 0 {
+		return fmt.Errorf("visitor_repo.Update: %w", errs.NewNotFound("visitor"))
+	} else if i < 0 {
+		return nil
+	} else if visitorFields.Version != 0 && visitorFields.Version != visitorRepo.store.visitors[i].Version {
+		return fmt.Errorf("visitor_repo.Update: %w", errs.NewConflict("visitor"))
+	}
+
+	visitor := &visitorRepo.store.visitors[i]
+	setIfNotEmpty(&visitor.FirstName, visitorFields.FirstName)
+	setIfNotEmpty(&visitor.LastName, visitorFields.LastName)
+	setIfNotEmpty(&visitor.Relationship, visitorFields.Relationship)
+	if !visitorFields.AccessStart.IsZero() {
+		visitor.AccessStart = toStoredTime(visitorFields.AccessStart)
+	}
+	if !visitorFields.AccessEnd.IsZero() {
+		visitor.AccessEnd = toStoredTime(visitorFields.AccessEnd)
+	}
+	if visitorFields.Version != 0 {
+		visitor.Version++
+	}
+
+	return nil
+}
+
+func (visitorRepo VisitorRepo) Delete(ctx context.Context, visitorID string) error {
+	visitorRepo.store.mu.Lock()
+	defer visitorRepo.store.mu.Unlock()
+
+	if deleted := visitorRepo.store.deleteVisitors(func(visitor models.Visitor) bool { return visitor.ID == visitorID }); deleted == 0 {
+		return fmt.Errorf("visitor_repo.Delete: %w", errs.NewNotFound("visitor"))
+	}
+
+	return nil
+}
+
+func (visitorRepo VisitorRepo) GetOne(ctx context.Context, visitorID string) (models.Visitor, error) {
+	visitorRepo.store.mu.Lock()
+	defer visitorRepo.store.mu.Unlock()
+
+	i := util.Find(visitorRepo.store.visitors, func(visitor models.Visitor) bool { return visitor.ID == visitorID })
+	if i < 0 {
+		return models.Visitor{}, fmt.Errorf("visitor_repo.GetOne: %w", errs.NewNotFound("visitor"))
+	}
+
+	return visitorRepo.store.visitors[i], nil
+}
+
+// visitorSearchDocument is the counterpart of the visitorSearchDocument of storage/sqlrepo
+func visitorSearchDocument(visitor models.Visitor) string {
+	return strings.ToLower(visitor.ResidentID + " " + visitor.FirstName + " " + visitor.LastName)
+}
@@ -0,0 +1,138 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dannyvelas/parkspot-backend/errs"
+	"github.com/dannyvelas/parkspot-backend/models"
+	"github.com/dannyvelas/parkspot-backend/storage/selectopts"
+	"github.com/dannyvelas/parkspot-backend/util"
+	"github.com/google/uuid"
+)
+
+type WaitlistRepo struct {
+	table[models.WaitlistEntry]
+	store *store
+}
+
+func newWaitlistRepo(store *store) WaitlistRepo {
+	return WaitlistRepo{
+		table: table[models.WaitlistEntry]{
+			name: "waitlist_entry",
+			id:   func(entry models.WaitlistEntry) any { return entry.ID },
+			search: func(entry models.WaitlistEntry, query string) bool {
+				return matchesAny(query, entry.ResidentID, entry.LicensePlate)
+			},
+			dates: func(entry models.WaitlistEntry) (time.Time, time.Time) { return entry.StartDate, entry.EndDate },
+			orderBy: func(a, b models.WaitlistEntry) int {
+				return a.CreatedAt.Compare(b.CreatedAt)
+			},
+		},
+		store: store,
+	}
+}
+
+func (waitlistRepo WaitlistRepo) SelectWhere(ctx context.Context, entryFields models.WaitlistEntry, selectOpts ...selectopts.SelectOpt) ([]models.WaitlistEntry, error) {
+	waitlistRepo.store.mu.Lock()
+	defer waitlistRepo.store.mu.Unlock()
+
+	entries, err := waitlistRepo.selectWhere(waitlistRepo.store.waitlist, waitlistWhere(entryFields), selectOpts)
+	if err != nil {
+		return nil, fmt.Errorf("waitlist_repo.SelectWhere: %w", err)
+	}
+
+	return entries, nil
+}
+
+func (waitlistRepo WaitlistRepo) SelectCountWhere(ctx context.Context, entryFields models.WaitlistEntry, selectOpts ...selectopts.SelectOpt) (int, error) {
+	waitlistRepo.store.mu.Lock()
+	defer waitlistRepo.store.mu.Unlock()
+
+	totalAmount, err := waitlistRepo.countWhere(waitlistRepo.store.waitlist, waitlistWhere(entryFields), selectOpts)
+	if err != nil {
+		return 0, fmt.Errorf("waitlist_repo.SelectCountWhere: %w", err)
+	}
+
+	return totalAmount, nil
+}
+
+func waitlistWhere(entryFields models.WaitlistEntry) func(models.WaitlistEntry) bool {
+	return func(entry models.WaitlistEntry) bool {
+		return (entryFields.ResidentID == "" || entryFields.ResidentID == entry.ResidentID) &&
+			(entryFields.Status == "" || entryFields.Status == entry.Status)
+	}
+}
+
+func (waitlistRepo WaitlistRepo) GetOne(ctx context.Context, id string) (models.WaitlistEntry, error) {
+	waitlistRepo.store.mu.Lock()
+	defer waitlistRepo.store.mu.Unlock()
+
+	i := util.Find(waitlistRepo.store.waitlist, func(entry models.WaitlistEntry) bool { return entry.ID == id })
+	if i < 0 {
+		return models.WaitlistEntry{}, fmt.Errorf("waitlist_repo.GetOne: %w", errs.NewNotFound("waitlist entry"))
+	}
+
+	return waitlistRepo.store.waitlist[i], nil
+}
+
+func (waitlistRepo WaitlistRepo) Create(ctx context.Context, desiredEntry models.WaitlistEntry) (string, error) {
+	waitlistRepo.store.mu.Lock()
+	defer waitlistRepo.store.mu.Unlock()
+
+	if !waitlistRepo.store.residentExists(desiredEntry.ResidentID) ||
+		(desiredEntry.SpaceID != "" && !waitlistRepo.store.parkingSpaceExists(desiredEntry.SpaceID)) {
+		return "", fmt.Errorf("waitlist_repo.Create: %w: %v", errs.ErrDBExec, errForeignKeyViolation)
+	}
+
+	entryID := uuid.NewString()
+	waitlistRepo.store.waitlist = append(waitlistRepo.store.waitlist, models.NewWaitlistEntry(
+		entryID,
+		desiredEntry.ResidentID,
+		desiredEntry.CarID,
+		desiredEntry.LicensePlate,
+		desiredEntry.Color,
+		desiredEntry.Make,
+		desiredEntry.Model,
+		toStoredTime(desiredEntry.StartDate),
+		toStoredTime(desiredEntry.EndDate),
+		desiredEntry.ExceptionReason,
+		desiredEntry.SpaceID,
+		desiredEntry.Status,
+		desiredEntry.Reason,
+		0,
+		toStoredTime(desiredEntry.CreatedAt),
+	))
+
+	return entryID, nil
+}
+
+func (waitlistRepo WaitlistRepo) Update(ctx context.Context, entryFields models.WaitlistEntry) error {
+	waitlistRepo.store.mu.Lock()
+	defer waitlistRepo.store.mu.Unlock()
+
+	if entryFields.PermitID != 0 && !waitlistRepo.store.permitExists(entryFields.PermitID) {
+		return fmt.Errorf("waitlist_repo.Update: %w: %v", errs.ErrDBExec, errForeignKeyViolation)
+	}
+
+	i := util.Find(waitlistRepo.store.waitlist, func(entry models.WaitlistEntry) bool { return entry.ID == entryFields.ID })
+	if i < 0 {
+		return fmt.Errorf("waitlist_repo.Update: %w", errs.NewNotFound("waitlist entry"))
+	}
+
+	entry := &waitlistRepo.store.waitlist[i]
+	setIfNotEmpty(&entry.Status, entryFields.Status)
+	setIfNotEmpty(&entry.Reason, entryFields.Reason)
+	setIfNotEmpty(&entry.PermitID, entryFields.PermitID)
+
+	return nil
+}
+
+func (waitlistRepo WaitlistRepo) Reset(ctx context.Context) error {
+	waitlistRepo.store.mu.Lock()
+	defer waitlistRepo.store.mu.Unlock()
+
+	waitlistRepo.store.waitlist = nil
+	return nil
+}
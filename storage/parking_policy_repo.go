@@ -0,0 +1,13 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/dannyvelas/parkspot-backend/models"
+)
+
+type ParkingPolicyRepo interface {
+	Get(ctx context.Context) (models.ParkingPolicy, error)
+	Set(ctx context.Context, policy models.ParkingPolicy) error
+	Reset(ctx context.Context) error // for testing purposes
+}
@@ -0,0 +1,17 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/dannyvelas/parkspot-backend/models"
+	"github.com/dannyvelas/parkspot-backend/storage/selectopts"
+)
+
+type ParkingSpaceRepo interface {
+	SelectWhere(ctx context.Context, parkingSpaceFields models.ParkingSpace, selectOpts ...selectopts.SelectOpt) ([]models.ParkingSpace, error)
+	SelectCountWhere(ctx context.Context, parkingSpaceFields models.ParkingSpace, selectOpts ...selectopts.SelectOpt) (int, error)
+	GetOne(ctx context.Context, id string) (models.ParkingSpace, error)
+	Create(ctx context.Context, desiredParkingSpace models.ParkingSpace) (string, error)
+	Delete(ctx context.Context, id string) error
+	Reset(ctx context.Context) error // for testing purposes
+}
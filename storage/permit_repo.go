@@ -1,16 +1,20 @@
 package storage
 
 import (
+	"context"
+
 	"github.com/dannyvelas/parkspot-backend/models"
 	"github.com/dannyvelas/parkspot-backend/storage/selectopts"
 )
 
 type PermitRepo interface {
-	SelectWhere(permitFields models.Permit, selectOpts ...selectopts.SelectOpt) ([]models.Permit, error)
-	SelectCountWhere(permitFields models.Permit, selectOpts ...selectopts.SelectOpt) (int, error)
-	GetOne(id int) (models.Permit, error)
-	Create(desiredPermit models.Permit) (int, error)
-	Delete(id int) error
-	Update(permitFields models.Permit) error
-	Reset() error // for testing purposes
+	SelectWhere(ctx context.Context, permitFields models.Permit, selectOpts ...selectopts.SelectOpt) ([]models.Permit, error)
+	SelectCountWhere(ctx context.Context, permitFields models.Permit, selectOpts ...selectopts.SelectOpt) (int, error)
+	GetOne(ctx context.Context, id int) (models.Permit, error)
+	Create(ctx context.Context, desiredPermit models.Permit) (int, error)
+	Delete(ctx context.Context, id int) error
+	// Update edits the permit of the ID of permitFields, and increments its version. when permitFields has a version,
+	// the permit is only edited if it is still at that version, and a conflict is returned otherwise
+	Update(ctx context.Context, permitFields models.Permit) error
+	Reset(ctx context.Context) error // for testing purposes
 }
@@ -0,0 +1,15 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/dannyvelas/parkspot-backend/models"
+)
+
+type PermitRuleRepo interface {
+	SelectWhere(ctx context.Context, permitRuleFields models.PermitRule) ([]models.PermitRule, error)
+	GetOne(ctx context.Context, id string) (models.PermitRule, error)
+	Create(ctx context.Context, desiredPermitRule models.PermitRule) (string, error)
+	Delete(ctx context.Context, id string) error
+	Reset(ctx context.Context) error // for testing purposes
+}
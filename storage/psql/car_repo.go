@@ -1,205 +0,0 @@
-package psql
-
-import (
-	"database/sql"
-	"fmt"
-	"strings"
-
-	"github.com/Masterminds/squirrel"
-	"github.com/dannyvelas/parkspot-backend/errs"
-	"github.com/dannyvelas/parkspot-backend/models"
-	"github.com/dannyvelas/parkspot-backend/storage"
-	"github.com/dannyvelas/parkspot-backend/storage/selectopts"
-	"github.com/jmoiron/sqlx"
-)
-
-type CarRepo struct {
-	driver      *sqlx.DB
-	carSelect   squirrel.SelectBuilder
-	countSelect squirrel.SelectBuilder
-}
-
-func NewCarRepo(driver *sqlx.DB) storage.CarRepo {
-	carSelect := stmtBuilder.Select(
-		"car.id",
-		"car.resident_id",
-		"car.license_plate",
-		"car.color",
-		"car.make",
-		"car.model",
-		"car.amt_parking_days_used",
-	).From("car")
-	countSelect := stmtBuilder.Select("count(*)").From("car")
-
-	return CarRepo{
-		driver:      driver,
-		carSelect:   carSelect,
-		countSelect: countSelect,
-	}
-}
-
-func (carRepo CarRepo) GetOne(id string) (models.Car, error) {
-	query, args, err := carRepo.carSelect.Where("car.id = $1", id).ToSql()
-	if err != nil {
-		return models.Car{}, fmt.Errorf("car_repo.GetOne: %w: %v", errs.ErrDBBuildingQuery, err)
-	}
-
-	car := car{}
-	err = carRepo.driver.Get(&car, query, args...)
-	if err == sql.ErrNoRows {
-		return models.Car{}, fmt.Errorf("car_repo.GetOne: %w", errs.NewNotFound("car"))
-	} else if err != nil {
-		return models.Car{}, fmt.Errorf("car_repo.GetOne: %w: %v", errs.ErrDBQuery, err)
-	}
-
-	return car.toModels(), nil
-}
-
-func (carRepo CarRepo) SelectWhere(carFields models.Car, selectOpts ...selectopts.SelectOpt) ([]models.Car, error) {
-	selector := carRepo.carSelect
-	for _, opt := range selectOpts {
-		selector = opt.Dispatch(carRepo, selector)
-	}
-
-	carSelect := selector.Where(rmEmptyVals(squirrel.Eq{
-		"resident_id":   carFields.ResidentID,
-		"license_plate": carFields.LicensePlate,
-		"color":         carFields.Color,
-		"make":          carFields.Make,
-		"model":         carFields.Model,
-	}))
-	query, args, err := carSelect.ToSql()
-	if err != nil {
-		return nil, fmt.Errorf("car_repo.SelectWhere: %w: %v", errs.ErrDBBuildingQuery, err)
-	}
-
-	cars := carSlice{}
-	err = carRepo.driver.Select(&cars, query, args...)
-	if err != nil {
-		return nil, fmt.Errorf("car_repo.SelectWhere: %w: %v", errs.ErrDBQuery, err)
-	}
-
-	return cars.toModels(), nil
-}
-
-func (carRepo CarRepo) SelectCountWhere(carFields models.Car, selectOpts ...selectopts.SelectOpt) (int, error) {
-	selector := carRepo.countSelect
-	for _, opt := range selectOpts {
-		selector = opt.Dispatch(carRepo, selector)
-	}
-
-	countSelect := selector.Where(rmEmptyVals(squirrel.Eq{
-		"resident_id":   carFields.ResidentID,
-		"license_plate": carFields.LicensePlate,
-		"color":         carFields.Color,
-		"make":          carFields.Make,
-		"model":         carFields.Model,
-	}))
-	query, args, err := countSelect.ToSql()
-	if err != nil {
-		return 0, fmt.Errorf("car_repo.SelectCount: %w: %v", errs.ErrDBBuildingQuery, err)
-	}
-
-	var totalAmount int
-	err = carRepo.driver.Get(&totalAmount, query, args...)
-	if err != nil {
-		return 0, fmt.Errorf("car_repo.GetCount: %w: %v", errs.ErrDBQuery, err)
-	}
-
-	return totalAmount, nil
-}
-
-func (carRepo CarRepo) Create(desiredCar models.Car) (string, error) {
-	updateMap := make(squirrel.Eq)
-	if desiredCar.ID != "" {
-		updateMap["id"] = desiredCar.ID
-	}
-	updateMap["resident_id"] = desiredCar.ResidentID
-	updateMap["license_plate"] = desiredCar.LicensePlate
-	updateMap["color"] = desiredCar.Color
-	updateMap["make"] = desiredCar.Make
-	updateMap["model"] = desiredCar.Model
-
-	query, args, err := stmtBuilder.Insert("car").SetMap(updateMap).Suffix("RETURNING id").ToSql()
-	if err != nil {
-		return "", fmt.Errorf("car_repo.Create: %w: %v", errs.ErrDBBuildingQuery, err)
-	}
-
-	var id string
-	err = carRepo.driver.Get(&id, query, args...)
-	if err != nil {
-		return "", fmt.Errorf("car_repo.Create: %w: %v", errs.ErrDBExec, err)
-	}
-
-	return id, nil
-}
-
-func (carRepo CarRepo) AddToAmtParkingDaysUsed(id string, days int) error {
-	const query = `
-    UPDATE car SET amt_parking_days_used = amt_parking_days_used + $1
-    WHERE id = $2
-  `
-
-	_, err := carRepo.driver.Exec(query, days, id)
-	if err != nil {
-		return fmt.Errorf("car_repo.AddToAmtParkingDaysUsed: %w: %v", errs.ErrDBExec, err)
-	}
-
-	return nil
-}
-
-func (carRepo CarRepo) Update(carFields models.Car) error {
-	carUpdate := stmtBuilder.Update("car").SetMap(rmEmptyVals(squirrel.Eq{
-		"license_plate": carFields.LicensePlate,
-		"color":         carFields.Color,
-		"make":          carFields.Make,
-		"model":         carFields.Model,
-	}))
-	if carFields.AmtParkingDaysUsed != nil {
-		carUpdate = carUpdate.Set("amt_parking_days_used", *carFields.AmtParkingDaysUsed)
-	}
-
-	query, args, err := carUpdate.Where("car.id = ?", carFields.ID).ToSql()
-	if err != nil {
-		return fmt.Errorf("car_repo.Update: %w: %v", errs.ErrDBBuildingQuery, err)
-	}
-
-	_, err = carRepo.driver.Exec(query, args...)
-	if err != nil {
-		return fmt.Errorf("car_repo.Update: %w: %v", errs.ErrDBExec, err)
-	}
-
-	return nil
-}
-
-func (carRepo CarRepo) Delete(id string) error {
-	const query = `DELETE FROM car WHERE id = $1`
-
-	_, err := carRepo.driver.Exec(query, id)
-	if err != nil {
-		return fmt.Errorf("car_repo.Delete: %w: %v", errs.ErrDBExec, err)
-	}
-
-	return nil
-}
-
-func (carRepo CarRepo) Reset() error {
-	_, err := carRepo.driver.Exec("DELETE FROM car")
-	if err != nil {
-		return fmt.Errorf("car_repo.Reset: %w: %v", errs.ErrDBExec, err)
-	}
-
-	return nil
-}
-
-// SearchAsSQL implements selectops.Repo
-func (carRepo CarRepo) SearchAsSQL(query string) squirrel.Sqlizer {
-	lcQuery := strings.ToLower(query)
-	return squirrel.Or{
-		squirrel.Expr("LOWER(car.resident_id) = ?", lcQuery),
-		squirrel.Expr("LOWER(car.license_plate) = ?", lcQuery),
-		squirrel.Expr("LOWER(car.color) = ?", lcQuery),
-		squirrel.Expr("LOWER(car.make) = ?", lcQuery),
-		squirrel.Expr("LOWER(car.model) = ?", lcQuery),
-	}
-}
@@ -0,0 +1,23 @@
+package psql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dannyvelas/parkspot-backend/storage/storagetest"
+)
+
+func TestDatabase(t *testing.T) {
+	container, database, err := NewSandboxDatabase(time.Local)
+	if err != nil {
+		t.Fatalf("error getting sandbox database: %v", err)
+	}
+	defer func() {
+		if err := container.Terminate(context.Background()); err != nil {
+			t.Errorf("error tearing down container: %v", err)
+		}
+	}()
+
+	storagetest.Run(t, database)
+}
@@ -0,0 +1,19 @@
+package psql
+
+import (
+	"github.com/Masterminds/squirrel"
+	"github.com/dannyvelas/parkspot-backend/storage/sqlrepo"
+	"github.com/lib/pq"
+)
+
+// Dialect is the SQL of postgres. searches are ranked by the similarity() of pg_trgm
+var Dialect = sqlrepo.Dialect{
+	Placeholder: squirrel.Dollar,
+	NormalizedLicensePlate: func(column string) string {
+		return "TRANSLATE(UPPER(" + column + "), 'OI -', '01')"
+	},
+	Similarity: "similarity",
+	StringList: func(list *[]string) sqlrepo.StringList {
+		return (*pq.StringArray)(list)
+	},
+}
@@ -1,2 +1,3 @@
-// Package psql provides a PostgreSQL implementation of the storage interface.
+// Package psql provides a PostgreSQL implementation of the storage interface. its repos are those of
+// storage/sqlrepo, written in Dialect.
 package psql
@@ -0,0 +1,144 @@
+package psql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"slices"
+
+	"github.com/dannyvelas/parkspot-backend/errs"
+	"github.com/dannyvelas/parkspot-backend/migrations"
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/source"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+)
+
+// seedMigrationsTable keeps the version of the seeds apart from the version of the schema
+const seedMigrationsTable = "seed_migrations"
+
+// Migrator migrates the schema of the database with the migrations that are embedded in the migrations package
+func (database Database) Migrator() (*migrate.Migrate, error) {
+	schema, err := iofs.New(migrations.FS, ".")
+	if err != nil {
+		return nil, fmt.Errorf("error reading embedded migrations: %v", err)
+	}
+
+	return database.newMigrator(schema, postgres.DefaultMigrationsTable)
+}
+
+// SeedMigrator migrates the sample data that is embedded in the migrations package, or the seeds of dir when
+// dir is not empty
+func (database Database) SeedMigrator(dir string) (*migrate.Migrate, error) {
+	var seeds source.Driver
+	var err error
+	if dir == "" {
+		seeds, err = iofs.New(migrations.SeedFS, "seed")
+	} else {
+		seeds, err = iofs.New(os.DirFS(dir), ".")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading seeds: %v", err)
+	}
+
+	return database.newMigrator(seeds, seedMigrationsTable)
+}
+
+// newMigrator migrates with a connection of its own, because closing a migrator closes the connection that it
+// was made with
+func (database Database) newMigrator(migrations source.Driver, table string) (*migrate.Migrate, error) {
+	ctx := context.Background()
+	conn, err := database.driver.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("database: %w: %v", errs.ErrDBConnecting, err)
+	}
+
+	driver, err := postgres.WithConnection(ctx, conn, &postgres.Config{MigrationsTable: table})
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to initialize the migrate driver: %v", err)
+	}
+
+	migrator, err := migrate.NewWithInstance("iofs", migrations, "postgres", driver)
+	if err != nil {
+		driver.Close()
+		return nil, fmt.Errorf("failed to initialize migrate: %v", err)
+	}
+
+	return migrator, nil
+}
+
+// MigrateUp applies the migrations of the schema that the database is missing. migrate holds a postgres advisory
+// lock while it migrates, so instances that start at the same time wait for each other instead of racing
+func (database Database) MigrateUp() error {
+	migrator, err := database.Migrator()
+	if err != nil {
+		return fmt.Errorf("database.MigrateUp: %w: %v", errs.ErrDBMigrating, err)
+	}
+	defer migrator.Close()
+
+	if err := migrator.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("database.MigrateUp: %w: %v", errs.ErrDBMigrating, err)
+	}
+
+	return nil
+}
+
+// CheckSchema fails when the database is missing migrations of the schema that are embedded in this binary, when
+// its last migration failed, or when its version is not a migration of this binary. a version that this binary
+// doesn't have was migrated by a newer binary, whose schema this one can't be trusted to serve
+func (database Database) CheckSchema() error {
+	versions, err := migrationVersions()
+	if err != nil {
+		return fmt.Errorf("database.CheckSchema: %v", err)
+	}
+	latest := versions[len(versions)-1]
+
+	migrator, err := database.Migrator()
+	if err != nil {
+		return fmt.Errorf("database.CheckSchema: %v", err)
+	}
+	defer migrator.Close()
+
+	version, dirty, err := migrator.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		return fmt.Errorf("database.CheckSchema: %w: no migrations are applied, the latest is %d", errs.ErrDBSchemaBehind, latest)
+	} else if err != nil {
+		return fmt.Errorf("database.CheckSchema: %w: %v", errs.ErrDBQuery, err)
+	} else if dirty {
+		return fmt.Errorf("database.CheckSchema: %w: migration %d failed and must be fixed by hand", errs.ErrDBSchemaDirty, version)
+	} else if !slices.Contains(versions, version) {
+		return fmt.Errorf("database.CheckSchema: %w: version is %d, the latest of this binary is %d", errs.ErrDBSchemaUnknown, version, latest)
+	} else if version < latest {
+		return fmt.Errorf("database.CheckSchema: %w: version is %d, the latest is %d", errs.ErrDBSchemaBehind, version, latest)
+	}
+
+	return nil
+}
+
+// migrationVersions are the versions of the migrations of the schema, in order
+func migrationVersions() ([]uint, error) {
+	schema, err := iofs.New(migrations.FS, ".")
+	if err != nil {
+		return nil, fmt.Errorf("error reading embedded migrations: %v", err)
+	}
+	defer schema.Close()
+
+	version, err := schema.First()
+	if err != nil {
+		return nil, fmt.Errorf("error reading first migration: %v", err)
+	}
+	versions := []uint{version}
+	for {
+		next, err := schema.Next(version)
+		if errors.Is(err, fs.ErrNotExist) {
+			return versions, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("error reading migration after %d: %v", version, err)
+		}
+		versions = append(versions, next)
+		version = next
+	}
+}
@@ -0,0 +1,72 @@
+package psql
+
+import (
+	"io/fs"
+	"strings"
+	"testing"
+
+	"github.com/dannyvelas/parkspot-backend/migrations"
+)
+
+// latestMigration is the version of the last migration of the schema
+func latestMigration() (uint, error) {
+	versions, err := migrationVersions()
+	if err != nil {
+		return 0, err
+	}
+
+	return versions[len(versions)-1], nil
+}
+
+func TestLatestMigration(t *testing.T) {
+	latest, err := latestMigration()
+	if err != nil {
+		t.Fatalf("error getting latest migration: %v", err)
+	}
+
+	ups, err := fs.Glob(migrations.FS, "*.up.sql")
+	if err != nil {
+		t.Fatalf("error listing migrations: %v", err)
+	}
+	if latest != uint(len(ups)) {
+		t.Fatalf("expected latest migration to be %d, got %d", len(ups), latest)
+	}
+}
+
+// databases that were migrated while the sample data was in the schema are at version 6, so the schema must keep
+// every version up to it
+func TestMigrationVersions(t *testing.T) {
+	versions, err := migrationVersions()
+	if err != nil {
+		t.Fatalf("error getting migration versions: %v", err)
+	}
+
+	for i, version := range versions {
+		if version != uint(i+1) {
+			t.Fatalf("expected migration %d to have version %d, got %d", i, i+1, version)
+		}
+	}
+	if len(versions) <= 6 {
+		t.Fatalf("expected migrations to go past version 6, the latest is %d", len(versions))
+	}
+}
+
+func TestSchemaHasNoSeeds(t *testing.T) {
+	ups, err := fs.Glob(migrations.FS, "*.up.sql")
+	if err != nil {
+		t.Fatalf("error listing migrations: %v", err)
+	}
+	for _, up := range ups {
+		if strings.Contains(up, "seed") {
+			t.Fatalf("expected sample data to be in the seed folder, found %s in the schema migrations", up)
+		}
+	}
+
+	seeds, err := fs.Glob(migrations.SeedFS, "seed/*.up.sql")
+	if err != nil {
+		t.Fatalf("error listing seeds: %v", err)
+	}
+	if len(seeds) == 0 {
+		t.Fatalf("expected the seed folder to have sample data")
+	}
+}
@@ -6,9 +6,11 @@ import (
 	"github.com/dannyvelas/parkspot-backend/config"
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/wait"
+	"time"
 )
 
-func NewSandboxDatabase() (testcontainers.Container, Database, error) {
+// NewSandboxDatabase starts a postgres container and migrates it. location is the timezone of the community
+func NewSandboxDatabase(location *time.Location) (testcontainers.Container, Database, error) {
 	ctx := context.Background()
 	req := testcontainers.ContainerRequest{
 		Image:        "postgres",
@@ -37,15 +39,15 @@ func NewSandboxDatabase() (testcontainers.Container, Database, error) {
 	}
 	postgresURL := fmt.Sprintf("postgresql://postgres:postgres@%s/postgres?sslmode=disable&connect_timeout=60", postgresEndpoint)
 
-	database, err := NewDatabase(config.PostgresConfig{URL: postgresURL})
+	database, err := NewDatabase(config.DatabaseConfig{URL: postgresURL}, location)
 	if err != nil {
 		postgresContainer.Terminate(ctx)
 		return nil, Database{}, fmt.Errorf("tearing down because failed to instantiate database: %v", err)
 	}
 
-	if err := database.CreateSchemas(); err != nil {
+	if err := database.MigrateUp(); err != nil {
 		postgresContainer.Terminate(ctx)
-		return nil, Database{}, fmt.Errorf("tearing down because failed to seed schemas: %v", err)
+		return nil, Database{}, fmt.Errorf("tearing down because failed to migrate schemas: %v", err)
 	}
 
 	return postgresContainer, database, nil
@@ -1,16 +1,20 @@
 package storage
 
 import (
+	"context"
+
 	"github.com/dannyvelas/parkspot-backend/models"
 	"github.com/dannyvelas/parkspot-backend/storage/selectopts"
 )
 
 type ResidentRepo interface {
-	SelectWhere(residentFields models.Resident, selectOpts ...selectopts.SelectOpt) ([]models.Resident, error)
-	SelectCountWhere(residentFields models.Resident, selectOpts ...selectopts.SelectOpt) (int, error)
-	AddToAmtParkingDaysUsed(id string, days int) error
-	Create(resident models.Resident) error
-	Delete(residentID string) error
-	Update(residentFields models.Resident) error
-	Reset() error // for testing
+	SelectWhere(ctx context.Context, residentFields models.Resident, selectOpts ...selectopts.SelectOpt) ([]models.Resident, error)
+	SelectCountWhere(ctx context.Context, residentFields models.Resident, selectOpts ...selectopts.SelectOpt) (int, error)
+	AddToAmtParkingDaysUsed(ctx context.Context, id string, days int) error
+	Create(ctx context.Context, resident models.Resident) error
+	Delete(ctx context.Context, residentID string) error
+	// Update edits the resident of the ID of residentFields, and increments its version. when residentFields has a version,
+	// the resident is only edited if it is still at that version, and a conflict is returned otherwise
+	Update(ctx context.Context, residentFields models.Resident) error
+	Reset(ctx context.Context) error // for testing
 }
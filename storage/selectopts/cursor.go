@@ -0,0 +1,136 @@
+package selectopts
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/Masterminds/squirrel"
+)
+
+// Cursor points at a row of a sorted list. the page of a cursor starts right after its row, or ends right
+// before it when Before is true. the zero Cursor points at the start of the list
+type Cursor struct {
+	Sort   Sort
+	ID     string
+	Before bool
+}
+
+type encodedCursor struct {
+	Sort   string `json:"s"`
+	ID     string `json:"id"`
+	Before bool   `json:"b,omitempty"`
+}
+
+// Encode turns cursor into an opaque string that clients can send back
+func (cursor Cursor) Encode() string {
+	encoded, _ := json.Marshal(encodedCursor{Sort: cursor.Sort.String(), ID: cursor.ID, Before: cursor.Before})
+	return base64.RawURLEncoding.EncodeToString(encoded)
+}
+
+// DecodeCursor parses a string from Cursor.Encode
+func DecodeCursor(s string) (Cursor, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return Cursor{}, fmt.Errorf("cursor is not base64: %v", err)
+	}
+
+	var encoded encodedCursor
+	if err := json.Unmarshal(decoded, &encoded); err != nil {
+		return Cursor{}, fmt.Errorf("cursor is not json: %v", err)
+	}
+	if encoded.ID == "" {
+		return Cursor{}, fmt.Errorf("cursor has no id")
+	}
+
+	sort, err := ParseSort(encoded.Sort)
+	if err != nil {
+		return Cursor{}, fmt.Errorf("cursor has an invalid sort: %v", err)
+	}
+
+	return Cursor{Sort: sort, ID: encoded.ID, Before: encoded.Before}, nil
+}
+
+// IsValidID is whether id has the type of the ids of the rows of repo, so that a cursor with it can point at a row
+func IsValidID(repo any, id string) bool {
+	idRepo, ok := repo.(IDRepo)
+	if !ok || idRepo.IDType() != IntField {
+		return true
+	}
+	_, err := strconv.ParseInt(id, 10, 64)
+	return err == nil
+}
+
+type cursorOp struct {
+	cursor Cursor
+}
+
+// WithCursor sorts rows by cursor.Sort, and keeps the rows after the row of cursor. when cursor.Before is true,
+// it keeps the rows before the row of cursor instead, nearest first, so callers must reverse them
+func WithCursor(cursor Cursor) cursorOp {
+	return cursorOp{cursor}
+}
+
+func (cursorOp cursorOp) Dispatch(repo Repo, selector squirrel.SelectBuilder) squirrel.SelectBuilder {
+	cursor := cursorOp.cursor
+	table, column := sortColumn(repo, cursor.Sort)
+	qualify := func(column string) string {
+		if table == "" {
+			return column
+		}
+		return table + "." + column
+	}
+
+	// rows before the cursor are the rows after it in the opposite order
+	desc := cursor.Sort.Desc != cursor.Before
+	direction, comparison := "ASC", ">"
+	if desc {
+		direction, comparison = "DESC", "<"
+	}
+
+	if column == "id" {
+		selector = selector.OrderBy(qualify("id") + " " + direction)
+	} else {
+		selector = selector.OrderBy(qualify(column)+" "+direction, qualify("id")+" "+direction)
+	}
+
+	if cursor.ID == "" {
+		return selector
+	} else if column == "id" {
+		return selector.Where(fmt.Sprintf("%s %s ?", qualify("id"), comparison), cursor.ID)
+	}
+
+	// the row of the cursor is looked up, so that cursors hold no values of the sorted column
+	return selector.Where(fmt.Sprintf("(%s, %s) %s (SELECT cursor_row.%s, cursor_row.id FROM %s AS cursor_row WHERE cursor_row.id = ?)",
+		qualify(column), qualify("id"), comparison, column, table), cursor.ID)
+}
+
+func (cursorOp cursorOp) Apply(options *Options) {
+	options.Cursor = &cursorOp.cursor
+}
+
+type pageOp struct {
+	limit, offset int
+}
+
+// WithPage selects limit rows, starting at offset, plus one more row, so that callers can tell whether
+// there are rows after the page
+func WithPage(limit, offset int) pageOp {
+	return pageOp{limit, offset}
+}
+
+func (pageOp pageOp) Dispatch(repo Repo, selector squirrel.SelectBuilder) squirrel.SelectBuilder {
+	selector = selector.Limit(uint64(getBoundedLimit(pageOp.limit)) + 1)
+	if pageOp.offset > 0 {
+		selector = selector.Offset(uint64(pageOp.offset))
+	}
+	return selector
+}
+
+func (pageOp pageOp) Apply(options *Options) {
+	options.Limit = getBoundedLimit(pageOp.limit) + 1
+	if pageOp.offset > 0 {
+		options.Offset = pageOp.offset
+	}
+}
@@ -0,0 +1,65 @@
+package selectopts
+
+import (
+	"testing"
+
+	"github.com/Masterminds/squirrel"
+)
+
+type sortRepo struct{}
+
+func (sortRepo) SearchAsSQL(string) squirrel.Sqlizer { return squirrel.Expr("") }
+func (sortRepo) SortColumns() map[string]string      { return map[string]string{"startDate": "start_ts"} }
+func (sortRepo) Table() string                       { return "permit" }
+
+func TestWithCursor(t *testing.T) {
+	tests := map[string]struct {
+		cursor   Cursor
+		expected string
+	}{
+		"start of list by id": {
+			cursor:   Cursor{},
+			expected: "SELECT id FROM permit ORDER BY permit.id ASC",
+		},
+		"after row by startDate": {
+			cursor:   Cursor{Sort: Sort{Field: "startDate"}, ID: "4"},
+			expected: "SELECT id FROM permit WHERE (permit.start_ts, permit.id) > (SELECT cursor_row.start_ts, cursor_row.id FROM permit AS cursor_row WHERE cursor_row.id = $1) ORDER BY permit.start_ts ASC, permit.id ASC",
+		},
+		"before row by startDate descending": {
+			cursor:   Cursor{Sort: Sort{Field: "startDate", Desc: true}, ID: "4", Before: true},
+			expected: "SELECT id FROM permit WHERE (permit.start_ts, permit.id) > (SELECT cursor_row.start_ts, cursor_row.id FROM permit AS cursor_row WHERE cursor_row.id = $1) ORDER BY permit.start_ts ASC, permit.id ASC",
+		},
+		"after row by id descending": {
+			cursor:   Cursor{Sort: Sort{Field: "id", Desc: true}, ID: "4"},
+			expected: "SELECT id FROM permit WHERE permit.id < $1 ORDER BY permit.id DESC",
+		},
+	}
+
+	for name, test := range tests {
+		selector := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar).Select("id").From("permit")
+		query, _, err := WithCursor(test.cursor).Dispatch(sortRepo{}, selector).ToSql()
+		if err != nil {
+			t.Fatalf("%s failed: %v", name, err)
+		}
+		if query != test.expected {
+			t.Errorf("%s failed: expected %q, got %q", name, test.expected, query)
+		}
+	}
+}
+
+func TestDecodeCursor(t *testing.T) {
+	cursor := Cursor{Sort: Sort{Field: "startDate", Desc: true}, ID: "4", Before: true}
+	decoded, err := DecodeCursor(cursor.Encode())
+	if err != nil {
+		t.Fatalf("error decoding cursor: %v", err)
+	}
+	if decoded != cursor {
+		t.Errorf("expected %+v, got %+v", cursor, decoded)
+	}
+
+	for _, invalid := range []string{"", "not base64!", "bm90IGpzb24", "e30"} {
+		if _, err := DecodeCursor(invalid); err == nil {
+			t.Errorf("expected an error decoding %q", invalid)
+		}
+	}
+}
@@ -18,3 +18,7 @@ func (dateIntersect dateIntersect) Dispatch(repo Repo, selector squirrel.SelectB
 		Where("start_ts <= ?", dateIntersect.endDate.Unix()).
 		Where("end_ts >= ?", dateIntersect.startDate.Unix())
 }
+
+func (dateIntersect dateIntersect) Apply(options *Options) {
+	options.DateIntersect = &DateRange{StartDate: dateIntersect.startDate, EndDate: dateIntersect.endDate}
+}
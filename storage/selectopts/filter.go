@@ -0,0 +1,161 @@
+package selectopts
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/dannyvelas/parkspot-backend/config"
+)
+
+type Op string
+
+const (
+	Eq    Op = "="
+	NotEq Op = "!="
+	Gt    Op = ">"
+	Gte   Op = ">="
+	Lt    Op = "<"
+	Lte   Op = "<="
+)
+
+// Ops are ordered so that operators that start with another operator come first, like >= before >
+var Ops = []Op{NotEq, Gte, Lte, Eq, Gt, Lt}
+
+type FieldType int
+
+const (
+	// StringField is compared ignoring case, and only with = and !=
+	StringField FieldType = iota
+	// BoolField is true or false, and is only compared with = and !=
+	BoolField
+	IntField
+	// DateField is a day of the community's calendar, like 2025-01-01, stored as a unix timestamp
+	DateField
+)
+
+// FilterField is a field that rows can be filtered by
+type FilterField struct {
+	Column string
+	Type   FieldType
+	// Location is the timezone of the community, whose calendar the days of a DateField are days of
+	Location *time.Location
+}
+
+// Filter keeps the rows whose Field compares to Value with Op, like startDate >= 2025-01-01
+type Filter struct {
+	Field string
+	Op    Op
+	Value string
+}
+
+var (
+	ErrNotFilterable     = errors.New("field is not filterable")
+	ErrFilterOp          = errors.New("field cannot be compared with this operator")
+	ErrFilterValueFormat = errors.New("value does not have the format of the field")
+)
+
+// CheckFilter returns ErrNotFilterable, ErrFilterOp or ErrFilterValueFormat when repo can't filter its rows with filter
+func CheckFilter(repo any, filter Filter) error {
+	_, _, err := filterAsSQL(repo, filter)
+	return err
+}
+
+// FilterableFields lists the fields that repo can filter its rows by
+func FilterableFields(repo any) []string {
+	var fields []string
+	if filterRepo, ok := repo.(FilterRepo); ok {
+		for field := range filterRepo.FilterFields() {
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+type filters struct {
+	filters []Filter
+}
+
+// WithFilters keeps the rows that pass every filter. filters that don't pass CheckFilter are skipped
+func WithFilters(f ...Filter) filters {
+	return filters{f}
+}
+
+func (filters filters) Dispatch(repo Repo, selector squirrel.SelectBuilder) squirrel.SelectBuilder {
+	for _, filter := range filters.filters {
+		column, value, err := filterAsSQL(repo, filter)
+		if err != nil {
+			continue
+		}
+		selector = selector.Where(fmt.Sprintf("%s %s ?", column, filter.Op), value)
+	}
+	return selector
+}
+
+func (filters filters) Apply(options *Options) {
+	options.Filters = append(options.Filters, filters.filters...)
+}
+
+// FilterValue is the value that the field of a row is compared to by filter, for repos that don't filter with
+// SQL. it is a lowercase string for StringField, a bool for BoolField, an int64 for IntField and a unix
+// timestamp for DateField. its error is the error of CheckFilter
+func FilterValue(repo any, filter Filter) (any, error) {
+	_, value, err := filterAsSQL(repo, filter)
+	if intValue, ok := value.(int); ok {
+		value = int64(intValue)
+	}
+	return value, err
+}
+
+func filterAsSQL(repo any, filter Filter) (column string, value any, err error) {
+	filterRepo, ok := repo.(FilterRepo)
+	if !ok {
+		return "", nil, ErrNotFilterable
+	}
+	field, ok := filterRepo.FilterFields()[filter.Field]
+	if !ok {
+		return "", nil, ErrNotFilterable
+	}
+
+	// the operator is written into the query, so it must be one of Ops
+	if !slices.Contains(Ops, filter.Op) {
+		return "", nil, ErrFilterOp
+	}
+
+	switch field.Type {
+	case StringField:
+		if filter.Op != Eq && filter.Op != NotEq {
+			return "", nil, ErrFilterOp
+		}
+		return "LOWER(CAST(" + field.Column + " AS TEXT))", strings.ToLower(filter.Value), nil
+	case BoolField:
+		if filter.Op != Eq && filter.Op != NotEq {
+			return "", nil, ErrFilterOp
+		}
+		value, err := strconv.ParseBool(filter.Value)
+		if err != nil {
+			return "", nil, ErrFilterValueFormat
+		}
+		return field.Column, value, nil
+	case IntField:
+		value, err := strconv.Atoi(filter.Value)
+		if err != nil {
+			return "", nil, ErrFilterValueFormat
+		}
+		return field.Column, value, nil
+	case DateField:
+		date, err := time.ParseInLocation(config.DateFormat, filter.Value, field.Location)
+		if err != nil {
+			return "", nil, ErrFilterValueFormat
+		}
+		return field.Column, date.Unix(), nil
+	default:
+		return "", nil, ErrNotFilterable
+	}
+}
@@ -0,0 +1,60 @@
+package selectopts
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/Masterminds/squirrel"
+)
+
+type filterRepo struct{}
+
+func (filterRepo) SearchAsSQL(string) squirrel.Sqlizer { return squirrel.Expr("") }
+func (filterRepo) FilterFields() map[string]FilterField {
+	return map[string]FilterField{
+		"make":        {Column: "permit.make", Type: StringField},
+		"affectsDays": {Column: "permit.affects_days", Type: BoolField},
+		"id":          {Column: "permit.id", Type: IntField},
+	}
+}
+
+func TestWithFilters(t *testing.T) {
+	selector := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar).Select("id").From("permit")
+	query, args, err := WithFilters(
+		Filter{Field: "make", Op: Eq, Value: "Honda"},
+		Filter{Field: "affectsDays", Op: NotEq, Value: "false"},
+		Filter{Field: "id", Op: Gte, Value: "4"},
+		Filter{Field: "color", Op: Eq, Value: "red"},
+	).Dispatch(filterRepo{}, selector).ToSql()
+	if err != nil {
+		t.Fatalf("error building query: %v", err)
+	}
+
+	expectedQuery := "SELECT id FROM permit WHERE LOWER(CAST(permit.make AS TEXT)) = $1 AND permit.affects_days != $2 AND permit.id >= $3"
+	if query != expectedQuery {
+		t.Errorf("expected %q, got %q", expectedQuery, query)
+	}
+	if expectedArgs := "[honda false 4]"; fmt.Sprint(args) != expectedArgs {
+		t.Errorf("expected %s, got %v", expectedArgs, args)
+	}
+}
+
+func TestCheckFilter(t *testing.T) {
+	tests := map[string]struct {
+		filter   Filter
+		expected error
+	}{
+		"valid":                   {filter: Filter{Field: "id", Op: Lt, Value: "4"}, expected: nil},
+		"unknown field":           {filter: Filter{Field: "color", Op: Eq, Value: "red"}, expected: ErrNotFilterable},
+		"string compared by >":    {filter: Filter{Field: "make", Op: Gt, Value: "honda"}, expected: ErrFilterOp},
+		"operator that is not ok": {filter: Filter{Field: "id", Op: "= 1 OR 1", Value: "1"}, expected: ErrFilterOp},
+		"bool that is not a bool": {filter: Filter{Field: "affectsDays", Op: Eq, Value: "yes please"}, expected: ErrFilterValueFormat},
+	}
+
+	for name, test := range tests {
+		if err := CheckFilter(filterRepo{}, test.filter); !errors.Is(err, test.expected) {
+			t.Errorf("%s failed: expected %v, got %v", name, test.expected, err)
+		}
+	}
+}
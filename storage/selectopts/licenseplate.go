@@ -0,0 +1,31 @@
+package selectopts
+
+import (
+	"github.com/Masterminds/squirrel"
+	"github.com/dannyvelas/parkspot-backend/util"
+)
+
+type licensePlate struct {
+	licensePlate string
+}
+
+// WithLicensePlate matches rows whose license plate is equal to licensePlate once both are normalized
+// with util.NormalizeLicensePlate
+func WithLicensePlate(lp string) licensePlate {
+	return licensePlate{lp}
+}
+
+func (licensePlate licensePlate) Dispatch(repo Repo, selector squirrel.SelectBuilder) squirrel.SelectBuilder {
+	licensePlateRepo, ok := repo.(LicensePlateRepo)
+	if !ok || licensePlate.licensePlate == "" {
+		return selector
+	}
+
+	return selector.Where(licensePlateRepo.LicensePlateAsSQL(util.NormalizeLicensePlate(licensePlate.licensePlate)))
+}
+
+func (licensePlate licensePlate) Apply(options *Options) {
+	if licensePlate.licensePlate != "" {
+		options.LicensePlate = util.NormalizeLicensePlate(licensePlate.licensePlate)
+	}
+}
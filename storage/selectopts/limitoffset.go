@@ -23,6 +23,15 @@ func (limitAndOffset limitAndOffset) Dispatch(repo Repo, selector squirrel.Selec
 	return selector
 }
 
+func (limitAndOffset limitAndOffset) Apply(options *Options) {
+	if limitAndOffset.limit >= 0 {
+		options.Limit = getBoundedLimit(limitAndOffset.limit)
+	}
+	if limitAndOffset.offset >= 0 {
+		options.Offset = limitAndOffset.offset
+	}
+}
+
 // helpers
 func getBoundedLimit(limit int) int {
 	if limit > config.MaxLimit {
@@ -0,0 +1,41 @@
+package selectopts
+
+import (
+	"time"
+
+	"github.com/dannyvelas/parkspot-backend/models"
+)
+
+// Options are the select options of a query, for repos that don't select with SQL, like the repos of
+// storage/memory. the zero Options select every row. when an option is given more than once, the last one
+// is kept, except for filters, which add up
+type Options struct {
+	// Status is models.AnyStatus when rows are not selected by status
+	Status models.Status
+	Search string
+	// SearchRank is the search that rows are sorted by, most relevant first, before they are sorted by Cursor
+	SearchRank string
+	// DateIntersect is nil when rows are not selected by dates
+	DateIntersect *DateRange
+	// LicensePlate is normalized with util.NormalizeLicensePlate
+	LicensePlate string
+	Filters      []Filter
+	// Cursor is nil when rows are not sorted by a cursor
+	Cursor *Cursor
+	// Limit is -1 when rows are not limited. Offset is 0 when no rows are skipped
+	Limit, Offset int
+}
+
+// DateRange is the range of days of WithDateIntersect
+type DateRange struct {
+	StartDate, EndDate time.Time
+}
+
+// NewOptions applies selectOpts to the zero Options
+func NewOptions(selectOpts ...SelectOpt) Options {
+	options := Options{Limit: -1}
+	for _, opt := range selectOpts {
+		opt.Apply(&options)
+	}
+	return options
+}
@@ -0,0 +1,35 @@
+package selectopts
+
+import (
+	"testing"
+
+	"github.com/dannyvelas/parkspot-backend/models"
+)
+
+func TestNewOptions(t *testing.T) {
+	options := NewOptions()
+	if options.Status != models.AnyStatus || options.Limit != -1 || options.Cursor != nil || options.DateIntersect != nil {
+		t.Fatalf("expected zero options to select every row, got %+v", options)
+	}
+
+	options = NewOptions(
+		WithStatus(models.ActiveStatus),
+		WithLimitAndOffset(10, 20),
+		WithLicensePlate("oil-123"),
+		WithFilters(Filter{Field: "make", Op: Eq, Value: "honda"}),
+		WithFilters(Filter{Field: "id", Op: Gte, Value: "4"}),
+		WithStatus(models.ExpiredStatus),
+	)
+	if options.Status != models.ExpiredStatus {
+		t.Errorf("expected the last status to be kept, got %v", options.Status)
+	}
+	if options.Limit != 10 || options.Offset != 20 {
+		t.Errorf("expected limit 10 and offset 20, got %d and %d", options.Limit, options.Offset)
+	}
+	if options.LicensePlate != "01L123" {
+		t.Errorf("expected %q, got %q", "01L123", options.LicensePlate)
+	}
+	if len(options.Filters) != 2 {
+		t.Errorf("expected filters to add up, got %v", options.Filters)
+	}
+}
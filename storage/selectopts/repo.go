@@ -12,3 +12,31 @@ type Repo interface {
 type StatusRepo interface {
 	StatusAsSQL(models.Status) (squirrel.Sqlizer, bool)
 }
+
+type LicensePlateRepo interface {
+	LicensePlateAsSQL(normalizedLicensePlate string) squirrel.Sqlizer
+}
+
+// SortRepo is a repo whose rows can be sorted by fields other than id, and paged by cursor
+type SortRepo interface {
+	// SortColumns maps the fields that rows can be sorted by, like startDate, to their columns
+	SortColumns() map[string]string
+	// Table is the table where the row of a cursor is looked up
+	Table() string
+}
+
+// IDRepo is a repo whose ids aren't strings, like the integer ids of permits
+type IDRepo interface {
+	IDType() FieldType
+}
+
+// RankRepo is a repo that can rank the rows that match a search by how relevant they are to it
+type RankRepo interface {
+	SearchRankAsSQL(string) squirrel.Sqlizer
+}
+
+// FilterRepo is a repo whose rows can be filtered by the values of their fields
+type FilterRepo interface {
+	// FilterFields maps the fields that rows can be filtered by, like startDate, to their columns and types
+	FilterFields() map[string]FilterField
+}
@@ -1,6 +1,8 @@
 package selectopts
 
 import (
+	"strings"
+
 	"github.com/Masterminds/squirrel"
 )
 
@@ -13,8 +15,39 @@ func WithSearch(s string) search {
 }
 
 func (search search) Dispatch(repo Repo, selector squirrel.SelectBuilder) squirrel.SelectBuilder {
-	if search.search == "" {
+	if strings.TrimSpace(search.search) == "" {
 		return selector
 	}
 	return selector.Where(repo.SearchAsSQL(search.search))
 }
+
+func (search search) Apply(options *Options) {
+	options.Search = search.search
+}
+
+type searchRank struct {
+	search string
+}
+
+// WithSearchRank sorts the rows that match a search by how relevant they are to it, most relevant first.
+// it must come before other options that sort rows, which only break ties between rows that are equally relevant
+func WithSearchRank(s string) searchRank {
+	return searchRank{s}
+}
+
+func (searchRank searchRank) Dispatch(repo Repo, selector squirrel.SelectBuilder) squirrel.SelectBuilder {
+	rankRepo, ok := repo.(RankRepo)
+	if !ok || strings.TrimSpace(searchRank.search) == "" {
+		return selector
+	}
+
+	rankSQL, args, err := rankRepo.SearchRankAsSQL(searchRank.search).ToSql()
+	if err != nil {
+		return selector
+	}
+	return selector.OrderByClause(rankSQL+" DESC", args...)
+}
+
+func (searchRank searchRank) Apply(options *Options) {
+	options.SearchRank = searchRank.search
+}
@@ -6,4 +6,6 @@ import (
 
 type SelectOpt interface {
 	Dispatch(Repo, squirrel.SelectBuilder) squirrel.SelectBuilder
+	// Apply sets the option on the Options of a repo that doesn't select with SQL
+	Apply(*Options)
 }
@@ -0,0 +1,74 @@
+package selectopts
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Sort orders rows by a field, like startDate. ties are broken by id, so that the order is total.
+// the zero Sort orders rows by id, ascending
+type Sort struct {
+	Field string
+	Desc  bool
+}
+
+// ParseSort parses a sort of the form field:asc or field:desc. the direction is optional, and ascending by default
+func ParseSort(s string) (Sort, error) {
+	field, direction, _ := strings.Cut(s, ":")
+	switch direction {
+	case "", "asc":
+		return Sort{Field: field}, nil
+	case "desc":
+		return Sort{Field: field, Desc: true}, nil
+	default:
+		return Sort{}, fmt.Errorf("direction must be asc or desc, got %q", direction)
+	}
+}
+
+func (sort Sort) String() string {
+	if sort.Desc {
+		return sort.Field + ":desc"
+	}
+	return sort.Field + ":asc"
+}
+
+// IsSortable is true when repo can sort its rows by sort.Field
+func IsSortable(repo any, sort Sort) bool {
+	if sort.Field == "" || sort.Field == "id" {
+		return true
+	}
+	sortRepo, ok := repo.(SortRepo)
+	if !ok {
+		return false
+	}
+	_, ok = sortRepo.SortColumns()[sort.Field]
+	return ok
+}
+
+// SortableFields lists the fields that repo can sort its rows by
+func SortableFields(repo any) []string {
+	fields := []string{"id"}
+	if sortRepo, ok := repo.(SortRepo); ok {
+		for field := range sortRepo.SortColumns() {
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields[1:])
+	return fields
+}
+
+// sortColumn returns the table of repo and the column of sort.Field. rows are sorted by id when repo can't
+// sort them by sort.Field
+func sortColumn(repo Repo, sort Sort) (table, column string) {
+	sortRepo, ok := repo.(SortRepo)
+	if !ok {
+		return "", "id"
+	}
+
+	column, ok = sortRepo.SortColumns()[sort.Field]
+	if !ok {
+		return sortRepo.Table(), "id"
+	}
+	return sortRepo.Table(), column
+}
@@ -26,3 +26,7 @@ func (status status) Dispatch(repo Repo, selector squirrel.SelectBuilder) squirr
 
 	return selector.Where(statusAsSQL)
 }
+
+func (status status) Apply(options *Options) {
+	options.Status = status.status
+}
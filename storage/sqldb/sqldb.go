@@ -0,0 +1,38 @@
+// Package sqldb opens the SQL database that the app is configured with: PostgreSQL, or SQLite when DATABASE_URL
+// starts with sqlite://.
+package sqldb
+
+import (
+	"github.com/dannyvelas/parkspot-backend/config"
+	"github.com/dannyvelas/parkspot-backend/storage"
+	"github.com/dannyvelas/parkspot-backend/storage/psql"
+	"github.com/dannyvelas/parkspot-backend/storage/sqlite"
+	"github.com/golang-migrate/migrate/v4"
+	"time"
+)
+
+// Database is a storage.Database whose schema is migrated with the migrations of the migrations package
+type Database interface {
+	storage.Database
+	Migrator() (*migrate.Migrate, error)
+	SeedMigrator(dir string) (*migrate.Migrate, error)
+	MigrateUp() error
+	CheckSchema() error
+}
+
+// Open connects to the database of the URL of databaseConfig. location is the timezone of the community
+func Open(databaseConfig config.DatabaseConfig, location *time.Location) (Database, error) {
+	if databaseConfig.IsSQLite() {
+		database, err := sqlite.NewDatabase(databaseConfig, location)
+		if err != nil {
+			return nil, err
+		}
+		return database, nil
+	}
+
+	database, err := psql.NewDatabase(databaseConfig, location)
+	if err != nil {
+		return nil, err
+	}
+	return database, nil
+}
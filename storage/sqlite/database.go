@@ -0,0 +1,59 @@
+package sqlite
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"time"
+
+	"github.com/dannyvelas/parkspot-backend/config"
+	"github.com/dannyvelas/parkspot-backend/errs"
+	"github.com/dannyvelas/parkspot-backend/storage/sqlrepo"
+	"github.com/dannyvelas/parkspot-backend/util"
+	"github.com/jmoiron/sqlx"
+	moderncsqlite "modernc.org/sqlite"
+)
+
+func init() {
+	// searches are ranked by the similarity() of pg_trgm, which sqlite doesn't have
+	moderncsqlite.MustRegisterDeterministicScalarFunction("similarity", 2, func(_ *moderncsqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+		document, _ := args[0].(string)
+		query, _ := args[1].(string)
+		return util.Similarity(document, query), nil
+	})
+}
+
+type Database struct {
+	dataSourceName string
+	driver         *sqlx.DB
+	sqlrepo.Repos
+}
+
+// NewDatabase opens the sqlite file of the URL of databaseConfig, and creates it if it doesn't exist. location is
+// the timezone of the community
+func NewDatabase(databaseConfig config.DatabaseConfig, location *time.Location) (Database, error) {
+	dataSourceName := dataSourceName(databaseConfig.SQLitePath())
+	driver, err := sqlx.Open("sqlite", dataSourceName)
+	if err != nil {
+		return Database{}, fmt.Errorf("database: %w: %v", errs.ErrDBConnecting, err)
+	}
+	// sqlite lets one connection write at a time. sharing one connection queues writes up instead of failing
+	// them with SQLITE_BUSY
+	driver.SetMaxOpenConns(1)
+
+	err = driver.Ping()
+	if err != nil {
+		return Database{}, fmt.Errorf("database: %w: %v", errs.ErrDBPinging, err)
+	}
+
+	return Database{
+		dataSourceName: dataSourceName,
+		driver:         driver,
+		Repos:          sqlrepo.NewRepos(driver, Dialect, location, databaseConfig.QueryTimeout),
+	}, nil
+}
+
+// dataSourceName turns on the foreign keys of every connection to the file of path, since sqlite ignores them
+// by default, and makes connections wait for each other instead of failing when the file is locked
+func dataSourceName(path string) string {
+	return "file:" + path + "?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)"
+}
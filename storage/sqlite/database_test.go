@@ -0,0 +1,48 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dannyvelas/parkspot-backend/config"
+	"github.com/dannyvelas/parkspot-backend/errs"
+	"github.com/dannyvelas/parkspot-backend/models"
+	"github.com/dannyvelas/parkspot-backend/storage/storagetest"
+)
+
+// newTestDatabase creates a migrated sqlite file that is removed when t ends
+func newTestDatabase(t *testing.T) Database {
+	t.Helper()
+
+	database, err := NewDatabase(config.DatabaseConfig{URL: "sqlite://" + filepath.Join(t.TempDir(), "parkspot.db")}, time.Local)
+	if err != nil {
+		t.Fatalf("error opening database: %v", err)
+	}
+	t.Cleanup(func() { database.driver.Close() })
+
+	if err := database.MigrateUp(); err != nil {
+		t.Fatalf("error migrating database: %v", err)
+	}
+	return database
+}
+
+func TestDatabase(t *testing.T) {
+	storagetest.Run(t, newTestDatabase(t))
+}
+
+func TestCancelledQuery(t *testing.T) {
+	database := newTestDatabase(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := database.ResidentRepo().SelectWhere(ctx, models.Resident{}); !errors.Is(err, errs.ErrDBQuery) {
+		t.Errorf("expected %v when the context is cancelled, got: %v", errs.ErrDBQuery, err)
+	}
+	if _, err := database.ResidentRepo().SelectWhere(context.Background(), models.Resident{}); err != nil {
+		t.Errorf("expected queries of other contexts to run, got: %v", err)
+	}
+}
@@ -0,0 +1,54 @@
+package sqlite
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/dannyvelas/parkspot-backend/storage/sqlrepo"
+)
+
+// Dialect is the SQL of sqlite. sqlite has no TRANSLATE, so each character of a license plate is replaced by
+// itself, and LIKE needs to be told that wildcards are escaped with a backslash. similarity() is registered
+// in the init of this package
+var Dialect = sqlrepo.Dialect{
+	Placeholder: squirrel.Question,
+	NormalizedLicensePlate: func(column string) string {
+		return "REPLACE(REPLACE(REPLACE(REPLACE(UPPER(" + column + "), 'O', '0'), 'I', '1'), ' ', ''), '-', '')"
+	},
+	LikeEscape: `ESCAPE '\'`,
+	Similarity: "similarity",
+	StringList: func(list *[]string) sqlrepo.StringList {
+		return (*stringList)(list)
+	},
+}
+
+// stringList stores a list of strings as a JSON array, since sqlite has no arrays. an empty list is NULL
+type stringList []string
+
+func (list stringList) Value() (driver.Value, error) {
+	if len(list) == 0 {
+		return nil, nil
+	}
+
+	bytes, err := json.Marshal([]string(list))
+	if err != nil {
+		return nil, err
+	}
+	return string(bytes), nil
+}
+
+func (list *stringList) Scan(src any) error {
+	switch src := src.(type) {
+	case nil:
+		*list = nil
+		return nil
+	case string:
+		return json.Unmarshal([]byte(src), (*[]string)(list))
+	case []byte:
+		return json.Unmarshal(src, (*[]string)(list))
+	default:
+		return fmt.Errorf("cannot scan %T into a list of strings", src)
+	}
+}
@@ -0,0 +1,4 @@
+// Package sqlite provides a SQLite implementation of the storage interface, for communities that don't want to
+// run PostgreSQL and for local development. its repos are those of storage/sqlrepo, written in Dialect.
+// unlike in postgres, LOWER only lowers ASCII letters.
+package sqlite
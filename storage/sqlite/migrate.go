@@ -0,0 +1,172 @@
+package sqlite
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/dannyvelas/parkspot-backend/errs"
+	"github.com/dannyvelas/parkspot-backend/migrations"
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/sqlite"
+	"github.com/golang-migrate/migrate/v4/source"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/jmoiron/sqlx"
+)
+
+// seedMigrationsTable keeps the version of the seeds apart from the version of the schema
+const seedMigrationsTable = "seed_migrations"
+
+// Migrator migrates the schema of the database with the sqlite migrations that are embedded in the migrations package
+func (database Database) Migrator() (*migrate.Migrate, error) {
+	schema, err := iofs.New(migrations.SQLiteFS, "sqlite")
+	if err != nil {
+		return nil, fmt.Errorf("error reading embedded migrations: %v", err)
+	}
+
+	return database.newMigrator(schema, sqlite.DefaultMigrationsTable)
+}
+
+// SeedMigrator migrates the sample data that is embedded in the migrations package, or the seeds of dir when
+// dir is not empty. seeds are written for postgres, so their statements that only postgres runs are skipped
+func (database Database) SeedMigrator(dir string) (*migrate.Migrate, error) {
+	var seeds source.Driver
+	var err error
+	if dir == "" {
+		seeds, err = iofs.New(migrations.SeedFS, "seed")
+	} else {
+		seeds, err = iofs.New(os.DirFS(dir), ".")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading seeds: %v", err)
+	}
+
+	return database.newMigrator(sqliteSeeds{seeds}, seedMigrationsTable)
+}
+
+// sqliteSeeds skips the statements of seeds that reset the sequences of ids in postgres. sqlite keeps
+// autoincremented ids after the largest id that was inserted by itself
+type sqliteSeeds struct {
+	source.Driver
+}
+
+func (seeds sqliteSeeds) ReadUp(version uint) (io.ReadCloser, string, error) {
+	r, identifier, err := seeds.Driver.ReadUp(version)
+	if err != nil {
+		return nil, "", err
+	}
+	defer r.Close()
+
+	seed, err := io.ReadAll(r)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var lines []string
+	for _, line := range strings.SplitAfter(string(seed), "\n") {
+		if !strings.HasPrefix(strings.ToUpper(strings.TrimSpace(line)), "ALTER SEQUENCE") {
+			lines = append(lines, line)
+		}
+	}
+	return io.NopCloser(strings.NewReader(strings.Join(lines, ""))), identifier, nil
+}
+
+// newMigrator migrates with a connection of its own, because closing a migrator closes the connection that it
+// was made with. migrations are not wrapped in a transaction, because they begin and commit their own
+func (database Database) newMigrator(migrations source.Driver, table string) (*migrate.Migrate, error) {
+	conn, err := sqlx.Open("sqlite", database.dataSourceName)
+	if err != nil {
+		return nil, fmt.Errorf("database: %w: %v", errs.ErrDBConnecting, err)
+	}
+
+	driver, err := sqlite.WithInstance(conn.DB, &sqlite.Config{MigrationsTable: table, NoTxWrap: true})
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to initialize the migrate driver: %v", err)
+	}
+
+	migrator, err := migrate.NewWithInstance("iofs", migrations, "sqlite", driver)
+	if err != nil {
+		driver.Close()
+		return nil, fmt.Errorf("failed to initialize migrate: %v", err)
+	}
+
+	return migrator, nil
+}
+
+// MigrateUp applies the migrations of the schema that the database is missing. a sqlite file is served by one
+// instance, so unlike in postgres, there are no instances to race with
+func (database Database) MigrateUp() error {
+	migrator, err := database.Migrator()
+	if err != nil {
+		return fmt.Errorf("database.MigrateUp: %w: %v", errs.ErrDBMigrating, err)
+	}
+	defer migrator.Close()
+
+	if err := migrator.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("database.MigrateUp: %w: %v", errs.ErrDBMigrating, err)
+	}
+
+	return nil
+}
+
+// CheckSchema fails when the database is missing migrations of the schema that are embedded in this binary, when
+// its last migration failed, or when its version is not a migration of this binary. a version that this binary
+// doesn't have was migrated by a newer binary, whose schema this one can't be trusted to serve
+func (database Database) CheckSchema() error {
+	versions, err := migrationVersions()
+	if err != nil {
+		return fmt.Errorf("database.CheckSchema: %v", err)
+	}
+	latest := versions[len(versions)-1]
+
+	migrator, err := database.Migrator()
+	if err != nil {
+		return fmt.Errorf("database.CheckSchema: %v", err)
+	}
+	defer migrator.Close()
+
+	version, dirty, err := migrator.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		return fmt.Errorf("database.CheckSchema: %w: no migrations are applied, the latest is %d", errs.ErrDBSchemaBehind, latest)
+	} else if err != nil {
+		return fmt.Errorf("database.CheckSchema: %w: %v", errs.ErrDBQuery, err)
+	} else if dirty {
+		return fmt.Errorf("database.CheckSchema: %w: migration %d failed and must be fixed by hand", errs.ErrDBSchemaDirty, version)
+	} else if !slices.Contains(versions, version) {
+		return fmt.Errorf("database.CheckSchema: %w: version is %d, the latest of this binary is %d", errs.ErrDBSchemaUnknown, version, latest)
+	} else if version < latest {
+		return fmt.Errorf("database.CheckSchema: %w: version is %d, the latest is %d", errs.ErrDBSchemaBehind, version, latest)
+	}
+
+	return nil
+}
+
+// migrationVersions are the versions of the migrations of the schema, in order
+func migrationVersions() ([]uint, error) {
+	schema, err := iofs.New(migrations.SQLiteFS, "sqlite")
+	if err != nil {
+		return nil, fmt.Errorf("error reading embedded migrations: %v", err)
+	}
+	defer schema.Close()
+
+	version, err := schema.First()
+	if err != nil {
+		return nil, fmt.Errorf("error reading first migration: %v", err)
+	}
+	versions := []uint{version}
+	for {
+		next, err := schema.Next(version)
+		if errors.Is(err, fs.ErrNotExist) {
+			return versions, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("error reading migration after %d: %v", version, err)
+		}
+		versions = append(versions, next)
+		version = next
+	}
+}
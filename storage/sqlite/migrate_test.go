@@ -0,0 +1,95 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"testing"
+
+	"github.com/dannyvelas/parkspot-backend/errs"
+	"github.com/dannyvelas/parkspot-backend/migrations"
+	"github.com/dannyvelas/parkspot-backend/models"
+)
+
+// latestMigration is the version of the last migration of the schema
+func latestMigration() (uint, error) {
+	versions, err := migrationVersions()
+	if err != nil {
+		return 0, err
+	}
+
+	return versions[len(versions)-1], nil
+}
+
+func TestLatestMigration(t *testing.T) {
+	latest, err := latestMigration()
+	if err != nil {
+		t.Fatalf("error getting latest migration: %v", err)
+	}
+
+	// the versions of the schema of sqlite are the versions of the schema of postgres
+	ups, err := fs.Glob(migrations.FS, "*.up.sql")
+	if err != nil {
+		t.Fatalf("error listing migrations: %v", err)
+	}
+	if latest != uint(len(ups)) {
+		t.Fatalf("expected latest migration to be %d, got %d", len(ups), latest)
+	}
+}
+
+func TestCheckSchema_UnknownVersion(t *testing.T) {
+	database := newTestDatabase(t)
+
+	migrator, err := database.Migrator()
+	if err != nil {
+		t.Fatalf("error getting migrator: %v", err)
+	}
+	defer migrator.Close()
+
+	// a version after the latest is one that a newer binary migrated to
+	latest, err := latestMigration()
+	if err != nil {
+		t.Fatalf("error getting latest migration: %v", err)
+	}
+	if err := migrator.Force(int(latest) + 1); err != nil {
+		t.Fatalf("error forcing version: %v", err)
+	}
+
+	if err := database.CheckSchema(); !errors.Is(err, errs.ErrDBSchemaUnknown) {
+		t.Fatalf("expected %v, got %v", errs.ErrDBSchemaUnknown, err)
+	}
+}
+
+// the seeds are shared with postgres, so they must stay within the SQL that both understand
+func TestSeeds(t *testing.T) {
+	database := newTestDatabase(t)
+	if err := database.CheckSchema(); err != nil {
+		t.Fatalf("expected schema to be migrated: %v", err)
+	}
+
+	seedMigrator, err := database.SeedMigrator("")
+	if err != nil {
+		t.Fatalf("error getting seed migrator: %v", err)
+	}
+	defer seedMigrator.Close()
+
+	if err := seedMigrator.Up(); err != nil {
+		t.Fatalf("error migrating seeds up: %v", err)
+	}
+	amtPermits, err := database.PermitRepo().SelectCountWhere(context.Background(), models.Permit{})
+	if err != nil {
+		t.Fatalf("error counting permits: %v", err)
+	} else if amtPermits == 0 {
+		t.Fatalf("expected seeds to create permits")
+	}
+
+	if err := seedMigrator.Down(); err != nil {
+		t.Fatalf("error migrating seeds down: %v", err)
+	}
+	amtResidents, err := database.ResidentRepo().SelectCountWhere(context.Background(), models.Resident{})
+	if err != nil {
+		t.Fatalf("error counting residents: %v", err)
+	} else if amtResidents != 0 {
+		t.Fatalf("expected seeds to be removed, got %d residents", amtResidents)
+	}
+}
@@ -0,0 +1,32 @@
+package sqlrepo
+
+import (
+	"database/sql"
+
+	"github.com/dannyvelas/parkspot-backend/i18n"
+	"github.com/dannyvelas/parkspot-backend/models"
+)
+
+type admin struct {
+	ID            string         `db:"id"`
+	FirstName     string         `db:"first_name"`
+	LastName      string         `db:"last_name"`
+	Email         string         `db:"email"`
+	Password      string         `db:"password"`
+	IsPrivileged  bool           `db:"is_privileged"`
+	PreferredLang sql.NullString `db:"preferred_lang"`
+	TokenVersion  int            `db:"token_version"`
+}
+
+func (admin admin) toModels() models.Admin {
+	return models.NewAdmin(
+		admin.ID,
+		admin.FirstName,
+		admin.LastName,
+		admin.Email,
+		admin.Password,
+		admin.IsPrivileged,
+		i18n.Lang(admin.PreferredLang.String),
+		admin.TokenVersion,
+	)
+}
@@ -1,8 +1,10 @@
-package psql
+package sqlrepo
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/Masterminds/squirrel"
 	"github.com/dannyvelas/parkspot-backend/errs"
@@ -12,27 +14,27 @@ import (
 )
 
 type AdminRepo struct {
-	driver *sqlx.DB
+	driver timeoutDB
 }
 
-func NewAdminRepo(driver *sqlx.DB) storage.AdminRepo {
-	return AdminRepo{driver}
+func NewAdminRepo(driver *sqlx.DB, dialect Dialect, queryTimeout time.Duration) storage.AdminRepo {
+	return AdminRepo{newTimeoutDB(driver, dialect, queryTimeout)}
 }
 
-func (adminRepo AdminRepo) GetOne(id string) (models.Admin, error) {
+func (adminRepo AdminRepo) GetOne(ctx context.Context, id string) (models.Admin, error) {
 	if id == "" {
 		return models.Admin{}, fmt.Errorf("admin_repo.GetOne: %w: Empty ID", errs.ErrDBInvalidArg)
 	}
 
 	const query = `
     SELECT
-      id, first_name, last_name, email, password, is_privileged, token_version
+      id, first_name, last_name, email, password, is_privileged, preferred_lang, token_version
     FROM admin
-    WHERE LOWER(id) = LOWER($1)
+    WHERE LOWER(id) = LOWER(?)
   `
 
 	var admin admin
-	err := adminRepo.driver.Get(&admin, query, id)
+	err := adminRepo.driver.GetContext(ctx, &admin, query, id)
 	if err == sql.ErrNoRows {
 		return models.Admin{}, fmt.Errorf("admin_repo.GetOne: %w", errs.NewNotFound("admin"))
 	} else if err != nil {
@@ -42,7 +44,7 @@ func (adminRepo AdminRepo) GetOne(id string) (models.Admin, error) {
 	return admin.toModels(), nil
 }
 
-func (adminRepo AdminRepo) Update(adminFields models.Admin) error {
+func (adminRepo AdminRepo) Update(ctx context.Context, adminFields models.Admin) error {
 	adminUpdate := stmtBuilder.Update("admin").SetMap(rmEmptyVals(squirrel.Eq{
 		"first_name":    adminFields.FirstName,
 		"last_name":     adminFields.LastName,
@@ -50,6 +52,9 @@ func (adminRepo AdminRepo) Update(adminFields models.Admin) error {
 		"password":      adminFields.Password,
 		"is_privileged": adminFields.IsPrivileged,
 	}))
+	if adminFields.PreferredLang != "" {
+		adminUpdate = adminUpdate.Set("preferred_lang", adminFields.PreferredLang)
+	}
 	if adminFields.TokenVersion != nil {
 		adminUpdate = adminUpdate.Set("token_version", *adminFields.TokenVersion)
 	}
@@ -59,7 +64,7 @@ func (adminRepo AdminRepo) Update(adminFields models.Admin) error {
 		return fmt.Errorf("admin_repo.Update: %w: %v", errs.ErrDBBuildingQuery, err)
 	}
 
-	_, err = adminRepo.driver.Exec(query, args...)
+	_, err = adminRepo.driver.ExecContext(ctx, query, args...)
 	if err != nil {
 		return fmt.Errorf("admin_repo.Update: %w: %v", errs.ErrDBExec, err)
 	}
@@ -67,23 +72,24 @@ func (adminRepo AdminRepo) Update(adminFields models.Admin) error {
 	return nil
 }
 
-func (adminRepo AdminRepo) Create(desiredAdmin models.Admin) error {
+func (adminRepo AdminRepo) Create(ctx context.Context, desiredAdmin models.Admin) error {
 	sq := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
 	query, args, err := sq.
 		Insert("admin").
 		SetMap(squirrel.Eq{
-			"id":            desiredAdmin.ID,
-			"first_name":    desiredAdmin.FirstName,
-			"last_name":     desiredAdmin.LastName,
-			"email":         desiredAdmin.Email,
-			"password":      desiredAdmin.Password,
-			"is_privileged": desiredAdmin.IsPrivileged,
+			"id":             desiredAdmin.ID,
+			"first_name":     desiredAdmin.FirstName,
+			"last_name":      desiredAdmin.LastName,
+			"email":          desiredAdmin.Email,
+			"password":       desiredAdmin.Password,
+			"is_privileged":  desiredAdmin.IsPrivileged,
+			"preferred_lang": toNullString(string(desiredAdmin.PreferredLang)),
 		}).ToSql()
 	if err != nil {
 		return fmt.Errorf("admin_repo.Create: %w: %v", errs.ErrDBBuildingQuery, err)
 	}
 
-	_, err = adminRepo.driver.Exec(query, args...)
+	_, err = adminRepo.driver.ExecContext(ctx, query, args...)
 	if err != nil {
 		return fmt.Errorf("admin_repo.Create: %w: %v", errs.ErrDBExec, err)
 	}
@@ -91,10 +97,10 @@ func (adminRepo AdminRepo) Create(desiredAdmin models.Admin) error {
 	return nil
 }
 
-func (adminRepo AdminRepo) Delete(id string) error {
-	const query = `DELETE FROM admin WHERE id = $1`
+func (adminRepo AdminRepo) Delete(ctx context.Context, id string) error {
+	const query = `DELETE FROM admin WHERE id = ?`
 
-	res, err := adminRepo.driver.Exec(query, id)
+	res, err := adminRepo.driver.ExecContext(ctx, query, id)
 	if err != nil {
 		return fmt.Errorf("admin_repo.Delete: %w: %v", errs.ErrDBExec, err)
 	}
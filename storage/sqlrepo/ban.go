@@ -0,0 +1,48 @@
+package sqlrepo
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/dannyvelas/parkspot-backend/models"
+)
+
+type ban struct {
+	ID           string         `db:"id"`
+	LicensePlate sql.NullString `db:"license_plate"`
+	FirstName    sql.NullString `db:"first_name"`
+	LastName     sql.NullString `db:"last_name"`
+	Reason       string         `db:"reason"`
+	CreatedBy    string         `db:"created_by"`
+	CreatedTS    int64          `db:"created_ts"`
+	ExpiresTS    sql.NullInt64  `db:"expires_ts"`
+}
+
+func (ban ban) toModels() models.Ban {
+	var expiresAt *time.Time
+	if ban.ExpiresTS.Valid {
+		t := time.Unix(ban.ExpiresTS.Int64, 0)
+		expiresAt = &t
+	}
+
+	return models.NewBan(
+		ban.ID,
+		ban.LicensePlate.String,
+		ban.FirstName.String,
+		ban.LastName.String,
+		ban.Reason,
+		ban.CreatedBy,
+		time.Unix(ban.CreatedTS, 0), // time.Unix() returns time in local tz
+		expiresAt,
+	)
+}
+
+type banSlice []ban
+
+func (bans banSlice) toModels() []models.Ban {
+	modelsBans := make([]models.Ban, 0, len(bans))
+	for _, ban := range bans {
+		modelsBans = append(modelsBans, ban.toModels())
+	}
+	return modelsBans
+}
@@ -0,0 +1,216 @@
+package sqlrepo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/dannyvelas/parkspot-backend/errs"
+	"github.com/dannyvelas/parkspot-backend/models"
+	"github.com/dannyvelas/parkspot-backend/storage"
+	"github.com/dannyvelas/parkspot-backend/storage/selectopts"
+	"github.com/jmoiron/sqlx"
+)
+
+type BanRepo struct {
+	driver      timeoutDB
+	dialect     Dialect
+	banSelect   squirrel.SelectBuilder
+	countSelect squirrel.SelectBuilder
+}
+
+func NewBanRepo(driver *sqlx.DB, dialect Dialect, queryTimeout time.Duration) storage.BanRepo {
+	banSelect := stmtBuilder.Select(
+		"ban.id",
+		"ban.license_plate",
+		"ban.first_name",
+		"ban.last_name",
+		"ban.reason",
+		"ban.created_by",
+		"ban.created_ts",
+		"ban.expires_ts",
+	).From("ban")
+	countSelect := stmtBuilder.Select("count(*)").From("ban")
+
+	return BanRepo{
+		driver:      newTimeoutDB(driver, dialect, queryTimeout),
+		dialect:     dialect,
+		banSelect:   banSelect,
+		countSelect: countSelect,
+	}
+}
+
+func (banRepo BanRepo) SelectWhere(ctx context.Context, banFields models.Ban, selectOpts ...selectopts.SelectOpt) ([]models.Ban, error) {
+	selector := banRepo.banSelect
+	for _, opt := range selectOpts {
+		selector = opt.Dispatch(banRepo, selector)
+	}
+
+	banSelect := selector.Where(banWhere(banFields)).OrderBy("ban.created_ts DESC")
+
+	query, args, err := banSelect.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("ban_repo.SelectWhere: %w: %v", errs.ErrDBBuildingQuery, err)
+	}
+
+	bans := banSlice{}
+	err = banRepo.driver.SelectContext(ctx, &bans, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("ban_repo.SelectWhere: %w: %v", errs.ErrDBQuery, err)
+	}
+
+	return bans.toModels(), nil
+}
+
+func (banRepo BanRepo) SelectCountWhere(ctx context.Context, banFields models.Ban, selectOpts ...selectopts.SelectOpt) (int, error) {
+	selector := banRepo.countSelect
+	for _, opt := range selectOpts {
+		selector = opt.Dispatch(banRepo, selector)
+	}
+
+	query, args, err := selector.Where(banWhere(banFields)).ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("ban_repo.SelectCountWhere: %w: %v", errs.ErrDBBuildingQuery, err)
+	}
+
+	var totalAmount int
+	err = banRepo.driver.GetContext(ctx, &totalAmount, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("ban_repo.SelectCountWhere: %w: %v", errs.ErrDBQuery, err)
+	}
+
+	return totalAmount, nil
+}
+
+func (banRepo BanRepo) GetOne(ctx context.Context, id string) (models.Ban, error) {
+	query, args, err := banRepo.banSelect.Where("ban.id = ?", id).ToSql()
+	if err != nil {
+		return models.Ban{}, fmt.Errorf("ban_repo.GetOne: %w: %v", errs.ErrDBBuildingQuery, err)
+	}
+
+	ban := ban{}
+	err = banRepo.driver.GetContext(ctx, &ban, query, args...)
+	if err == sql.ErrNoRows {
+		return models.Ban{}, fmt.Errorf("ban_repo.GetOne: %w", errs.NewNotFound("ban"))
+	} else if err != nil {
+		return models.Ban{}, fmt.Errorf("ban_repo.GetOne: %w: %v", errs.ErrDBQuery, err)
+	}
+
+	return ban.toModels(), nil
+}
+
+func (banRepo BanRepo) Create(ctx context.Context, desiredBan models.Ban) (string, error) {
+	nullableLicensePlate := sql.NullString{}
+	if desiredBan.LicensePlate != "" {
+		nullableLicensePlate = sql.NullString{String: desiredBan.LicensePlate, Valid: true}
+	}
+	nullableFirstName := sql.NullString{}
+	if desiredBan.FirstName != "" {
+		nullableFirstName = sql.NullString{String: desiredBan.FirstName, Valid: true}
+	}
+	nullableLastName := sql.NullString{}
+	if desiredBan.LastName != "" {
+		nullableLastName = sql.NullString{String: desiredBan.LastName, Valid: true}
+	}
+	nullableExpiresTS := sql.NullInt64{}
+	if desiredBan.ExpiresAt != nil {
+		nullableExpiresTS = sql.NullInt64{Int64: desiredBan.ExpiresAt.Unix(), Valid: true}
+	}
+
+	query, args, err := stmtBuilder.
+		Insert("ban").
+		SetMap(squirrel.Eq{
+			"license_plate": nullableLicensePlate,
+			"first_name":    nullableFirstName,
+			"last_name":     nullableLastName,
+			"reason":        desiredBan.Reason,
+			"created_by":    desiredBan.CreatedBy,
+			"created_ts":    desiredBan.CreatedAt.Unix(),
+			"expires_ts":    nullableExpiresTS,
+		}).
+		Suffix("RETURNING ban.id").
+		ToSql()
+	if err != nil {
+		return "", fmt.Errorf("ban_repo.Create: %w: %v", errs.ErrDBBuildingQuery, err)
+	}
+
+	var banID string
+	err = banRepo.driver.GetContext(ctx, &banID, query, args...)
+	if err != nil {
+		return "", fmt.Errorf("ban_repo.Create: %w: %v", errs.ErrDBExec, err)
+	}
+
+	return banID, nil
+}
+
+func (banRepo BanRepo) Delete(ctx context.Context, id string) error {
+	const query = `DELETE FROM ban WHERE id = ?`
+
+	res, err := banRepo.driver.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("ban_repo.Delete: %w: %v", errs.ErrDBExec, err)
+	}
+
+	if rowsAffected, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("ban_repo.Delete: %w: %v", errs.ErrDBGetRowsAffected, err)
+	} else if rowsAffected == 0 {
+		return fmt.Errorf("ban_repo.Delete: %w", errs.NewNotFound("ban"))
+	}
+
+	return nil
+}
+
+func (banRepo BanRepo) Reset(ctx context.Context) error {
+	_, err := banRepo.driver.ExecContext(ctx, "DELETE FROM ban")
+	if err != nil {
+		return fmt.Errorf("ban_repo.Reset: %w: %v", errs.ErrDBExec, err)
+	}
+
+	return nil
+}
+
+// StatusAsSQL implements selectopts.StatusRepo. an active ban is one that has not expired. like the statuses of
+// permits, it uses the clock of this process instead of the one of the database
+func (banRepo BanRepo) StatusAsSQL(status models.Status) (squirrel.Sqlizer, bool) {
+	now := time.Now().Unix()
+	statusToSQL := map[models.Status]squirrel.Sqlizer{
+		models.ActiveStatus:  squirrel.Expr("(ban.expires_ts IS NULL OR ban.expires_ts > ?)", now),
+		models.ExpiredStatus: squirrel.Expr("ban.expires_ts <= ?", now),
+	}
+
+	whereSQL, ok := statusToSQL[status]
+	return whereSQL, ok
+}
+
+func (banRepo BanRepo) SearchAsSQL(query string) squirrel.Sqlizer {
+	lcQuery := strings.ToLower(query)
+	return squirrel.Or{
+		squirrel.Expr("LOWER(ban.license_plate) = ?", lcQuery),
+		squirrel.Expr("LOWER(ban.first_name) = ?", lcQuery),
+		squirrel.Expr("LOWER(ban.last_name) = ?", lcQuery),
+	}
+}
+
+// LicensePlateAsSQL implements selectopts.LicensePlateRepo
+func (banRepo BanRepo) LicensePlateAsSQL(normalizedLicensePlate string) squirrel.Sqlizer {
+	return squirrel.Expr(banRepo.dialect.NormalizedLicensePlate("ban.license_plate")+" = ?", normalizedLicensePlate)
+}
+
+// helpers
+
+// banWhere matches visitor names case-insensitively, since they are typed in by hand
+func banWhere(banFields models.Ban) squirrel.And {
+	where := squirrel.And{rmEmptyVals(squirrel.Eq{
+		"ban.created_by": banFields.CreatedBy,
+	})}
+	if banFields.FirstName != "" {
+		where = append(where, squirrel.Expr("LOWER(ban.first_name) = ?", strings.ToLower(strings.TrimSpace(banFields.FirstName))))
+	}
+	if banFields.LastName != "" {
+		where = append(where, squirrel.Expr("LOWER(ban.last_name) = ?", strings.ToLower(strings.TrimSpace(banFields.LastName))))
+	}
+	return where
+}
@@ -1,4 +1,4 @@
-package psql
+package sqlrepo
 
 import (
 	"database/sql"
@@ -13,6 +13,7 @@ type car struct {
 	Make               sql.NullString `db:"make"`
 	Model              sql.NullString `db:"model"`
 	AmtParkingDaysUsed int            `db:"amt_parking_days_used"`
+	Version            int            `db:"version"`
 }
 
 func (car car) toModels() models.Car {
@@ -23,7 +24,8 @@ func (car car) toModels() models.Car {
 		car.Color,
 		car.Make.String,
 		car.Model.String,
-		car.AmtParkingDaysUsed)
+		car.AmtParkingDaysUsed,
+		car.Version)
 }
 
 type carSlice []car
@@ -0,0 +1,265 @@
+package sqlrepo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/dannyvelas/parkspot-backend/errs"
+	"github.com/dannyvelas/parkspot-backend/models"
+	"github.com/dannyvelas/parkspot-backend/storage"
+	"github.com/dannyvelas/parkspot-backend/storage/selectopts"
+	"github.com/jmoiron/sqlx"
+)
+
+type CarRepo struct {
+	driver      timeoutDB
+	dialect     Dialect
+	carSelect   squirrel.SelectBuilder
+	countSelect squirrel.SelectBuilder
+}
+
+func NewCarRepo(driver *sqlx.DB, dialect Dialect, queryTimeout time.Duration) storage.CarRepo {
+	carSelect := stmtBuilder.Select(
+		"car.id",
+		"car.resident_id",
+		"car.license_plate",
+		"car.color",
+		"car.make",
+		"car.model",
+		"car.amt_parking_days_used",
+		"car.version",
+	).From("car")
+	countSelect := stmtBuilder.Select("count(*)").From("car")
+
+	return CarRepo{
+		driver:      newTimeoutDB(driver, dialect, queryTimeout),
+		dialect:     dialect,
+		carSelect:   carSelect,
+		countSelect: countSelect,
+	}
+}
+
+func (carRepo CarRepo) GetOne(ctx context.Context, id string) (models.Car, error) {
+	query, args, err := carRepo.carSelect.Where("car.id = ?", id).ToSql()
+	if err != nil {
+		return models.Car{}, fmt.Errorf("car_repo.GetOne: %w: %v", errs.ErrDBBuildingQuery, err)
+	}
+
+	car := car{}
+	err = carRepo.driver.GetContext(ctx, &car, query, args...)
+	if err == sql.ErrNoRows {
+		return models.Car{}, fmt.Errorf("car_repo.GetOne: %w", errs.NewNotFound("car"))
+	} else if err != nil {
+		return models.Car{}, fmt.Errorf("car_repo.GetOne: %w: %v", errs.ErrDBQuery, err)
+	}
+
+	return car.toModels(), nil
+}
+
+func (carRepo CarRepo) SelectWhere(ctx context.Context, carFields models.Car, selectOpts ...selectopts.SelectOpt) ([]models.Car, error) {
+	selector := carRepo.carSelect
+	for _, opt := range selectOpts {
+		selector = opt.Dispatch(carRepo, selector)
+	}
+
+	carSelect := selector.Where(rmEmptyVals(squirrel.Eq{
+		"resident_id":   carFields.ResidentID,
+		"license_plate": carFields.LicensePlate,
+		"color":         carFields.Color,
+		"make":          carFields.Make,
+		"model":         carFields.Model,
+	}))
+	query, args, err := carSelect.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("car_repo.SelectWhere: %w: %v", errs.ErrDBBuildingQuery, err)
+	}
+
+	cars := carSlice{}
+	err = carRepo.driver.SelectContext(ctx, &cars, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("car_repo.SelectWhere: %w: %v", errs.ErrDBQuery, err)
+	}
+
+	return cars.toModels(), nil
+}
+
+func (carRepo CarRepo) SelectCountWhere(ctx context.Context, carFields models.Car, selectOpts ...selectopts.SelectOpt) (int, error) {
+	selector := carRepo.countSelect
+	for _, opt := range selectOpts {
+		selector = opt.Dispatch(carRepo, selector)
+	}
+
+	countSelect := selector.Where(rmEmptyVals(squirrel.Eq{
+		"resident_id":   carFields.ResidentID,
+		"license_plate": carFields.LicensePlate,
+		"color":         carFields.Color,
+		"make":          carFields.Make,
+		"model":         carFields.Model,
+	}))
+	query, args, err := countSelect.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("car_repo.SelectCount: %w: %v", errs.ErrDBBuildingQuery, err)
+	}
+
+	var totalAmount int
+	err = carRepo.driver.GetContext(ctx, &totalAmount, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("car_repo.GetCount: %w: %v", errs.ErrDBQuery, err)
+	}
+
+	return totalAmount, nil
+}
+
+func (carRepo CarRepo) Create(ctx context.Context, desiredCar models.Car) (string, error) {
+	query, args, err := carInsert(desiredCar).Suffix("RETURNING id").ToSql()
+	if err != nil {
+		return "", fmt.Errorf("car_repo.Create: %w: %v", errs.ErrDBBuildingQuery, err)
+	}
+
+	var id string
+	err = carRepo.driver.GetContext(ctx, &id, query, args...)
+	if err != nil {
+		return "", fmt.Errorf("car_repo.Create: %w: %v", errs.ErrDBExec, err)
+	}
+
+	return id, nil
+}
+
+func carInsert(desiredCar models.Car) squirrel.InsertBuilder {
+	updateMap := make(squirrel.Eq)
+	if desiredCar.ID != "" {
+		updateMap["id"] = desiredCar.ID
+	}
+	updateMap["resident_id"] = desiredCar.ResidentID
+	updateMap["license_plate"] = desiredCar.LicensePlate
+	updateMap["color"] = desiredCar.Color
+	updateMap["make"] = desiredCar.Make
+	updateMap["model"] = desiredCar.Model
+
+	return stmtBuilder.Insert("car").SetMap(updateMap)
+}
+
+func (carRepo CarRepo) AddToAmtParkingDaysUsed(ctx context.Context, id string, days int) error {
+	const query = `
+    UPDATE car SET amt_parking_days_used = amt_parking_days_used + ?
+    WHERE id = ?
+  `
+
+	_, err := carRepo.driver.ExecContext(ctx, query, days, id)
+	if err != nil {
+		return fmt.Errorf("car_repo.AddToAmtParkingDaysUsed: %w: %v", errs.ErrDBExec, err)
+	}
+
+	return nil
+}
+
+func (carRepo CarRepo) Update(ctx context.Context, carFields models.Car) error {
+	carUpdate := stmtBuilder.Update("car").SetMap(rmEmptyVals(squirrel.Eq{
+		"license_plate": carFields.LicensePlate,
+		"color":         carFields.Color,
+		"make":          carFields.Make,
+		"model":         carFields.Model,
+	}))
+	if carFields.AmtParkingDaysUsed != nil {
+		carUpdate = carUpdate.Set("amt_parking_days_used", *carFields.AmtParkingDaysUsed)
+	}
+
+	carUpdate = carUpdate.Where("car.id = ?", carFields.ID)
+	// only the edits of users are made to a version. updates of the service itself, like of the parking days
+	// used, leave the version alone, so that they don't make the edits of users conflict
+	if carFields.Version != 0 {
+		carUpdate = carUpdate.Set("version", squirrel.Expr("version + 1")).Where("car.version = ?", carFields.Version)
+	}
+
+	query, args, err := carUpdate.ToSql()
+	if err != nil {
+		return fmt.Errorf("car_repo.Update: %w: %v", errs.ErrDBBuildingQuery, err)
+	}
+
+	res, err := carRepo.driver.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("car_repo.Update: %w: %v", errs.ErrDBExec, err)
+	}
+
+	// an edit of a version that isn't the one in the database changes no rows
+	if carFields.Version == 0 {
+		return nil
+	} else if rowsAffected, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("car_repo.Update: %w: %v", errs.ErrDBGetRowsAffected, err)
+	} else if rowsAffected != 0 {
+		return nil
+	}
+
+	if _, err := carRepo.GetOne(ctx, carFields.ID); err != nil {
+		return fmt.Errorf("car_repo.Update: %w", err)
+	}
+	return fmt.Errorf("car_repo.Update: %w", errs.NewConflict("car"))
+}
+
+func (carRepo CarRepo) Delete(ctx context.Context, id string) error {
+	const query = `DELETE FROM car WHERE id = ?`
+
+	_, err := carRepo.driver.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("car_repo.Delete: %w: %v", errs.ErrDBExec, err)
+	}
+
+	return nil
+}
+
+func (carRepo CarRepo) Reset(ctx context.Context) error {
+	_, err := carRepo.driver.ExecContext(ctx, "DELETE FROM car")
+	if err != nil {
+		return fmt.Errorf("car_repo.Reset: %w: %v", errs.ErrDBExec, err)
+	}
+
+	return nil
+}
+
+// carSearchDocument must stay equal to the expression of car_search_idx of the postgres migrations
+const carSearchDocument = "LOWER(car.resident_id || ' ' || car.license_plate || ' ' || car.color || ' ' || " +
+	"COALESCE(car.make, '') || ' ' || COALESCE(car.model, ''))"
+
+// SearchAsSQL implements selectops.Repo
+func (carRepo CarRepo) SearchAsSQL(query string) squirrel.Sqlizer {
+	return carRepo.dialect.searchAsSQL(carSearchDocument, query)
+}
+
+// SearchRankAsSQL implements selectopts.RankRepo
+func (carRepo CarRepo) SearchRankAsSQL(query string) squirrel.Sqlizer {
+	return carRepo.dialect.searchRankAsSQL(carSearchDocument, query)
+}
+
+// LicensePlateAsSQL implements selectopts.LicensePlateRepo
+func (carRepo CarRepo) LicensePlateAsSQL(normalizedLicensePlate string) squirrel.Sqlizer {
+	return squirrel.Expr(carRepo.dialect.NormalizedLicensePlate("car.license_plate")+" = ?", normalizedLicensePlate)
+}
+
+// SortColumns implements selectopts.SortRepo
+func (carRepo CarRepo) SortColumns() map[string]string {
+	return map[string]string{
+		"licensePlate": "license_plate",
+		"residentID":   "resident_id",
+		"color":        "color",
+	}
+}
+
+// Table implements selectopts.SortRepo
+func (carRepo CarRepo) Table() string {
+	return "car"
+}
+
+// FilterFields implements selectopts.FilterRepo
+func (carRepo CarRepo) FilterFields() map[string]selectopts.FilterField {
+	return map[string]selectopts.FilterField{
+		"residentID":         {Column: "car.resident_id", Type: selectopts.StringField},
+		"licensePlate":       {Column: "car.license_plate", Type: selectopts.StringField},
+		"color":              {Column: "car.color", Type: selectopts.StringField},
+		"make":               {Column: "car.make", Type: selectopts.StringField},
+		"model":              {Column: "car.model", Type: selectopts.StringField},
+		"amtParkingDaysUsed": {Column: "car.amt_parking_days_used", Type: selectopts.IntField},
+	}
+}
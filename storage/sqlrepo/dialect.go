@@ -0,0 +1,29 @@
+package sqlrepo
+
+import (
+	"database/sql"
+	"database/sql/driver"
+
+	"github.com/Masterminds/squirrel"
+)
+
+// Dialect is what the queries of the repos need that differs between database engines
+type Dialect struct {
+	// Placeholder is the format that the ? placeholders of queries are written in before they run, like $1
+	Placeholder squirrel.PlaceholderFormat
+	// NormalizedLicensePlate is the SQL counterpart of util.NormalizeLicensePlate, of the value of column
+	NormalizedLicensePlate func(column string) string
+	// LikeEscape is the ESCAPE clause of LIKE patterns, whose wildcards are escaped with a backslash. it is empty
+	// when backslashes escape wildcards without one
+	LikeEscape string
+	// Similarity is a function of two strings that returns how similar they are, from 0 to 1
+	Similarity string
+	// StringList turns a list of strings into a value of a column, and scans it back
+	StringList func(list *[]string) StringList
+}
+
+// StringList is a list of strings that is stored in one column
+type StringList interface {
+	sql.Scanner
+	driver.Valuer
+}
@@ -0,0 +1,3 @@
+// Package sqlrepo implements the repos of the storage interface with SQL. the queries are written once, and the
+// parts of them that differ between database engines are taken from a Dialect, like storage/psql.Dialect.
+package sqlrepo
@@ -0,0 +1,55 @@
+package sqlrepo
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/dannyvelas/parkspot-backend/models"
+)
+
+type gateEvent struct {
+	ID           string         `db:"id"`
+	ResidentID   string         `db:"resident_id"`
+	VisitorID    sql.NullString `db:"visitor_id"`
+	PermitID     sql.NullInt64  `db:"permit_id"`
+	LicensePlate sql.NullString `db:"license_plate"`
+	RecordedBy   string         `db:"recorded_by"`
+	CheckInTS    int64          `db:"check_in_ts"`
+	CheckOutTS   sql.NullInt64  `db:"check_out_ts"`
+	AllowedUntil sql.NullInt64  `db:"allowed_until"`
+}
+
+func (gateEvent gateEvent) toModels() models.GateEvent {
+	var checkOut *time.Time
+	if gateEvent.CheckOutTS.Valid {
+		checkOutTime := time.Unix(gateEvent.CheckOutTS.Int64, 0)
+		checkOut = &checkOutTime
+	}
+
+	var allowedUntil time.Time
+	if gateEvent.AllowedUntil.Valid {
+		allowedUntil = time.Unix(gateEvent.AllowedUntil.Int64, 0)
+	}
+
+	return models.NewGateEvent(
+		gateEvent.ID,
+		gateEvent.ResidentID,
+		gateEvent.VisitorID.String,
+		int(gateEvent.PermitID.Int64),
+		gateEvent.LicensePlate.String,
+		gateEvent.RecordedBy,
+		time.Unix(gateEvent.CheckInTS, 0), // time.Unix() returns time in local tz
+		checkOut,
+		allowedUntil,
+	)
+}
+
+type gateEventSlice []gateEvent
+
+func (gateEvents gateEventSlice) toModels() []models.GateEvent {
+	modelsGateEvents := make([]models.GateEvent, 0, len(gateEvents))
+	for _, gateEvent := range gateEvents {
+		modelsGateEvents = append(modelsGateEvents, gateEvent.toModels())
+	}
+	return modelsGateEvents
+}
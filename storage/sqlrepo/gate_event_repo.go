@@ -0,0 +1,221 @@
+package sqlrepo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/dannyvelas/parkspot-backend/errs"
+	"github.com/dannyvelas/parkspot-backend/models"
+	"github.com/dannyvelas/parkspot-backend/storage"
+	"github.com/dannyvelas/parkspot-backend/storage/selectopts"
+	"github.com/jmoiron/sqlx"
+)
+
+type GateEventRepo struct {
+	driver          timeoutDB
+	dialect         Dialect
+	gateEventSelect squirrel.SelectBuilder
+	countSelect     squirrel.SelectBuilder
+}
+
+func NewGateEventRepo(driver *sqlx.DB, dialect Dialect, queryTimeout time.Duration) storage.GateEventRepo {
+	// a gate event is joined with the pass it was recorded against,
+	// so that we know until when the guest is allowed to be on the property
+	gateEventSelect := stmtBuilder.Select(
+		"gate_event.id",
+		"gate_event.resident_id",
+		"gate_event.visitor_id",
+		"gate_event.permit_id",
+		"gate_event.license_plate",
+		"gate_event.recorded_by",
+		"gate_event.check_in_ts",
+		"gate_event.check_out_ts",
+		"COALESCE(visitor.access_end, permit.end_ts) AS allowed_until",
+	).
+		From("gate_event").
+		LeftJoin("visitor ON visitor.id = gate_event.visitor_id").
+		LeftJoin("permit ON permit.id = gate_event.permit_id")
+	countSelect := stmtBuilder.Select("count(*)").
+		From("gate_event").
+		LeftJoin("visitor ON visitor.id = gate_event.visitor_id").
+		LeftJoin("permit ON permit.id = gate_event.permit_id")
+
+	return GateEventRepo{
+		driver:          newTimeoutDB(driver, dialect, queryTimeout),
+		dialect:         dialect,
+		gateEventSelect: gateEventSelect,
+		countSelect:     countSelect,
+	}
+}
+
+func (gateEventRepo GateEventRepo) SelectWhere(ctx context.Context, gateEventFields models.GateEvent, selectOpts ...selectopts.SelectOpt) ([]models.GateEvent, error) {
+	selector := gateEventRepo.gateEventSelect
+	for _, opt := range selectOpts {
+		selector = opt.Dispatch(gateEventRepo, selector)
+	}
+
+	gateEventSelect := selector.Where(rmEmptyVals(squirrel.Eq{
+		"gate_event.id":            gateEventFields.ID,
+		"gate_event.resident_id":   gateEventFields.ResidentID,
+		"gate_event.visitor_id":    gateEventFields.VisitorID,
+		"gate_event.permit_id":     gateEventFields.PermitID,
+		"gate_event.license_plate": gateEventFields.LicensePlate,
+	})).OrderBy("gate_event.check_in_ts DESC")
+
+	query, args, err := gateEventSelect.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("gate_event_repo.SelectWhere: %w: %v", errs.ErrDBBuildingQuery, err)
+	}
+
+	gateEvents := gateEventSlice{}
+	err = gateEventRepo.driver.SelectContext(ctx, &gateEvents, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("gate_event_repo.SelectWhere: %w: %v", errs.ErrDBQuery, err)
+	}
+
+	return gateEvents.toModels(), nil
+}
+
+func (gateEventRepo GateEventRepo) SelectCountWhere(ctx context.Context, gateEventFields models.GateEvent, selectOpts ...selectopts.SelectOpt) (int, error) {
+	selector := gateEventRepo.countSelect
+	for _, opt := range selectOpts {
+		selector = opt.Dispatch(gateEventRepo, selector)
+	}
+
+	countSelect := selector.Where(rmEmptyVals(squirrel.Eq{
+		"gate_event.id":            gateEventFields.ID,
+		"gate_event.resident_id":   gateEventFields.ResidentID,
+		"gate_event.visitor_id":    gateEventFields.VisitorID,
+		"gate_event.permit_id":     gateEventFields.PermitID,
+		"gate_event.license_plate": gateEventFields.LicensePlate,
+	}))
+
+	query, args, err := countSelect.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("gate_event_repo.SelectCountWhere: %w: %v", errs.ErrDBBuildingQuery, err)
+	}
+
+	var totalAmount int
+	err = gateEventRepo.driver.GetContext(ctx, &totalAmount, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("gate_event_repo.SelectCountWhere: %w: %v", errs.ErrDBQuery, err)
+	}
+
+	return totalAmount, nil
+}
+
+func (gateEventRepo GateEventRepo) GetOne(ctx context.Context, id string) (models.GateEvent, error) {
+	query, args, err := gateEventRepo.gateEventSelect.Where("gate_event.id = ?", id).ToSql()
+	if err != nil {
+		return models.GateEvent{}, fmt.Errorf("gate_event_repo.GetOne: %w: %v", errs.ErrDBBuildingQuery, err)
+	}
+
+	gateEvent := gateEvent{}
+	err = gateEventRepo.driver.GetContext(ctx, &gateEvent, query, args...)
+	if err == sql.ErrNoRows {
+		return models.GateEvent{}, fmt.Errorf("gate_event_repo.GetOne: %w", errs.NewNotFound("gate event"))
+	} else if err != nil {
+		return models.GateEvent{}, fmt.Errorf("gate_event_repo.GetOne: %w: %v", errs.ErrDBQuery, err)
+	}
+
+	return gateEvent.toModels(), nil
+}
+
+func (gateEventRepo GateEventRepo) Create(ctx context.Context, desiredGateEvent models.GateEvent) (string, error) {
+	// visitors arrive without a license plate and permits arrive without a visitor id
+	nullableVisitorID := sql.NullString{}
+	if desiredGateEvent.VisitorID != "" {
+		nullableVisitorID = sql.NullString{String: desiredGateEvent.VisitorID, Valid: true}
+	}
+	nullablePermitID := sql.NullInt64{}
+	if desiredGateEvent.PermitID != 0 {
+		nullablePermitID = sql.NullInt64{Int64: int64(desiredGateEvent.PermitID), Valid: true}
+	}
+	nullableLicensePlate := sql.NullString{}
+	if desiredGateEvent.LicensePlate != "" {
+		nullableLicensePlate = sql.NullString{String: desiredGateEvent.LicensePlate, Valid: true}
+	}
+
+	query, args, err := stmtBuilder.
+		Insert("gate_event").
+		SetMap(squirrel.Eq{
+			"resident_id":   desiredGateEvent.ResidentID,
+			"visitor_id":    nullableVisitorID,
+			"permit_id":     nullablePermitID,
+			"license_plate": nullableLicensePlate,
+			"recorded_by":   desiredGateEvent.RecordedBy,
+			"check_in_ts":   desiredGateEvent.CheckIn.Unix(),
+		}).
+		Suffix("RETURNING gate_event.id").
+		ToSql()
+	if err != nil {
+		return "", fmt.Errorf("gate_event_repo.Create: %w: %v", errs.ErrDBBuildingQuery, err)
+	}
+
+	var gateEventID string
+	err = gateEventRepo.driver.GetContext(ctx, &gateEventID, query, args...)
+	if err != nil {
+		return "", fmt.Errorf("gate_event_repo.Create: %w: %v", errs.ErrDBExec, err)
+	}
+
+	return gateEventID, nil
+}
+
+func (gateEventRepo GateEventRepo) SetCheckOut(ctx context.Context, id string, checkOut time.Time) error {
+	const query = `UPDATE gate_event SET check_out_ts = ? WHERE id = ?`
+
+	res, err := gateEventRepo.driver.ExecContext(ctx, query, checkOut.Unix(), id)
+	if err != nil {
+		return fmt.Errorf("gate_event_repo.SetCheckOut: %w: %v", errs.ErrDBExec, err)
+	}
+
+	if rowsAffected, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("gate_event_repo.SetCheckOut: %w: %v", errs.ErrDBGetRowsAffected, err)
+	} else if rowsAffected == 0 {
+		return fmt.Errorf("gate_event_repo.SetCheckOut: %w", errs.NewNotFound("gate event"))
+	}
+
+	return nil
+}
+
+func (gateEventRepo GateEventRepo) Reset(ctx context.Context) error {
+	_, err := gateEventRepo.driver.ExecContext(ctx, "DELETE FROM gate_event")
+	if err != nil {
+		return fmt.Errorf("gate_event_repo.Reset: %w: %v", errs.ErrDBExec, err)
+	}
+
+	return nil
+}
+
+func (gateEventRepo GateEventRepo) SearchAsSQL(query string) squirrel.Sqlizer {
+	lcQuery := strings.ToLower(query)
+	return squirrel.Or{
+		squirrel.Expr("LOWER(gate_event.resident_id) = ?", lcQuery),
+		squirrel.Expr("LOWER(gate_event.license_plate) = ?", lcQuery),
+	}
+}
+
+// StatusAsSQL implements selectopts.StatusRepo. for gate events, active means that the guest is still on the property.
+// like the statuses of permits, overstays use the clock of this process instead of the one of the database
+func (gateEventRepo GateEventRepo) StatusAsSQL(status models.Status) (squirrel.Sqlizer, bool) {
+	now := time.Now().Unix()
+	statusToSQL := map[models.Status]squirrel.Sqlizer{
+		models.ActiveStatus: squirrel.Expr("gate_event.check_out_ts IS NULL"),
+		models.OverstayStatus: squirrel.And{
+			squirrel.Expr("gate_event.check_out_ts IS NULL"),
+			squirrel.Expr("COALESCE(visitor.access_end, permit.end_ts) < ?", now),
+		},
+	}
+
+	whereSQL, ok := statusToSQL[status]
+	return whereSQL, ok
+}
+
+// LicensePlateAsSQL implements selectopts.LicensePlateRepo
+func (gateEventRepo GateEventRepo) LicensePlateAsSQL(normalizedLicensePlate string) squirrel.Sqlizer {
+	return squirrel.Expr(gateEventRepo.dialect.NormalizedLicensePlate("gate_event.license_plate")+" = ?", normalizedLicensePlate)
+}
@@ -0,0 +1,55 @@
+package sqlrepo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dannyvelas/parkspot-backend/errs"
+	"github.com/dannyvelas/parkspot-backend/models"
+	"github.com/dannyvelas/parkspot-backend/storage"
+	"github.com/jmoiron/sqlx"
+)
+
+type ImportRepo struct {
+	driver timeoutDB
+}
+
+func NewImportRepo(driver *sqlx.DB, dialect Dialect, queryTimeout time.Duration) storage.ImportRepo {
+	return ImportRepo{driver: newTimeoutDB(driver, dialect, queryTimeout)}
+}
+
+func (importRepo ImportRepo) Import(ctx context.Context, residents []models.Resident, cars []models.Car) error {
+	tx, err := importRepo.driver.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("import_repo.Import: %w: error beginning transaction: %v", errs.ErrDBExec, err)
+	}
+	// rolling back a committed transaction does nothing
+	defer tx.Rollback()
+
+	for _, resident := range residents {
+		query, args, err := residentInsert(resident).ToSql()
+		if err != nil {
+			return fmt.Errorf("import_repo.Import: %w: %v", errs.ErrDBBuildingQuery, err)
+		}
+		if _, err := importRepo.driver.txExecContext(ctx, tx, query, args...); err != nil {
+			return fmt.Errorf("import_repo.Import: %w: error creating resident %s: %v", errs.ErrDBExec, resident.ID, err)
+		}
+	}
+
+	for _, car := range cars {
+		query, args, err := carInsert(car).ToSql()
+		if err != nil {
+			return fmt.Errorf("import_repo.Import: %w: %v", errs.ErrDBBuildingQuery, err)
+		}
+		if _, err := importRepo.driver.txExecContext(ctx, tx, query, args...); err != nil {
+			return fmt.Errorf("import_repo.Import: %w: error creating car %s: %v", errs.ErrDBExec, car.LicensePlate, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("import_repo.Import: %w: error committing transaction: %v", errs.ErrDBExec, err)
+	}
+
+	return nil
+}
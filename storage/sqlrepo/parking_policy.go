@@ -0,0 +1,23 @@
+package sqlrepo
+
+import (
+	"time"
+
+	"github.com/dannyvelas/parkspot-backend/models"
+)
+
+type parkingPolicy struct {
+	MaxParkingDays   int   `db:"max_parking_days"`
+	MaxPermitLength  int   `db:"max_permit_length"`
+	MaxActivePermits int   `db:"max_active_permits"`
+	LatestEndTS      int64 `db:"latest_end_ts"`
+}
+
+func (parkingPolicy parkingPolicy) toModels() models.ParkingPolicy {
+	return models.ParkingPolicy{
+		MaxParkingDays:   parkingPolicy.MaxParkingDays,
+		MaxPermitLength:  parkingPolicy.MaxPermitLength,
+		MaxActivePermits: parkingPolicy.MaxActivePermits,
+		LatestEndDate:    time.Unix(parkingPolicy.LatestEndTS, 0), // time.Unix() returns time in local tz
+	}
+}
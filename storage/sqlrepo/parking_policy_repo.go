@@ -0,0 +1,72 @@
+package sqlrepo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/dannyvelas/parkspot-backend/errs"
+	"github.com/dannyvelas/parkspot-backend/models"
+	"github.com/dannyvelas/parkspot-backend/storage"
+	"github.com/jmoiron/sqlx"
+)
+
+type ParkingPolicyRepo struct {
+	driver timeoutDB
+}
+
+func NewParkingPolicyRepo(driver *sqlx.DB, dialect Dialect, queryTimeout time.Duration) storage.ParkingPolicyRepo {
+	return ParkingPolicyRepo{driver: newTimeoutDB(driver, dialect, queryTimeout)}
+}
+
+// Get returns models.DefaultParkingPolicy if no parking policy has been saved yet
+func (parkingPolicyRepo ParkingPolicyRepo) Get(ctx context.Context) (models.ParkingPolicy, error) {
+	const query = `
+    SELECT max_parking_days, max_permit_length, max_active_permits, latest_end_ts
+    FROM parking_policy
+  `
+
+	parkingPolicy := parkingPolicy{}
+	err := parkingPolicyRepo.driver.GetContext(ctx, &parkingPolicy, query)
+	if err == sql.ErrNoRows {
+		return models.DefaultParkingPolicy, nil
+	} else if err != nil {
+		return models.ParkingPolicy{}, fmt.Errorf("parking_policy_repo.Get: %w: %v", errs.ErrDBQuery, err)
+	}
+
+	return parkingPolicy.toModels(), nil
+}
+
+func (parkingPolicyRepo ParkingPolicyRepo) Set(ctx context.Context, policy models.ParkingPolicy) error {
+	const query = `
+    INSERT INTO parking_policy(max_parking_days, max_permit_length, max_active_permits, latest_end_ts)
+    VALUES(?, ?, ?, ?)
+    ON CONFLICT (id) DO UPDATE SET
+      max_parking_days = EXCLUDED.max_parking_days,
+      max_permit_length = EXCLUDED.max_permit_length,
+      max_active_permits = EXCLUDED.max_active_permits,
+      latest_end_ts = EXCLUDED.latest_end_ts
+  `
+
+	_, err := parkingPolicyRepo.driver.ExecContext(ctx, query,
+		policy.MaxParkingDays,
+		policy.MaxPermitLength,
+		policy.MaxActivePermits,
+		policy.LatestEndDate.Unix(),
+	)
+	if err != nil {
+		return fmt.Errorf("parking_policy_repo.Set: %w: %v", errs.ErrDBExec, err)
+	}
+
+	return nil
+}
+
+func (parkingPolicyRepo ParkingPolicyRepo) Reset(ctx context.Context) error {
+	_, err := parkingPolicyRepo.driver.ExecContext(ctx, "DELETE FROM parking_policy")
+	if err != nil {
+		return fmt.Errorf("parking_policy_repo.Reset: %w: %v", errs.ErrDBExec, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,29 @@
+package sqlrepo
+
+import (
+	"github.com/dannyvelas/parkspot-backend/models"
+)
+
+type parkingSpace struct {
+	ID    string `db:"id"`
+	Zone  string `db:"zone"`
+	Label string `db:"label"`
+}
+
+func (parkingSpace parkingSpace) toModels() models.ParkingSpace {
+	return models.NewParkingSpace(
+		parkingSpace.ID,
+		parkingSpace.Zone,
+		parkingSpace.Label,
+	)
+}
+
+type parkingSpaceSlice []parkingSpace
+
+func (parkingSpaces parkingSpaceSlice) toModels() []models.ParkingSpace {
+	modelsParkingSpaces := make([]models.ParkingSpace, 0, len(parkingSpaces))
+	for _, parkingSpace := range parkingSpaces {
+		modelsParkingSpaces = append(modelsParkingSpaces, parkingSpace.toModels())
+	}
+	return modelsParkingSpaces
+}
@@ -0,0 +1,160 @@
+package sqlrepo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/dannyvelas/parkspot-backend/errs"
+	"github.com/dannyvelas/parkspot-backend/models"
+	"github.com/dannyvelas/parkspot-backend/storage"
+	"github.com/dannyvelas/parkspot-backend/storage/selectopts"
+	"github.com/jmoiron/sqlx"
+)
+
+type ParkingSpaceRepo struct {
+	driver             timeoutDB
+	parkingSpaceSelect squirrel.SelectBuilder
+	countSelect        squirrel.SelectBuilder
+}
+
+func NewParkingSpaceRepo(driver *sqlx.DB, dialect Dialect, queryTimeout time.Duration) storage.ParkingSpaceRepo {
+	parkingSpaceSelect := stmtBuilder.Select(
+		"parking_space.id",
+		"parking_space.zone",
+		"parking_space.label",
+	).From("parking_space")
+	countSelect := stmtBuilder.Select("count(*)").From("parking_space")
+
+	return ParkingSpaceRepo{
+		driver:             newTimeoutDB(driver, dialect, queryTimeout),
+		parkingSpaceSelect: parkingSpaceSelect,
+		countSelect:        countSelect,
+	}
+}
+
+func (parkingSpaceRepo ParkingSpaceRepo) SelectWhere(ctx context.Context, parkingSpaceFields models.ParkingSpace, selectOpts ...selectopts.SelectOpt) ([]models.ParkingSpace, error) {
+	selector := parkingSpaceRepo.parkingSpaceSelect
+	for _, opt := range selectOpts {
+		selector = opt.Dispatch(parkingSpaceRepo, selector)
+	}
+
+	parkingSpaceSelect := selector.Where(rmEmptyVals(squirrel.Eq{
+		"zone":  parkingSpaceFields.Zone,
+		"label": parkingSpaceFields.Label,
+	})).OrderBy("parking_space.zone", "parking_space.label")
+
+	query, args, err := parkingSpaceSelect.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("parking_space_repo.SelectWhere: %w: %v", errs.ErrDBBuildingQuery, err)
+	}
+
+	parkingSpaces := parkingSpaceSlice{}
+	err = parkingSpaceRepo.driver.SelectContext(ctx, &parkingSpaces, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("parking_space_repo.SelectWhere: %w: %v", errs.ErrDBQuery, err)
+	}
+
+	return parkingSpaces.toModels(), nil
+}
+
+func (parkingSpaceRepo ParkingSpaceRepo) SelectCountWhere(ctx context.Context, parkingSpaceFields models.ParkingSpace, selectOpts ...selectopts.SelectOpt) (int, error) {
+	selector := parkingSpaceRepo.countSelect
+	for _, opt := range selectOpts {
+		selector = opt.Dispatch(parkingSpaceRepo, selector)
+	}
+
+	countSelect := selector.Where(rmEmptyVals(squirrel.Eq{
+		"zone":  parkingSpaceFields.Zone,
+		"label": parkingSpaceFields.Label,
+	}))
+
+	query, args, err := countSelect.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("parking_space_repo.SelectCountWhere: %w: %v", errs.ErrDBBuildingQuery, err)
+	}
+
+	var totalAmount int
+	err = parkingSpaceRepo.driver.GetContext(ctx, &totalAmount, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("parking_space_repo.SelectCountWhere: %w: %v", errs.ErrDBQuery, err)
+	}
+
+	return totalAmount, nil
+}
+
+func (parkingSpaceRepo ParkingSpaceRepo) GetOne(ctx context.Context, id string) (models.ParkingSpace, error) {
+	query, args, err := parkingSpaceRepo.parkingSpaceSelect.Where("parking_space.id = ?", id).ToSql()
+	if err != nil {
+		return models.ParkingSpace{}, fmt.Errorf("parking_space_repo.GetOne: %w: %v", errs.ErrDBBuildingQuery, err)
+	}
+
+	parkingSpace := parkingSpace{}
+	err = parkingSpaceRepo.driver.GetContext(ctx, &parkingSpace, query, args...)
+	if err == sql.ErrNoRows {
+		return models.ParkingSpace{}, fmt.Errorf("parking_space_repo.GetOne: %w", errs.NewNotFound("parking space"))
+	} else if err != nil {
+		return models.ParkingSpace{}, fmt.Errorf("parking_space_repo.GetOne: %w: %v", errs.ErrDBQuery, err)
+	}
+
+	return parkingSpace.toModels(), nil
+}
+
+func (parkingSpaceRepo ParkingSpaceRepo) Create(ctx context.Context, desiredParkingSpace models.ParkingSpace) (string, error) {
+	query, args, err := stmtBuilder.
+		Insert("parking_space").
+		SetMap(squirrel.Eq{
+			"zone":  desiredParkingSpace.Zone,
+			"label": desiredParkingSpace.Label,
+		}).
+		Suffix("RETURNING parking_space.id").
+		ToSql()
+	if err != nil {
+		return "", fmt.Errorf("parking_space_repo.Create: %w: %v", errs.ErrDBBuildingQuery, err)
+	}
+
+	var parkingSpaceID string
+	err = parkingSpaceRepo.driver.GetContext(ctx, &parkingSpaceID, query, args...)
+	if err != nil {
+		return "", fmt.Errorf("parking_space_repo.Create: %w: %v", errs.ErrDBExec, err)
+	}
+
+	return parkingSpaceID, nil
+}
+
+func (parkingSpaceRepo ParkingSpaceRepo) Delete(ctx context.Context, id string) error {
+	const query = `DELETE FROM parking_space WHERE id = ?`
+
+	res, err := parkingSpaceRepo.driver.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("parking_space_repo.Delete: %w: %v", errs.ErrDBExec, err)
+	}
+
+	if rowsAffected, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("parking_space_repo.Delete: %w: %v", errs.ErrDBGetRowsAffected, err)
+	} else if rowsAffected == 0 {
+		return fmt.Errorf("parking_space_repo.Delete: %w", errs.NewNotFound("parking space"))
+	}
+
+	return nil
+}
+
+func (parkingSpaceRepo ParkingSpaceRepo) Reset(ctx context.Context) error {
+	_, err := parkingSpaceRepo.driver.ExecContext(ctx, "DELETE FROM parking_space")
+	if err != nil {
+		return fmt.Errorf("parking_space_repo.Reset: %w: %v", errs.ErrDBExec, err)
+	}
+
+	return nil
+}
+
+func (parkingSpaceRepo ParkingSpaceRepo) SearchAsSQL(query string) squirrel.Sqlizer {
+	lcQuery := strings.ToLower(query)
+	return squirrel.Or{
+		squirrel.Expr("LOWER(parking_space.zone) = ?", lcQuery),
+		squirrel.Expr("LOWER(parking_space.label) = ?", lcQuery),
+	}
+}
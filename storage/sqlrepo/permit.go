@@ -1,4 +1,4 @@
-package psql
+package sqlrepo
 
 import (
 	"database/sql"
@@ -19,6 +19,9 @@ type permit struct {
 	RequestTS       sql.NullInt64  `db:"request_ts"`
 	AffectsDays     bool           `db:"affects_days"`
 	ExceptionReason sql.NullString `db:"exception_reason"`
+	SpaceID         sql.NullString `db:"space_id"`
+	DaysCharged     sql.NullInt64  `db:"days_charged"`
+	Version         int            `db:"version"`
 }
 
 func (permit permit) toModels() models.Permit {
@@ -35,9 +38,21 @@ func (permit permit) toModels() models.Permit {
 		permit.RequestTS.Int64,
 		permit.AffectsDays,
 		permit.ExceptionReason.String,
+		permit.SpaceID.String,
+		permit.daysCharged(),
+		permit.Version,
 	)
 }
 
+// daysCharged is nil for permits that were created before the days that they charged were stored
+func (permit permit) daysCharged() *int {
+	if !permit.DaysCharged.Valid {
+		return nil
+	}
+	daysCharged := int(permit.DaysCharged.Int64)
+	return &daysCharged
+}
+
 type permitSlice []permit
 
 func (permits permitSlice) toModels() []models.Permit {
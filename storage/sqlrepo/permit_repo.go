@@ -0,0 +1,312 @@
+package sqlrepo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/dannyvelas/parkspot-backend/errs"
+	"github.com/dannyvelas/parkspot-backend/models"
+	"github.com/dannyvelas/parkspot-backend/storage"
+	"github.com/dannyvelas/parkspot-backend/storage/selectopts"
+	"github.com/jmoiron/sqlx"
+)
+
+type PermitRepo struct {
+	driver       timeoutDB
+	dialect      Dialect
+	location     *time.Location
+	permitSelect squirrel.SelectBuilder
+	countSelect  squirrel.SelectBuilder
+}
+
+func NewPermitRepo(driver *sqlx.DB, dialect Dialect, location *time.Location, queryTimeout time.Duration) storage.PermitRepo {
+	permitSelect := stmtBuilder.Select(
+		"permit.id AS permit_id",
+		"permit.resident_id",
+		"permit.car_id",
+		"permit.license_plate",
+		"permit.color",
+		"permit.make",
+		"permit.model",
+		"permit.start_ts",
+		"permit.end_ts",
+		"permit.request_ts",
+		"permit.affects_days",
+		"permit.exception_reason",
+		"permit.space_id",
+		"permit.days_charged",
+		"permit.version",
+	).From("permit")
+	countSelect := stmtBuilder.Select("count(*)").From("permit")
+
+	return PermitRepo{
+		driver:       newTimeoutDB(driver, dialect, queryTimeout),
+		dialect:      dialect,
+		location:     location,
+		permitSelect: permitSelect,
+		countSelect:  countSelect,
+	}
+}
+
+func (permitRepo PermitRepo) SelectWhere(ctx context.Context, permitFields models.Permit, selectOpts ...selectopts.SelectOpt) ([]models.Permit, error) {
+	selector := permitRepo.permitSelect
+	for _, opt := range selectOpts {
+		selector = opt.Dispatch(permitRepo, selector)
+	}
+
+	permitSelect := selector.Where(rmEmptyVals(squirrel.Eq{
+		"resident_id":   permitFields.ResidentID,
+		"car_id":        permitFields.CarID,
+		"license_plate": permitFields.LicensePlate,
+		"color":         permitFields.Color,
+		"make":          permitFields.Make,
+		"model":         permitFields.Model,
+		"space_id":      permitFields.SpaceID,
+	}))
+
+	query, args, err := permitSelect.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("permit_repo.SelectWhere: %w: %v", errs.ErrDBBuildingQuery, err)
+	}
+
+	permits := permitSlice{}
+	err = permitRepo.driver.SelectContext(ctx, &permits, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("permit_repo.SelectWhere: %w: %v. %s. %v", errs.ErrDBQuery, err, query, args)
+	}
+
+	return permits.toModels(), nil
+}
+
+func (permitRepo PermitRepo) SelectCountWhere(ctx context.Context, permitFields models.Permit, selectOpts ...selectopts.SelectOpt) (int, error) {
+	selector := permitRepo.countSelect
+	for _, opt := range selectOpts {
+		selector = opt.Dispatch(permitRepo, selector)
+	}
+
+	countSelect := selector.Where(rmEmptyVals(squirrel.Eq{
+		"resident_id":   permitFields.ResidentID,
+		"car_id":        permitFields.CarID,
+		"license_plate": permitFields.LicensePlate,
+		"color":         permitFields.Color,
+		"make":          permitFields.Make,
+		"model":         permitFields.Model,
+		"space_id":      permitFields.SpaceID,
+	}))
+
+	query, args, err := countSelect.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("permit_repo.GetCount: %w: %v", errs.ErrDBBuildingQuery, err)
+	}
+
+	var totalAmount int
+	err = permitRepo.driver.GetContext(ctx, &totalAmount, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("permit_repo.GetCount: %w: %v", errs.ErrDBQuery, err)
+	}
+
+	return totalAmount, nil
+}
+
+func (permitRepo PermitRepo) GetOne(ctx context.Context, id int) (models.Permit, error) {
+	query, args, err := permitRepo.permitSelect.Where("permit.id = ?", id).ToSql()
+	if err != nil {
+		return models.Permit{}, fmt.Errorf("permit_repo.GetOne: %w: %v", errs.ErrDBBuildingQuery, err)
+	}
+
+	permit := permit{}
+	err = permitRepo.driver.GetContext(ctx, &permit, query, args...)
+	if err == sql.ErrNoRows {
+		return models.Permit{}, fmt.Errorf("permit_repo.GetOne: %w", errs.NewNotFound("permit"))
+	} else if err != nil {
+		return models.Permit{}, fmt.Errorf("permit_repo.GetOne: %w: %v", errs.ErrDBQuery, err)
+	}
+
+	return permit.toModels(), nil
+}
+
+func (permitRepo PermitRepo) Create(ctx context.Context, desiredPermit models.Permit) (int, error) {
+	// see whether exceptionReason is empty and convert appropriately
+	nullableReason := sql.NullString{}
+	if desiredPermit.ExceptionReason != "" {
+		nullableReason = sql.NullString{String: desiredPermit.ExceptionReason, Valid: true}
+	}
+	nullableSpaceID := sql.NullString{}
+	if desiredPermit.SpaceID != "" {
+		nullableSpaceID = sql.NullString{String: desiredPermit.SpaceID, Valid: true}
+	}
+	nullableDaysCharged := sql.NullInt64{}
+	if desiredPermit.DaysCharged != nil {
+		nullableDaysCharged = sql.NullInt64{Int64: int64(*desiredPermit.DaysCharged), Valid: true}
+	}
+
+	query, args, err := stmtBuilder.
+		Insert("permit").
+		SetMap(squirrel.Eq{
+			"resident_id":      desiredPermit.ResidentID,
+			"car_id":           desiredPermit.CarID,
+			"license_plate":    desiredPermit.LicensePlate,
+			"color":            desiredPermit.Color,
+			"make":             desiredPermit.Make,
+			"model":            desiredPermit.Model,
+			"start_ts":         desiredPermit.StartDate.Unix(),
+			"end_ts":           desiredPermit.EndDate.Unix(),
+			"request_ts":       time.Now().Unix(),
+			"affects_days":     desiredPermit.AffectsDays,
+			"exception_reason": nullableReason,
+			"space_id":         nullableSpaceID,
+			"days_charged":     nullableDaysCharged,
+		}).
+		Suffix("RETURNING permit.id").
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("permit_repo.Create: %w: %v", errs.ErrDBBuildingQuery, err)
+	}
+
+	var permitID int
+	err = permitRepo.driver.GetContext(ctx, &permitID, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("permit_repo.Create: %w: %v", errs.ErrDBExec, err)
+	}
+
+	return permitID, nil
+}
+
+func (permitRepo PermitRepo) Delete(ctx context.Context, id int) error {
+	const query = `DELETE FROM permit WHERE id = ?`
+
+	res, err := permitRepo.driver.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("permit_repo.Delete: %w: %v", errs.ErrDBExec, err)
+	}
+
+	if rowsAffected, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("permit_repo.Delete: %w: %v", errs.ErrDBGetRowsAffected, err)
+	} else if rowsAffected == 0 {
+		return fmt.Errorf("permit_repo.Delete: %w", errs.NewNotFound("permit"))
+	}
+
+	return nil
+}
+
+func (permitRepo PermitRepo) Update(ctx context.Context, permitFields models.Permit) error {
+	permitUpdate := stmtBuilder.Update("permit").SetMap(rmEmptyVals(squirrel.Eq{
+		"license_plate": permitFields.LicensePlate,
+		"color":         permitFields.Color,
+		"make":          permitFields.Make,
+		"model":         permitFields.Model,
+	}))
+
+	permitUpdate = permitUpdate.Where("permit.id = ?", permitFields.ID)
+	// only the edits of users are made to a version. updates of the service itself, like of the parking days
+	// used, leave the version alone, so that they don't make the edits of users conflict
+	if permitFields.Version != 0 {
+		permitUpdate = permitUpdate.Set("version", squirrel.Expr("version + 1")).Where("permit.version = ?", permitFields.Version)
+	}
+
+	query, args, err := permitUpdate.ToSql()
+	if err != nil {
+		return fmt.Errorf("permit_repo.Update: %w: %v", errs.ErrDBBuildingQuery, err)
+	}
+
+	res, err := permitRepo.driver.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("permit_repo.Update: %w: %v", errs.ErrDBExec, err)
+	}
+
+	// an edit of a version that isn't the one in the database changes no rows
+	if permitFields.Version == 0 {
+		return nil
+	} else if rowsAffected, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("permit_repo.Update: %w: %v", errs.ErrDBGetRowsAffected, err)
+	} else if rowsAffected != 0 {
+		return nil
+	}
+
+	if _, err := permitRepo.GetOne(ctx, permitFields.ID); err != nil {
+		return fmt.Errorf("permit_repo.Update: %w", err)
+	}
+	return fmt.Errorf("permit_repo.Update: %w", errs.NewConflict("permit"))
+}
+
+func (permitRepo PermitRepo) Reset(ctx context.Context) error {
+	_, err := permitRepo.driver.ExecContext(ctx, "DELETE FROM permit")
+	if err != nil {
+		return fmt.Errorf("permit_repo.Reset: %w: %v", errs.ErrDBExec, err)
+	}
+
+	return nil
+}
+
+// permitSearchDocument must stay equal to the expression of permit_search_idx of the postgres migrations
+const permitSearchDocument = "LOWER(CAST(permit.id AS TEXT) || ' ' || permit.resident_id || ' ' || permit.license_plate || ' ' || " +
+	"permit.color || ' ' || COALESCE(permit.make, '') || ' ' || COALESCE(permit.model, ''))"
+
+func (permitRepo PermitRepo) SearchAsSQL(query string) squirrel.Sqlizer {
+	return permitRepo.dialect.searchAsSQL(permitSearchDocument, query)
+}
+
+// SearchRankAsSQL implements selectopts.RankRepo
+func (permitRepo PermitRepo) SearchRankAsSQL(query string) squirrel.Sqlizer {
+	return permitRepo.dialect.searchRankAsSQL(permitSearchDocument, query)
+}
+
+// StatusAsSQL uses the clock of this process instead of the one of the database, so that the statuses
+// match models.Permit.IsActiveAt and models.Permit.IsExpiredAt
+func (permitRepo PermitRepo) StatusAsSQL(status models.Status) (squirrel.Sqlizer, bool) {
+	now := time.Now().Unix()
+	statusToSQL := map[models.Status]squirrel.Sqlizer{
+		models.ActiveStatus: squirrel.And{
+			squirrel.Expr("permit.start_ts <= ?", now),
+			squirrel.Expr("permit.end_ts >= ?", now),
+		},
+		models.ExceptionStatus: squirrel.Expr("permit.exception_reason IS NOT NULL"),
+		models.ExpiredStatus:   squirrel.Expr("permit.end_ts <= ?", models.ExpiredBefore(time.Now(), permitRepo.location).Unix()),
+	}
+
+	whereSQL, ok := statusToSQL[status]
+	return whereSQL, ok
+}
+
+// LicensePlateAsSQL implements selectopts.LicensePlateRepo
+func (permitRepo PermitRepo) LicensePlateAsSQL(normalizedLicensePlate string) squirrel.Sqlizer {
+	return squirrel.Expr(permitRepo.dialect.NormalizedLicensePlate("permit.license_plate")+" = ?", normalizedLicensePlate)
+}
+
+// SortColumns implements selectopts.SortRepo
+func (permitRepo PermitRepo) SortColumns() map[string]string {
+	return map[string]string{
+		"startDate":    "start_ts",
+		"endDate":      "end_ts",
+		"licensePlate": "license_plate",
+		"residentID":   "resident_id",
+	}
+}
+
+// IDType implements selectopts.IDRepo
+func (permitRepo PermitRepo) IDType() selectopts.FieldType {
+	return selectopts.IntField
+}
+
+// Table implements selectopts.SortRepo
+func (permitRepo PermitRepo) Table() string {
+	return "permit"
+}
+
+// FilterFields implements selectopts.FilterRepo
+func (permitRepo PermitRepo) FilterFields() map[string]selectopts.FilterField {
+	return map[string]selectopts.FilterField{
+		"id":           {Column: "permit.id", Type: selectopts.IntField},
+		"residentID":   {Column: "permit.resident_id", Type: selectopts.StringField},
+		"licensePlate": {Column: "permit.license_plate", Type: selectopts.StringField},
+		"color":        {Column: "permit.color", Type: selectopts.StringField},
+		"make":         {Column: "permit.make", Type: selectopts.StringField},
+		"model":        {Column: "permit.model", Type: selectopts.StringField},
+		"startDate":    {Column: "permit.start_ts", Type: selectopts.DateField, Location: permitRepo.location},
+		"endDate":      {Column: "permit.end_ts", Type: selectopts.DateField, Location: permitRepo.location},
+		"affectsDays":  {Column: "permit.affects_days", Type: selectopts.BoolField},
+	}
+}
@@ -0,0 +1,54 @@
+package sqlrepo
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/dannyvelas/parkspot-backend/models"
+)
+
+type permitRule struct {
+	ID           string         `db:"id"`
+	Name         string         `db:"name"`
+	Kind         string         `db:"kind"`
+	Target       string         `db:"target"`
+	Relationship sql.NullString `db:"relationship"`
+	// Dates is scanned by the StringList of the dialect of the repo, since engines store lists differently
+	Dates    any           `db:"dates"`
+	MaxDays  sql.NullInt64 `db:"max_days"`
+	MaxCount sql.NullInt64 `db:"max_count"`
+	Weight   sql.NullInt64 `db:"weight"`
+}
+
+func (permitRule permitRule) toModels(dialect Dialect) (models.PermitRule, error) {
+	var dates []string
+	if err := dialect.StringList(&dates).Scan(permitRule.Dates); err != nil {
+		return models.PermitRule{}, fmt.Errorf("error scanning dates of permit rule: %v", err)
+	}
+
+	return models.NewPermitRule(
+		permitRule.ID,
+		permitRule.Name,
+		models.PermitRuleKind(permitRule.Kind),
+		models.PermitRuleTarget(permitRule.Target),
+		permitRule.Relationship.String,
+		dates,
+		int(permitRule.MaxDays.Int64),
+		int(permitRule.MaxCount.Int64),
+		int(permitRule.Weight.Int64),
+	), nil
+}
+
+type permitRuleSlice []permitRule
+
+func (permitRules permitRuleSlice) toModels(dialect Dialect) ([]models.PermitRule, error) {
+	modelsPermitRules := make([]models.PermitRule, 0, len(permitRules))
+	for _, permitRule := range permitRules {
+		modelsPermitRule, err := permitRule.toModels(dialect)
+		if err != nil {
+			return nil, err
+		}
+		modelsPermitRules = append(modelsPermitRules, modelsPermitRule)
+	}
+	return modelsPermitRules, nil
+}
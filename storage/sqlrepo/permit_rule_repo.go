@@ -0,0 +1,139 @@
+package sqlrepo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/dannyvelas/parkspot-backend/errs"
+	"github.com/dannyvelas/parkspot-backend/models"
+	"github.com/dannyvelas/parkspot-backend/storage"
+	"github.com/jmoiron/sqlx"
+)
+
+type PermitRuleRepo struct {
+	driver           timeoutDB
+	dialect          Dialect
+	permitRuleSelect squirrel.SelectBuilder
+}
+
+func NewPermitRuleRepo(driver *sqlx.DB, dialect Dialect, queryTimeout time.Duration) storage.PermitRuleRepo {
+	permitRuleSelect := stmtBuilder.Select(
+		"permit_rule.id",
+		"permit_rule.name",
+		"permit_rule.kind",
+		"permit_rule.target",
+		"permit_rule.relationship",
+		"permit_rule.dates",
+		"permit_rule.max_days",
+		"permit_rule.max_count",
+		"permit_rule.weight",
+	).From("permit_rule")
+
+	return PermitRuleRepo{
+		driver:           newTimeoutDB(driver, dialect, queryTimeout),
+		dialect:          dialect,
+		permitRuleSelect: permitRuleSelect,
+	}
+}
+
+func (permitRuleRepo PermitRuleRepo) SelectWhere(ctx context.Context, permitRuleFields models.PermitRule) ([]models.PermitRule, error) {
+	permitRuleSelect := permitRuleRepo.permitRuleSelect.Where(rmEmptyVals(squirrel.Eq{
+		"kind":   string(permitRuleFields.Kind),
+		"target": string(permitRuleFields.Target),
+	})).OrderBy("permit_rule.name")
+
+	query, args, err := permitRuleSelect.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("permit_rule_repo.SelectWhere: %w: %v", errs.ErrDBBuildingQuery, err)
+	}
+
+	permitRules := permitRuleSlice{}
+	err = permitRuleRepo.driver.SelectContext(ctx, &permitRules, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("permit_rule_repo.SelectWhere: %w: %v", errs.ErrDBQuery, err)
+	}
+
+	modelsPermitRules, err := permitRules.toModels(permitRuleRepo.dialect)
+	if err != nil {
+		return nil, fmt.Errorf("permit_rule_repo.SelectWhere: %w: %v", errs.ErrDBQuery, err)
+	}
+	return modelsPermitRules, nil
+}
+
+func (permitRuleRepo PermitRuleRepo) GetOne(ctx context.Context, id string) (models.PermitRule, error) {
+	query, args, err := permitRuleRepo.permitRuleSelect.Where("permit_rule.id = ?", id).ToSql()
+	if err != nil {
+		return models.PermitRule{}, fmt.Errorf("permit_rule_repo.GetOne: %w: %v", errs.ErrDBBuildingQuery, err)
+	}
+
+	permitRule := permitRule{}
+	err = permitRuleRepo.driver.GetContext(ctx, &permitRule, query, args...)
+	if err == sql.ErrNoRows {
+		return models.PermitRule{}, fmt.Errorf("permit_rule_repo.GetOne: %w", errs.NewNotFound("permit rule"))
+	} else if err != nil {
+		return models.PermitRule{}, fmt.Errorf("permit_rule_repo.GetOne: %w: %v", errs.ErrDBQuery, err)
+	}
+
+	modelsPermitRule, err := permitRule.toModels(permitRuleRepo.dialect)
+	if err != nil {
+		return models.PermitRule{}, fmt.Errorf("permit_rule_repo.GetOne: %w: %v", errs.ErrDBQuery, err)
+	}
+	return modelsPermitRule, nil
+}
+
+func (permitRuleRepo PermitRuleRepo) Create(ctx context.Context, desiredPermitRule models.PermitRule) (string, error) {
+	query, args, err := stmtBuilder.
+		Insert("permit_rule").
+		SetMap(squirrel.Eq{
+			"name":         desiredPermitRule.Name,
+			"kind":         string(desiredPermitRule.Kind),
+			"target":       string(desiredPermitRule.Target),
+			"relationship": toNullString(desiredPermitRule.Relationship),
+			"dates":        permitRuleRepo.dialect.StringList(&desiredPermitRule.Dates),
+			"max_days":     toNullInt64(desiredPermitRule.MaxDays),
+			"max_count":    toNullInt64(desiredPermitRule.MaxCount),
+			"weight":       toNullInt64(desiredPermitRule.Weight),
+		}).
+		Suffix("RETURNING permit_rule.id").
+		ToSql()
+	if err != nil {
+		return "", fmt.Errorf("permit_rule_repo.Create: %w: %v", errs.ErrDBBuildingQuery, err)
+	}
+
+	var permitRuleID string
+	err = permitRuleRepo.driver.GetContext(ctx, &permitRuleID, query, args...)
+	if err != nil {
+		return "", fmt.Errorf("permit_rule_repo.Create: %w: %v", errs.ErrDBExec, err)
+	}
+
+	return permitRuleID, nil
+}
+
+func (permitRuleRepo PermitRuleRepo) Delete(ctx context.Context, id string) error {
+	const query = `DELETE FROM permit_rule WHERE id = ?`
+
+	res, err := permitRuleRepo.driver.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("permit_rule_repo.Delete: %w: %v", errs.ErrDBExec, err)
+	}
+
+	if rowsAffected, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("permit_rule_repo.Delete: %w: %v", errs.ErrDBGetRowsAffected, err)
+	} else if rowsAffected == 0 {
+		return fmt.Errorf("permit_rule_repo.Delete: %w", errs.NewNotFound("permit rule"))
+	}
+
+	return nil
+}
+
+func (permitRuleRepo PermitRuleRepo) Reset(ctx context.Context) error {
+	_, err := permitRuleRepo.driver.ExecContext(ctx, "DELETE FROM permit_rule")
+	if err != nil {
+		return fmt.Errorf("permit_rule_repo.Reset: %w: %v", errs.ErrDBExec, err)
+	}
+
+	return nil
+}
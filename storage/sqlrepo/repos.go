@@ -0,0 +1,96 @@
+package sqlrepo
+
+import (
+	"time"
+
+	"github.com/dannyvelas/parkspot-backend/storage"
+	"github.com/jmoiron/sqlx"
+)
+
+// Repos are the repos of a storage.Database whose queries are written in dialect. location is the timezone of
+// the community, whose calendar the days of permits and visitors are days of
+type Repos struct {
+	adminRepo         storage.AdminRepo
+	residentRepo      storage.ResidentRepo
+	carRepo           storage.CarRepo
+	permitRepo        storage.PermitRepo
+	visitorRepo       storage.VisitorRepo
+	gateEventRepo     storage.GateEventRepo
+	violationRepo     storage.ViolationRepo
+	banRepo           storage.BanRepo
+	parkingSpaceRepo  storage.ParkingSpaceRepo
+	waitlistRepo      storage.WaitlistRepo
+	parkingPolicyRepo storage.ParkingPolicyRepo
+	permitRuleRepo    storage.PermitRuleRepo
+	importRepo        storage.ImportRepo
+}
+
+func NewRepos(driver *sqlx.DB, dialect Dialect, location *time.Location, queryTimeout time.Duration) Repos {
+	return Repos{
+		adminRepo:         NewAdminRepo(driver, dialect, queryTimeout),
+		residentRepo:      NewResidentRepo(driver, dialect, queryTimeout),
+		carRepo:           NewCarRepo(driver, dialect, queryTimeout),
+		permitRepo:        NewPermitRepo(driver, dialect, location, queryTimeout),
+		visitorRepo:       NewVisitorRepo(driver, dialect, location, queryTimeout),
+		gateEventRepo:     NewGateEventRepo(driver, dialect, queryTimeout),
+		violationRepo:     NewViolationRepo(driver, dialect, queryTimeout),
+		banRepo:           NewBanRepo(driver, dialect, queryTimeout),
+		parkingSpaceRepo:  NewParkingSpaceRepo(driver, dialect, queryTimeout),
+		waitlistRepo:      NewWaitlistRepo(driver, dialect, queryTimeout),
+		parkingPolicyRepo: NewParkingPolicyRepo(driver, dialect, queryTimeout),
+		permitRuleRepo:    NewPermitRuleRepo(driver, dialect, queryTimeout),
+		importRepo:        NewImportRepo(driver, dialect, queryTimeout),
+	}
+}
+
+func (repos Repos) AdminRepo() storage.AdminRepo {
+	return repos.adminRepo
+}
+
+func (repos Repos) ResidentRepo() storage.ResidentRepo {
+	return repos.residentRepo
+}
+
+func (repos Repos) CarRepo() storage.CarRepo {
+	return repos.carRepo
+}
+
+func (repos Repos) PermitRepo() storage.PermitRepo {
+	return repos.permitRepo
+}
+
+func (repos Repos) VisitorRepo() storage.VisitorRepo {
+	return repos.visitorRepo
+}
+
+func (repos Repos) GateEventRepo() storage.GateEventRepo {
+	return repos.gateEventRepo
+}
+
+func (repos Repos) ViolationRepo() storage.ViolationRepo {
+	return repos.violationRepo
+}
+
+func (repos Repos) BanRepo() storage.BanRepo {
+	return repos.banRepo
+}
+
+func (repos Repos) ParkingSpaceRepo() storage.ParkingSpaceRepo {
+	return repos.parkingSpaceRepo
+}
+
+func (repos Repos) WaitlistRepo() storage.WaitlistRepo {
+	return repos.waitlistRepo
+}
+
+func (repos Repos) ParkingPolicyRepo() storage.ParkingPolicyRepo {
+	return repos.parkingPolicyRepo
+}
+
+func (repos Repos) PermitRuleRepo() storage.PermitRuleRepo {
+	return repos.permitRuleRepo
+}
+
+func (repos Repos) ImportRepo() storage.ImportRepo {
+	return repos.importRepo
+}
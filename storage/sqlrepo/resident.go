@@ -0,0 +1,48 @@
+package sqlrepo
+
+import (
+	"database/sql"
+
+	"github.com/dannyvelas/parkspot-backend/i18n"
+	"github.com/dannyvelas/parkspot-backend/models"
+)
+
+type resident struct {
+	ID                 string         `db:"id"`
+	FirstName          string         `db:"first_name"`
+	LastName           string         `db:"last_name"`
+	Phone              string         `db:"phone"`
+	Email              string         `db:"email"`
+	Password           string         `db:"password"`
+	UnlimDays          bool           `db:"unlim_days"`
+	AmtParkingDaysUsed int            `db:"amt_parking_days_used"`
+	PreferredLang      sql.NullString `db:"preferred_lang"`
+	TokenVersion       int            `db:"token_version"`
+	Version            int            `db:"version"`
+}
+
+func (resident resident) toModels() models.Resident {
+	return models.NewResident(
+		resident.ID,
+		resident.FirstName,
+		resident.LastName,
+		resident.Phone,
+		resident.Email,
+		resident.Password,
+		resident.UnlimDays,
+		resident.AmtParkingDaysUsed,
+		i18n.Lang(resident.PreferredLang.String),
+		resident.TokenVersion,
+		resident.Version,
+	)
+}
+
+type residentSlice []resident
+
+func (residents residentSlice) toModels() []models.Resident {
+	modelsResidents := make([]models.Resident, 0, len(residents))
+	for _, resident := range residents {
+		modelsResidents = append(modelsResidents, resident.toModels())
+	}
+	return modelsResidents
+}
@@ -0,0 +1,266 @@
+package sqlrepo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/dannyvelas/parkspot-backend/errs"
+	"github.com/dannyvelas/parkspot-backend/models"
+	"github.com/dannyvelas/parkspot-backend/storage"
+	"github.com/dannyvelas/parkspot-backend/storage/selectopts"
+	"github.com/jmoiron/sqlx"
+)
+
+type ResidentRepo struct {
+	driver         timeoutDB
+	dialect        Dialect
+	residentSelect squirrel.SelectBuilder
+	countSelect    squirrel.SelectBuilder
+}
+
+func NewResidentRepo(driver *sqlx.DB, dialect Dialect, queryTimeout time.Duration) storage.ResidentRepo {
+	residentSelect := stmtBuilder.Select(
+		"id",
+		"first_name",
+		"last_name",
+		"phone",
+		"email",
+		"password",
+		"unlim_days",
+		"amt_parking_days_used",
+		"preferred_lang",
+		"token_version",
+		"version",
+	).From("resident")
+	countSelect := stmtBuilder.Select("count(*)").From("resident")
+
+	return ResidentRepo{
+		driver:         newTimeoutDB(driver, dialect, queryTimeout),
+		dialect:        dialect,
+		residentSelect: residentSelect,
+		countSelect:    countSelect,
+	}
+}
+
+func (residentRepo ResidentRepo) SelectWhere(ctx context.Context, residentFields models.Resident, selectOpts ...selectopts.SelectOpt) ([]models.Resident, error) {
+	selector := residentRepo.residentSelect
+	for _, opt := range selectOpts {
+		selector = opt.Dispatch(residentRepo, selector)
+	}
+
+	residentSelect := selector.Where(rmEmptyVals(squirrel.Eq{
+		"id":         residentFields.ID,
+		"first_name": residentFields.FirstName,
+		"last_name":  residentFields.LastName,
+		"phone":      residentFields.Phone,
+		"email":      residentFields.Email,
+	})).OrderBy("first_name ASC")
+
+	query, args, err := residentSelect.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("resident_repo.SelectWhere: %w: %v", errs.ErrDBBuildingQuery, err)
+	}
+
+	residents := residentSlice{}
+	err = residentRepo.driver.SelectContext(ctx, &residents, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("resident_repo.SelectWhere: %w: %v", errs.ErrDBQuery, err)
+	}
+
+	return residents.toModels(), nil
+}
+
+func (residentRepo ResidentRepo) SelectCountWhere(ctx context.Context, residentFields models.Resident, selectOpts ...selectopts.SelectOpt) (int, error) {
+	selector := residentRepo.countSelect
+	for _, opt := range selectOpts {
+		selector = opt.Dispatch(residentRepo, selector)
+	}
+
+	countSelect := selector.Where(rmEmptyVals(squirrel.Eq{
+		"id":         residentFields.ID,
+		"first_name": residentFields.FirstName,
+		"last_name":  residentFields.LastName,
+		"phone":      residentFields.Phone,
+		"email":      residentFields.Email,
+	}))
+
+	query, args, err := countSelect.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("resident_repo.SelectCountWhere: %w: %v", errs.ErrDBBuildingQuery, err)
+	}
+
+	var totalAmount int
+	err = residentRepo.driver.GetContext(ctx, &totalAmount, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("resident_repo.SelectCountWhere: %w: %v", errs.ErrDBQuery, err)
+	}
+
+	return totalAmount, nil
+}
+
+func (residentRepo ResidentRepo) AddToAmtParkingDaysUsed(ctx context.Context, id string, days int) error {
+	const query = `
+    UPDATE resident SET amt_parking_days_used = amt_parking_days_used + ?
+    WHERE id = ?
+  `
+
+	_, err := residentRepo.driver.ExecContext(ctx, query, days, id)
+	if err != nil {
+		return fmt.Errorf("resident_repo.AddToAmtParkingDaysUsed: %w: %v", errs.ErrDBExec, err)
+	}
+
+	return nil
+}
+
+func (residentRepo ResidentRepo) Create(ctx context.Context, resident models.Resident) error {
+	query, args, err := residentInsert(resident).ToSql()
+	if err != nil {
+		return fmt.Errorf("resident_repo.Create: %w: %v", errs.ErrDBBuildingQuery, err)
+	}
+
+	_, err = residentRepo.driver.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("resident_repo.Create: %w: %v", errs.ErrDBExec, err)
+	}
+
+	return nil
+}
+
+func residentInsert(resident models.Resident) squirrel.InsertBuilder {
+	// cast *resident.UnlimDays to bool
+	unlimDays := false
+	if resident.UnlimDays != nil {
+		unlimDays = *resident.UnlimDays
+	}
+
+	return stmtBuilder.
+		Insert("resident").
+		SetMap(squirrel.Eq{
+			"id":             resident.ID,
+			"first_name":     resident.FirstName,
+			"last_name":      resident.LastName,
+			"phone":          resident.Phone,
+			"email":          resident.Email,
+			"password":       resident.Password,
+			"unlim_days":     unlimDays,
+			"preferred_lang": toNullString(string(resident.PreferredLang)),
+		})
+}
+
+func (residentRepo ResidentRepo) Delete(ctx context.Context, residentID string) error {
+	const query = `DELETE FROM resident WHERE id = ?`
+
+	res, err := residentRepo.driver.ExecContext(ctx, query, residentID)
+	if err != nil {
+		return fmt.Errorf("resident_repo.Delete: %w: %v", errs.ErrDBExec, err)
+	}
+
+	if rowsAffected, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("resident_repo.Delete: %w: %v", errs.ErrDBGetRowsAffected, err)
+	} else if rowsAffected == 0 {
+		return fmt.Errorf("resident_repo.Delete: %w", errs.NewNotFound("resident"))
+	}
+
+	return nil
+}
+
+func (residentRepo ResidentRepo) Update(ctx context.Context, residentFields models.Resident) error {
+	residentUpdate := stmtBuilder.Update("resident").SetMap(rmEmptyVals(squirrel.Eq{
+		"first_name":     residentFields.FirstName,
+		"last_name":      residentFields.LastName,
+		"phone":          residentFields.Phone,
+		"email":          residentFields.Email,
+		"password":       residentFields.Password,
+		"preferred_lang": residentFields.PreferredLang,
+	}))
+	if residentFields.UnlimDays != nil {
+		residentUpdate = residentUpdate.Set("unlim_days", *residentFields.UnlimDays)
+	}
+	if residentFields.AmtParkingDaysUsed != nil {
+		residentUpdate = residentUpdate.Set("amt_parking_days_used", *residentFields.AmtParkingDaysUsed)
+	}
+	if residentFields.TokenVersion != nil {
+		residentUpdate = residentUpdate.Set("token_version", *residentFields.TokenVersion)
+	}
+
+	residentUpdate = residentUpdate.Where("resident.id = ?", residentFields.ID)
+	// only the edits of users are made to a version. updates of the service itself, like of the parking days
+	// used, leave the version alone, so that they don't make the edits of users conflict
+	if residentFields.Version != 0 {
+		residentUpdate = residentUpdate.Set("version", squirrel.Expr("version + 1")).Where("resident.version = ?", residentFields.Version)
+	}
+
+	query, args, err := residentUpdate.ToSql()
+	if err != nil {
+		return fmt.Errorf("resident_repo.Update: %w: %v", errs.ErrDBBuildingQuery, err)
+	}
+
+	res, err := residentRepo.driver.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("resident_repo.Update: %w: %v", errs.ErrDBExec, err)
+	}
+
+	// an edit of a version that isn't the one in the database changes no rows
+	if residentFields.Version == 0 {
+		return nil
+	} else if rowsAffected, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("resident_repo.Update: %w: %v", errs.ErrDBGetRowsAffected, err)
+	} else if rowsAffected != 0 {
+		return nil
+	}
+
+	if amt, err := residentRepo.SelectCountWhere(ctx, models.Resident{ID: residentFields.ID}); err != nil {
+		return fmt.Errorf("resident_repo.Update: %w", err)
+	} else if amt == 0 {
+		return fmt.Errorf("resident_repo.Update: %w", errs.NewNotFound("resident"))
+	}
+	return fmt.Errorf("resident_repo.Update: %w", errs.NewConflict("resident"))
+}
+
+func (residentRepo ResidentRepo) Reset(ctx context.Context) error {
+	_, err := residentRepo.driver.ExecContext(ctx, "DELETE FROM resident")
+	if err != nil {
+		return fmt.Errorf("resident_repo.Reset: %w: %v", errs.ErrDBExec, err)
+	}
+
+	return nil
+}
+
+// residentSearchDocument must stay equal to the expression of resident_search_idx of the postgres migrations
+const residentSearchDocument = "LOWER(resident.id || ' ' || resident.first_name || ' ' || resident.last_name)"
+
+func (residentRepo ResidentRepo) SearchAsSQL(query string) squirrel.Sqlizer {
+	return residentRepo.dialect.searchAsSQL(residentSearchDocument, query)
+}
+
+// SearchRankAsSQL implements selectopts.RankRepo
+func (residentRepo ResidentRepo) SearchRankAsSQL(query string) squirrel.Sqlizer {
+	return residentRepo.dialect.searchRankAsSQL(residentSearchDocument, query)
+}
+
+// SortColumns implements selectopts.SortRepo
+func (residentRepo ResidentRepo) SortColumns() map[string]string {
+	return map[string]string{
+		"firstName": "first_name",
+		"lastName":  "last_name",
+		"email":     "email",
+	}
+}
+
+// Table implements selectopts.SortRepo
+func (residentRepo ResidentRepo) Table() string {
+	return "resident"
+}
+
+// FilterFields implements selectopts.FilterRepo
+func (residentRepo ResidentRepo) FilterFields() map[string]selectopts.FilterField {
+	return map[string]selectopts.FilterField{
+		"firstName":          {Column: "resident.first_name", Type: selectopts.StringField},
+		"lastName":           {Column: "resident.last_name", Type: selectopts.StringField},
+		"email":              {Column: "resident.email", Type: selectopts.StringField},
+		"unlimDays":          {Column: "resident.unlim_days", Type: selectopts.BoolField},
+		"amtParkingDaysUsed": {Column: "resident.amt_parking_days_used", Type: selectopts.IntField},
+	}
+}
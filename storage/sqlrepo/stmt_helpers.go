@@ -0,0 +1,57 @@
+package sqlrepo
+
+import (
+	"database/sql"
+	"github.com/Masterminds/squirrel"
+	"reflect"
+	"strings"
+)
+
+var (
+	// stmtBuilder writes placeholders as ?. timeoutDB writes them in the format of its dialect before queries run
+	stmtBuilder = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Question)
+)
+
+func rmEmptyVals(whereClause squirrel.Eq) squirrel.Eq {
+	newClause := make(squirrel.Eq)
+	for key, value := range whereClause {
+		if !reflect.ValueOf(value).IsZero() {
+			newClause[key] = value
+		}
+	}
+
+	return newClause
+}
+
+// toNullString stores empty strings as NULL
+func toNullString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
+
+// toNullInt64 stores zeroes as NULL
+func toNullInt64(value int) sql.NullInt64 {
+	return sql.NullInt64{Int64: int64(value), Valid: value != 0}
+}
+
+// searchAsSQL matches the rows whose search document contains every term of query, ignoring case.
+// a search document is a lowercase expression that joins the searchable columns of a table. the postgres
+// migrations index each one with a trigram index, so that searching for parts of words is fast
+func (dialect Dialect) searchAsSQL(document string, query string) squirrel.Sqlizer {
+	like := document + " LIKE ?"
+	if dialect.LikeEscape != "" {
+		like += " " + dialect.LikeEscape
+	}
+
+	matchesAll := squirrel.And{}
+	for _, term := range strings.Fields(strings.ToLower(query)) {
+		matchesAll = append(matchesAll, squirrel.Expr(like, "%"+likeEscaper.Replace(term)+"%"))
+	}
+	return matchesAll
+}
+
+// searchRankAsSQL is how relevant a row is to query, from 0 to 1
+func (dialect Dialect) searchRankAsSQL(document string, query string) squirrel.Sqlizer {
+	return squirrel.Expr(dialect.Similarity+"("+document+", ?)", strings.ToLower(query))
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
@@ -0,0 +1,50 @@
+package sqlrepo
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestSearchAsSQL(t *testing.T) {
+	tests := map[string]struct {
+		dialect      Dialect
+		query        string
+		expectedSQL  string
+		expectedArgs []any
+	}{
+		"one term": {
+			query:        "Toy",
+			expectedSQL:  "(doc LIKE ?)",
+			expectedArgs: []any{"%toy%"},
+		},
+		"many terms": {
+			query:        " Smith   John ",
+			expectedSQL:  "(doc LIKE ? AND doc LIKE ?)",
+			expectedArgs: []any{"%smith%", "%john%"},
+		},
+		"like wildcards": {
+			query:        `50%_\`,
+			expectedSQL:  "(doc LIKE ?)",
+			expectedArgs: []any{`%50\%\_\\%`},
+		},
+		"escape clause": {
+			dialect:      Dialect{LikeEscape: `ESCAPE '\'`},
+			query:        "Smith John",
+			expectedSQL:  `(doc LIKE ? ESCAPE '\' AND doc LIKE ? ESCAPE '\')`,
+			expectedArgs: []any{"%smith%", "%john%"},
+		},
+	}
+
+	for name, test := range tests {
+		sql, args, err := test.dialect.searchAsSQL("doc", test.query).ToSql()
+		if err != nil {
+			t.Fatalf("%s failed: %v", name, err)
+		}
+		if sql != test.expectedSQL {
+			t.Errorf("%s failed: expected %q, got %q", name, test.expectedSQL, sql)
+		}
+		if fmt.Sprint(args) != fmt.Sprint(test.expectedArgs) {
+			t.Errorf("%s failed: expected %q, got %q", name, test.expectedArgs, args)
+		}
+	}
+}
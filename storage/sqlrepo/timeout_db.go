@@ -0,0 +1,76 @@
+package sqlrepo
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+// timeoutDB runs each query with a deadline of queryTimeout, on top of the deadline of its context.
+// queries only have the deadline of their context when queryTimeout is 0. the ? placeholders of queries are
+// written in the format of placeholder before they run
+type timeoutDB struct {
+	*sqlx.DB
+	queryTimeout time.Duration
+	placeholder  squirrel.PlaceholderFormat
+}
+
+func newTimeoutDB(driver *sqlx.DB, dialect Dialect, queryTimeout time.Duration) timeoutDB {
+	return timeoutDB{DB: driver, queryTimeout: queryTimeout, placeholder: dialect.Placeholder}
+}
+
+func (db timeoutDB) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if db.queryTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, db.queryTimeout)
+}
+
+func (db timeoutDB) GetContext(ctx context.Context, dest any, query string, args ...any) error {
+	query, err := db.placeholder.ReplacePlaceholders(query)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := db.withTimeout(ctx)
+	defer cancel()
+	return db.DB.GetContext(ctx, dest, query, args...)
+}
+
+func (db timeoutDB) SelectContext(ctx context.Context, dest any, query string, args ...any) error {
+	query, err := db.placeholder.ReplacePlaceholders(query)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := db.withTimeout(ctx)
+	defer cancel()
+	return db.DB.SelectContext(ctx, dest, query, args...)
+}
+
+func (db timeoutDB) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	query, err := db.placeholder.ReplacePlaceholders(query)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := db.withTimeout(ctx)
+	defer cancel()
+	return db.DB.ExecContext(ctx, query, args...)
+}
+
+// txExecContext runs a query of tx with a deadline of queryTimeout. the transaction itself only has the deadline of
+// the context that it began with
+func (db timeoutDB) txExecContext(ctx context.Context, tx *sqlx.Tx, query string, args ...any) (sql.Result, error) {
+	query, err := db.placeholder.ReplacePlaceholders(query)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := db.withTimeout(ctx)
+	defer cancel()
+	return tx.ExecContext(ctx, query, args...)
+}
@@ -0,0 +1,52 @@
+package sqlrepo
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/dannyvelas/parkspot-backend/models"
+)
+
+type violation struct {
+	ID               string         `db:"id"`
+	LicensePlate     string         `db:"license_plate"`
+	Location         string         `db:"location"`
+	PhotoRef         sql.NullString `db:"photo_ref"`
+	Type             string         `db:"type"`
+	RecordedBy       string         `db:"recorded_by"`
+	ResidentID       sql.NullString `db:"resident_id"`
+	Action           string         `db:"action"`
+	Status           string         `db:"status"`
+	ResidentNotified bool           `db:"resident_notified"`
+	DisputeReason    sql.NullString `db:"dispute_reason"`
+	Resolution       sql.NullString `db:"resolution"`
+	TS               int64          `db:"ts"`
+}
+
+func (violation violation) toModels() models.Violation {
+	return models.NewViolation(
+		violation.ID,
+		violation.LicensePlate,
+		violation.Location,
+		violation.PhotoRef.String,
+		violation.Type,
+		violation.RecordedBy,
+		violation.ResidentID.String,
+		models.ViolationAction(violation.Action),
+		models.ViolationStatus(violation.Status),
+		violation.ResidentNotified,
+		violation.DisputeReason.String,
+		violation.Resolution.String,
+		time.Unix(violation.TS, 0), // time.Unix() returns time in local tz
+	)
+}
+
+type violationSlice []violation
+
+func (violations violationSlice) toModels() []models.Violation {
+	modelsViolations := make([]models.Violation, 0, len(violations))
+	for _, violation := range violations {
+		modelsViolations = append(modelsViolations, violation.toModels())
+	}
+	return modelsViolations
+}
@@ -0,0 +1,211 @@
+package sqlrepo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/dannyvelas/parkspot-backend/errs"
+	"github.com/dannyvelas/parkspot-backend/models"
+	"github.com/dannyvelas/parkspot-backend/storage"
+	"github.com/dannyvelas/parkspot-backend/storage/selectopts"
+	"github.com/jmoiron/sqlx"
+)
+
+type ViolationRepo struct {
+	driver          timeoutDB
+	dialect         Dialect
+	violationSelect squirrel.SelectBuilder
+	countSelect     squirrel.SelectBuilder
+}
+
+func NewViolationRepo(driver *sqlx.DB, dialect Dialect, queryTimeout time.Duration) storage.ViolationRepo {
+	violationSelect := stmtBuilder.Select(
+		"violation.id",
+		"violation.license_plate",
+		"violation.location",
+		"violation.photo_ref",
+		"violation.type",
+		"violation.recorded_by",
+		"violation.resident_id",
+		"violation.action",
+		"violation.status",
+		"violation.resident_notified",
+		"violation.dispute_reason",
+		"violation.resolution",
+		"violation.ts",
+	).From("violation")
+	countSelect := stmtBuilder.Select("count(*)").From("violation")
+
+	return ViolationRepo{
+		driver:          newTimeoutDB(driver, dialect, queryTimeout),
+		dialect:         dialect,
+		violationSelect: violationSelect,
+		countSelect:     countSelect,
+	}
+}
+
+func (violationRepo ViolationRepo) SelectWhere(ctx context.Context, violationFields models.Violation, selectOpts ...selectopts.SelectOpt) ([]models.Violation, error) {
+	selector := violationRepo.violationSelect
+	for _, opt := range selectOpts {
+		selector = opt.Dispatch(violationRepo, selector)
+	}
+
+	violationSelect := selector.Where(rmEmptyVals(squirrel.Eq{
+		"resident_id": violationFields.ResidentID,
+		"type":        violationFields.Type,
+		"action":      violationFields.Action,
+		"status":      violationFields.Status,
+		"recorded_by": violationFields.RecordedBy,
+	})).OrderBy("violation.ts DESC")
+
+	query, args, err := violationSelect.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("violation_repo.SelectWhere: %w: %v", errs.ErrDBBuildingQuery, err)
+	}
+
+	violations := violationSlice{}
+	err = violationRepo.driver.SelectContext(ctx, &violations, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("violation_repo.SelectWhere: %w: %v", errs.ErrDBQuery, err)
+	}
+
+	return violations.toModels(), nil
+}
+
+func (violationRepo ViolationRepo) SelectCountWhere(ctx context.Context, violationFields models.Violation, selectOpts ...selectopts.SelectOpt) (int, error) {
+	selector := violationRepo.countSelect
+	for _, opt := range selectOpts {
+		selector = opt.Dispatch(violationRepo, selector)
+	}
+
+	countSelect := selector.Where(rmEmptyVals(squirrel.Eq{
+		"resident_id": violationFields.ResidentID,
+		"type":        violationFields.Type,
+		"action":      violationFields.Action,
+		"status":      violationFields.Status,
+		"recorded_by": violationFields.RecordedBy,
+	}))
+
+	query, args, err := countSelect.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("violation_repo.SelectCountWhere: %w: %v", errs.ErrDBBuildingQuery, err)
+	}
+
+	var totalAmount int
+	err = violationRepo.driver.GetContext(ctx, &totalAmount, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("violation_repo.SelectCountWhere: %w: %v", errs.ErrDBQuery, err)
+	}
+
+	return totalAmount, nil
+}
+
+func (violationRepo ViolationRepo) GetOne(ctx context.Context, id string) (models.Violation, error) {
+	query, args, err := violationRepo.violationSelect.Where("violation.id = ?", id).ToSql()
+	if err != nil {
+		return models.Violation{}, fmt.Errorf("violation_repo.GetOne: %w: %v", errs.ErrDBBuildingQuery, err)
+	}
+
+	violation := violation{}
+	err = violationRepo.driver.GetContext(ctx, &violation, query, args...)
+	if err == sql.ErrNoRows {
+		return models.Violation{}, fmt.Errorf("violation_repo.GetOne: %w", errs.NewNotFound("violation"))
+	} else if err != nil {
+		return models.Violation{}, fmt.Errorf("violation_repo.GetOne: %w: %v", errs.ErrDBQuery, err)
+	}
+
+	return violation.toModels(), nil
+}
+
+func (violationRepo ViolationRepo) Create(ctx context.Context, desiredViolation models.Violation) (string, error) {
+	nullablePhotoRef := sql.NullString{}
+	if desiredViolation.PhotoRef != "" {
+		nullablePhotoRef = sql.NullString{String: desiredViolation.PhotoRef, Valid: true}
+	}
+	nullableResidentID := sql.NullString{}
+	if desiredViolation.ResidentID != "" {
+		nullableResidentID = sql.NullString{String: desiredViolation.ResidentID, Valid: true}
+	}
+
+	query, args, err := stmtBuilder.
+		Insert("violation").
+		SetMap(squirrel.Eq{
+			"license_plate":     desiredViolation.LicensePlate,
+			"location":          desiredViolation.Location,
+			"photo_ref":         nullablePhotoRef,
+			"type":              desiredViolation.Type,
+			"recorded_by":       desiredViolation.RecordedBy,
+			"resident_id":       nullableResidentID,
+			"action":            desiredViolation.Action,
+			"status":            desiredViolation.Status,
+			"resident_notified": desiredViolation.ResidentNotified,
+			"ts":                desiredViolation.Timestamp.Unix(),
+		}).
+		Suffix("RETURNING violation.id").
+		ToSql()
+	if err != nil {
+		return "", fmt.Errorf("violation_repo.Create: %w: %v", errs.ErrDBBuildingQuery, err)
+	}
+
+	var violationID string
+	err = violationRepo.driver.GetContext(ctx, &violationID, query, args...)
+	if err != nil {
+		return "", fmt.Errorf("violation_repo.Create: %w: %v", errs.ErrDBExec, err)
+	}
+
+	return violationID, nil
+}
+
+func (violationRepo ViolationRepo) Update(ctx context.Context, violationFields models.Violation) error {
+	violationUpdate := stmtBuilder.Update("violation").SetMap(rmEmptyVals(squirrel.Eq{
+		"status":            violationFields.Status,
+		"resident_notified": violationFields.ResidentNotified,
+		"dispute_reason":    violationFields.DisputeReason,
+		"resolution":        violationFields.Resolution,
+	}))
+
+	query, args, err := violationUpdate.Where("violation.id = ?", violationFields.ID).ToSql()
+	if err != nil {
+		return fmt.Errorf("violation_repo.Update: %w: %v", errs.ErrDBBuildingQuery, err)
+	}
+
+	res, err := violationRepo.driver.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("violation_repo.Update: %w: %v", errs.ErrDBExec, err)
+	}
+
+	if rowsAffected, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("violation_repo.Update: %w: %v", errs.ErrDBGetRowsAffected, err)
+	} else if rowsAffected == 0 {
+		return fmt.Errorf("violation_repo.Update: %w", errs.NewNotFound("violation"))
+	}
+
+	return nil
+}
+
+func (violationRepo ViolationRepo) Reset(ctx context.Context) error {
+	_, err := violationRepo.driver.ExecContext(ctx, "DELETE FROM violation")
+	if err != nil {
+		return fmt.Errorf("violation_repo.Reset: %w: %v", errs.ErrDBExec, err)
+	}
+
+	return nil
+}
+
+func (violationRepo ViolationRepo) SearchAsSQL(query string) squirrel.Sqlizer {
+	lcQuery := strings.ToLower(query)
+	return squirrel.Or{
+		squirrel.Expr("LOWER(violation.license_plate) = ?", lcQuery),
+		squirrel.Expr("LOWER(violation.resident_id) = ?", lcQuery),
+		squirrel.Expr("LOWER(violation.location) = ?", lcQuery),
+	}
+}
+
+// LicensePlateAsSQL implements selectopts.LicensePlateRepo
+func (violationRepo ViolationRepo) LicensePlateAsSQL(normalizedLicensePlate string) squirrel.Sqlizer {
+	return squirrel.Expr(violationRepo.dialect.NormalizedLicensePlate("violation.license_plate")+" = ?", normalizedLicensePlate)
+}
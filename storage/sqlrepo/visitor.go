@@ -1,4 +1,4 @@
-package psql
+package sqlrepo
 
 import (
 	"github.com/dannyvelas/parkspot-backend/models"
@@ -13,6 +13,7 @@ type visitor struct {
 	Relationship string `db:"relationship"`
 	AccessStart  int64  `db:"access_start"`
 	AccessEnd    int64  `db:"access_end"`
+	Version      int    `db:"version"`
 }
 
 func (visitor visitor) toModels() models.Visitor {
@@ -24,6 +25,7 @@ func (visitor visitor) toModels() models.Visitor {
 		Relationship: visitor.Relationship,
 		AccessStart:  time.Unix(visitor.AccessStart, 0), // time.Unix() returns time in local tz
 		AccessEnd:    time.Unix(visitor.AccessEnd, 0),
+		Version:      visitor.Version,
 	}
 }
 
@@ -0,0 +1,269 @@
+package sqlrepo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/dannyvelas/parkspot-backend/errs"
+	"github.com/dannyvelas/parkspot-backend/models"
+	"github.com/dannyvelas/parkspot-backend/storage"
+	"github.com/dannyvelas/parkspot-backend/storage/selectopts"
+	"github.com/jmoiron/sqlx"
+)
+
+type VisitorRepo struct {
+	driver        timeoutDB
+	dialect       Dialect
+	location      *time.Location
+	visitorSelect squirrel.SelectBuilder
+	countSelect   squirrel.SelectBuilder
+}
+
+func NewVisitorRepo(driver *sqlx.DB, dialect Dialect, location *time.Location, queryTimeout time.Duration) storage.VisitorRepo {
+	visitorSelect := stmtBuilder.Select(
+		"id",
+		"resident_id",
+		"first_name",
+		"last_name",
+		"relationship",
+		"access_start",
+		"access_end",
+		"version",
+	).From("visitor")
+	countSelect := stmtBuilder.Select("count(*)").From("visitor")
+
+	return VisitorRepo{
+		driver:        newTimeoutDB(driver, dialect, queryTimeout),
+		dialect:       dialect,
+		location:      location,
+		visitorSelect: visitorSelect,
+		countSelect:   countSelect,
+	}
+}
+
+func (visitorRepo VisitorRepo) SelectWhere(ctx context.Context, visitorFields models.Visitor, selectOpts ...selectopts.SelectOpt) ([]models.Visitor, error) {
+	selector := visitorRepo.visitorSelect
+	for _, opt := range selectOpts {
+		selector = opt.Dispatch(visitorRepo, selector)
+	}
+
+	visitorSelect := selector.Where(rmEmptyVals(squirrel.Eq{
+		"id":          visitorFields.ID,
+		"resident_id": visitorFields.ResidentID,
+		"first_name":  visitorFields.FirstName,
+		"last_name":   visitorFields.LastName,
+	}))
+
+	query, args, err := visitorSelect.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("visitor_repo.SelectWhere: %w: %v", errs.ErrDBBuildingQuery, err)
+	}
+
+	visitors := visitorSlice{}
+	err = visitorRepo.driver.SelectContext(ctx, &visitors, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("visitor_repo.SelectWhere: %w: %v", errs.ErrDBQuery, err)
+	}
+
+	return visitors.toModels(), nil
+}
+
+func (visitorRepo VisitorRepo) SelectCountWhere(ctx context.Context, visitorFields models.Visitor, selectOpts ...selectopts.SelectOpt) (int, error) {
+	selector := visitorRepo.countSelect
+	for _, opt := range selectOpts {
+		selector = opt.Dispatch(visitorRepo, selector)
+	}
+
+	countSelect := selector.Where(rmEmptyVals(squirrel.Eq{
+		"id":          visitorFields.ID,
+		"resident_id": visitorFields.ResidentID,
+		"first_name":  visitorFields.FirstName,
+		"last_name":   visitorFields.LastName,
+	}))
+	query, args, err := countSelect.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("visitor_repo.SelectCountWhere: %w: %v", errs.ErrDBBuildingQuery, err)
+	}
+
+	var totalAmount int
+	err = visitorRepo.driver.GetContext(ctx, &totalAmount, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("visitor_repo.SelectCountWhere: %w: %v", errs.ErrDBQuery, err)
+	}
+
+	return totalAmount, nil
+}
+
+func (visitorRepo VisitorRepo) Create(ctx context.Context, desiredVisitor models.Visitor) (string, error) {
+	sq := squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+	query, args, err := sq.
+		Insert("visitor").
+		SetMap(squirrel.Eq{
+			"resident_id":  desiredVisitor.ResidentID,
+			"first_name":   desiredVisitor.FirstName,
+			"last_name":    desiredVisitor.LastName,
+			"relationship": desiredVisitor.Relationship,
+			"access_start": desiredVisitor.AccessStart.Unix(),
+			"access_end":   desiredVisitor.AccessEnd.Unix(),
+		}).
+		Suffix("RETURNING visitor.id").
+		ToSql()
+	if err != nil {
+		return "", fmt.Errorf("visitor_repo.Create: %w: %v", errs.ErrDBBuildingQuery, err)
+	}
+
+	var visitorID string
+	err = visitorRepo.driver.GetContext(ctx, &visitorID, query, args...)
+	if err != nil {
+		return "", fmt.Errorf("visitor_repo.Create: %w: %v", errs.ErrDBExec, err)
+	}
+
+	return visitorID, nil
+}
+
+func (visitorRepo VisitorRepo) Update(ctx context.Context, visitorFields models.Visitor) error {
+	visitorUpdate := stmtBuilder.Update("visitor").SetMap(rmEmptyVals(squirrel.Eq{
+		"first_name":   visitorFields.FirstName,
+		"last_name":    visitorFields.LastName,
+		"relationship": visitorFields.Relationship,
+	}))
+	if !visitorFields.AccessStart.IsZero() {
+		visitorUpdate = visitorUpdate.Set("access_start", visitorFields.AccessStart.Unix())
+	}
+	if !visitorFields.AccessEnd.IsZero() {
+		visitorUpdate = visitorUpdate.Set("access_end", visitorFields.AccessEnd.Unix())
+	}
+
+	visitorUpdate = visitorUpdate.Where("visitor.id = ?", visitorFields.ID)
+	if visitorFields.Version != 0 {
+		visitorUpdate = visitorUpdate.Set("version", squirrel.Expr("version + 1")).Where("visitor.version = ?", visitorFields.Version)
+	}
+
+	query, args, err := visitorUpdate.ToSql()
+	if err != nil {
+		return fmt.Errorf("visitor_repo.Update: %w: %v", errs.ErrDBBuildingQuery, err)
+	}
+
+	res, err := visitorRepo.driver.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("visitor_repo.Update: %w: %v", errs.ErrDBExec, err)
+	}
+
+	// an edit of a version that isn't the one in the database changes no rows
+	if visitorFields.Version == 0 {
+		return nil
+	} else if rowsAffected, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("visitor_repo.Update: %w: %v", errs.ErrDBGetRowsAffected, err)
+	} else if rowsAffected != 0 {
+		return nil
+	}
+
+	if _, err := visitorRepo.GetOne(ctx, visitorFields.ID); err != nil {
+		return fmt.Errorf("visitor_repo.Update: %w", err)
+	}
+	return fmt.Errorf("visitor_repo.Update: %w", errs.NewConflict("visitor"))
+}
+
+func (visitorRepo VisitorRepo) Delete(ctx context.Context, visitorID string) error {
+	tx, err := visitorRepo.driver.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("visitor_repo.Delete: %w: error beginning transaction: %v", errs.ErrDBExec, err)
+	}
+	// rolling back a committed transaction does nothing
+	defer tx.Rollback()
+
+	// deleting the visitor unsets the visitor_id of its gate events, after which the ones that are still open
+	// couldn't be checked out anymore
+	const checkOutQuery = `UPDATE gate_event SET check_out_ts = ? WHERE visitor_id = ? AND check_out_ts IS NULL`
+	if _, err := visitorRepo.driver.txExecContext(ctx, tx, checkOutQuery, time.Now().Unix(), visitorID); err != nil {
+		return fmt.Errorf("visitor_repo.Delete: %w: error checking out gate events: %v", errs.ErrDBExec, err)
+	}
+
+	const deleteQuery = `DELETE FROM visitor WHERE id = ?`
+	res, err := visitorRepo.driver.txExecContext(ctx, tx, deleteQuery, visitorID)
+	if err != nil {
+		return fmt.Errorf("visitor_repo.Delete: %w: %v", errs.ErrDBExec, err)
+	}
+
+	if rowsAffected, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("visitor_repo.Delete: %w: %v", errs.ErrDBGetRowsAffected, err)
+	} else if rowsAffected == 0 {
+		return fmt.Errorf("visitor_repo.Delete: %w", errs.NewNotFound("visitor"))
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("visitor_repo.Delete: %w: error committing transaction: %v", errs.ErrDBExec, err)
+	}
+
+	return nil
+}
+
+func (visitorRepo VisitorRepo) GetOne(ctx context.Context, visitorID string) (models.Visitor, error) {
+	query, args, err := visitorRepo.visitorSelect.Where("visitor.id = ?", visitorID).ToSql()
+	if err != nil {
+		return models.Visitor{}, fmt.Errorf("visitor_repo.GetOne: %w: %v", errs.ErrDBBuildingQuery, err)
+	}
+
+	visitor := visitor{}
+	err = visitorRepo.driver.GetContext(ctx, &visitor, query, args...)
+	if err == sql.ErrNoRows {
+		return models.Visitor{}, fmt.Errorf("visitor_repo.GetOne: %w", errs.NewNotFound("visitor"))
+	} else if err != nil {
+		return models.Visitor{}, fmt.Errorf("visitor_repo.GetOne: %w: %v", errs.ErrDBQuery, err)
+	}
+
+	return visitor.toModels(), nil
+}
+
+// visitorSearchDocument must stay equal to the expression of visitor_search_idx of the postgres migrations
+const visitorSearchDocument = "LOWER(visitor.resident_id || ' ' || visitor.first_name || ' ' || visitor.last_name)"
+
+func (visitorRepo VisitorRepo) SearchAsSQL(query string) squirrel.Sqlizer {
+	return visitorRepo.dialect.searchAsSQL(visitorSearchDocument, query)
+}
+
+// SearchRankAsSQL implements selectopts.RankRepo
+func (visitorRepo VisitorRepo) SearchRankAsSQL(query string) squirrel.Sqlizer {
+	return visitorRepo.dialect.searchRankAsSQL(visitorSearchDocument, query)
+}
+
+func (visitorRepo VisitorRepo) StatusAsSQL(status models.Status) (squirrel.Sqlizer, bool) {
+	if status == models.ActiveStatus {
+		now := time.Now().Unix()
+		return squirrel.And{
+			squirrel.Expr("visitor.access_start <= ?", now),
+			squirrel.Expr("visitor.access_end >= ?", now),
+		}, true
+	}
+	return nil, false
+}
+
+// SortColumns implements selectopts.SortRepo
+func (visitorRepo VisitorRepo) SortColumns() map[string]string {
+	return map[string]string{
+		"firstName":   "first_name",
+		"lastName":    "last_name",
+		"accessStart": "access_start",
+		"accessEnd":   "access_end",
+	}
+}
+
+// Table implements selectopts.SortRepo
+func (visitorRepo VisitorRepo) Table() string {
+	return "visitor"
+}
+
+// FilterFields implements selectopts.FilterRepo
+func (visitorRepo VisitorRepo) FilterFields() map[string]selectopts.FilterField {
+	return map[string]selectopts.FilterField{
+		"residentID":   {Column: "visitor.resident_id", Type: selectopts.StringField},
+		"firstName":    {Column: "visitor.first_name", Type: selectopts.StringField},
+		"lastName":     {Column: "visitor.last_name", Type: selectopts.StringField},
+		"relationship": {Column: "visitor.relationship", Type: selectopts.StringField},
+		"accessStart":  {Column: "visitor.access_start", Type: selectopts.DateField, Location: visitorRepo.location},
+		"accessEnd":    {Column: "visitor.access_end", Type: selectopts.DateField, Location: visitorRepo.location},
+	}
+}
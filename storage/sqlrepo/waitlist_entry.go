@@ -0,0 +1,56 @@
+package sqlrepo
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/dannyvelas/parkspot-backend/models"
+)
+
+type waitlistEntry struct {
+	ID              string         `db:"id"`
+	ResidentID      string         `db:"resident_id"`
+	CarID           sql.NullString `db:"car_id"`
+	LicensePlate    sql.NullString `db:"license_plate"`
+	Color           sql.NullString `db:"color"`
+	Make            sql.NullString `db:"make"`
+	Model           sql.NullString `db:"model"`
+	StartTS         int64          `db:"start_ts"`
+	EndTS           int64          `db:"end_ts"`
+	ExceptionReason sql.NullString `db:"exception_reason"`
+	SpaceID         sql.NullString `db:"space_id"`
+	Status          string         `db:"status"`
+	Reason          sql.NullString `db:"reason"`
+	PermitID        sql.NullInt64  `db:"permit_id"`
+	CreatedTS       int64          `db:"created_ts"`
+}
+
+func (entry waitlistEntry) toModels() models.WaitlistEntry {
+	return models.NewWaitlistEntry(
+		entry.ID,
+		entry.ResidentID,
+		entry.CarID.String,
+		entry.LicensePlate.String,
+		entry.Color.String,
+		entry.Make.String,
+		entry.Model.String,
+		time.Unix(entry.StartTS, 0), // time.Unix() returns time in local tz
+		time.Unix(entry.EndTS, 0),
+		entry.ExceptionReason.String,
+		entry.SpaceID.String,
+		models.WaitlistStatus(entry.Status),
+		entry.Reason.String,
+		int(entry.PermitID.Int64),
+		time.Unix(entry.CreatedTS, 0),
+	)
+}
+
+type waitlistEntrySlice []waitlistEntry
+
+func (entries waitlistEntrySlice) toModels() []models.WaitlistEntry {
+	modelsEntries := make([]models.WaitlistEntry, 0, len(entries))
+	for _, entry := range entries {
+		modelsEntries = append(modelsEntries, entry.toModels())
+	}
+	return modelsEntries
+}
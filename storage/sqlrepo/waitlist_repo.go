@@ -0,0 +1,193 @@
+package sqlrepo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/dannyvelas/parkspot-backend/errs"
+	"github.com/dannyvelas/parkspot-backend/models"
+	"github.com/dannyvelas/parkspot-backend/storage"
+	"github.com/dannyvelas/parkspot-backend/storage/selectopts"
+	"github.com/jmoiron/sqlx"
+)
+
+type WaitlistRepo struct {
+	driver      timeoutDB
+	entrySelect squirrel.SelectBuilder
+	countSelect squirrel.SelectBuilder
+}
+
+func NewWaitlistRepo(driver *sqlx.DB, dialect Dialect, queryTimeout time.Duration) storage.WaitlistRepo {
+	entrySelect := stmtBuilder.Select(
+		"waitlist_entry.id",
+		"waitlist_entry.resident_id",
+		"waitlist_entry.car_id",
+		"waitlist_entry.license_plate",
+		"waitlist_entry.color",
+		"waitlist_entry.make",
+		"waitlist_entry.model",
+		"waitlist_entry.start_ts",
+		"waitlist_entry.end_ts",
+		"waitlist_entry.exception_reason",
+		"waitlist_entry.space_id",
+		"waitlist_entry.status",
+		"waitlist_entry.reason",
+		"waitlist_entry.permit_id",
+		"waitlist_entry.created_ts",
+	).From("waitlist_entry")
+	countSelect := stmtBuilder.Select("count(*)").From("waitlist_entry")
+
+	return WaitlistRepo{
+		driver:      newTimeoutDB(driver, dialect, queryTimeout),
+		entrySelect: entrySelect,
+		countSelect: countSelect,
+	}
+}
+
+// SelectWhere returns the oldest entries first, since that is the order in which they are promoted
+func (waitlistRepo WaitlistRepo) SelectWhere(ctx context.Context, entryFields models.WaitlistEntry, selectOpts ...selectopts.SelectOpt) ([]models.WaitlistEntry, error) {
+	selector := waitlistRepo.entrySelect
+	for _, opt := range selectOpts {
+		selector = opt.Dispatch(waitlistRepo, selector)
+	}
+
+	entrySelect := selector.Where(rmEmptyVals(squirrel.Eq{
+		"resident_id": entryFields.ResidentID,
+		"status":      entryFields.Status,
+	})).OrderBy("waitlist_entry.created_ts ASC")
+
+	query, args, err := entrySelect.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("waitlist_repo.SelectWhere: %w: %v", errs.ErrDBBuildingQuery, err)
+	}
+
+	entries := waitlistEntrySlice{}
+	err = waitlistRepo.driver.SelectContext(ctx, &entries, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("waitlist_repo.SelectWhere: %w: %v", errs.ErrDBQuery, err)
+	}
+
+	return entries.toModels(), nil
+}
+
+func (waitlistRepo WaitlistRepo) SelectCountWhere(ctx context.Context, entryFields models.WaitlistEntry, selectOpts ...selectopts.SelectOpt) (int, error) {
+	selector := waitlistRepo.countSelect
+	for _, opt := range selectOpts {
+		selector = opt.Dispatch(waitlistRepo, selector)
+	}
+
+	countSelect := selector.Where(rmEmptyVals(squirrel.Eq{
+		"resident_id": entryFields.ResidentID,
+		"status":      entryFields.Status,
+	}))
+
+	query, args, err := countSelect.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("waitlist_repo.SelectCountWhere: %w: %v", errs.ErrDBBuildingQuery, err)
+	}
+
+	var totalAmount int
+	err = waitlistRepo.driver.GetContext(ctx, &totalAmount, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("waitlist_repo.SelectCountWhere: %w: %v", errs.ErrDBQuery, err)
+	}
+
+	return totalAmount, nil
+}
+
+func (waitlistRepo WaitlistRepo) GetOne(ctx context.Context, id string) (models.WaitlistEntry, error) {
+	query, args, err := waitlistRepo.entrySelect.Where("waitlist_entry.id = ?", id).ToSql()
+	if err != nil {
+		return models.WaitlistEntry{}, fmt.Errorf("waitlist_repo.GetOne: %w: %v", errs.ErrDBBuildingQuery, err)
+	}
+
+	entry := waitlistEntry{}
+	err = waitlistRepo.driver.GetContext(ctx, &entry, query, args...)
+	if err == sql.ErrNoRows {
+		return models.WaitlistEntry{}, fmt.Errorf("waitlist_repo.GetOne: %w", errs.NewNotFound("waitlist entry"))
+	} else if err != nil {
+		return models.WaitlistEntry{}, fmt.Errorf("waitlist_repo.GetOne: %w: %v", errs.ErrDBQuery, err)
+	}
+
+	return entry.toModels(), nil
+}
+
+func (waitlistRepo WaitlistRepo) Create(ctx context.Context, desiredEntry models.WaitlistEntry) (string, error) {
+	query, args, err := stmtBuilder.
+		Insert("waitlist_entry").
+		SetMap(squirrel.Eq{
+			"resident_id":      desiredEntry.ResidentID,
+			"car_id":           toNullString(desiredEntry.CarID),
+			"license_plate":    toNullString(desiredEntry.LicensePlate),
+			"color":            toNullString(desiredEntry.Color),
+			"make":             toNullString(desiredEntry.Make),
+			"model":            toNullString(desiredEntry.Model),
+			"start_ts":         desiredEntry.StartDate.Unix(),
+			"end_ts":           desiredEntry.EndDate.Unix(),
+			"exception_reason": toNullString(desiredEntry.ExceptionReason),
+			"space_id":         toNullString(desiredEntry.SpaceID),
+			"status":           desiredEntry.Status,
+			"reason":           toNullString(desiredEntry.Reason),
+			"created_ts":       desiredEntry.CreatedAt.Unix(),
+		}).
+		Suffix("RETURNING waitlist_entry.id").
+		ToSql()
+	if err != nil {
+		return "", fmt.Errorf("waitlist_repo.Create: %w: %v", errs.ErrDBBuildingQuery, err)
+	}
+
+	var entryID string
+	err = waitlistRepo.driver.GetContext(ctx, &entryID, query, args...)
+	if err != nil {
+		return "", fmt.Errorf("waitlist_repo.Create: %w: %v", errs.ErrDBExec, err)
+	}
+
+	return entryID, nil
+}
+
+func (waitlistRepo WaitlistRepo) Update(ctx context.Context, entryFields models.WaitlistEntry) error {
+	entryUpdate := stmtBuilder.Update("waitlist_entry").SetMap(rmEmptyVals(squirrel.Eq{
+		"status":    entryFields.Status,
+		"reason":    entryFields.Reason,
+		"permit_id": entryFields.PermitID,
+	}))
+
+	query, args, err := entryUpdate.Where("waitlist_entry.id = ?", entryFields.ID).ToSql()
+	if err != nil {
+		return fmt.Errorf("waitlist_repo.Update: %w: %v", errs.ErrDBBuildingQuery, err)
+	}
+
+	res, err := waitlistRepo.driver.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("waitlist_repo.Update: %w: %v", errs.ErrDBExec, err)
+	}
+
+	if rowsAffected, err := res.RowsAffected(); err != nil {
+		return fmt.Errorf("waitlist_repo.Update: %w: %v", errs.ErrDBGetRowsAffected, err)
+	} else if rowsAffected == 0 {
+		return fmt.Errorf("waitlist_repo.Update: %w", errs.NewNotFound("waitlist entry"))
+	}
+
+	return nil
+}
+
+func (waitlistRepo WaitlistRepo) Reset(ctx context.Context) error {
+	_, err := waitlistRepo.driver.ExecContext(ctx, "DELETE FROM waitlist_entry")
+	if err != nil {
+		return fmt.Errorf("waitlist_repo.Reset: %w: %v", errs.ErrDBExec, err)
+	}
+
+	return nil
+}
+
+func (waitlistRepo WaitlistRepo) SearchAsSQL(query string) squirrel.Sqlizer {
+	lcQuery := strings.ToLower(query)
+	return squirrel.Or{
+		squirrel.Expr("LOWER(waitlist_entry.resident_id) = ?", lcQuery),
+		squirrel.Expr("LOWER(waitlist_entry.license_plate) = ?", lcQuery),
+	}
+}
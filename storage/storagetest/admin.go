@@ -0,0 +1,47 @@
+package storagetest
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dannyvelas/parkspot-backend/errs"
+	"github.com/dannyvelas/parkspot-backend/models"
+)
+
+func (suite *repoSuite) TestAdmin_CreateUpdateDelete() {
+	adminRepo := suite.database.AdminRepo()
+	// admins aren't reset between tests, so these ones are deleted when this test ends
+	defer func() {
+		_ = adminRepo.Delete(context.Background(), "storagetest1")
+		_ = adminRepo.Delete(context.Background(), "storagetest2")
+	}()
+
+	suite.Require().NoError(adminRepo.Create(context.Background(), models.Admin{ID: "storagetest1", FirstName: "jane", LastName: "doe", Email: "storagetest1@example.com", Password: "notapassword"}))
+	suite.Require().NoError(adminRepo.Create(context.Background(), models.Admin{ID: "storagetest2", FirstName: "john", LastName: "doe", Email: "storagetest2@example.com", Password: "notapassword", IsPrivileged: true}))
+	suite.requireExecErr(adminRepo.Create(context.Background(), models.Admin{ID: "storagetest3", FirstName: "jim", LastName: "doe", Email: "storagetest1@example.com", Password: "notapassword"}))
+
+	_, err := adminRepo.GetOne(context.Background(), "")
+	suite.True(errors.Is(err, errs.ErrDBInvalidArg), "expected %v, got: %v", errs.ErrDBInvalidArg, err)
+
+	// admins log in with an id that they may type in any case
+	admin, err := adminRepo.GetOne(context.Background(), "StorageTest2")
+	suite.Require().NoError(err)
+	suite.Equal("storagetest2", admin.ID)
+	suite.True(admin.IsPrivileged)
+	suite.Require().NotNil(admin.TokenVersion)
+	suite.Zero(*admin.TokenVersion)
+
+	suite.requireExecErr(adminRepo.Update(context.Background(), models.Admin{ID: "storagetest2", Email: "storagetest1@example.com"}))
+	tokenVersion := 1
+	suite.Require().NoError(adminRepo.Update(context.Background(), models.Admin{ID: "storagetest2", FirstName: "johnny", TokenVersion: &tokenVersion}))
+	admin, err = adminRepo.GetOne(context.Background(), "storagetest2")
+	suite.Require().NoError(err)
+	suite.Equal("johnny", admin.FirstName)
+	suite.Equal("storagetest2@example.com", admin.Email)
+	suite.Equal(1, *admin.TokenVersion)
+
+	suite.Require().NoError(adminRepo.Delete(context.Background(), "storagetest1"))
+	suite.requireNotFound(adminRepo.Delete(context.Background(), "storagetest1"))
+	_, err = adminRepo.GetOne(context.Background(), "storagetest1")
+	suite.requireNotFound(err)
+}
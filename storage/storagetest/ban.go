@@ -0,0 +1,57 @@
+package storagetest
+
+import (
+	"context"
+	"github.com/dannyvelas/parkspot-backend/models"
+	"github.com/dannyvelas/parkspot-backend/storage/selectopts"
+	"github.com/dannyvelas/parkspot-backend/util"
+)
+
+func banID(ban models.Ban) string { return ban.ID }
+
+func (suite *repoSuite) TestBan_CreateDelete() {
+	banRepo := suite.database.BanRepo()
+	newBan := func(ban models.Ban) string {
+		ban.Reason, ban.CreatedBy = "reason", "admin"
+		id, err := banRepo.Create(context.Background(), ban)
+		suite.Require().NoError(err)
+		return id
+	}
+	plateBan := newBan(models.Ban{LicensePlate: "ABC123", CreatedAt: suite.daysFromNow(-3)})
+	visitorBan := newBan(models.Ban{FirstName: "Jane", LastName: "Doe", CreatedAt: suite.daysFromNow(-2), ExpiresAt: util.ToPtr(suite.daysFromNow(1))})
+	expiredBan := newBan(models.Ban{LicensePlate: "XYZ789", CreatedAt: suite.daysFromNow(-1), ExpiresAt: util.ToPtr(suite.daysFromNow(-1))})
+
+	ban, err := banRepo.GetOne(context.Background(), visitorBan)
+	suite.Require().NoError(err)
+	suite.Require().NotNil(ban.ExpiresAt)
+	suite.Equal(suite.daysFromNow(1).Unix(), ban.ExpiresAt.Unix())
+
+	tests := map[string]struct {
+		banFields  models.Ban
+		selectOpts []selectopts.SelectOpt
+		expected   []string
+	}{
+		// latest bans come first
+		"all":     {expected: []string{expiredBan, visitorBan, plateBan}},
+		"active":  {selectOpts: []selectopts.SelectOpt{selectopts.WithStatus(models.ActiveStatus)}, expected: []string{visitorBan, plateBan}},
+		"expired": {selectOpts: []selectopts.SelectOpt{selectopts.WithStatus(models.ExpiredStatus)}, expected: []string{expiredBan}},
+		// names of visitors are typed in by hand
+		"name":          {banFields: models.Ban{FirstName: " jane ", LastName: "DOE"}, expected: []string{visitorBan}},
+		"search":        {selectOpts: []selectopts.SelectOpt{selectopts.WithSearch("xyz789")}, expected: []string{expiredBan}},
+		"license plate": {selectOpts: []selectopts.SelectOpt{selectopts.WithLicensePlate("abc-i23")}, expected: []string{plateBan}},
+	}
+	for testName, test := range tests {
+		bans, err := banRepo.SelectWhere(context.Background(), test.banFields, test.selectOpts...)
+		suite.Require().NoError(err, testName)
+		suite.Equal(test.expected, ids(bans, banID), testName)
+
+		amtBans, err := banRepo.SelectCountWhere(context.Background(), test.banFields, test.selectOpts...)
+		suite.Require().NoError(err, testName)
+		suite.Equal(len(test.expected), amtBans, testName)
+	}
+
+	suite.Require().NoError(banRepo.Delete(context.Background(), plateBan))
+	suite.requireNotFound(banRepo.Delete(context.Background(), plateBan))
+	_, err = banRepo.GetOne(context.Background(), plateBan)
+	suite.requireNotFound(err)
+}
@@ -0,0 +1,114 @@
+package storagetest
+
+import (
+	"context"
+	"github.com/dannyvelas/parkspot-backend/models"
+	"github.com/dannyvelas/parkspot-backend/storage/selectopts"
+	"github.com/dannyvelas/parkspot-backend/util"
+)
+
+func carID(car models.Car) string { return car.ID }
+
+func (suite *repoSuite) TestCar_CreateUpdateDelete() {
+	carRepo := suite.database.CarRepo()
+	resident := suite.createResident("B0000001", "john", "smith")
+	car := suite.createCar(resident.ID, "ABC123", "red")
+
+	// license plates are unique, and cars belong to residents that exist
+	_, err := carRepo.Create(context.Background(), models.Car{ResidentID: resident.ID, LicensePlate: car.LicensePlate, Color: "blue"})
+	suite.requireExecErr(err)
+	_, err = carRepo.Create(context.Background(), models.Car{ResidentID: "B0000009", LicensePlate: "XYZ789", Color: "blue"})
+	suite.requireExecErr(err)
+
+	other := suite.createCar(resident.ID, "XYZ789", "blue")
+	suite.requireExecErr(carRepo.Update(context.Background(), models.Car{ID: other.ID, LicensePlate: car.LicensePlate}))
+
+	suite.Require().NoError(carRepo.Update(context.Background(), models.Car{ID: car.ID, Color: "green", AmtParkingDaysUsed: util.ToPtr(2)}))
+	suite.Require().NoError(carRepo.AddToAmtParkingDaysUsed(context.Background(), car.ID, 3))
+	gotCar, err := carRepo.GetOne(context.Background(), car.ID)
+	suite.Require().NoError(err)
+	suite.Equal("green", gotCar.Color)
+	suite.Equal(car.LicensePlate, gotCar.LicensePlate)
+	suite.Equal(5, *gotCar.AmtParkingDaysUsed)
+
+	// the ids of cars can be chosen, like the ids of imported cars
+	chosenID := "9b6d89a6-0b66-4170-be8d-eba43f8bf478"
+	id, err := carRepo.Create(context.Background(), models.Car{ID: chosenID, ResidentID: resident.ID, LicensePlate: "CHOSEN1", Color: "white"})
+	suite.Require().NoError(err)
+	suite.Equal(chosenID, id)
+
+	suite.Require().NoError(carRepo.Delete(context.Background(), car.ID))
+	_, err = carRepo.GetOne(context.Background(), car.ID)
+	suite.requireNotFound(err)
+	// deleting a car that doesn't exist is not an error
+	suite.NoError(carRepo.Delete(context.Background(), car.ID))
+}
+
+func (suite *repoSuite) TestCar_Versions() {
+	carRepo := suite.database.CarRepo()
+	resident := suite.createResident("B0000001", "john", "smith")
+	car := suite.createCar(resident.ID, "ABC123", "red")
+
+	suite.Require().NoError(carRepo.Update(context.Background(), models.Car{ID: car.ID, Color: "green", Version: car.Version}))
+	suite.requireConflict(carRepo.Update(context.Background(), models.Car{ID: car.ID, Color: "blue", Version: car.Version}))
+	suite.Require().NoError(carRepo.Update(context.Background(), models.Car{ID: car.ID, AmtParkingDaysUsed: util.ToPtr(3)}))
+	suite.requireNotFound(carRepo.Update(context.Background(), models.Car{ID: "9b6d89a6-0b66-4170-be8d-eba43f8bf478", Color: "blue", Version: 1}))
+
+	gotCar, err := carRepo.GetOne(context.Background(), car.ID)
+	suite.Require().NoError(err)
+	suite.Equal("green", gotCar.Color)
+	suite.Equal(car.Version+1, gotCar.Version, "expected updating the parking days used to not change the version")
+}
+
+func (suite *repoSuite) TestCar_SelectWhere() {
+	carRepo := suite.database.CarRepo()
+	suite.createResident("B0000001", "john", "smith")
+	suite.createResident("B0000002", "mary", "smith")
+	car1 := suite.createCar("B0000001", "OIL 123", "red")
+	car2 := suite.createCar("B0000001", "ABC123", "blue")
+	car3 := suite.createCar("B0000002", "XYZ789", "red")
+
+	tests := map[string]struct {
+		carFields  models.Car
+		selectOpts []selectopts.SelectOpt
+		expected   []string
+	}{
+		"fields":        {carFields: models.Car{ResidentID: "B0000001", Color: "red"}, expected: []string{car1.ID}},
+		"license plate": {selectOpts: []selectopts.SelectOpt{selectopts.WithLicensePlate("o1l-123")}, expected: []string{car1.ID}},
+		"search":        {selectOpts: []selectopts.SelectOpt{selectopts.WithSearch("b0000001 toyota")}, expected: []string{car1.ID, car2.ID}},
+		"filter": {selectOpts: []selectopts.SelectOpt{selectopts.WithFilters(
+			selectopts.Filter{Field: "color", Op: selectopts.Eq, Value: "RED"},
+		)}, expected: []string{car1.ID, car3.ID}},
+	}
+	for testName, test := range tests {
+		cars, err := carRepo.SelectWhere(context.Background(), test.carFields, test.selectOpts...)
+		suite.Require().NoError(err, testName)
+		suite.ElementsMatch(test.expected, ids(cars, carID), testName)
+
+		amtCars, err := carRepo.SelectCountWhere(context.Background(), test.carFields, test.selectOpts...)
+		suite.Require().NoError(err, testName)
+		suite.Equal(len(test.expected), amtCars, testName)
+	}
+}
+
+func (suite *repoSuite) TestCar_Sort() {
+	carRepo := suite.database.CarRepo()
+	suite.createResident("B0000001", "john", "smith")
+	carB := suite.createCar("B0000001", "BBB111", "red")
+	carA := suite.createCar("B0000001", "AAA111", "red")
+	carC := suite.createCar("B0000001", "CCC111", "blue")
+
+	byColor := selectopts.Cursor{Sort: selectopts.Sort{Field: "color", Desc: true}}
+	cars, err := carRepo.SelectWhere(context.Background(), models.Car{}, selectopts.WithCursor(byColor))
+	suite.Require().NoError(err)
+	suite.Require().Len(cars, 3)
+	// ties are sorted by id, in the direction of the sort
+	suite.Equal(carC.ID, cars[2].ID)
+	suite.ElementsMatch([]string{carA.ID, carB.ID}, ids(cars[:2], carID))
+	suite.Greater(cars[0].ID, cars[1].ID)
+
+	byLicensePlate := selectopts.Cursor{Sort: selectopts.Sort{Field: "licensePlate"}}
+	cars, err = carRepo.SelectWhere(context.Background(), models.Car{}, selectopts.WithCursor(byLicensePlate))
+	suite.Require().NoError(err)
+	suite.Equal([]string{carA.ID, carB.ID, carC.ID}, ids(cars, carID))
+}
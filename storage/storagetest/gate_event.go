@@ -0,0 +1,99 @@
+package storagetest
+
+import (
+	"context"
+	"github.com/dannyvelas/parkspot-backend/models"
+	"github.com/dannyvelas/parkspot-backend/storage/selectopts"
+	"time"
+)
+
+func gateEventID(gateEvent models.GateEvent) string { return gateEvent.ID }
+
+func (suite *repoSuite) TestGateEvent_CheckInAndOut() {
+	gateEventRepo := suite.database.GateEventRepo()
+	resident := suite.createResident("B0000001", "john", "smith")
+	car := suite.createCar(resident.ID, "ABC123", "red")
+	overstayed := suite.createVisitor(resident.ID, "jane", -2, -1)
+	permit := suite.createPermit(car, 0, 1, "")
+
+	visitorEventID, err := gateEventRepo.Create(context.Background(), models.GateEvent{ResidentID: resident.ID, VisitorID: overstayed.ID, RecordedBy: "guard", CheckIn: suite.daysFromNow(-2)})
+	suite.Require().NoError(err)
+	permitEventID, err := gateEventRepo.Create(context.Background(), models.GateEvent{ResidentID: resident.ID, PermitID: permit.ID, LicensePlate: car.LicensePlate, RecordedBy: "guard", CheckIn: suite.now})
+	suite.Require().NoError(err)
+	_, err = gateEventRepo.Create(context.Background(), models.GateEvent{ResidentID: resident.ID, PermitID: permit.ID + 100, RecordedBy: "guard", CheckIn: suite.now})
+	suite.requireExecErr(err)
+
+	// gate events know until when their guest is allowed to stay
+	visitorEvent, err := gateEventRepo.GetOne(context.Background(), visitorEventID)
+	suite.Require().NoError(err)
+	suite.Equal(overstayed.AccessEnd.Unix(), visitorEvent.AllowedUntil.Unix())
+	suite.Nil(visitorEvent.CheckOut)
+	permitEvent, err := gateEventRepo.GetOne(context.Background(), permitEventID)
+	suite.Require().NoError(err)
+	suite.Equal(permit.EndDate.Unix(), permitEvent.AllowedUntil.Unix())
+
+	tests := map[models.Status][]string{
+		models.AnyStatus:      {permitEventID, visitorEventID},
+		models.ActiveStatus:   {permitEventID, visitorEventID},
+		models.OverstayStatus: {visitorEventID},
+	}
+	for status, expected := range tests {
+		gateEvents, err := gateEventRepo.SelectWhere(context.Background(), models.GateEvent{}, selectopts.WithStatus(status))
+		suite.Require().NoError(err)
+		// latest check-ins come first
+		suite.Equal(expected, ids(gateEvents, gateEventID), "status %d", status)
+	}
+
+	suite.Require().NoError(gateEventRepo.SetCheckOut(context.Background(), visitorEventID, suite.now))
+	suite.requireNotFound(gateEventRepo.SetCheckOut(context.Background(), "9b6d89a6-0b66-4170-be8d-eba43f8bf478", suite.now))
+	visitorEvent, err = gateEventRepo.GetOne(context.Background(), visitorEventID)
+	suite.Require().NoError(err)
+	suite.Require().NotNil(visitorEvent.CheckOut)
+	suite.Equal(suite.now.Unix(), visitorEvent.CheckOut.Unix())
+
+	amtOnProperty, err := gateEventRepo.SelectCountWhere(context.Background(), models.GateEvent{}, selectopts.WithStatus(models.ActiveStatus))
+	suite.Require().NoError(err)
+	suite.Equal(1, amtOnProperty)
+
+	gateEvents, err := gateEventRepo.SelectWhere(context.Background(), models.GateEvent{}, selectopts.WithLicensePlate("abc-123"))
+	suite.Require().NoError(err)
+	suite.Equal([]string{permitEventID}, ids(gateEvents, gateEventID))
+
+	// deleting the pass of a gate event keeps the gate event
+	suite.Require().NoError(suite.database.PermitRepo().Delete(context.Background(), permit.ID))
+	permitEvent, err = gateEventRepo.GetOne(context.Background(), permitEventID)
+	suite.Require().NoError(err)
+	suite.Zero(permitEvent.PermitID)
+	suite.True(permitEvent.AllowedUntil.IsZero())
+
+	_, err = gateEventRepo.GetOne(context.Background(), "9b6d89a6-0b66-4170-be8d-eba43f8bf478")
+	suite.requireNotFound(err)
+}
+
+func (suite *repoSuite) TestGateEvent_DeletingVisitorChecksOut() {
+	gateEventRepo := suite.database.GateEventRepo()
+	resident := suite.createResident("B0000001", "john", "smith")
+	visitor := suite.createVisitor(resident.ID, "jane", -1, 1)
+
+	checkedOutID, err := gateEventRepo.Create(context.Background(), models.GateEvent{ResidentID: resident.ID, VisitorID: visitor.ID, RecordedBy: "guard", CheckIn: suite.daysFromNow(-1)})
+	suite.Require().NoError(err)
+	suite.Require().NoError(gateEventRepo.SetCheckOut(context.Background(), checkedOutID, suite.daysFromNow(-1).Add(time.Hour)))
+	openID, err := gateEventRepo.Create(context.Background(), models.GateEvent{ResidentID: resident.ID, VisitorID: visitor.ID, RecordedBy: "guard", CheckIn: suite.now})
+	suite.Require().NoError(err)
+
+	suite.Require().NoError(suite.database.VisitorRepo().Delete(context.Background(), visitor.ID))
+
+	// the guest of the deleted visitor is no longer on the property, and its earlier visit keeps its check-out
+	amtOnProperty, err := gateEventRepo.SelectCountWhere(context.Background(), models.GateEvent{}, selectopts.WithStatus(models.ActiveStatus))
+	suite.Require().NoError(err)
+	suite.Zero(amtOnProperty)
+
+	openEvent, err := gateEventRepo.GetOne(context.Background(), openID)
+	suite.Require().NoError(err)
+	suite.Empty(openEvent.VisitorID)
+	suite.Require().NotNil(openEvent.CheckOut)
+	checkedOutEvent, err := gateEventRepo.GetOne(context.Background(), checkedOutID)
+	suite.Require().NoError(err)
+	suite.Require().NotNil(checkedOutEvent.CheckOut)
+	suite.Equal(suite.daysFromNow(-1).Add(time.Hour).Unix(), checkedOutEvent.CheckOut.Unix())
+}
@@ -0,0 +1,40 @@
+package storagetest
+
+import (
+	"context"
+	"github.com/dannyvelas/parkspot-backend/models"
+)
+
+func (suite *repoSuite) TestImport_AllOrNothing() {
+	resident := suite.createResident("B0000001", "john", "smith")
+	suite.createCar(resident.ID, "TAKEN1", "red")
+
+	residents := []models.Resident{
+		models.NewResident("B0000002", "jane", "doe", "1234567890", "jane@example.com", "notapassword", false, 0, "", 0, 1),
+		models.NewResident("B0000003", "jim", "doe", "1234567890", "jim@example.com", "notapassword", false, 0, "", 0, 1),
+	}
+	cars := []models.Car{
+		{ResidentID: "B0000002", LicensePlate: "NEW1", Color: "blue", Make: "honda", Model: "civic"},
+		{ResidentID: "B0000003", LicensePlate: "TAKEN1", Color: "blue", Make: "honda", Model: "civic"},
+	}
+	suite.requireExecErr(suite.database.ImportRepo().Import(context.Background(), residents, cars))
+
+	// nothing of a failed import is kept
+	amtResidents, err := suite.database.ResidentRepo().SelectCountWhere(context.Background(), models.Resident{})
+	suite.Require().NoError(err)
+	suite.Equal(1, amtResidents)
+	amtCars, err := suite.database.CarRepo().SelectCountWhere(context.Background(), models.Car{})
+	suite.Require().NoError(err)
+	suite.Equal(1, amtCars)
+
+	cars[1].LicensePlate = "NEW2"
+	suite.Require().NoError(suite.database.ImportRepo().Import(context.Background(), residents, cars))
+	importedCars, err := suite.database.CarRepo().SelectWhere(context.Background(), models.Car{ResidentID: "B0000003"})
+	suite.Require().NoError(err)
+	suite.Require().Len(importedCars, 1)
+	suite.Equal("NEW2", importedCars[0].LicensePlate)
+	imported, err := suite.database.ResidentRepo().SelectWhere(context.Background(), models.Resident{ID: "B0000002"})
+	suite.Require().NoError(err)
+	suite.Require().Len(imported, 1)
+	suite.Equal("jane", imported[0].FirstName)
+}
@@ -0,0 +1,167 @@
+package storagetest
+
+import (
+	"context"
+	"github.com/dannyvelas/parkspot-backend/models"
+	"github.com/dannyvelas/parkspot-backend/storage/selectopts"
+)
+
+func parkingSpaceID(parkingSpace models.ParkingSpace) string { return parkingSpace.ID }
+
+func waitlistEntryID(entry models.WaitlistEntry) string { return entry.ID }
+
+func (suite *repoSuite) TestParkingSpace_CreateDelete() {
+	parkingSpaceRepo := suite.database.ParkingSpaceRepo()
+	newParkingSpace := func(zone, label string) string {
+		id, err := parkingSpaceRepo.Create(context.Background(), models.ParkingSpace{Zone: zone, Label: label})
+		suite.Require().NoError(err, "error creating parking space %s %s", zone, label)
+		return id
+	}
+	b2 := newParkingSpace("b", "2")
+	a2 := newParkingSpace("a", "2")
+	a1 := newParkingSpace("a", "1")
+
+	_, err := parkingSpaceRepo.Create(context.Background(), models.ParkingSpace{Zone: "a", Label: "1"})
+	suite.requireExecErr(err)
+
+	// spaces are sorted by zone, then by label
+	parkingSpaces, err := parkingSpaceRepo.SelectWhere(context.Background(), models.ParkingSpace{})
+	suite.Require().NoError(err)
+	suite.Equal([]string{a1, a2, b2}, ids(parkingSpaces, parkingSpaceID))
+
+	parkingSpaces, err = parkingSpaceRepo.SelectWhere(context.Background(), models.ParkingSpace{Zone: "a"}, selectopts.WithLimitAndOffset(1, 1))
+	suite.Require().NoError(err)
+	suite.Equal([]string{a2}, ids(parkingSpaces, parkingSpaceID))
+
+	amtParkingSpaces, err := parkingSpaceRepo.SelectCountWhere(context.Background(), models.ParkingSpace{Label: "2"})
+	suite.Require().NoError(err)
+	suite.Equal(2, amtParkingSpaces)
+
+	// deleting a space frees the permits that were parked in it
+	resident := suite.createResident("B0000001", "john", "smith")
+	permit := suite.createPermit(suite.createCar(resident.ID, "ABC123", "red"), 0, 1, "")
+	permit.SpaceID = a1
+	_, err = suite.database.PermitRepo().Create(context.Background(), permit)
+	suite.Require().NoError(err)
+	spacePermits, err := suite.database.PermitRepo().SelectWhere(context.Background(), models.Permit{SpaceID: a1})
+	suite.Require().NoError(err)
+	suite.Require().Len(spacePermits, 1)
+
+	suite.Require().NoError(parkingSpaceRepo.Delete(context.Background(), a1))
+	suite.requireNotFound(parkingSpaceRepo.Delete(context.Background(), a1))
+	_, err = parkingSpaceRepo.GetOne(context.Background(), a1)
+	suite.requireNotFound(err)
+
+	spacePermit, err := suite.database.PermitRepo().GetOne(context.Background(), spacePermits[0].ID)
+	suite.Require().NoError(err)
+	suite.Empty(spacePermit.SpaceID)
+}
+
+func (suite *repoSuite) TestWaitlist_CreateUpdate() {
+	waitlistRepo := suite.database.WaitlistRepo()
+	resident := suite.createResident("B0000001", "john", "smith")
+	permit := suite.createPermit(suite.createCar(resident.ID, "ABC123", "red"), 0, 1, "")
+	newEntry := func(licensePlate string, start, end, createdDaysAgo int) string {
+		id, err := waitlistRepo.Create(context.Background(), models.WaitlistEntry{
+			ResidentID:   resident.ID,
+			LicensePlate: licensePlate,
+			StartDate:    suite.daysFromNow(start),
+			EndDate:      suite.daysFromNow(end),
+			Status:       models.WaitlistWaiting,
+			CreatedAt:    suite.daysFromNow(-createdDaysAgo),
+		})
+		suite.Require().NoError(err)
+		return id
+	}
+	newer := newEntry("XYZ789", 5, 6, 1)
+	older := newEntry("ABC123", 0, 1, 2)
+
+	_, err := waitlistRepo.Create(context.Background(), models.WaitlistEntry{ResidentID: "B0000009", Status: models.WaitlistWaiting})
+	suite.requireExecErr(err)
+
+	// the waitlist is first come, first served
+	entries, err := waitlistRepo.SelectWhere(context.Background(), models.WaitlistEntry{})
+	suite.Require().NoError(err)
+	suite.Equal([]string{older, newer}, ids(entries, waitlistEntryID))
+
+	entries, err = waitlistRepo.SelectWhere(context.Background(), models.WaitlistEntry{}, selectopts.WithDateIntersect(suite.daysFromNow(4), suite.daysFromNow(7)))
+	suite.Require().NoError(err)
+	suite.Equal([]string{newer}, ids(entries, waitlistEntryID))
+
+	suite.Require().NoError(waitlistRepo.Update(context.Background(), models.WaitlistEntry{ID: older, Status: models.WaitlistPromoted, PermitID: permit.ID}))
+	suite.requireExecErr(waitlistRepo.Update(context.Background(), models.WaitlistEntry{ID: newer, PermitID: permit.ID + 100}))
+	suite.requireNotFound(waitlistRepo.Update(context.Background(), models.WaitlistEntry{ID: "9b6d89a6-0b66-4170-be8d-eba43f8bf478", Status: models.WaitlistCancelled}))
+
+	entry, err := waitlistRepo.GetOne(context.Background(), older)
+	suite.Require().NoError(err)
+	suite.Equal(models.WaitlistPromoted, entry.Status)
+	suite.Equal(permit.ID, entry.PermitID)
+
+	amtWaiting, err := waitlistRepo.SelectCountWhere(context.Background(), models.WaitlistEntry{Status: models.WaitlistWaiting})
+	suite.Require().NoError(err)
+	suite.Equal(1, amtWaiting)
+
+	// deleting the permit of a promoted entry keeps the entry
+	suite.Require().NoError(suite.database.PermitRepo().Delete(context.Background(), permit.ID))
+	entry, err = waitlistRepo.GetOne(context.Background(), older)
+	suite.Require().NoError(err)
+	suite.Zero(entry.PermitID)
+}
+
+func (suite *repoSuite) TestParkingPolicy_GetSet() {
+	parkingPolicyRepo := suite.database.ParkingPolicyRepo()
+
+	policy, err := parkingPolicyRepo.Get(context.Background())
+	suite.Require().NoError(err)
+	suite.Equal(models.DefaultParkingPolicy.MaxParkingDays, policy.MaxParkingDays)
+	suite.Equal(models.DefaultParkingPolicy.LatestEndDate.Unix(), policy.LatestEndDate.Unix())
+
+	desiredPolicy := models.ParkingPolicy{MaxParkingDays: 30, MaxPermitLength: 7, MaxActivePermits: 1, LatestEndDate: suite.daysFromNow(90)}
+	suite.Require().NoError(parkingPolicyRepo.Set(context.Background(), desiredPolicy))
+	// there is one policy, so setting it again replaces it
+	desiredPolicy.MaxActivePermits = 3
+	suite.Require().NoError(parkingPolicyRepo.Set(context.Background(), desiredPolicy))
+
+	policy, err = parkingPolicyRepo.Get(context.Background())
+	suite.Require().NoError(err)
+	suite.Equal(30, policy.MaxParkingDays)
+	suite.Equal(7, policy.MaxPermitLength)
+	suite.Equal(3, policy.MaxActivePermits)
+	suite.Equal(desiredPolicy.LatestEndDate.Unix(), policy.LatestEndDate.Unix())
+}
+
+func (suite *repoSuite) TestPermitRule_CreateDelete() {
+	permitRuleRepo := suite.database.PermitRuleRepo()
+	newPermitRule := func(permitRule models.PermitRule) string {
+		id, err := permitRuleRepo.Create(context.Background(), permitRule)
+		suite.Require().NoError(err, "error creating permit rule %s", permitRule.Name)
+		return id
+	}
+	weekends := newPermitRule(models.PermitRule{Name: "weekends", Kind: models.WeekendWeightRule, Target: models.PermitTarget, Weight: 2})
+	holidays := newPermitRule(models.PermitRule{Name: "holidays", Kind: models.BlackoutDatesRule, Target: models.VisitorTarget, Relationship: "contractor", Dates: []string{"2024-12-25", "2025-01-01"}})
+	longStays := newPermitRule(models.PermitRule{Name: "long stays", Kind: models.MaxLengthRule, Target: models.PermitTarget, MaxDays: 5})
+
+	_, err := permitRuleRepo.Create(context.Background(), models.PermitRule{Name: "weekends", Kind: models.MaxPerMonthRule, Target: models.PermitTarget, MaxCount: 1})
+	suite.requireExecErr(err)
+
+	permitRule, err := permitRuleRepo.GetOne(context.Background(), holidays)
+	suite.Require().NoError(err)
+	suite.Equal("contractor", permitRule.Relationship)
+	suite.Equal([]string{"2024-12-25", "2025-01-01"}, permitRule.Dates)
+
+	// rules are sorted by name
+	permitRules, err := permitRuleRepo.SelectWhere(context.Background(), models.PermitRule{})
+	suite.Require().NoError(err)
+	suite.Equal([]string{holidays, longStays, weekends}, ids(permitRules, permitRuleID))
+
+	permitRules, err = permitRuleRepo.SelectWhere(context.Background(), models.PermitRule{Target: models.PermitTarget})
+	suite.Require().NoError(err)
+	suite.Equal([]string{longStays, weekends}, ids(permitRules, permitRuleID))
+
+	suite.Require().NoError(permitRuleRepo.Delete(context.Background(), holidays))
+	suite.requireNotFound(permitRuleRepo.Delete(context.Background(), holidays))
+	_, err = permitRuleRepo.GetOne(context.Background(), holidays)
+	suite.requireNotFound(err)
+}
+
+func permitRuleID(permitRule models.PermitRule) string { return permitRule.ID }
@@ -0,0 +1,167 @@
+package storagetest
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/dannyvelas/parkspot-backend/models"
+	"github.com/dannyvelas/parkspot-backend/storage/selectopts"
+	"github.com/dannyvelas/parkspot-backend/util"
+)
+
+func permitID(permit models.Permit) string { return strconv.Itoa(permit.ID) }
+
+func (suite *repoSuite) TestPermit_CreateUpdateDelete() {
+	permitRepo := suite.database.PermitRepo()
+	resident := suite.createResident("B0000001", "john", "smith")
+	car := suite.createCar(resident.ID, "ABC123", "red")
+	permit := suite.createPermit(car, 0, 2, "")
+
+	gotPermit, err := permitRepo.GetOne(context.Background(), permit.ID)
+	suite.Require().NoError(err)
+	suite.Equal(permit.StartDate.Unix(), gotPermit.StartDate.Unix())
+	suite.Equal(permit.EndDate.Unix(), gotPermit.EndDate.Unix())
+	suite.Equal(car.LicensePlate, gotPermit.LicensePlate)
+	suite.NotZero(gotPermit.RequestTS)
+	suite.Nil(gotPermit.DaysCharged)
+
+	chargedID, err := permitRepo.Create(context.Background(), models.Permit{ResidentID: resident.ID, CarID: car.ID, LicensePlate: car.LicensePlate, Color: car.Color, DaysCharged: util.ToPtr(3)})
+	suite.Require().NoError(err)
+	gotPermit, err = permitRepo.GetOne(context.Background(), chargedID)
+	suite.Require().NoError(err)
+	suite.Require().NotNil(gotPermit.DaysCharged)
+	suite.Equal(3, *gotPermit.DaysCharged)
+
+	_, err = permitRepo.Create(context.Background(), models.Permit{ResidentID: "B0000009", CarID: car.ID, LicensePlate: "X", Color: "X"})
+	suite.requireExecErr(err)
+
+	suite.Require().NoError(permitRepo.Update(context.Background(), models.Permit{ID: permit.ID, Color: "blue"}))
+	gotPermit, err = permitRepo.GetOne(context.Background(), permit.ID)
+	suite.Require().NoError(err)
+	suite.Equal("blue", gotPermit.Color)
+	suite.Equal(car.Make, gotPermit.Make)
+
+	suite.Require().NoError(permitRepo.Delete(context.Background(), permit.ID))
+	suite.requireNotFound(permitRepo.Delete(context.Background(), permit.ID))
+	_, err = permitRepo.GetOne(context.Background(), permit.ID)
+	suite.requireNotFound(err)
+
+	// like a sequence, the ids of deleted permits are not reused
+	nextPermit := suite.createPermit(car, 0, 2, "")
+	suite.Greater(nextPermit.ID, permit.ID)
+}
+
+func (suite *repoSuite) TestPermit_Versions() {
+	permitRepo := suite.database.PermitRepo()
+	resident := suite.createResident("B0000001", "john", "smith")
+	permit := suite.createPermit(suite.createCar(resident.ID, "ABC123", "red"), 0, 2, "")
+
+	suite.Require().NoError(permitRepo.Update(context.Background(), models.Permit{ID: permit.ID, Color: "green", Version: permit.Version}))
+	suite.requireConflict(permitRepo.Update(context.Background(), models.Permit{ID: permit.ID, Color: "blue", Version: permit.Version}))
+	suite.requireNotFound(permitRepo.Update(context.Background(), models.Permit{ID: permit.ID + 1, Color: "blue", Version: 1}))
+
+	gotPermit, err := permitRepo.GetOne(context.Background(), permit.ID)
+	suite.Require().NoError(err)
+	suite.Equal("green", gotPermit.Color)
+	suite.Equal(permit.Version+1, gotPermit.Version)
+}
+
+func (suite *repoSuite) TestPermit_Status() {
+	permitRepo := suite.database.PermitRepo()
+	resident := suite.createResident("B0000001", "john", "smith")
+	car := suite.createCar(resident.ID, "ABC123", "red")
+	active := suite.createPermit(car, -1, 1, "")
+	exception := suite.createPermit(car, -1, 1, "guest of honor")
+	// permits are only expired once models.ExpiredGraceDays days have passed since the day that they ended
+	ended := suite.createPermit(car, -2, -1, "")
+	expired := suite.createPermit(car, -4, -3, "")
+	upcoming := suite.createPermit(car, 2, 3, "")
+
+	tests := map[models.Status][]string{
+		models.AnyStatus:       {permitID(active), permitID(exception), permitID(ended), permitID(expired), permitID(upcoming)},
+		models.ActiveStatus:    {permitID(active), permitID(exception)},
+		models.ExceptionStatus: {permitID(exception)},
+		models.ExpiredStatus:   {permitID(expired)},
+	}
+	for status, expected := range tests {
+		permits, err := permitRepo.SelectWhere(context.Background(), models.Permit{}, selectopts.WithStatus(status))
+		suite.Require().NoError(err)
+		suite.ElementsMatch(expected, ids(permits, permitID), "status %d", status)
+
+		amtPermits, err := permitRepo.SelectCountWhere(context.Background(), models.Permit{}, selectopts.WithStatus(status))
+		suite.Require().NoError(err)
+		suite.Equal(len(expected), amtPermits, "status %d", status)
+	}
+}
+
+func (suite *repoSuite) TestPermit_DateIntersectAndFilters() {
+	permitRepo := suite.database.PermitRepo()
+	resident := suite.createResident("B0000001", "john", "smith")
+	car := suite.createCar(resident.ID, "ABC123", "red")
+	early := suite.createPermit(car, 0, 2, "")
+	late := suite.createPermit(car, 5, 7, "exception")
+
+	permits, err := permitRepo.SelectWhere(context.Background(), models.Permit{},
+		selectopts.WithDateIntersect(suite.daysFromNow(2), suite.daysFromNow(4)))
+	suite.Require().NoError(err)
+	suite.Equal([]string{permitID(early)}, ids(permits, permitID))
+
+	permits, err = permitRepo.SelectWhere(context.Background(), models.Permit{},
+		selectopts.WithFilters(selectopts.Filter{Field: "affectsDays", Op: selectopts.Eq, Value: "false"}))
+	suite.Require().NoError(err)
+	suite.Equal([]string{permitID(late)}, ids(permits, permitID))
+
+	permits, err = permitRepo.SelectWhere(context.Background(), models.Permit{},
+		selectopts.WithFilters(selectopts.Filter{Field: "id", Op: selectopts.Gt, Value: permitID(early)}))
+	suite.Require().NoError(err)
+	suite.Equal([]string{permitID(late)}, ids(permits, permitID))
+}
+
+func (suite *repoSuite) TestPermit_Paging() {
+	permitRepo := suite.database.PermitRepo()
+	resident := suite.createResident("B0000001", "john", "smith")
+	car := suite.createCar(resident.ID, "ABC123", "red")
+	var permits []models.Permit
+	for _, start := range []int{3, 1, 4, 0, 2} {
+		permits = append(permits, suite.createPermit(car, start, start+1, ""))
+	}
+	// sorted by start date
+	sorted := []string{permitID(permits[3]), permitID(permits[1]), permitID(permits[4]), permitID(permits[0]), permitID(permits[2])}
+	byStartDate := selectopts.Sort{Field: "startDate"}
+
+	page, err := permitRepo.SelectWhere(context.Background(), models.Permit{}, selectopts.WithCursor(selectopts.Cursor{Sort: byStartDate}), selectopts.WithPage(2, 0))
+	suite.Require().NoError(err)
+	// pages have one more row, so that callers can tell whether there are rows after them
+	suite.Equal(sorted[:3], ids(page, permitID))
+
+	page, err = permitRepo.SelectWhere(context.Background(), models.Permit{},
+		selectopts.WithCursor(selectopts.Cursor{Sort: byStartDate, ID: sorted[1]}), selectopts.WithPage(2, 0))
+	suite.Require().NoError(err)
+	suite.Equal(sorted[2:5], ids(page, permitID))
+
+	// rows before a cursor come nearest first
+	page, err = permitRepo.SelectWhere(context.Background(), models.Permit{},
+		selectopts.WithCursor(selectopts.Cursor{Sort: byStartDate, ID: sorted[3], Before: true}), selectopts.WithPage(2, 0))
+	suite.Require().NoError(err)
+	suite.Equal([]string{sorted[2], sorted[1], sorted[0]}, ids(page, permitID))
+
+	page, err = permitRepo.SelectWhere(context.Background(), models.Permit{},
+		selectopts.WithCursor(selectopts.Cursor{Sort: selectopts.Sort{Field: "startDate", Desc: true}}), selectopts.WithPage(2, 2))
+	suite.Require().NoError(err)
+	suite.Equal([]string{sorted[2], sorted[1], sorted[0]}, ids(page, permitID))
+
+	page, err = permitRepo.SelectWhere(context.Background(), models.Permit{},
+		selectopts.WithCursor(selectopts.Cursor{Sort: byStartDate}), selectopts.WithLimitAndOffset(2, 1))
+	suite.Require().NoError(err)
+	suite.Equal(sorted[1:3], ids(page, permitID))
+
+	// a cursor by id is compared with the ids of permits, which are integers
+	page, err = permitRepo.SelectWhere(context.Background(), models.Permit{},
+		selectopts.WithCursor(selectopts.Cursor{Sort: selectopts.Sort{Field: "id"}, ID: permitID(permits[2])}))
+	suite.Require().NoError(err)
+	suite.Equal([]string{permitID(permits[3]), permitID(permits[4])}, ids(page, permitID))
+
+	// so cursors with ids that aren't integers are rejected before they reach the repo
+	suite.True(selectopts.IsValidID(permitRepo, permitID(permits[2])))
+	suite.False(selectopts.IsValidID(permitRepo, "not a number"))
+}
@@ -0,0 +1,173 @@
+package storagetest
+
+import (
+	"context"
+	"github.com/dannyvelas/parkspot-backend/models"
+	"github.com/dannyvelas/parkspot-backend/storage/selectopts"
+	"github.com/dannyvelas/parkspot-backend/util"
+)
+
+func residentID(resident models.Resident) string { return resident.ID }
+
+func (suite *repoSuite) TestResident_CreateUpdateDelete() {
+	residentRepo := suite.database.ResidentRepo()
+	resident := suite.createResident("B0000001", "john", "smith")
+
+	suite.requireExecErr(residentRepo.Create(context.Background(), resident))
+
+	suite.Require().NoError(residentRepo.Update(context.Background(), models.Resident{
+		ID:                 resident.ID,
+		LastName:           "smithers",
+		UnlimDays:          util.ToPtr(true),
+		AmtParkingDaysUsed: util.ToPtr(3),
+		TokenVersion:       util.ToPtr(2),
+	}))
+	suite.Require().NoError(residentRepo.AddToAmtParkingDaysUsed(context.Background(), resident.ID, 4))
+
+	residents, err := residentRepo.SelectWhere(context.Background(), models.Resident{ID: resident.ID})
+	suite.Require().NoError(err)
+	suite.Require().Len(residents, 1)
+	suite.Equal("john", residents[0].FirstName)
+	suite.Equal("smithers", residents[0].LastName)
+	suite.Equal(resident.Email, residents[0].Email)
+	suite.True(*residents[0].UnlimDays)
+	suite.Equal(7, *residents[0].AmtParkingDaysUsed)
+	suite.Equal(2, *residents[0].TokenVersion)
+
+	// updates of residents that don't exist change nothing
+	suite.NoError(residentRepo.Update(context.Background(), models.Resident{ID: "B0000009", FirstName: "nobody"}))
+	suite.NoError(residentRepo.AddToAmtParkingDaysUsed(context.Background(), "B0000009", 1))
+
+	suite.Require().NoError(residentRepo.Delete(context.Background(), resident.ID))
+	suite.requireNotFound(residentRepo.Delete(context.Background(), resident.ID))
+
+	residents, err = residentRepo.SelectWhere(context.Background(), models.Resident{})
+	suite.Require().NoError(err)
+	suite.Empty(residents)
+}
+
+func (suite *repoSuite) TestResident_Versions() {
+	residentRepo := suite.database.ResidentRepo()
+	resident := suite.createResident("B0000001", "john", "smith")
+
+	// only edits that are made to a version increment it, so updates like logging out don't make them conflict
+	suite.Require().NoError(residentRepo.Update(context.Background(), models.Resident{ID: resident.ID, FirstName: "jim", Version: 1}))
+	suite.Require().NoError(residentRepo.Update(context.Background(), models.Resident{ID: resident.ID, TokenVersion: util.ToPtr(1)}))
+	suite.Require().NoError(residentRepo.Update(context.Background(), models.Resident{ID: resident.ID, AmtParkingDaysUsed: util.ToPtr(3)}))
+	suite.requireConflict(residentRepo.Update(context.Background(), models.Resident{ID: resident.ID, FirstName: "jack", Version: 1}))
+	suite.Require().NoError(residentRepo.Update(context.Background(), models.Resident{ID: resident.ID, LastName: "smyth", Version: 2}))
+	suite.requireNotFound(residentRepo.Update(context.Background(), models.Resident{ID: "B0000009", FirstName: "nobody", Version: 1}))
+
+	residents, err := residentRepo.SelectWhere(context.Background(), models.Resident{ID: resident.ID})
+	suite.Require().NoError(err)
+	suite.Require().Len(residents, 1)
+	suite.Equal("jim", residents[0].FirstName)
+	suite.Equal(3, residents[0].Version)
+}
+
+func (suite *repoSuite) TestResident_DeleteCascades() {
+	resident := suite.createResident("B0000001", "john", "smith")
+	car := suite.createCar(resident.ID, "CASCADE1", "red")
+	permit := suite.createPermit(car, 0, 1, "")
+	visitor := suite.createVisitor(resident.ID, "jane", 0, 1)
+	_, err := suite.database.GateEventRepo().Create(context.Background(), models.GateEvent{ResidentID: resident.ID, VisitorID: visitor.ID, RecordedBy: "guard", CheckIn: suite.now})
+	suite.Require().NoError(err)
+	violationID, err := suite.database.ViolationRepo().Create(context.Background(), models.Violation{
+		LicensePlate: car.LicensePlate,
+		Location:     "lot",
+		Type:         "other",
+		RecordedBy:   "guard",
+		ResidentID:   resident.ID,
+		Action:       models.ViolationWarning,
+		Status:       models.ViolationOpen,
+		Timestamp:    suite.now,
+	})
+	suite.Require().NoError(err)
+
+	suite.Require().NoError(suite.database.ResidentRepo().Delete(context.Background(), resident.ID))
+
+	amtCars, err := suite.database.CarRepo().SelectCountWhere(context.Background(), models.Car{})
+	suite.Require().NoError(err)
+	suite.Zero(amtCars)
+	_, err = suite.database.PermitRepo().GetOne(context.Background(), permit.ID)
+	suite.requireNotFound(err)
+	_, err = suite.database.VisitorRepo().GetOne(context.Background(), visitor.ID)
+	suite.requireNotFound(err)
+	amtGateEvents, err := suite.database.GateEventRepo().SelectCountWhere(context.Background(), models.GateEvent{})
+	suite.Require().NoError(err)
+	suite.Zero(amtGateEvents)
+
+	// violations outlive the residents they were about
+	violation, err := suite.database.ViolationRepo().GetOne(context.Background(), violationID)
+	suite.Require().NoError(err)
+	suite.Empty(violation.ResidentID)
+}
+
+func (suite *repoSuite) TestResident_SortedByFirstName() {
+	suite.createResident("B0000001", "carl", "c")
+	suite.createResident("B0000002", "alice", "a")
+	suite.createResident("B0000003", "bob", "b")
+
+	residents, err := suite.database.ResidentRepo().SelectWhere(context.Background(), models.Resident{})
+	suite.Require().NoError(err)
+	suite.Equal([]string{"B0000002", "B0000003", "B0000001"}, ids(residents, residentID))
+}
+
+func (suite *repoSuite) TestResident_Search() {
+	residentRepo := suite.database.ResidentRepo()
+	suite.createResident("B0000001", "John", "Smith")
+	suite.createResident("B0000002", "Johnny", "Smithson")
+	suite.createResident("B0000003", "Mary", "Smith")
+
+	tests := map[string]struct {
+		search   string
+		expected []string
+	}{
+		"every term":     {search: "smith john", expected: []string{"B0000001", "B0000002"}},
+		"part of a word": {search: "SMITHS", expected: []string{"B0000002"}},
+		"id":             {search: "b0000003", expected: []string{"B0000003"}},
+		"no matches":     {search: "john mary", expected: []string{}},
+		"blank search":   {search: "  ", expected: []string{"B0000001", "B0000002", "B0000003"}},
+	}
+	for testName, test := range tests {
+		residents, err := residentRepo.SelectWhere(context.Background(), models.Resident{}, selectopts.WithSearch(test.search))
+		suite.Require().NoError(err, testName)
+		suite.ElementsMatch(test.expected, ids(residents, residentID), testName)
+
+		amtResidents, err := residentRepo.SelectCountWhere(context.Background(), models.Resident{}, selectopts.WithSearch(test.search))
+		suite.Require().NoError(err, testName)
+		suite.Equal(len(test.expected), amtResidents, testName)
+	}
+
+	// the closest match comes first
+	residents, err := residentRepo.SelectWhere(context.Background(), models.Resident{},
+		selectopts.WithSearchRank("john smith"), selectopts.WithSearch("john smith"))
+	suite.Require().NoError(err)
+	suite.Equal([]string{"B0000001", "B0000002"}, ids(residents, residentID))
+}
+
+func (suite *repoSuite) TestResident_Filters() {
+	residentRepo := suite.database.ResidentRepo()
+	suite.createResident("B0000001", "alice", "a")
+	suite.createResident("B0000002", "bob", "b")
+	suite.Require().NoError(residentRepo.Update(context.Background(), models.Resident{ID: "B0000002", UnlimDays: util.ToPtr(true)}))
+	suite.Require().NoError(residentRepo.AddToAmtParkingDaysUsed(context.Background(), "B0000001", 5))
+
+	tests := map[string]struct {
+		filters  []selectopts.Filter
+		expected []string
+	}{
+		"bool":              {filters: []selectopts.Filter{{Field: "unlimDays", Op: selectopts.Eq, Value: "true"}}, expected: []string{"B0000002"}},
+		"int":               {filters: []selectopts.Filter{{Field: "amtParkingDaysUsed", Op: selectopts.Gte, Value: "5"}}, expected: []string{"B0000001"}},
+		"text ignores case": {filters: []selectopts.Filter{{Field: "firstName", Op: selectopts.NotEq, Value: "ALICE"}}, expected: []string{"B0000002"}},
+		"every filter": {filters: []selectopts.Filter{
+			{Field: "unlimDays", Op: selectopts.Eq, Value: "false"},
+			{Field: "amtParkingDaysUsed", Op: selectopts.Lt, Value: "5"},
+		}, expected: []string{}},
+	}
+	for testName, test := range tests {
+		residents, err := residentRepo.SelectWhere(context.Background(), models.Resident{}, selectopts.WithFilters(test.filters...))
+		suite.Require().NoError(err, testName)
+		suite.ElementsMatch(test.expected, ids(residents, residentID), testName)
+	}
+}
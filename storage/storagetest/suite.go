@@ -0,0 +1,128 @@
+// Package storagetest checks that an implementation of storage.Database behaves like the others: that its repos
+// keep the same constraints and cascades, and select the same rows with the same select options.
+package storagetest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/dannyvelas/parkspot-backend/errs"
+	"github.com/dannyvelas/parkspot-backend/models"
+	"github.com/dannyvelas/parkspot-backend/storage"
+	"github.com/stretchr/testify/suite"
+)
+
+// Run runs the conformance suite against database. every test starts by resetting the repos of database, so
+// database must not hold rows that matter
+func Run(t *testing.T, database storage.Database) {
+	suite.Run(t, &repoSuite{database: database})
+}
+
+type repoSuite struct {
+	suite.Suite
+	database storage.Database
+	now      time.Time
+}
+
+func (suite *repoSuite) SetupTest() {
+	// deleting residents deletes their cars, permits, visitors, gate events and waitlist entries
+	resets := []func(context.Context) error{
+		suite.database.ResidentRepo().Reset,
+		suite.database.ViolationRepo().Reset,
+		suite.database.BanRepo().Reset,
+		suite.database.ParkingSpaceRepo().Reset,
+		suite.database.ParkingPolicyRepo().Reset,
+		suite.database.PermitRuleRepo().Reset,
+	}
+	for _, reset := range resets {
+		suite.Require().NoError(reset(context.Background()), "error resetting repos before test")
+	}
+
+	// rows are stored with a precision of seconds
+	suite.now = time.Unix(time.Now().Unix(), 0)
+}
+
+// helpers
+
+func (suite *repoSuite) createResident(id, firstName, lastName string) models.Resident {
+	resident := models.NewResident(id, firstName, lastName, "1234567890", firstName+"@example.com", "notapassword", false, 0, "", 0, 1)
+	suite.Require().NoError(suite.database.ResidentRepo().Create(context.Background(), resident), "error creating resident %s", id)
+	return resident
+}
+
+func (suite *repoSuite) createCar(residentID, licensePlate, color string) models.Car {
+	car := models.Car{ResidentID: residentID, LicensePlate: licensePlate, Color: color, Make: "toyota", Model: "corolla"}
+	id, err := suite.database.CarRepo().Create(context.Background(), car)
+	suite.Require().NoError(err, "error creating car %s", licensePlate)
+	return models.NewCar(id, residentID, licensePlate, color, car.Make, car.Model, 0, 1)
+}
+
+// createPermit creates a permit for car that starts start days from now and ends end days from now
+func (suite *repoSuite) createPermit(car models.Car, start, end int, exceptionReason string) models.Permit {
+	permit := models.Permit{
+		ResidentID:      car.ResidentID,
+		CarID:           car.ID,
+		LicensePlate:    car.LicensePlate,
+		Color:           car.Color,
+		Make:            car.Make,
+		Model:           car.Model,
+		StartDate:       suite.daysFromNow(start),
+		EndDate:         suite.daysFromNow(end),
+		AffectsDays:     exceptionReason == "",
+		ExceptionReason: exceptionReason,
+	}
+	id, err := suite.database.PermitRepo().Create(context.Background(), permit)
+	suite.Require().NoError(err, "error creating permit for %s", car.LicensePlate)
+	permit.ID = id
+	permit.Version = 1
+	return permit
+}
+
+func (suite *repoSuite) createVisitor(residentID, firstName string, start, end int) models.Visitor {
+	visitor := models.Visitor{
+		ResidentID:   residentID,
+		FirstName:    firstName,
+		LastName:     "guest",
+		Relationship: "fam/fri",
+		AccessStart:  suite.daysFromNow(start),
+		AccessEnd:    suite.daysFromNow(end),
+	}
+	id, err := suite.database.VisitorRepo().Create(context.Background(), visitor)
+	suite.Require().NoError(err, "error creating visitor %s", firstName)
+	visitor.ID = id
+	visitor.Version = 1
+	return visitor
+}
+
+func (suite *repoSuite) daysFromNow(days int) time.Time {
+	return suite.now.AddDate(0, 0, days)
+}
+
+func (suite *repoSuite) requireNotFound(err error) {
+	suite.Require().Error(err)
+	var apiErr *errs.APIErr
+	suite.Require().ErrorAs(err, &apiErr)
+	suite.Equal(http.StatusNotFound, apiErr.StatusCode)
+}
+
+func (suite *repoSuite) requireConflict(err error) {
+	suite.Require().Error(err)
+	suite.True(errors.Is(err, errs.Conflict), "expected %v, got: %v", errs.Conflict, err)
+}
+
+func (suite *repoSuite) requireExecErr(err error) {
+	suite.Require().Error(err)
+	suite.True(errors.Is(err, errs.ErrDBExec), "expected %v, got: %v", errs.ErrDBExec, err)
+}
+
+// ids returns the ids of rows, in order
+func ids[T any](rows []T, id func(T) string) []string {
+	rowIDs := make([]string, 0, len(rows))
+	for _, row := range rows {
+		rowIDs = append(rowIDs, id(row))
+	}
+	return rowIDs
+}
@@ -0,0 +1,65 @@
+package storagetest
+
+import (
+	"context"
+	"github.com/dannyvelas/parkspot-backend/models"
+	"github.com/dannyvelas/parkspot-backend/storage/selectopts"
+)
+
+func violationID(violation models.Violation) string { return violation.ID }
+
+func (suite *repoSuite) TestViolation_CreateUpdate() {
+	violationRepo := suite.database.ViolationRepo()
+	suite.createResident("B0000001", "john", "smith")
+	newViolation := func(licensePlate, residentID string, daysAgo int) string {
+		id, err := violationRepo.Create(context.Background(), models.Violation{
+			LicensePlate: licensePlate,
+			Location:     "lot b",
+			Type:         "noPermit",
+			RecordedBy:   "guard",
+			ResidentID:   residentID,
+			Action:       models.ViolationTow,
+			Status:       models.ViolationOpen,
+			Timestamp:    suite.daysFromNow(-daysAgo),
+		})
+		suite.Require().NoError(err)
+		return id
+	}
+	older := newViolation("ABC123", "B0000001", 2)
+	stranger := newViolation("XYZ789", "", 1)
+
+	_, err := violationRepo.Create(context.Background(), models.Violation{LicensePlate: "X", Location: "x", Type: "other", RecordedBy: "guard", ResidentID: "B0000009", Action: models.ViolationWarning, Status: models.ViolationOpen})
+	suite.requireExecErr(err)
+
+	suite.Require().NoError(violationRepo.Update(context.Background(), models.Violation{ID: older, Status: models.ViolationDisputed, DisputeReason: "my guest", ResidentNotified: true}))
+	suite.requireNotFound(violationRepo.Update(context.Background(), models.Violation{ID: "9b6d89a6-0b66-4170-be8d-eba43f8bf478", Status: models.ViolationUpheld}))
+
+	violation, err := violationRepo.GetOne(context.Background(), older)
+	suite.Require().NoError(err)
+	suite.Equal(models.ViolationDisputed, violation.Status)
+	suite.Equal("my guest", violation.DisputeReason)
+	suite.True(violation.ResidentNotified)
+	suite.Equal(models.ViolationTow, violation.Action)
+
+	tests := map[string]struct {
+		violationFields models.Violation
+		selectOpts      []selectopts.SelectOpt
+		expected        []string
+	}{
+		// latest violations come first
+		"all":            {expected: []string{stranger, older}},
+		"status":         {violationFields: models.Violation{Status: models.ViolationOpen}, expected: []string{stranger}},
+		"search":         {selectOpts: []selectopts.SelectOpt{selectopts.WithSearch("LOT B")}, expected: []string{stranger, older}},
+		"partial search": {selectOpts: []selectopts.SelectOpt{selectopts.WithSearch("lot")}, expected: []string{}},
+		"license plate":  {selectOpts: []selectopts.SelectOpt{selectopts.WithLicensePlate("xyz 789")}, expected: []string{stranger}},
+	}
+	for testName, test := range tests {
+		violations, err := violationRepo.SelectWhere(context.Background(), test.violationFields, test.selectOpts...)
+		suite.Require().NoError(err, testName)
+		suite.Equal(test.expected, ids(violations, violationID), testName)
+
+		amtViolations, err := violationRepo.SelectCountWhere(context.Background(), test.violationFields, test.selectOpts...)
+		suite.Require().NoError(err, testName)
+		suite.Equal(len(test.expected), amtViolations, testName)
+	}
+}
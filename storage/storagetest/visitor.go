@@ -0,0 +1,79 @@
+package storagetest
+
+import (
+	"context"
+	"github.com/dannyvelas/parkspot-backend/config"
+	"github.com/dannyvelas/parkspot-backend/models"
+	"github.com/dannyvelas/parkspot-backend/storage/selectopts"
+)
+
+func visitorID(visitor models.Visitor) string { return visitor.ID }
+
+func (suite *repoSuite) TestVisitor_CreateDelete() {
+	visitorRepo := suite.database.VisitorRepo()
+	resident := suite.createResident("B0000001", "john", "smith")
+	visitor := suite.createVisitor(resident.ID, "jane", 0, 1)
+
+	gotVisitor, err := visitorRepo.GetOne(context.Background(), visitor.ID)
+	suite.Require().NoError(err)
+	suite.Equal(visitor.FirstName, gotVisitor.FirstName)
+	suite.Equal(visitor.AccessEnd.Unix(), gotVisitor.AccessEnd.Unix())
+
+	_, err = visitorRepo.Create(context.Background(), models.Visitor{ResidentID: "B0000009", FirstName: "x", LastName: "x", Relationship: "fam/fri"})
+	suite.requireExecErr(err)
+
+	suite.Require().NoError(visitorRepo.Delete(context.Background(), visitor.ID))
+	suite.requireNotFound(visitorRepo.Delete(context.Background(), visitor.ID))
+	_, err = visitorRepo.GetOne(context.Background(), visitor.ID)
+	suite.requireNotFound(err)
+}
+
+func (suite *repoSuite) TestVisitor_SelectWhere() {
+	visitorRepo := suite.database.VisitorRepo()
+	suite.createResident("B0000001", "john", "smith")
+	suite.createResident("B0000002", "mary", "smith")
+	current := suite.createVisitor("B0000001", "jane", -1, 1)
+	past := suite.createVisitor("B0000001", "joe", -3, -2)
+	other := suite.createVisitor("B0000002", "jane", 1, 2)
+
+	tests := map[string]struct {
+		visitorFields models.Visitor
+		selectOpts    []selectopts.SelectOpt
+		expected      []string
+	}{
+		"resident": {visitorFields: models.Visitor{ResidentID: "B0000001"}, expected: []string{current.ID, past.ID}},
+		"active":   {selectOpts: []selectopts.SelectOpt{selectopts.WithStatus(models.ActiveStatus)}, expected: []string{current.ID}},
+		// visitors only have an active status
+		"expired": {selectOpts: []selectopts.SelectOpt{selectopts.WithStatus(models.ExpiredStatus)}, expected: []string{current.ID, past.ID, other.ID}},
+		"search":  {selectOpts: []selectopts.SelectOpt{selectopts.WithSearch("b0000002 JANE")}, expected: []string{other.ID}},
+		"filter": {selectOpts: []selectopts.SelectOpt{selectopts.WithFilters(
+			selectopts.Filter{Field: "accessStart", Op: selectopts.Lt, Value: suite.now.Format(config.DateFormat)},
+		)}, expected: []string{current.ID, past.ID}},
+	}
+	for testName, test := range tests {
+		visitors, err := visitorRepo.SelectWhere(context.Background(), test.visitorFields, test.selectOpts...)
+		suite.Require().NoError(err, testName)
+		suite.ElementsMatch(test.expected, ids(visitors, visitorID), testName)
+
+		amtVisitors, err := visitorRepo.SelectCountWhere(context.Background(), test.visitorFields, test.selectOpts...)
+		suite.Require().NoError(err, testName)
+		suite.Equal(len(test.expected), amtVisitors, testName)
+	}
+}
+
+func (suite *repoSuite) TestVisitor_Versions() {
+	visitorRepo := suite.database.VisitorRepo()
+	resident := suite.createResident("B0000001", "john", "smith")
+	visitor := suite.createVisitor(resident.ID, "jane", 0, 1)
+
+	suite.Require().NoError(visitorRepo.Update(context.Background(), models.Visitor{ID: visitor.ID, LastName: "doe", AccessEnd: suite.daysFromNow(2), Version: visitor.Version}))
+	suite.requireConflict(visitorRepo.Update(context.Background(), models.Visitor{ID: visitor.ID, LastName: "roe", Version: visitor.Version}))
+	suite.requireNotFound(visitorRepo.Update(context.Background(), models.Visitor{ID: "9b6d89a6-0b66-4170-be8d-eba43f8bf478", LastName: "roe", Version: 1}))
+
+	gotVisitor, err := visitorRepo.GetOne(context.Background(), visitor.ID)
+	suite.Require().NoError(err)
+	suite.Equal("doe", gotVisitor.LastName)
+	suite.Equal("jane", gotVisitor.FirstName)
+	suite.Equal(suite.daysFromNow(2).Unix(), gotVisitor.AccessEnd.Unix())
+	suite.Equal(visitor.Version+1, gotVisitor.Version)
+}
@@ -0,0 +1,17 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/dannyvelas/parkspot-backend/models"
+	"github.com/dannyvelas/parkspot-backend/storage/selectopts"
+)
+
+type ViolationRepo interface {
+	SelectWhere(ctx context.Context, violationFields models.Violation, selectOpts ...selectopts.SelectOpt) ([]models.Violation, error)
+	SelectCountWhere(ctx context.Context, violationFields models.Violation, selectOpts ...selectopts.SelectOpt) (int, error)
+	GetOne(ctx context.Context, id string) (models.Violation, error)
+	Create(ctx context.Context, desiredViolation models.Violation) (string, error)
+	Update(ctx context.Context, violationFields models.Violation) error
+	Reset(ctx context.Context) error // for testing purposes
+}
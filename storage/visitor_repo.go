@@ -1,14 +1,20 @@
 package storage
 
 import (
+	"context"
+
 	"github.com/dannyvelas/parkspot-backend/models"
 	"github.com/dannyvelas/parkspot-backend/storage/selectopts"
 )
 
 type VisitorRepo interface {
-	SelectWhere(models.Visitor, ...selectopts.SelectOpt) ([]models.Visitor, error)
-	SelectCountWhere(models.Visitor, ...selectopts.SelectOpt) (int, error)
-	Create(desiredVisitor models.Visitor) (string, error)
-	Delete(visitorID string) error
-	GetOne(visitorID string) (models.Visitor, error)
+	SelectWhere(ctx context.Context, visitorFields models.Visitor, selectOpts ...selectopts.SelectOpt) ([]models.Visitor, error)
+	SelectCountWhere(ctx context.Context, visitorFields models.Visitor, selectOpts ...selectopts.SelectOpt) (int, error)
+	Create(ctx context.Context, desiredVisitor models.Visitor) (string, error)
+	// Update edits the visitor of the ID of visitorFields, and increments its version. when visitorFields has a
+	// version, the visitor is only edited if it is still at that version, and a conflict is returned otherwise
+	Update(ctx context.Context, visitorFields models.Visitor) error
+	// Delete deletes the visitor of visitorID, and checks out the gate events of it that are still open
+	Delete(ctx context.Context, visitorID string) error
+	GetOne(ctx context.Context, visitorID string) (models.Visitor, error)
 }
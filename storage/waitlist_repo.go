@@ -0,0 +1,17 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/dannyvelas/parkspot-backend/models"
+	"github.com/dannyvelas/parkspot-backend/storage/selectopts"
+)
+
+type WaitlistRepo interface {
+	SelectWhere(ctx context.Context, entryFields models.WaitlistEntry, selectOpts ...selectopts.SelectOpt) ([]models.WaitlistEntry, error)
+	SelectCountWhere(ctx context.Context, entryFields models.WaitlistEntry, selectOpts ...selectopts.SelectOpt) (int, error)
+	GetOne(ctx context.Context, id string) (models.WaitlistEntry, error)
+	Create(ctx context.Context, desiredEntry models.WaitlistEntry) (string, error)
+	Update(ctx context.Context, entryFields models.WaitlistEntry) error
+	Reset(ctx context.Context) error // for testing purposes
+}
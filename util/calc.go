@@ -4,6 +4,20 @@ import (
 	"time"
 )
 
+// GetAmtDays is the amount of calendar days from the day of startDate to the day of endDate, where the days
+// are those of the calendar of startDate's location. days are counted on the calendar instead of as
+// 24 hour periods so that the 23 and 25 hour days of DST transitions still count as one day each
 func GetAmtDays(startDate, endDate time.Time) int {
-	return int(endDate.Sub(startDate).Hours() / 24)
+	endDate = endDate.In(startDate.Location())
+
+	// UTC has no DST, so the difference between two of its midnights is always a multiple of 24 hours
+	startDay := time.Date(startDate.Year(), startDate.Month(), startDate.Day(), 0, 0, 0, 0, time.UTC)
+	endDay := time.Date(endDate.Year(), endDate.Month(), endDate.Day(), 0, 0, 0, 0, time.UTC)
+
+	return int(endDay.Sub(startDay).Hours() / 24)
+}
+
+// StartOfDay is the midnight that starts the day of t, in the location of t
+func StartOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
 }
@@ -0,0 +1,80 @@
+package util
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetAmtDays(t *testing.T) {
+	miami, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("error loading location: %v", err)
+	}
+
+	tests := map[string]struct {
+		startDate time.Time
+		endDate   time.Time
+		expected  int
+	}{
+		"one regular day": {
+			startDate: time.Date(2026, time.January, 10, 0, 0, 0, 0, miami),
+			endDate:   time.Date(2026, time.January, 11, 0, 0, 0, 0, miami),
+			expected:  1,
+		},
+		"spring forward day is 23 hours": {
+			startDate: time.Date(2026, time.March, 8, 0, 0, 0, 0, miami),
+			endDate:   time.Date(2026, time.March, 9, 0, 0, 0, 0, miami),
+			expected:  1,
+		},
+		"fall back day is 25 hours": {
+			startDate: time.Date(2026, time.November, 1, 0, 0, 0, 0, miami),
+			endDate:   time.Date(2026, time.November, 2, 0, 0, 0, 0, miami),
+			expected:  1,
+		},
+		"week across spring forward": {
+			startDate: time.Date(2026, time.March, 5, 0, 0, 0, 0, miami),
+			endDate:   time.Date(2026, time.March, 12, 0, 0, 0, 0, miami),
+			expected:  7,
+		},
+		"late night permit": {
+			startDate: time.Date(2026, time.January, 10, 22, 0, 0, 0, miami),
+			endDate:   time.Date(2026, time.January, 11, 2, 0, 0, 0, miami),
+			expected:  1,
+		},
+		"same day": {
+			startDate: time.Date(2026, time.January, 10, 8, 0, 0, 0, miami),
+			endDate:   time.Date(2026, time.January, 10, 22, 0, 0, 0, miami),
+			expected:  0,
+		},
+		"end date sent in UTC": {
+			// 2026-01-11 03:00 UTC is still 2026-01-10 in Miami
+			startDate: time.Date(2026, time.January, 9, 0, 0, 0, 0, miami),
+			endDate:   time.Date(2026, time.January, 11, 3, 0, 0, 0, time.UTC),
+			expected:  1,
+		},
+	}
+
+	for testName, test := range tests {
+		if result := GetAmtDays(test.startDate, test.endDate); result != test.expected {
+			t.Errorf("%s failed: expected %d, got %d", testName, test.expected, result)
+		}
+	}
+}
+
+func TestStartOfDay(t *testing.T) {
+	miami, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("error loading location: %v", err)
+	}
+
+	// on fall back, 01:30 happens twice. both are on the same day
+	firstOneThirty := time.Date(2026, time.November, 1, 5, 30, 0, 0, time.UTC).In(miami)
+	secondOneThirty := firstOneThirty.Add(time.Hour)
+	expected := time.Date(2026, time.November, 1, 0, 0, 0, 0, miami)
+
+	for _, moment := range []time.Time{firstOneThirty, secondOneThirty} {
+		if result := StartOfDay(moment); !result.Equal(expected) {
+			t.Errorf("StartOfDay(%v) failed: expected %v, got %v", moment, expected, result)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package util
 import (
 	"regexp"
 	"strconv"
+	"strings"
 )
 
 func ToPosInt(value string) int {
@@ -34,3 +35,11 @@ func ToBool(value string) bool {
 func ToPtr[T any](v T) *T {
 	return &v
 }
+
+// NormalizeLicensePlate makes license plates that are read or typed differently comparable:
+// it ignores case, spaces and dashes, and treats the letters O and I as the digits 0 and 1
+func NormalizeLicensePlate(licensePlate string) string {
+	return licensePlateReplacer.Replace(strings.ToUpper(licensePlate))
+}
+
+var licensePlateReplacer = strings.NewReplacer(" ", "", "-", "", "O", "0", "I", "1")
@@ -0,0 +1,25 @@
+package util
+
+import (
+	"testing"
+)
+
+func TestNormalizeLicensePlate(t *testing.T) {
+	tests := map[string]struct {
+		argument string
+		expected string
+	}{
+		"lowercase":      {argument: "abc123", expected: "ABC123"},
+		"spaces":         {argument: "AB C 123", expected: "ABC123"},
+		"dashes":         {argument: "ABC-123", expected: "ABC123"},
+		"letter O":       {argument: "B0O", expected: "B00"},
+		"letter I":       {argument: "1IL", expected: "11L"},
+		"all confusions": {argument: " oi-ko 9 ", expected: "01K09"},
+	}
+
+	for testName, test := range tests {
+		if result := NormalizeLicensePlate(test.argument); result != test.expected {
+			t.Errorf("%s failed: expected %q, got %q", testName, test.expected, result)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package util
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Similarity is the similarity() of the pg_trgm extension of PostgreSQL, for storage that doesn't have it:
+// how many trigrams a and b share, out of the trigrams of both, from 0 to 1
+func Similarity(a, b string) float64 {
+	trigramsA, trigramsB := trigrams(a), trigrams(b)
+	if len(trigramsA) == 0 || len(trigramsB) == 0 {
+		return 0
+	}
+
+	shared := 0
+	for trigram := range trigramsA {
+		if _, ok := trigramsB[trigram]; ok {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(trigramsA)+len(trigramsB)-shared)
+}
+
+// trigrams are the trigrams of the words of s, like pg_trgm extracts them: words are runs of letters and
+// digits, in lowercase, with two spaces before them and one after them
+func trigrams(s string) map[string]struct{} {
+	set := map[string]struct{}{}
+	words := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, word := range words {
+		padded := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(padded); i++ {
+			set[string(padded[i:i+3])] = struct{}{}
+		}
+	}
+	return set
+}
@@ -0,0 +1,25 @@
+package util
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSimilarity(t *testing.T) {
+	tests := map[string]struct {
+		a, b     string
+		expected float64
+	}{
+		// the example of the documentation of pg_trgm
+		"part of words": {a: "word", b: "two words", expected: 4.0 / 11},
+		"same words":    {a: "Toyota Corolla", b: "corolla toyota", expected: 1},
+		"no words":      {a: "", b: "toyota", expected: 0},
+		"different":     {a: "abc", b: "xyz", expected: 0},
+	}
+
+	for name, test := range tests {
+		if actual := Similarity(test.a, test.b); math.Abs(actual-test.expected) > 1e-9 {
+			t.Errorf("%s: expected %v, got %v", name, test.expected, actual)
+		}
+	}
+}